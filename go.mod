@@ -4,6 +4,7 @@ go 1.24.5
 
 require (
 	github.com/gorilla/mux v1.8.1
+	github.com/gorilla/websocket v1.5.3
 	github.com/spf13/cobra v1.9.1
 )
 
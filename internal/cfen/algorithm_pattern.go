@@ -0,0 +1,110 @@
+package cfen
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ehrlich-b/cube/internal/cube"
+)
+
+// AlgorithmPattern generates the Pattern stored with an algorithm: the
+// CFEN of a solved 3x3 after the moves, or if masked, the same with every
+// piece the moves leave in place greyed out. Moves written for a bigger
+// cube, such as 4x4 parity algorithms with their numbered inner layer
+// turns, have no pattern and return an error.
+func AlgorithmPattern(moves string, masked bool) (string, error) {
+	parsed, err := cube.ParseScrambleForSize(moves, 3)
+	if err != nil {
+		return "", err
+	}
+	for _, move := range parsed {
+		if move.Layer > 0 {
+			return "", fmt.Errorf("'%s' turns inner layers of a bigger cube", moves)
+		}
+	}
+	c := cube.NewCube(3)
+	c.ApplyMoves(parsed)
+
+	if masked {
+		return GeneratePieceMaskedCFEN(c, cube.NewCube(3))
+	}
+	return GenerateCFEN(c)
+}
+
+// CheckAlgorithmPattern regenerates alg's Pattern from its moves and
+// returns an error if the stored one differs, which means one of the two
+// was edited without the other. A stored pattern with wildcards is checked
+// against the masked pattern. Algorithms without a Pattern pass.
+func CheckAlgorithmPattern(alg cube.Algorithm) error {
+	if alg.Pattern == "" {
+		return nil
+	}
+	stored, err := ParseCFEN(alg.Pattern)
+	if err != nil {
+		return fmt.Errorf("invalid pattern '%s': %v", alg.Pattern, err)
+	}
+
+	generated, err := AlgorithmPattern(alg.Moves, hasWildcards(stored))
+	if err != nil {
+		return fmt.Errorf("can't generate pattern: %v", err)
+	}
+	// Compare parsed forms so run-length spelling doesn't matter
+	want, err := ParseCFEN(generated)
+	if err != nil {
+		return err
+	}
+	if stored.String() != want.String() {
+		return fmt.Errorf("pattern '%s' doesn't match the moves, which give '%s'", alg.Pattern, generated)
+	}
+	return nil
+}
+
+// ErrVerificationFailed is returned by VerifyAlgorithm when the moves run
+// but don't reach the target
+var ErrVerificationFailed = errors.New("verification failed")
+
+// VerifyAlgorithm applies alg's moves to the cube described by startCFEN
+// and checks that the result matches targetCFEN, whose wildcards match any
+// color. It returns ErrVerificationFailed if the result doesn't match, or
+// another error if a CFEN or the moves don't parse.
+func VerifyAlgorithm(alg cube.Algorithm, startCFEN, targetCFEN string) error {
+	startState, err := ParseCFEN(startCFEN)
+	if err != nil {
+		return fmt.Errorf("parsing start CFEN: %v", err)
+	}
+	targetState, err := ParseCFEN(targetCFEN)
+	if err != nil {
+		return fmt.Errorf("parsing target CFEN: %v", err)
+	}
+
+	c, err := startState.ToCube()
+	if err != nil {
+		return fmt.Errorf("converting start CFEN to cube: %v", err)
+	}
+	moves, err := cube.ParseScramble(alg.Moves)
+	if err != nil {
+		return fmt.Errorf("parsing algorithm moves: %v", err)
+	}
+	c.ApplyMoves(moves)
+
+	matches, err := targetState.MatchesCube(c)
+	if err != nil {
+		return fmt.Errorf("matching result to target: %v", err)
+	}
+	if !matches {
+		return ErrVerificationFailed
+	}
+	return nil
+}
+
+// hasWildcards reports whether any sticker of state is a wildcard
+func hasWildcards(state *CFENState) bool {
+	for _, face := range state.Faces {
+		for _, color := range face.Stickers {
+			if color == cube.Grey {
+				return true
+			}
+		}
+	}
+	return false
+}
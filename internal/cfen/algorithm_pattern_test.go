@@ -0,0 +1,81 @@
+package cfen
+
+import (
+	"testing"
+
+	"github.com/ehrlich-b/cube/internal/cube"
+)
+
+// TestDatabasePatterns checks that every stored algorithm pattern still
+// matches what the algorithm's moves do
+func TestDatabasePatterns(t *testing.T) {
+	checked := 0
+	for _, alg := range cube.GetAllAlgorithms() {
+		if alg.Pattern == "" {
+			continue
+		}
+		checked++
+		if err := CheckAlgorithmPattern(alg); err != nil {
+			t.Errorf("%s (%s): %v", alg.Name, alg.CaseID, err)
+		}
+	}
+	if checked == 0 {
+		t.Error("no algorithms have a pattern to check")
+	}
+}
+
+func TestCheckAlgorithmPattern(t *testing.T) {
+	sune := "R U R' U R U2 R'"
+	full, err := AlgorithmPattern(sune, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	masked, err := AlgorithmPattern(sune, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	antiSune, _ := AlgorithmPattern("R U2 R' U' R U' R'", false)
+	antiSuneMasked, _ := AlgorithmPattern("R U2 R' U' R U' R'", true)
+
+	tests := []struct {
+		name    string
+		pattern string
+		ok      bool
+	}{
+		{"no pattern", "", true},
+		{"full", full, true},
+		{"masked", masked, true},
+		{"other algorithm", antiSune, false},
+		{"other algorithm masked", antiSuneMasked, false},
+		{"invalid", "not a cfen", false},
+	}
+	for _, tt := range tests {
+		err := CheckAlgorithmPattern(cube.Algorithm{Name: "Sune", Moves: sune, Pattern: tt.pattern})
+		if (err == nil) != tt.ok {
+			t.Errorf("%s: CheckAlgorithmPattern() error = %v, want ok %v", tt.name, err, tt.ok)
+		}
+	}
+}
+
+func TestVerifyAlgorithm(t *testing.T) {
+	sune := cube.Algorithm{Name: "Sune", Moves: "R U R' U R U2 R'"}
+	pattern, err := AlgorithmPattern(sune.Moves, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	solved := SolvedCFEN(3)
+
+	if err := VerifyAlgorithm(sune, solved, pattern); err != nil {
+		t.Errorf("Sune from solved to its pattern: %v", err)
+	}
+	if err := VerifyAlgorithm(sune, solved, solved); err != ErrVerificationFailed {
+		t.Errorf("Sune from solved to solved: error = %v, want ErrVerificationFailed", err)
+	}
+	if err := VerifyAlgorithm(sune, "not a cfen", pattern); err == nil || err == ErrVerificationFailed {
+		t.Errorf("invalid start: error = %v, want a parse error", err)
+	}
+	bad := cube.Algorithm{Name: "Bad", Moves: "R Q"}
+	if err := VerifyAlgorithm(bad, solved, solved); err == nil || err == ErrVerificationFailed {
+		t.Errorf("invalid moves: error = %v, want a parse error", err)
+	}
+}
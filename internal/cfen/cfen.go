@@ -48,6 +48,12 @@ func (state *CFENState) String() string {
 	return sb.String()
 }
 
+// SolvedCFEN returns the CFEN of a solved size x size cube held with
+// yellow up and blue in front, such as YB|Y9/R9/B9/W9/O9/G9 for a 3x3
+func SolvedCFEN(size int) string {
+	return fmt.Sprintf("YB|Y%[1]d/R%[1]d/B%[1]d/W%[1]d/O%[1]d/G%[1]d", size*size)
+}
+
 // compactString returns run-length encoded representation of face stickers
 func (face *CFENFace) compactString() string {
 	if len(face.Stickers) == 0 {
@@ -63,7 +69,7 @@ func (face *CFENFace) compactString() string {
 			count++
 		} else {
 			// Write current run
-			sb.WriteString(currentColor.String())
+			sb.WriteString(colorToken(currentColor))
 			if count > 1 {
 				sb.WriteString(strconv.Itoa(count))
 			}
@@ -75,7 +81,7 @@ func (face *CFENFace) compactString() string {
 	}
 
 	// Write final run
-	sb.WriteString(currentColor.String())
+	sb.WriteString(colorToken(currentColor))
 	if count > 1 {
 		sb.WriteString(strconv.Itoa(count))
 	}
@@ -83,8 +89,27 @@ func (face *CFENFace) compactString() string {
 	return sb.String()
 }
 
-// ParseCFEN parses a CFEN string into a CFENState
+// lastLayerPrefix marks the last-layer shorthand accepted by ParseCFEN
+const lastLayerPrefix = "LL:"
+
+// ParseCFEN parses a CFEN string into a CFENState.
+//
+// A string starting with "LL:" uses the last-layer shorthand, which gives
+// only the U face and the top rows of the side faces, in U/R/F/L/B order:
+//
+//	LL:YB|RYBY5O/G2Y/GBY/BR2/O2Y
+//
+// Every other sticker is filled with its solved color for the orientation.
+//
+// Any face may end with sticker overrides in brackets, position:color pairs
+// that replace single stickers after the run-lengths are expanded. The
+// positions count from 0 in reading order, so Y9[0:R,8:O] is the yellow
+// face with its top left sticker red and its bottom right sticker orange.
 func ParseCFEN(cfenStr string) (*CFENState, error) {
+	if strings.HasPrefix(cfenStr, lastLayerPrefix) {
+		return parseLastLayerCFEN(strings.TrimPrefix(cfenStr, lastLayerPrefix))
+	}
+
 	// Split on | to separate orientation and faces
 	parts := strings.Split(cfenStr, "|")
 	if len(parts) != 2 {
@@ -102,6 +127,9 @@ func ParseCFEN(cfenStr string) (*CFENState, error) {
 	if err != nil {
 		return nil, fmt.Errorf("invalid faces '%s': %v", parts[1], err)
 	}
+	if err := cube.ValidateSize(dimension); err != nil {
+		return nil, fmt.Errorf("invalid faces '%s': %v", parts[1], err)
+	}
 
 	return &CFENState{
 		Orientation: *orientation,
@@ -110,6 +138,60 @@ func ParseCFEN(cfenStr string) (*CFENState, error) {
 	}, nil
 }
 
+// parseLastLayerCFEN parses the body of an "LL:" CFEN: an orientation and
+// the U face followed by the top rows of the R, F, L, and B faces
+func parseLastLayerCFEN(cfenStr string) (*CFENState, error) {
+	parts := strings.Split(cfenStr, "|")
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("invalid CFEN format: expected '%sorientation|U/R/F/L/B', got '%s%s'", lastLayerPrefix, lastLayerPrefix, cfenStr)
+	}
+
+	orientation, err := parseOrientation(parts[0])
+	if err != nil {
+		return nil, fmt.Errorf("invalid orientation '%s': %v", parts[0], err)
+	}
+
+	faceStrs := strings.Split(parts[1], "/")
+	if len(faceStrs) != 5 {
+		return nil, fmt.Errorf("invalid faces '%s': expected U face and 4 side rows separated by '/', got %d", parts[1], len(faceStrs))
+	}
+
+	up, err := parseFace(faceStrs[0])
+	if err != nil {
+		return nil, fmt.Errorf("invalid faces '%s': face 0: %v", parts[1], err)
+	}
+	stickers := len(up.Stickers)
+	dimension := int(sqrt(float64(stickers)))
+	if dimension*dimension != stickers {
+		return nil, fmt.Errorf("invalid faces '%s': face 0 has %d stickers, not a perfect square", parts[1], stickers)
+	}
+	if err := cube.ValidateSize(dimension); err != nil {
+		return nil, fmt.Errorf("invalid faces '%s': %v", parts[1], err)
+	}
+
+	// Start from a solved cube seen in this orientation, then overlay the
+	// given stickers
+	state, err := FromCube(cube.NewCube(dimension), *orientation)
+	if err != nil {
+		return nil, err
+	}
+	copy(state.Faces[0].Stickers, up.Stickers)
+
+	// Side rows in U/R/F/L/B order map to CFEN faces R, F, L, B
+	for i, faceIdx := range []int{1, 2, 4, 5} {
+		row, err := parseFace(faceStrs[i+1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid faces '%s': row %d: %v", parts[1], i+1, err)
+		}
+		if len(row.Stickers) != dimension {
+			return nil, fmt.Errorf("invalid faces '%s': row %d has %d stickers, expected %d", parts[1], i+1, len(row.Stickers), dimension)
+		}
+		copy(state.Faces[faceIdx].Stickers, row.Stickers)
+	}
+
+	return state, nil
+}
+
 // parseOrientation parses the orientation field (e.g., "WG")
 func parseOrientation(orientStr string) (*CFENOrientation, error) {
 	if len(orientStr) != 2 {
@@ -171,8 +253,23 @@ func parseFaces(facesStr string) ([6]CFENFace, int, error) {
 	return faces, dimension, nil
 }
 
-// parseFace parses a single face string with run-length encoding
+// parseFace parses a single face string with run-length encoding and
+// optional trailing sticker overrides
 func parseFace(faceStr string) (*CFENFace, error) {
+	if open := strings.IndexByte(faceStr, '['); open >= 0 {
+		if !strings.HasSuffix(faceStr, "]") {
+			return nil, fmt.Errorf("unclosed sticker overrides in '%s'", faceStr)
+		}
+		face, err := parseFace(faceStr[:open])
+		if err != nil {
+			return nil, err
+		}
+		if err := face.applyOverrides(faceStr[open+1 : len(faceStr)-1]); err != nil {
+			return nil, fmt.Errorf("invalid sticker overrides in '%s': %v", faceStr, err)
+		}
+		return face, nil
+	}
+
 	var stickers []cube.Color
 
 	// Regular expression to match color+optional_count patterns
@@ -223,26 +320,43 @@ func parseFace(faceStr string) (*CFENFace, error) {
 	}, nil
 }
 
-// parseColor converts a character to a Color
+// applyOverrides sets the stickers named by a comma-separated list of
+// position:color pairs, such as "0:R,8:O"
+func (face *CFENFace) applyOverrides(overrides string) error {
+	for _, pair := range strings.Split(overrides, ",") {
+		posStr, colorStr, ok := strings.Cut(pair, ":")
+		if !ok || len(colorStr) != 1 {
+			return fmt.Errorf("expected position:color, got '%s'", pair)
+		}
+		pos, err := strconv.Atoi(posStr)
+		if err != nil || pos < 0 || pos >= len(face.Stickers) {
+			return fmt.Errorf("position '%s' is not between 0 and %d", posStr, len(face.Stickers)-1)
+		}
+		color, err := parseColor(rune(colorStr[0]))
+		if err != nil {
+			return fmt.Errorf("invalid color '%s': %v", colorStr, err)
+		}
+		face.Stickers[pos] = color
+	}
+	return nil
+}
+
+// parseColor converts a CFEN color token to a Color. CFEN writes the
+// wildcard only as '?', so the '.' that cube.ParseColor also accepts is
+// rejected here.
 func parseColor(ch rune) (cube.Color, error) {
-	switch ch {
-	case 'W':
-		return cube.White, nil
-	case 'Y':
-		return cube.Yellow, nil
-	case 'R':
-		return cube.Red, nil
-	case 'O':
-		return cube.Orange, nil
-	case 'G':
-		return cube.Green, nil
-	case 'B':
-		return cube.Blue, nil
-	case '?':
-		return cube.Grey, nil // Wildcard
-	default:
+	if ch == '.' {
 		return cube.White, fmt.Errorf("unknown color character '%c'", ch)
 	}
+	return cube.ParseColor(ch)
+}
+
+// colorToken returns the CFEN token for a color, '?' for the Grey wildcard
+func colorToken(color cube.Color) string {
+	if color == cube.Grey {
+		return "?"
+	}
+	return color.String()
 }
 
 // sqrt returns the integer square root (simple implementation)
@@ -0,0 +1,294 @@
+package cfen
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ehrlich-b/cube/internal/cube"
+)
+
+func TestParseLastLayerCFEN(t *testing.T) {
+	tests := []struct {
+		name      string
+		shorthand string
+		full      string
+	}{
+		{"Sune case", "LL:YB|RYBY5O/G2Y/GBY/BR2/O2Y", "YB|RYBY5O/G2YR6/GBYB6/W9/BR2O6/O2YG6"},
+		{"Solved", "LL:YB|Y9/R3/B3/O3/G3", "YB|Y9/R9/B9/W9/O9/G9"},
+		{"Wildcards", "LL:YB|?9/?3/?3/?3/?3", "YB|?9/?3R6/?3B6/W9/?3O6/?3G6"},
+		{"2x2", "LL:YB|Y4/BR/RB/O2/G2", "YB|Y4/BR3/RB3/W4/O4/G4"},
+		{"4x4", "LL:YB|Y16/R4/B4/O4/G4", "YB|Y16/R16/B16/W16/O16/G16"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			shorthand, err := ParseCFEN(tt.shorthand)
+			if err != nil {
+				t.Fatalf("ParseCFEN(%q) error = %v", tt.shorthand, err)
+			}
+			full, err := ParseCFEN(tt.full)
+			if err != nil {
+				t.Fatalf("ParseCFEN(%q) error = %v", tt.full, err)
+			}
+			if shorthand.Dimension != full.Dimension {
+				t.Errorf("Dimension = %d, want %d", shorthand.Dimension, full.Dimension)
+			}
+			if shorthand.String() != full.String() {
+				t.Errorf("ParseCFEN(%q) = %s, want %s", tt.shorthand, shorthand.String(), full.String())
+			}
+		})
+	}
+}
+
+func TestParseLastLayerCFENErrors(t *testing.T) {
+	for _, cfenStr := range []string{
+		"LL:Y9/R3/B3/O3/G3",       // Missing orientation
+		"LL:YB|Y9/R3/B3/W9/O3/G3", // Six faces instead of five
+		"LL:YB|Y8/R3/B3/O3/G3",    // U face not square
+		"LL:YB|Y9/R9/B3/O3/G3",    // Side row too long
+		"LL:YB|Y9/R3/B3/O3/X3",    // Bad color
+		"LL:YB|R/G/B/O/G",         // 1x1
+	} {
+		if _, err := ParseCFEN(cfenStr); err == nil {
+			t.Errorf("ParseCFEN(%q) should fail", cfenStr)
+		}
+	}
+}
+
+func TestParseCFENRejectsTinyCubes(t *testing.T) {
+	if _, err := ParseCFEN("YB|Y/R/B/W/O/G"); err == nil {
+		t.Error("ParseCFEN should reject a 1x1")
+	}
+	if _, err := ParseCFEN("YB|Y4/R4/B4/W4/O4/G4"); err != nil {
+		t.Errorf("ParseCFEN(2x2) error = %v", err)
+	}
+}
+
+func TestCFENWildcards(t *testing.T) {
+	for _, bad := range []string{
+		"YB|Y4.Y4/R9/B9/W9/O9/G9",   // '.' is not a CFEN token
+		"YB|Y9[4:.]/R9/B9/W9/O9/G9", // Nor in an override
+		"Y.|Y9/R9/B9/W9/O9/G9",      // Nor in the orientation
+		"LL:YB|.9/R3/B3/O3/G3",      // Nor in last layer shorthand
+	} {
+		if _, err := ParseCFEN(bad); err == nil {
+			t.Errorf("ParseCFEN(%q) should fail", bad)
+		}
+	}
+
+	// Masked patterns write wildcards as '?' and parse back unchanged
+	moves, err := cube.ParseScramble("R U R' U'")
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := cube.NewCube(3)
+	c.ApplyMoves(moves)
+	pattern, err := GeneratePieceMaskedCFEN(c, cube.NewCube(3))
+	if err != nil {
+		t.Fatalf("GeneratePieceMaskedCFEN() error = %v", err)
+	}
+	if strings.Contains(pattern, ".") || !strings.Contains(pattern, "?") {
+		t.Errorf("GeneratePieceMaskedCFEN() = %s, want '?' wildcards", pattern)
+	}
+	state, err := ParseCFEN(pattern)
+	if err != nil {
+		t.Fatalf("ParseCFEN(%q) error = %v", pattern, err)
+	}
+	if state.String() != pattern {
+		t.Errorf("ParseCFEN(%q).String() = %s", pattern, state.String())
+	}
+}
+
+func TestSolvedCFEN(t *testing.T) {
+	if got := SolvedCFEN(3); got != "YB|Y9/R9/B9/W9/O9/G9" {
+		t.Errorf("SolvedCFEN(3) = %s", got)
+	}
+	for size := 2; size <= 7; size++ {
+		want, _ := GenerateCFEN(cube.NewCube(size))
+		if got := SolvedCFEN(size); got != want {
+			t.Errorf("SolvedCFEN(%d) = %s, want %s", size, got, want)
+		}
+	}
+}
+
+func TestGenerateCFENWithScheme(t *testing.T) {
+	japanese := map[cube.Face]cube.Color{
+		cube.Up: cube.White, cube.Down: cube.Blue, cube.Front: cube.Red,
+		cube.Back: cube.Orange, cube.Right: cube.Green, cube.Left: cube.Yellow,
+	}
+	c, err := cube.NewCubeWithScheme(3, japanese)
+	if err != nil {
+		t.Fatalf("NewCubeWithScheme() error = %v", err)
+	}
+
+	got, err := GenerateCFEN(c)
+	if err != nil {
+		t.Fatalf("GenerateCFEN() error = %v", err)
+	}
+	if want := "WR|W9/G9/R9/B9/Y9/O9"; got != want {
+		t.Errorf("GenerateCFEN(Japanese solved) = %s, want %s", got, want)
+	}
+
+	// A turned cube survives the round trip through CFEN
+	moves, _ := cube.ParseScramble("R U F'")
+	c.ApplyMoves(moves)
+	cfenStr, err := GenerateCFEN(c)
+	if err != nil {
+		t.Fatalf("GenerateCFEN() error = %v", err)
+	}
+	state, err := ParseCFEN(cfenStr)
+	if err != nil {
+		t.Fatalf("ParseCFEN(%q) error = %v", cfenStr, err)
+	}
+	back, err := state.ToCube()
+	if err != nil {
+		t.Fatalf("ToCube() error = %v", err)
+	}
+	if back.String() != c.String() {
+		t.Errorf("Round trip through %s changed the cube", cfenStr)
+	}
+}
+
+func TestCFENRoundTrip(t *testing.T) {
+	tests := []struct {
+		size     int
+		scramble string
+	}{
+		{2, "R U F' R2"},
+		{3, "R U R' U' F2 D L' B"},
+		{3, "M2 E S' x y"},
+		{4, "Rw U2 F' 2L D"},
+		{5, "3Rw U 2F' M2 L"},
+	}
+
+	for _, tt := range tests {
+		c := cube.NewCube(tt.size)
+		moves, err := cube.ParseScrambleForSize(tt.scramble, tt.size)
+		if err != nil {
+			t.Fatal(err)
+		}
+		c.ApplyMoves(moves)
+
+		cfenStr, err := GenerateCFEN(c)
+		if err != nil {
+			t.Errorf("%dx%d %q: GenerateCFEN() error = %v", tt.size, tt.size, tt.scramble, err)
+			continue
+		}
+		state, err := ParseCFEN(cfenStr)
+		if err != nil {
+			t.Errorf("ParseCFEN(%q) error = %v", cfenStr, err)
+			continue
+		}
+		back, err := state.ToCube()
+		if err != nil {
+			t.Errorf("%s: ToCube() error = %v", cfenStr, err)
+		} else if back.String() != c.String() {
+			t.Errorf("%dx%d %q: round trip through %s changed the cube", tt.size, tt.size, tt.scramble, cfenStr)
+		}
+	}
+}
+
+func TestDetectScheme(t *testing.T) {
+	// Japanese scheme: white opposite blue
+	state, err := ParseCFEN("WR|W9/G9/R9/B9/Y9/O9")
+	if err != nil {
+		t.Fatal(err)
+	}
+	scheme, err := state.DetectScheme()
+	if err != nil {
+		t.Fatalf("DetectScheme() error = %v", err)
+	}
+	want := map[cube.Face]cube.Color{
+		cube.Up: cube.White, cube.Down: cube.Blue, cube.Front: cube.Red,
+		cube.Back: cube.Orange, cube.Right: cube.Green, cube.Left: cube.Yellow,
+	}
+	for face, color := range want {
+		if scheme[face] != color {
+			t.Errorf("DetectScheme()[%s] = %s, want %s", face, scheme[face], color)
+		}
+	}
+
+	c, err := state.ToCube()
+	if err != nil {
+		t.Fatalf("ToCube() error = %v", err)
+	}
+	if c.HasStandardScheme() || c.SolvedColor(cube.Down) != cube.Blue || !c.IsSolvedStrict(nil) {
+		t.Error("ToCube() should build a solved cube with the Japanese scheme")
+	}
+
+	// The standard colors held another way keep the standard scheme
+	state, _ = ParseCFEN("YB|R9/W9/B9/O9/Y9/G9")
+	if c, err := state.ToCube(); err != nil || !c.HasStandardScheme() || !c.IsSolved() {
+		t.Errorf("ToCube(rotated standard) = %v, %v, want a solved standard cube", c, err)
+	}
+
+	for _, bad := range []string{
+		"YB|Y9/Y9/B9/W9/O9/G9",    // two yellow centers
+		"YB|Y4?Y4/R9/B9/W9/O9/G9", // wildcard center
+		"YB|Y4/R4/B4/W4/O4/G4",    // no fixed centers
+	} {
+		state, err := ParseCFEN(bad)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := state.DetectScheme(); err == nil {
+			t.Errorf("DetectScheme(%s) should fail", bad)
+		}
+	}
+
+	// Duplicate centers can't make a cube, but a wildcard center can
+	state, _ = ParseCFEN("YB|Y9/Y9/B9/W9/O9/G9")
+	if _, err := state.ToCube(); err == nil {
+		t.Error("ToCube() should reject two yellow centers")
+	}
+	state, _ = ParseCFEN("YB|Y4?Y4/R9/B9/W9/O9/G9")
+	if _, err := state.ToCube(); err != nil {
+		t.Errorf("ToCube() with a wildcard center error = %v", err)
+	}
+}
+
+func TestParseCFENStickerOverrides(t *testing.T) {
+	tests := []struct {
+		name      string
+		overrides string
+		full      string
+	}{
+		{"single", "YB|Y9[4:?]/R9/B9/W9/O9/G9", "YB|Y4?Y4/R9/B9/W9/O9/G9"},
+		{"corners", "YB|Y9[0:R,8:O]/R9/B9/W9/O9/G9", "YB|RY7O/R9/B9/W9/O9/G9"},
+		{"mixed runs", "YB|Y9/R3G3R3[3:R,5:W]/B9/W9/O9/G9", "YB|Y9/R4GWR3/B9/W9/O9/G9"},
+		{"several faces", "YB|?9[4:Y]/R9/B2?B6[2:B]/W9/O9/G9[0:Y]", "YB|?4Y?4/R9/B9/W9/O9/YG8"},
+		{"last override wins", "YB|Y9[1:R,1:G]/R9/B9/W9/O9/G9", "YB|YGY7/R9/B9/W9/O9/G9"},
+		{"last layer", "LL:YB|Y9[0:R]/R3[2:Y]/B3/O3/G3", "YB|RY8/R2YR6/B9/W9/O9/G9"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseCFEN(tt.overrides)
+			if err != nil {
+				t.Fatalf("ParseCFEN(%q) error = %v", tt.overrides, err)
+			}
+			want, err := ParseCFEN(tt.full)
+			if err != nil {
+				t.Fatalf("ParseCFEN(%q) error = %v", tt.full, err)
+			}
+			if got.String() != want.String() {
+				t.Errorf("ParseCFEN(%q) = %s, want %s", tt.overrides, got.String(), want.String())
+			}
+		})
+	}
+
+	for _, bad := range []string{
+		"YB|Y9[9:R]/R9/B9/W9/O9/G9",  // Position past the end
+		"YB|Y9[-1:R]/R9/B9/W9/O9/G9", // Negative position
+		"YB|Y9[0:X]/R9/B9/W9/O9/G9",  // Bad color
+		"YB|Y9[0R]/R9/B9/W9/O9/G9",   // Missing colon
+		"YB|Y9[0:RG]/R9/B9/W9/O9/G9", // Two colors
+		"YB|Y9[]/R9/B9/W9/O9/G9",     // Empty list
+		"YB|Y9[0:R/R9/B9/W9/O9/G9",   // Unclosed
+		"YB|Y9[0:R]Y/R9/B9/W9/O9/G9", // Runs after the overrides
+	} {
+		if _, err := ParseCFEN(bad); err == nil {
+			t.Errorf("ParseCFEN(%q) should fail", bad)
+		}
+	}
+}
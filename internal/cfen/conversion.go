@@ -6,10 +6,24 @@ import (
 	"github.com/ehrlich-b/cube/internal/cube"
 )
 
-// ToCube converts a CFENState to an internal Cube representation
+// ToCube converts a CFENState to an internal Cube representation. On odd
+// cubes whose centers all have colors, the cube takes the color scheme
+// DetectScheme reads from them, so a state from a cube with the Japanese
+// or a custom scheme converts to a cube with that scheme.
 func (state *CFENState) ToCube() (*cube.Cube, error) {
 	// Create new cube with correct dimension
 	newCube := cube.NewCube(state.Dimension)
+	if state.Dimension%2 == 1 && !state.hasWildcardCenter() {
+		scheme, err := state.DetectScheme()
+		if err != nil {
+			return nil, err
+		}
+		if !hasStandardOpposites(scheme) {
+			if newCube, err = cube.NewCubeWithScheme(state.Dimension, scheme); err != nil {
+				return nil, err
+			}
+		}
+	}
 
 	// Get face mapping based on CFEN orientation
 	faceMapping := getOrientationMapping(state.Orientation)
@@ -29,15 +43,77 @@ func (state *CFENState) ToCube() (*cube.Cube, error) {
 	return newCube, nil
 }
 
+// DetectScheme reads the color scheme from the centers of an odd cube:
+// the color ToCube's cube has on each face when solved. It returns an
+// error on even cubes, which have no fixed centers, and unless the six
+// centers have six different colors.
+func (state *CFENState) DetectScheme() (map[cube.Face]cube.Color, error) {
+	if state.Dimension%2 == 0 {
+		return nil, fmt.Errorf("a %dx%d has no fixed centers to read the color scheme from", state.Dimension, state.Dimension)
+	}
+
+	middle := state.Dimension / 2
+	faceMapping := getOrientationMapping(state.Orientation)
+	scheme := make(map[cube.Face]cube.Color, 6)
+	used := make(map[cube.Color]cube.Face, 6)
+	for cfenFaceIdx, cfenFace := range state.Faces {
+		face := faceMapping[cfenFaceIdx]
+		color := cfenFace.Stickers[middle*state.Dimension+middle]
+		if color == cube.Grey {
+			return nil, fmt.Errorf("the %s center is a wildcard", face)
+		}
+		if other, ok := used[color]; ok {
+			return nil, fmt.Errorf("the %s and %s centers are both %s; the centers need six different colors", other, face, color)
+		}
+		used[color] = face
+		scheme[face] = color
+	}
+	return scheme, nil
+}
+
+// hasWildcardCenter reports whether any face's center sticker is a
+// wildcard, as in a pattern that only cares about some stickers
+func (state *CFENState) hasWildcardCenter() bool {
+	middle := state.Dimension / 2
+	for _, cfenFace := range state.Faces {
+		if cfenFace.Stickers[middle*state.Dimension+middle] == cube.Grey {
+			return true
+		}
+	}
+	return false
+}
+
+// hasStandardOpposites reports whether a scheme pairs opposite faces as
+// the standard one does (yellow and white, blue and green, red and
+// orange). Such a scheme is the standard colors held another way, so the
+// cube keeps the standard scheme.
+func hasStandardOpposites(scheme map[cube.Face]cube.Color) bool {
+	standard := cube.NewCube(3)
+	opposites := make(map[cube.Color]cube.Color, 6)
+	for _, pair := range [][2]cube.Face{{cube.Up, cube.Down}, {cube.Front, cube.Back}, {cube.Left, cube.Right}} {
+		a, b := standard.SolvedColor(pair[0]), standard.SolvedColor(pair[1])
+		opposites[a], opposites[b] = b, a
+	}
+	for _, pair := range [][2]cube.Face{{cube.Up, cube.Down}, {cube.Front, cube.Back}, {cube.Left, cube.Right}} {
+		if opposites[scheme[pair[0]]] != scheme[pair[1]] {
+			return false
+		}
+	}
+	return true
+}
+
 // FromCube converts an internal Cube to CFENState
 func FromCube(c *cube.Cube, orientation CFENOrientation) (*CFENState, error) {
 	if c == nil {
 		return nil, fmt.Errorf("cube cannot be nil")
 	}
 
-	// Get reverse face mapping based on desired CFEN orientation
-	reverseFaceMapping := getReverseOrientationMapping(orientation)
+	return fromCubeFaces(c, orientation, getReverseOrientationMapping(orientation)), nil
+}
 
+// fromCubeFaces builds a CFENState taking each CFEN face from the internal
+// face reverseFaceMapping gives for it
+func fromCubeFaces(c *cube.Cube, orientation CFENOrientation, reverseFaceMapping [6]cube.Face) *CFENState {
 	var faces [6]CFENFace
 
 	for cfenFaceIdx := 0; cfenFaceIdx < 6; cfenFaceIdx++ {
@@ -62,11 +138,23 @@ func FromCube(c *cube.Cube, orientation CFENOrientation) (*CFENState, error) {
 		Orientation: orientation,
 		Faces:       faces,
 		Dimension:   c.Size,
-	}, nil
+	}
 }
 
 // GenerateCFEN creates a CFEN string from a cube with default orientation
 func GenerateCFEN(c *cube.Cube) (string, error) {
+	if c == nil {
+		return "", fmt.Errorf("cube cannot be nil")
+	}
+
+	// A cube with its own color scheme is written as it sits, labeled with
+	// the colors its scheme puts on Up and Front
+	if !c.HasStandardScheme() {
+		orientation := CFENOrientation{Up: c.SolvedColor(cube.Up), Front: c.SolvedColor(cube.Front)}
+		identity := [6]cube.Face{cube.Up, cube.Right, cube.Front, cube.Down, cube.Left, cube.Back}
+		return fromCubeFaces(c, orientation, identity).String(), nil
+	}
+
 	// Use default orientation matching cube's canonical orientation (Yellow up, Blue front)
 	orientation := CFENOrientation{
 		Up:    cube.Yellow,
@@ -181,3 +269,13 @@ func getReverseOrientationMapping(orientation CFENOrientation) [6]cube.Face {
 	// Default fallback
 	return [6]cube.Face{cube.Up, cube.Right, cube.Front, cube.Down, cube.Left, cube.Back}
 }
+
+// GeneratePieceMaskedCFEN creates a CFEN pattern of c in which every piece
+// that matches reference is greyed out as a whole, so the pattern shows only
+// the pieces that changed (3x3 only)
+func GeneratePieceMaskedCFEN(c, reference *cube.Cube) (string, error) {
+	if c.Size != 3 || reference.Size != 3 {
+		return "", fmt.Errorf("piece masks require 3x3 cubes, got %dx%d and %dx%d", c.Size, c.Size, reference.Size, reference.Size)
+	}
+	return GenerateCFEN(cube.GeneratePieceMask(c, reference).Apply(c))
+}
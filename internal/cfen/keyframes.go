@@ -0,0 +1,64 @@
+package cfen
+
+import (
+	"fmt"
+
+	"github.com/ehrlich-b/cube/internal/cube"
+)
+
+// Keyframe is one step of a solve animation: the move played and the CFEN of
+// the cube after it. The first keyframe of a sequence has no move and holds
+// the starting state.
+type Keyframe struct {
+	Move string `json:"move"`
+	CFEN string `json:"cfen"`
+}
+
+// SolveToKeyframes scrambles a solved 3x3 cube, solves it with the named
+// solver, and returns the starting state followed by one keyframe per
+// solution move
+func SolveToKeyframes(scramble, solver string) ([]Keyframe, error) {
+	moves, err := cube.ParseScramble(scramble)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing scramble: %w", err)
+	}
+
+	s, err := cube.GetSolver(solver)
+	if err != nil {
+		return nil, err
+	}
+
+	c := cube.NewCube(3)
+	c.ApplyMoves(moves)
+
+	result, err := cube.SolveVerified(s, c)
+	if err != nil {
+		return nil, fmt.Errorf("error solving cube: %w", err)
+	}
+
+	return Keyframes(c, result.Solution)
+}
+
+// Keyframes plays moves on a copy of c and records the state after each one.
+// The first keyframe holds the starting state.
+func Keyframes(c *cube.Cube, moves []cube.Move) ([]Keyframe, error) {
+	c = c.Clone()
+
+	start, err := GenerateCFEN(c)
+	if err != nil {
+		return nil, err
+	}
+
+	keyframes := make([]Keyframe, 0, len(moves)+1)
+	keyframes = append(keyframes, Keyframe{CFEN: start})
+	for _, move := range moves {
+		c.ApplyMove(move)
+		state, err := GenerateCFEN(c)
+		if err != nil {
+			return nil, err
+		}
+		keyframes = append(keyframes, Keyframe{Move: move.String(), CFEN: state})
+	}
+
+	return keyframes, nil
+}
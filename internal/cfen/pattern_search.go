@@ -0,0 +1,38 @@
+package cfen
+
+import (
+	"fmt"
+
+	"github.com/ehrlich-b/cube/internal/cube"
+)
+
+// PatternSearchDepth is the longest solution SolveToPattern searches for.
+// The search has no heuristic and checks every state with MatchesCube, so
+// each extra move costs roughly 13 times as much; 6 moves covers most
+// crosses and takes a few seconds at worst.
+const PatternSearchDepth = 6
+
+// SolveToPattern returns a shortest sequence of face turns that brings c
+// to a state matching pattern, a CFEN whose wildcard stickers match any
+// color. Masking everything but the cross, say, solves just the cross. It
+// returns a *cube.SearchLimitError if no sequence of up to
+// PatternSearchDepth moves matches. c is not modified.
+func SolveToPattern(c *cube.Cube, pattern string) (*cube.SolverResult, error) {
+	state, err := ParseCFEN(pattern)
+	if err != nil {
+		return nil, err
+	}
+	if c.Size != state.Dimension {
+		return nil, fmt.Errorf("cube dimension %d doesn't match pattern dimension %d", c.Size, state.Dimension)
+	}
+
+	matches := func(c *cube.Cube) bool {
+		ok, err := state.MatchesCube(c)
+		return err == nil && ok
+	}
+	result, err := cube.SolveToGoal(c, matches, PatternSearchDepth)
+	if limit, ok := err.(*cube.SearchLimitError); ok {
+		limit.Search = "pattern"
+	}
+	return result, err
+}
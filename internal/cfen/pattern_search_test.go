@@ -0,0 +1,58 @@
+package cfen
+
+import (
+	"testing"
+
+	"github.com/ehrlich-b/cube/internal/cube"
+)
+
+// crossPattern masks everything but the white cross on the D face
+const crossPattern = "YB|?9/?7R?/?7B?/?W?W3?W?/?7O?/?7G?"
+
+func TestSolveToPattern(t *testing.T) {
+	tests := []struct {
+		scramble string
+		maxSteps int
+	}{
+		{"", 0},
+		{"U2 R U R'", 0}, // Leaves the D edges in place
+		{"F R", 2},
+		{"D R' F2 L", 4},
+	}
+
+	pattern, err := ParseCFEN(crossPattern)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range tests {
+		c := cube.NewCube(3)
+		moves, _ := cube.ParseScramble(tt.scramble)
+		c.ApplyMoves(moves)
+		before := c.String()
+
+		result, err := SolveToPattern(c, crossPattern)
+		if err != nil {
+			t.Fatalf("%q: SolveToPattern() error = %v", tt.scramble, err)
+		}
+		if c.String() != before {
+			t.Errorf("%q: SolveToPattern modified the cube", tt.scramble)
+		}
+		if len(result.Solution) > tt.maxSteps {
+			t.Errorf("%q: solution %s is longer than %d moves", tt.scramble, cube.FormatMoves(result.Solution), tt.maxSteps)
+		}
+		c.ApplyMoves(result.Solution)
+		if ok, _ := pattern.MatchesCube(c); !ok {
+			t.Errorf("%q: solution %s doesn't reach the pattern", tt.scramble, cube.FormatMoves(result.Solution))
+		}
+	}
+}
+
+func TestSolveToPatternErrors(t *testing.T) {
+	c := cube.NewCube(3)
+	if _, err := SolveToPattern(c, "not a cfen"); err == nil {
+		t.Error("SolveToPattern with a bad CFEN succeeded")
+	}
+	if _, err := SolveToPattern(c, SolvedCFEN(2)); err == nil {
+		t.Error("SolveToPattern with a 2x2 pattern for a 3x3 succeeded")
+	}
+}
@@ -0,0 +1,112 @@
+package cli
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ehrlich-b/cube/internal/cube"
+	"github.com/spf13/cobra"
+)
+
+var algsCmd = &cobra.Command{
+	Use:   "algs",
+	Short: "Browse the algorithm database",
+	Long:  `Commands for browsing the algorithm database as a whole.`,
+}
+
+var algsStatsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Show move-count statistics for the algorithm database",
+	Long: `Show how many algorithms each category holds and how long they are:
+the minimum, maximum, and average move count and the full distribution.
+
+Moves are counted in the metric chosen with --metric (htm, qtm, stm, atm,
+or etm); see 'cube count --help' for how each metric counts.
+
+Examples:
+  cube algs stats
+  cube algs stats --category CFOP-OLL
+  cube algs stats --category PLL --metric qtm`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		category, _ := cmd.Flags().GetString("category")
+		metricName, _ := cmd.Flags().GetString("metric")
+
+		metric, err := cube.ParseMetric(metricName)
+		if err != nil {
+			return err
+		}
+
+		algorithms := cube.GetAllAlgorithms()
+		if category != "" {
+			algorithms = cube.GetByCategory(category)
+			if len(algorithms) == 0 {
+				return fmt.Errorf("no algorithms in category '%s'", category)
+			}
+		}
+
+		byCategory := make(map[string][]int)
+		var all []int
+		for _, alg := range algorithms {
+			moves, err := cube.ParseScramble(alg.Moves)
+			if err != nil {
+				continue
+			}
+			count := cube.CountMetric(moves, metric)
+			byCategory[alg.Category] = append(byCategory[alg.Category], count)
+			all = append(all, count)
+		}
+
+		categories := make([]string, 0, len(byCategory))
+		for name := range byCategory {
+			categories = append(categories, name)
+		}
+		sort.Strings(categories)
+
+		fmt.Printf("Algorithm statistics (%s)\n\n", metric)
+		for _, name := range categories {
+			printMoveCountStats(name, byCategory[name])
+		}
+		if len(categories) > 1 {
+			printMoveCountStats("All categories", all)
+		}
+		return nil
+	},
+}
+
+// printMoveCountStats prints the count, range, average, and distribution of
+// a category's move counts
+func printMoveCountStats(name string, counts []int) {
+	sort.Ints(counts)
+	total := 0
+	distribution := make(map[int]int)
+	for _, count := range counts {
+		total += count
+		distribution[count]++
+	}
+
+	noun := "algorithms"
+	if len(counts) == 1 {
+		noun = "algorithm"
+	}
+	fmt.Printf("%s: %d %s\n", name, len(counts), noun)
+	fmt.Printf("  Moves: min %d, max %d, average %.1f\n",
+		counts[0], counts[len(counts)-1], float64(total)/float64(len(counts)))
+
+	var lengths []string
+	for count := counts[0]; count <= counts[len(counts)-1]; count++ {
+		if n := distribution[count]; n > 0 {
+			lengths = append(lengths, fmt.Sprintf("%d:%d", count, n))
+		}
+	}
+	fmt.Printf("  Distribution (moves:algorithms): %s\n\n", strings.Join(lengths, " "))
+}
+
+func init() {
+	algsStatsCmd.Flags().StringP("category", "c", "", "Only include this category (e.g. CFOP-OLL, PLL)")
+	algsStatsCmd.Flags().StringP("metric", "m", "htm", "Move metric (htm, qtm, stm, atm, etm)")
+
+	algsCmd.AddCommand(algsStatsCmd)
+	rootCmd.AddCommand(algsCmd)
+}
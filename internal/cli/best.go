@@ -0,0 +1,147 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ehrlich-b/cube/internal/cfen"
+	"github.com/ehrlich-b/cube/internal/cube"
+	"github.com/spf13/cobra"
+)
+
+var bestCmd = &cobra.Command{
+	Use:   "best [case-id]",
+	Short: "Show the shortest database algorithm for a case",
+	Long: `Best picks the algorithm with the fewest moves for a case from the
+database, counted in the metric of your choice. Name the case by its ID, or
+pass a cube state with --cfen to have the OLL or PLL case recognized (the
+first two layers must be solved). With a state, Best also works out the U
+turns to make before and after the algorithm (the AUF), e.g. "Do U2, then
+the algorithm, then U'." A state that matches no case gets the closest
+one named instead, with how many stickers are off.
+
+Examples:
+  cube best OLL-27
+  cube best PLL-T --metric qtm
+  cube best --cfen "YB|RYBY5O/G2YR6/GBYB6/W9/BR2O6/O2YG6"`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeCaseIDs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		metricName, _ := cmd.Flags().GetString("metric")
+		state, _ := cmd.Flags().GetString("cfen")
+
+		metric, err := cube.ParseMetric(metricName)
+		if err != nil {
+			return err
+		}
+
+		var caseID string
+		var c *cube.Cube
+		switch {
+		case len(args) == 1 && state != "":
+			return fmt.Errorf("give either a case ID or --cfen, not both")
+		case len(args) == 1:
+			caseID = args[0]
+		case state != "":
+			caseID, c, err = recognizeCase(state)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("Recognized %s\n", caseID)
+		default:
+			return fmt.Errorf("give a case ID such as OLL-27, or a state with --cfen")
+		}
+
+		alg, ok := cube.ShortestAlgorithmFor(caseID, metric)
+		if !ok {
+			return fmt.Errorf("no algorithm for case '%s'", caseID)
+		}
+		moves, _ := cube.ParseScramble(alg.Moves)
+
+		fmt.Printf("%s - %s\n", alg.CaseID, alg.Name)
+		fmt.Printf("Moves: %s\n", alg.Moves)
+		fmt.Printf("Length: %d %s\n", cube.CountMetric(moves, metric), metric)
+		if others := len(cube.GetByCaseID(caseID)) - 1; others > 0 {
+			fmt.Printf("Shortest of %d algorithms for this case.\n", others+1)
+		}
+
+		if c != nil {
+			pre, post, ok := cube.BestAUF(c, alg)
+			if !ok {
+				return fmt.Errorf("no U turns before or after the algorithm solve this state; check that it really solves %s", caseID)
+			}
+			fmt.Println(describeAUF(pre, post))
+		}
+		return nil
+	},
+}
+
+// recognizeCase returns the OLL or PLL case of a CFEN state, and the state
+// as a cube
+func recognizeCase(state string) (string, *cube.Cube, error) {
+	parsed, err := cfen.ParseCFEN(state)
+	if err != nil {
+		return "", nil, fmt.Errorf("invalid CFEN: %v", err)
+	}
+	c, err := parsed.ToCube()
+	if err != nil {
+		return "", nil, fmt.Errorf("invalid CFEN: %v", err)
+	}
+
+	if caseID, ok := cube.RecognizeOLL(c); ok {
+		return caseID, c, nil
+	}
+	if caseID, ok := cube.RecognizePLL(c); ok {
+		return caseID, c, nil
+	}
+	return "", nil, fmt.Errorf("state is not a recognized OLL or PLL case%s", nearestCaseHint(c))
+}
+
+// nearestCaseHint names the OLL or PLL case closest to an unrecognized
+// state, to append to an error; PLL wins ties. A solved cube gets none.
+func nearestCaseHint(c *cube.Cube) string {
+	if c.IsSolved() {
+		return ""
+	}
+	caseID, distance := cube.NearestCase(c, "OLL")
+	if pll, pllDistance := cube.NearestCase(c, "PLL"); pllDistance >= 0 && (distance < 0 || pllDistance <= distance) {
+		caseID, distance = pll, pllDistance
+	}
+	if distance <= 0 {
+		return ""
+	}
+	stickers := "stickers"
+	if distance == 1 {
+		stickers = "sticker"
+	}
+	return fmt.Sprintf("; closest is %s, %d %s off", caseID, distance, stickers)
+}
+
+// describeAUF spells out the U turns around an algorithm
+func describeAUF(pre, post int) string {
+	steps := []string{"the algorithm"}
+	if pre != 0 {
+		steps = append([]string{cube.FormatMoves(cube.AUFMoves(pre))}, steps...)
+	}
+	if post != 0 {
+		steps = append(steps, cube.FormatMoves(cube.AUFMoves(post)))
+	}
+	if len(steps) == 1 {
+		return "Do the algorithm as written."
+	}
+	return "Do " + strings.Join(steps, ", then ") + "."
+}
+
+// completeCaseIDs suggests case IDs for the first argument
+func completeCaseIDs(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return matchingPrefix(cube.CaseIDs(), toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+func init() {
+	bestCmd.Flags().StringP("metric", "m", "htm", "Move metric to compare by (htm, qtm, stm, atm, etm)")
+	bestCmd.Flags().String("cfen", "", "Recognize the case from a CFEN state instead of naming it")
+	rootCmd.AddCommand(bestCmd)
+}
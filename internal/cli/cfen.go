@@ -2,6 +2,9 @@ package cli
 
 import (
 	"fmt"
+	"io"
+	"os"
+	"strings"
 
 	"github.com/ehrlich-b/cube/internal/cfen"
 	"github.com/ehrlich-b/cube/internal/cube"
@@ -17,7 +20,13 @@ Examples:
   cube parse-cfen "WG|W9/R9/G9/Y9/O9/B9"                    # Solved 3x3
   cube parse-cfen "WG|?W?WWW?W?/?9/?9/?9/?9/?9"              # White cross only
   cube parse-cfen "WG|W16/R16/G16/Y16/O16/B16"               # Solved 4x4
-  cube parse-cfen "WG|Y25/?25/?25/?25/?25/?25"               # 5x5 OLL drill`,
+  cube parse-cfen "WG|Y25/?25/?25/?25/?25/?25"               # 5x5 OLL drill
+  cube parse-cfen --check "WG|W9/R9/G9/Y9/O9/B9"            # Is this a real cube state?
+  cube parse-cfen --format facelets "WG|W9/R9/G9/Y9/O9/B9"   # Convert for another solver
+
+Use --check to test whether the state can be reached from solved (no
+twisted corner, flipped edge, or lone swap) and report its optimal
+solution length: exact for 2x2, and for 3x3 when it is at most 8 moves.`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		cfenStr := args[0]
@@ -34,6 +43,15 @@ Examples:
 			return fmt.Errorf("failed to convert CFEN to cube: %v", err)
 		}
 
+		if format, _ := cmd.Flags().GetString("format"); format != "net" {
+			state, err := formatState(cube, format)
+			if err != nil {
+				return err
+			}
+			fmt.Println(state)
+			return nil
+		}
+
 		// Get display flags
 		useColor, _ := cmd.Flags().GetBool("color")
 		useUnicode := useColor // Use Unicode blocks when color is enabled
@@ -48,7 +66,11 @@ Examples:
 		fmt.Printf("Orientation: %s up, %s front\n",
 			cfenState.Orientation.Up.String(),
 			cfenState.Orientation.Front.String())
-		fmt.Printf("Solved: %t\n\n", cube.IsSolved())
+		fmt.Printf("Solved: %t\n", cube.IsSolved())
+		if check, _ := cmd.Flags().GetBool("check"); check {
+			printValidity(cube)
+		}
+		fmt.Println()
 
 		// Display cube state
 		fmt.Print(cube.UnfoldedString(useColor && !useUnicode, useUnicode))
@@ -57,6 +79,29 @@ Examples:
 	},
 }
 
+// printValidity reports whether c is a reachable state and, if so, how
+// far it is from solved
+func printValidity(c *cube.Cube) {
+	if err := c.Validate(); err != nil {
+		fmt.Printf("Valid: false (%v)\n", err)
+		return
+	}
+	fmt.Println("Valid: true")
+
+	switch c.Size {
+	case 2:
+		fmt.Printf("Optimal solution: %d moves\n", len(cube.Solve2x2Optimal(c)))
+	case 3:
+		if optimal, found := cube.OptimalLength(c, cube.MaxOptimalSearchDepth); found {
+			fmt.Printf("Optimal solution: %d moves\n", optimal)
+		} else {
+			fmt.Printf("Optimal solution: more than %d moves\n", cube.MaxOptimalSearchDepth)
+		}
+	default:
+		fmt.Println("Optimal solution: unknown (only computed for 2x2 and 3x3)")
+	}
+}
+
 var generateCfenCmd = &cobra.Command{
 	Use:   "generate-cfen <scramble>",
 	Short: "Apply scramble moves and output the resulting CFEN string",
@@ -123,6 +168,53 @@ Examples:
 	},
 }
 
+var applyCfenCmd = &cobra.Command{
+	Use:   "apply-cfen <cfen> <moves>",
+	Short: "Apply moves to a CFEN state and output the resulting CFEN",
+	Long: `Apply moves to the cube a CFEN string describes and print the resulting
+state as CFEN, written in the same orientation as the input. Wildcard
+stickers move with their pieces, so drills can be built up step by step.
+This is the CFEN counterpart of twist.
+
+Use --show to also draw the resulting cube.
+
+Examples:
+  cube apply-cfen "YB|Y9/R9/B9/W9/O9/G9" "R U R' U'"
+  cube apply-cfen "YB|?Y?YYY?Y?/?9/?9/W9/?9/?9" "F R U R' U' F'" --show
+  cube apply-cfen "$(cube apply-cfen "YB|Y9/R9/B9/W9/O9/G9" "R U")" "U' R'"`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		state, err := cfen.ParseCFEN(args[0])
+		if err != nil {
+			return fmt.Errorf("failed to parse CFEN: %v", err)
+		}
+		c, err := state.ToCube()
+		if err != nil {
+			return fmt.Errorf("failed to convert CFEN to cube: %v", err)
+		}
+
+		moves, err := cube.ParseScrambleForSize(args[1], c.Size)
+		if err != nil {
+			return fmt.Errorf("invalid moves: %v", err)
+		}
+		c.ApplyMoves(moves)
+
+		result, err := cfen.FromCube(c, state.Orientation)
+		if err != nil {
+			return fmt.Errorf("failed to generate CFEN: %v", err)
+		}
+		fmt.Println(result.String())
+
+		if show, _ := cmd.Flags().GetBool("show"); show {
+			useColor, _ := cmd.Flags().GetBool("color")
+			useLetters, _ := cmd.Flags().GetBool("letters")
+			fmt.Println()
+			fmt.Print(c.UnfoldedString(useColor, useColor && !useLetters))
+		}
+		return nil
+	},
+}
+
 var verifyCfenCmd = &cobra.Command{
 	Use:   "verify-cfen <scramble> <solution> --target <cfen>",
 	Short: "Verify that a solution reaches the target CFEN state",
@@ -271,24 +363,94 @@ Examples:
 	},
 }
 
+var netCfenCmd = &cobra.Command{
+	Use:   "net-cfen [file]",
+	Short: "Read a cube drawn as an unfolded net and output its CFEN string",
+	Long: `Read a cube in the unfolded net layout that show, twist, and solve print,
+and output its state as a CFEN string. The net is read from the file, or
+from standard input when no file is given.
+
+This lets you copy a displayed cube, edit a sticker, and use the result
+with any command that takes a CFEN state. Letters, colored letters, and
+Unicode squares are all accepted.
+
+Examples:
+  cube twist "R U" > net.txt                   # then edit net.txt
+  cube net-cfen net.txt
+  cube show "R U R' U'" | cube net-cfen --check`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var input []byte
+		var err error
+		if len(args) == 1 {
+			input, err = os.ReadFile(args[0])
+		} else {
+			input, err = io.ReadAll(os.Stdin)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read net: %v", err)
+		}
+
+		c, err := cube.CubeFromNet(netLines(string(input)))
+		if err != nil {
+			return fmt.Errorf("failed to parse net: %v", err)
+		}
+
+		state, err := cfen.GenerateCFEN(c)
+		if err != nil {
+			return fmt.Errorf("failed to generate CFEN: %v", err)
+		}
+		fmt.Println(state)
+		if check, _ := cmd.Flags().GetBool("check"); check {
+			printValidity(c)
+		}
+		return nil
+	},
+}
+
+// netLines keeps only the lines of command output that look like part of a
+// net, so the text around a printed cube can be pasted along with it
+func netLines(text string) string {
+	var kept []string
+	for _, line := range strings.Split(text, "\n") {
+		if strings.Contains(line, ":") {
+			continue
+		}
+		kept = append(kept, line)
+	}
+	return strings.Join(kept, "\n")
+}
+
 func init() {
 	// Add flags to parse-cfen
 	parseCfenCmd.Flags().Bool("color", false, "Use colored output")
 	parseCfenCmd.Flags().Bool("letters", false, "Use colored letters instead of blocks")
+	parseCfenCmd.Flags().Bool("check", false, "Check that the state is reachable and report its optimal solution length")
+	parseCfenCmd.Flags().String("format", "net", "Output format (net, cfen, facelets, json)")
 
 	// Add flags to generate-cfen
 	generateCfenCmd.Flags().Int("dimension", 3, "Cube dimension (2-20)")
 	generateCfenCmd.Flags().String("start", "", "Starting CFEN state (default: solved)")
 
+	// Add flags to apply-cfen
+	applyCfenCmd.Flags().Bool("show", false, "Also draw the resulting cube")
+	applyCfenCmd.Flags().Bool("color", false, "Use colored output with --show")
+	applyCfenCmd.Flags().Bool("letters", false, "Use colored letters instead of blocks")
+
 	// Add flags to verify-cfen
 	verifyCfenCmd.Flags().String("target", "", "Target CFEN pattern (required)")
 	verifyCfenCmd.Flags().Int("dimension", 0, "Cube dimension (auto-detect from target if not specified)")
 	verifyCfenCmd.Flags().Bool("verbose", false, "Show detailed comparison")
 	verifyCfenCmd.MarkFlagRequired("target")
 
+	// Add flags to net-cfen
+	netCfenCmd.Flags().Bool("check", false, "Check that the state is reachable and report its optimal solution length")
+
 	// Register commands
 	rootCmd.AddCommand(parseCfenCmd)
 	rootCmd.AddCommand(generateCfenCmd)
+	rootCmd.AddCommand(applyCfenCmd)
 	rootCmd.AddCommand(verifyCfenCmd)
 	rootCmd.AddCommand(matchCfenCmd)
+	rootCmd.AddCommand(netCfenCmd)
 }
@@ -0,0 +1,95 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/ehrlich-b/cube/internal/cube"
+	"github.com/spf13/cobra"
+)
+
+var coachCmd = &cobra.Command{
+	Use:   "coach <session-file>",
+	Short: "Suggest which OLL and PLL cases to drill next",
+	Long: `Coach reads a practice log and suggests which cases to drill next. The log
+has one attempt per line, a case ID and the time in seconds it took:
+
+  # Monday
+  PLL-T 2.41
+  OLL-27 1.84
+
+Each case of a set (OLL or PLL) with attempts in the log is scored by how
+often it comes up in a solve times how slow it is: its mean time over the
+set's mean. A case with no attempts yet counts as twice as slow as
+average. The highest scores are worth drilling most.
+
+Use --weaknesses to list instead the cases whose mean time is behind their
+set's mean, furthest behind first. Record attempts into the log with
+cube train --session.
+
+Examples:
+  cube coach times.txt
+  cube coach times.txt --limit 0
+  cube coach times.txt --weaknesses`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		limit, _ := cmd.Flags().GetInt("limit")
+		weaknesses, _ := cmd.Flags().GetBool("weaknesses")
+
+		file, err := os.Open(args[0])
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		session, err := cube.ParseSession(file)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", args[0], err)
+		}
+
+		if weaknesses {
+			printWeaknesses(session.Weaknesses(), limit)
+			return nil
+		}
+
+		recommendations := cube.RecommendAlgorithms(session)
+		if len(recommendations) == 0 {
+			fmt.Println("No OLL or PLL attempts in the log.")
+			return nil
+		}
+		if limit > 0 && len(recommendations) > limit {
+			recommendations = recommendations[:limit]
+		}
+
+		fmt.Printf("%-8s %8s %9s %10s %9s %7s\n", "Case", "Chance", "Attempts", "Mean", "Slowness", "Score")
+		for _, rec := range recommendations {
+			mean := "-"
+			if rec.Attempts > 0 {
+				mean = fmt.Sprintf("%.2fs", rec.MeanTime.Seconds())
+			}
+			fmt.Printf("%-8s %7.2f%% %9d %10s %9.2f %7.4f\n",
+				rec.CaseID, rec.Probability*100, rec.Attempts, mean, rec.Slowness, rec.Score)
+		}
+		return nil
+	},
+}
+
+// printWeaknesses prints up to limit weaknesses (0 for all), one sentence each
+func printWeaknesses(weaknesses []cube.CaseStat, limit int) {
+	if len(weaknesses) == 0 {
+		fmt.Println("No case is behind its set's mean.")
+		return
+	}
+	if limit > 0 && len(weaknesses) > limit {
+		weaknesses = weaknesses[:limit]
+	}
+	for _, stat := range weaknesses {
+		fmt.Printf("Your %s average is %.2fs slower than your %s mean (%.2fs over %d attempts, best %.2fs)\n",
+			stat.CaseID, stat.Behind.Seconds(), stat.Set, stat.Mean.Seconds(), stat.Attempts, stat.Best.Seconds())
+	}
+}
+
+func init() {
+	coachCmd.Flags().IntP("limit", "n", 10, "Number of cases to suggest (0 for all)")
+	coachCmd.Flags().Bool("weaknesses", false, "List cases slower than their set's mean instead")
+	rootCmd.AddCommand(coachCmd)
+}
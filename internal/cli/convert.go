@@ -0,0 +1,46 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/ehrlich-b/cube/internal/cube"
+	"github.com/spf13/cobra"
+)
+
+var convertCmd = &cobra.Command{
+	Use:   "convert <moves>",
+	Short: "Rewrite a move sequence in WCA or SiGN notation",
+	Long: `Rewrite a move sequence with its wide turns in another notation style.
+
+Styles:
+  wca   Wide turns take a w suffix: Rw, 3Rw'
+  sign  Wide turns are a lowercase face: r, 3r'
+
+Face, slice, and layer turns and rotations are written the same in both.
+The input may use either style, or a mix of the two.
+
+Examples:
+  cube convert "Rw U Rw' F" --to sign
+  cube convert "r U R' U' r' F R F'" --to wca
+  cube convert "3r U2 3r'" --to wca`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		styleName, _ := cmd.Flags().GetString("to")
+
+		style, err := cube.ParseNotationStyle(styleName)
+		if err != nil {
+			return err
+		}
+		converted, err := cube.ConvertNotation(args[0], style)
+		if err != nil {
+			return fmt.Errorf("error parsing moves: %v", err)
+		}
+		fmt.Println(converted)
+		return nil
+	},
+}
+
+func init() {
+	convertCmd.Flags().StringP("to", "t", "wca", "Notation style to write (wca, sign)")
+	rootCmd.AddCommand(convertCmd)
+}
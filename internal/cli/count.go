@@ -0,0 +1,73 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/ehrlich-b/cube/internal/cube"
+	"github.com/spf13/cobra"
+)
+
+var countCmd = &cobra.Command{
+	Use:   "count <moves>",
+	Short: "Count a move sequence in one or every metric",
+	Long: `Count a move sequence in a move metric.
+
+Metrics:
+  htm  Half-turn metric, also called FTM: every outer or wide turn counts
+       one, slice turns count two
+  qtm  Quarter-turn metric: like HTM, but half turns count two
+  stm  Slice-turn metric: every layer turn counts one
+  atm  Axial-turn metric: consecutive turns about one axis count one
+  etm  Execution-turn metric: every move counts one, rotations included
+
+Rotations are free in every metric but ETM. Wide turns, written either as
+Rw or r, count as face turns.
+
+Examples:
+  cube count "R U R' U'"
+  cube count "M2 U M2 U2 M2 U M2" --metric qtm
+  cube count "r U R' U' r' F R F'" --all`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		metricName, _ := cmd.Flags().GetString("metric")
+		all, _ := cmd.Flags().GetBool("all")
+
+		moves, err := cube.ParseScramble(args[0])
+		if err != nil {
+			return fmt.Errorf("error parsing moves: %v", err)
+		}
+
+		if !all {
+			metric, err := cube.ParseMetric(metricName)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("%d %s\n", cube.CountMetric(moves, metric), metric)
+			return nil
+		}
+
+		for _, metric := range cube.Metrics() {
+			fmt.Printf("%s: %d\n", metric, cube.CountMetric(moves, metric))
+		}
+
+		var wide, slices, rotations int
+		for _, move := range moves {
+			switch {
+			case move.Rotation != cube.NoRotation:
+				rotations++
+			case cube.IsSliceTurn(move):
+				slices++
+			case move.Wide:
+				wide++
+			}
+		}
+		fmt.Printf("\nWide turns: %d, slice turns: %d, rotations: %d\n", wide, slices, rotations)
+		return nil
+	},
+}
+
+func init() {
+	countCmd.Flags().StringP("metric", "m", "htm", "Move metric (htm, qtm, stm, atm, etm)")
+	countCmd.Flags().BoolP("all", "a", false, "Print the count in every metric and the slice, wide, and rotation moves")
+	rootCmd.AddCommand(countCmd)
+}
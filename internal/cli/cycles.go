@@ -0,0 +1,43 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/ehrlich-b/cube/internal/cube"
+	"github.com/spf13/cobra"
+)
+
+var cyclesCmd = &cobra.Command{
+	Use:   "cycles <moves>",
+	Short: "Show how an algorithm cycles the corners and edges",
+	Long: `Apply an algorithm to a solved 3x3 and describe how it moves the corner
+and edge pieces, as disjoint cycles of positions. In "UF → UR → UL" the
+piece at UF moves to UR, the piece at UR to UL, and the piece at UL back
+to UF. Pieces that stay in place are left out, even if they are twisted or
+flipped.
+
+Examples:
+  cube cycles "R U R' U' R' F R2 U' R' U' R U R' F'"  # T-perm
+  cube cycles "R U' R U R U R U' R' U' R2"           # Ua-perm
+  cube cycles "R U R' U R U2 R'"                     # Sune`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		moves, err := cube.ParseScrambleForSize(args[0], 3)
+		if err != nil {
+			return fmt.Errorf("error parsing moves: %v", err)
+		}
+
+		lines := cube.DescribeCycles(cube.AnalyzeCycles(moves))
+		if len(lines) == 0 {
+			fmt.Println("No pieces move.")
+		}
+		for _, line := range lines {
+			fmt.Println(line)
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(cyclesCmd)
+}
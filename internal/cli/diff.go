@@ -0,0 +1,94 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/ehrlich-b/cube/internal/cfen"
+	"github.com/ehrlich-b/cube/internal/cube"
+	"github.com/spf13/cobra"
+)
+
+var diffCmd = &cobra.Command{
+	Use:   "diff <a> <b>",
+	Short: "Show the stickers that differ between two scrambles or CFEN states",
+	Long: `Diff applies each scramble to a solved cube and shows where the results
+differ: both cubes are drawn with the differing stickers highlighted, then
+each difference is listed. Use it to see how two algorithms differ in
+effect, or why a reconstruction didn't solve.
+
+With --cfen, the arguments are CFEN states instead of scrambles.
+
+Examples:
+  cube diff "R U R' U'" "R U' R' U'"
+  cube diff "R U R' U R U2 R'" "L' U' L U' L' U2 L" --color
+  cube diff --cfen "YB|Y9/R9/B9/W9/O9/G9" "YB|Y6B3/YG2R6/R3B2WB2W/W2GW2GW2G/B2WO6/O3YG2YG2"`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		useCFEN, _ := cmd.Flags().GetBool("cfen")
+		dimension, _ := cmd.Flags().GetInt("dimension")
+		useColor, _ := cmd.Flags().GetBool("color")
+		useLetters, _ := cmd.Flags().GetBool("letters")
+		useUnicode := useColor && !useLetters
+
+		cubes := make([]*cube.Cube, 2)
+		for i, arg := range args {
+			c, err := diffState(arg, useCFEN, dimension)
+			if err != nil {
+				return fmt.Errorf("%c: %v", 'a'+i, err)
+			}
+			cubes[i] = c
+		}
+
+		diffs, err := cube.DiffCubes(cubes[0], cubes[1])
+		if err != nil {
+			return err
+		}
+		if len(diffs) == 0 {
+			fmt.Println("The two states are identical.")
+			return nil
+		}
+
+		opts := cube.RenderOptions{Color: useColor, Unicode: useUnicode, Highlight: cube.DiffHighlight(diffs)}
+		for i, c := range cubes {
+			fmt.Printf("%c: %s\n\n", 'a'+i, args[i])
+			if err := c.Render(cmd.OutOrStdout(), opts); err != nil {
+				return err
+			}
+			fmt.Println()
+		}
+
+		fmt.Printf("%d stickers differ:\n", len(diffs))
+		for _, diff := range diffs {
+			fmt.Printf("  %s row %d col %d: %s -> %s\n", diff.Face, diff.Row+1, diff.Col+1, diff.A, diff.B)
+		}
+		return nil
+	},
+}
+
+// diffState builds the cube for one side of a diff: a CFEN state, or a
+// scramble applied to a solved cube
+func diffState(arg string, useCFEN bool, dimension int) (*cube.Cube, error) {
+	if useCFEN {
+		state, err := cfen.ParseCFEN(arg)
+		if err != nil {
+			return nil, fmt.Errorf("invalid CFEN: %v", err)
+		}
+		return state.ToCube()
+	}
+
+	c := newCube(dimension)
+	moves, err := cube.ParseScrambleForSize(arg, c.Size)
+	if err != nil {
+		return nil, fmt.Errorf("invalid scramble: %v", err)
+	}
+	c.ApplyMoves(moves)
+	return c, nil
+}
+
+func init() {
+	diffCmd.Flags().Bool("cfen", false, "Compare two CFEN states instead of two scrambles")
+	diffCmd.Flags().IntP("dimension", "d", 3, "Cube dimension for scrambles (NxNxN)")
+	diffCmd.Flags().Bool("color", false, "Use colored output")
+	diffCmd.Flags().Bool("letters", false, "Use colored letters instead of blocks when using --color")
+	rootCmd.AddCommand(diffCmd)
+}
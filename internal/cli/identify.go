@@ -34,7 +34,7 @@ var identifyCmd = &cobra.Command{
 			pattern = args[0]
 		} else if suggest {
 			// Use solved state as default for suggestions
-			pattern = "YB|Y9/R9/B9/W9/O9/G9"
+			pattern = cfen.SolvedCFEN(3)
 		} else {
 			return fmt.Errorf("CFEN pattern required (or use --suggest)")
 		}
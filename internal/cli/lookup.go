@@ -19,9 +19,28 @@ Examples:
   cube lookup "R U R' U'"
   cube lookup --category OLL
   cube lookup "T-Perm"
-  cube lookup --pattern "R U R' U'"
-  cube lookup --fuzzy "sun"  # fuzzy matches "Sune", "Anti-Sune"`,
-	Args: cobra.MaximumNArgs(1),
+  cube lookup --by-moves "R U R' U'"
+  cube lookup --exact OLL-27  # only an exact name or case ID
+  cube lookup --fuzzy "sun"  # fuzzy matches "Sune", "Anti-Sune"
+  cube lookup r --limit 0    # show every match, not just the top 10
+  cube lookup --category PLL --two-gen  # only <R,U> algorithms
+
+Algorithms with no recognition text of their own show one generated from
+their effect, such as "two adjacent corners twisted, edges solved".
+
+Each result shows its length in HTM and an estimate of how many regrips
+it takes (rotations, switches between R and L turns, and runs of B, D, E,
+or S turns), to compare how comfortable algorithms of equal length are.
+It also shows the faces the algorithm turns, such as <R,U>; --two-gen keeps
+only algorithms made of R and U turns alone, the fastest to execute.
+
+--exact and --by-moves print "No match." when nothing matches exactly,
+which makes them suited to scripts.
+
+Algorithm names, case IDs, and categories complete with <TAB> once shell
+completion is installed (see "cube completion --help").`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeAlgorithms,
 	Run: func(cmd *cobra.Command, args []string) {
 		query := ""
 		if len(args) > 0 {
@@ -29,20 +48,45 @@ Examples:
 		}
 
 		pattern, _ := cmd.Flags().GetString("pattern")
+		byMoves, _ := cmd.Flags().GetString("by-moves")
+		exact, _ := cmd.Flags().GetBool("exact")
+		if byMoves != "" {
+			pattern = byMoves
+		}
 		category, _ := cmd.Flags().GetString("category")
 		listAll, _ := cmd.Flags().GetBool("all")
 		fuzzy, _ := cmd.Flags().GetBool("fuzzy")
+		limit, _ := cmd.Flags().GetInt("limit")
+		minScore, _ := cmd.Flags().GetInt("min-score")
+		twoGen, _ := cmd.Flags().GetBool("two-gen")
+
+		// Filter two-gen algorithms out of every match, then apply the limit
+		searchLimit := limit
+		if twoGen {
+			searchLimit = 0
+		}
 
 		var results []cube.Algorithm
 
 		// Determine lookup method
 		if pattern != "" {
 			results = cube.LookupByMoves(pattern)
+			if len(results) == 0 {
+				fmt.Println("No match.")
+				return
+			}
 			fmt.Printf("Algorithms matching pattern '%s':\n\n", pattern)
+		} else if exact && query != "" {
+			results = cube.LookupExact(query)
+			if len(results) == 0 {
+				fmt.Println("No match.")
+				return
+			}
+			fmt.Printf("Algorithms named '%s':\n\n", query)
 		} else if category != "" {
 			results = cube.GetByCategory(category)
 			fmt.Printf("Algorithms in category '%s':\n\n", strings.ToUpper(category))
-		} else if listAll {
+		} else if listAll || (twoGen && query == "") {
 			results = cube.GetAllAlgorithms()
 			fmt.Println("All algorithms in database:")
 		} else if query != "" {
@@ -50,17 +94,24 @@ Examples:
 				results = cube.FuzzyLookupAlgorithm(query)
 				fmt.Printf("Fuzzy search results for '%s':\n\n", query)
 			} else {
-				results = cube.LookupAlgorithm(query)
+				results = cube.LookupAlgorithmN(query, searchLimit, minScore)
 				fmt.Printf("Algorithms matching '%s':\n\n", query)
 			}
 		} else {
-			fmt.Println("Please provide a query, use --pattern, --category, or --all")
+			fmt.Println("Please provide a query, use --by-moves, --category, or --all")
 			fmt.Println("\nExample: cube lookup sune")
 			fmt.Println("         cube lookup --category OLL")
 			fmt.Println("         cube lookup --all")
 			return
 		}
 
+		if twoGen {
+			results = twoGenOnly(results)
+			if query != "" && !fuzzy && limit > 0 && len(results) > limit {
+				results = results[:limit]
+			}
+		}
+
 		// Display results
 		if len(results) == 0 {
 			fmt.Println("No algorithms found.")
@@ -79,7 +130,16 @@ Examples:
 			}
 
 			fmt.Printf("Moves: %s\n", alg.Moves)
+			if moves, err := cube.ParseScramble(alg.Moves); err == nil {
+				fmt.Printf("Length: %d HTM, %d regrips\n", cube.CountMetric(moves, cube.HTM), cube.CountRegrips(moves))
+				fmt.Printf("Move group: %s\n", formatMoveGroup(cube.MoveGroup(moves)))
+			}
 			fmt.Printf("Description: %s\n", alg.Description)
+			if alg.HasRecognition() {
+				fmt.Printf("Recognition: %s\n", alg.Recognition)
+			} else if recognition := alg.GenerateRecognition(); recognition != "" {
+				fmt.Printf("Recognition (generated): %s\n", recognition)
+			}
 
 			// Show a preview if color is enabled
 			useColor, _ := cmd.Flags().GetBool("color")
@@ -93,9 +153,58 @@ Examples:
 		if len(results) > 1 {
 			fmt.Printf("\nFound %d algorithms.\n", len(results))
 		}
+		if query != "" && !fuzzy && limit > 0 && len(results) == limit {
+			fmt.Printf("Showing the top %d matches; use --limit 0 to show all.\n", limit)
+		}
 	},
 }
 
+// twoGenOnly keeps the algorithms made of R and U turns alone
+func twoGenOnly(algs []cube.Algorithm) []cube.Algorithm {
+	var kept []cube.Algorithm
+	for _, alg := range algs {
+		if moves, err := cube.ParseScramble(alg.Moves); err == nil && cube.IsTwoGen(moves) {
+			kept = append(kept, alg)
+		}
+	}
+	return kept
+}
+
+// formatMoveGroup writes faces the way cubers write move groups: <R,U,F>
+func formatMoveGroup(faces []cube.Face) string {
+	names := make([]string, len(faces))
+	for i, face := range faces {
+		names[i] = face.String()
+	}
+	return "<" + strings.Join(names, ",") + ">"
+}
+
+// completeAlgorithms suggests algorithm names and case IDs for the query
+func completeAlgorithms(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	candidates := append(cube.AlgorithmNames(), cube.CaseIDs()...)
+	return matchingPrefix(candidates, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeCategories suggests algorithm categories for --category
+func completeCategories(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return matchingPrefix(cube.AlgorithmCategories(), toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// matchingPrefix returns the candidates that start with prefix, ignoring case
+func matchingPrefix(candidates []string, prefix string) []string {
+	prefix = strings.ToLower(prefix)
+	var matches []string
+	for _, candidate := range candidates {
+		if strings.HasPrefix(strings.ToLower(candidate), prefix) {
+			matches = append(matches, candidate)
+		}
+	}
+	return matches
+}
+
 func previewAlgorithm(moves string, useColor bool) {
 	c := cube.NewCube(3)
 	parsedMoves, err := cube.ParseScramble(moves)
@@ -123,10 +232,16 @@ func previewAlgorithm(moves string, useColor bool) {
 }
 
 func init() {
-	lookupCmd.Flags().StringP("pattern", "p", "", "Look up by exact move sequence")
+	lookupCmd.Flags().StringP("pattern", "p", "", "Look up by move sequence (same as --by-moves)")
+	lookupCmd.Flags().String("by-moves", "", "Look up by move sequence, ignoring spacing and wide-move spelling")
+	lookupCmd.Flags().BoolP("exact", "e", false, "Only match the query as an exact name or case ID")
 	lookupCmd.Flags().StringP("category", "c", "", "Filter by category (OLL, PLL, F2L)")
 	lookupCmd.Flags().BoolP("all", "a", false, "List all algorithms")
 	lookupCmd.Flags().Bool("color", false, "Use colored output")
 	lookupCmd.Flags().Bool("preview", false, "Show preview of algorithm effect")
 	lookupCmd.Flags().BoolP("fuzzy", "f", false, "Use fuzzy string matching for better search")
+	lookupCmd.Flags().IntP("limit", "n", 10, "Maximum number of search results (0 for all)")
+	lookupCmd.Flags().Int("min-score", 0, "Minimum relevance score for search results")
+	lookupCmd.Flags().Bool("two-gen", false, "Only show algorithms made of R and U turns (<R,U>)")
+	lookupCmd.RegisterFlagCompletionFunc("category", completeCategories)
 }
@@ -0,0 +1,51 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/ehrlich-b/cube/internal/cube"
+	"github.com/spf13/cobra"
+)
+
+var orderCmd = &cobra.Command{
+	Use:   "order <moves>",
+	Short: "Show how many repetitions return a sequence to solved",
+	Long: `Print the order of a sequence on a 3x3: how many times it must be applied
+to a solved cube before the cube is solved again. Then show why, as the
+disjoint cycles the corners and edges travel along and the pieces left in
+place but twisted or flipped. A piece in a 3-cycle comes home every 3
+repetitions, so the order is a multiple of every cycle length, and more
+when pieces come home turned.
+
+Examples:
+  cube order "R U R' U' R' F R2 U' R' U' R U R' F'"   # T-perm: 2
+  cube order "R U"                                    # 105
+  cube order "U R2 F B R B2 R U2 L B2 R U' D' R2 F R' L B2 U2 F2"  # Superflip: 2`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		moves, err := cube.ParseScrambleForSize(args[0], 3)
+		if err != nil {
+			return fmt.Errorf("error parsing moves: %v", err)
+		}
+
+		order, err := cube.SequenceOrder(moves)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Order: %d\n", order)
+
+		lines := cube.DescribeCycles(cube.AnalyzeCycles(moves))
+		lines = append(lines, cube.DescribeTurnedInPlace(moves)...)
+		if len(lines) == 0 {
+			fmt.Println("No pieces move.")
+		}
+		for _, line := range lines {
+			fmt.Println(line)
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(orderCmd)
+}
@@ -0,0 +1,80 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ehrlich-b/cube/internal/cfen"
+	"github.com/ehrlich-b/cube/internal/cube"
+	"github.com/spf13/cobra"
+)
+
+var patternCmd = &cobra.Command{
+	Use:   "pattern <moves>",
+	Short: "Print the masked CFEN pattern an algorithm makes from solved",
+	Long: `Apply an algorithm to a solved 3x3 and print the pattern it leaves, with
+every piece the algorithm doesn't move greyed out. The masked CFEN is what
+the algorithm database's Pattern field holds, so this is the way to fill it
+in for a new entry. The touched pieces and the move count in every metric
+are printed below it.
+
+Use --quiet to print only the masked CFEN, or --full for the unmasked
+state as well. Give - as the moves to read them from standard input.
+
+Examples:
+  cube pattern "R U R' U R U2 R'"
+  cube pattern "M2 U M2 U2 M2 U M2" --full
+  cube pattern "R U R' U'" --quiet
+  echo "R U R' U'" | cube pattern - --quiet`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		quiet, _ := cmd.Flags().GetBool("quiet")
+		full, _ := cmd.Flags().GetBool("full")
+
+		sequence, err := movesArg(cmd, args[0])
+		if err != nil {
+			return err
+		}
+		moves, err := cube.ParseScrambleForSize(sequence, 3)
+		if err != nil {
+			return fmt.Errorf("error parsing moves: %v", err)
+		}
+
+		solved := cube.NewCube(3)
+		c := solved.Clone()
+		c.ApplyMoves(moves)
+
+		pattern, err := cfen.GeneratePieceMaskedCFEN(c, solved)
+		if err != nil {
+			return err
+		}
+		if quiet {
+			fmt.Println(pattern)
+			return nil
+		}
+
+		fmt.Printf("Moves:   %s\n", cube.FormatMoves(moves))
+		fmt.Printf("Pattern: %s\n", pattern)
+		if full {
+			state, err := cfen.GenerateCFEN(c)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("Full:    %s\n", state)
+		}
+		fmt.Printf("Pieces:  %s\n", cube.GeneratePieceMask(c, solved))
+
+		var counts []string
+		for _, metric := range cube.Metrics() {
+			counts = append(counts, fmt.Sprintf("%d %s", cube.CountMetric(moves, metric), metric))
+		}
+		fmt.Printf("Length:  %s\n", strings.Join(counts, ", "))
+		return nil
+	},
+}
+
+func init() {
+	patternCmd.Flags().BoolP("quiet", "q", false, "Print only the masked CFEN")
+	patternCmd.Flags().Bool("full", false, "Also print the unmasked CFEN")
+	rootCmd.AddCommand(patternCmd)
+}
@@ -0,0 +1,93 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/ehrlich-b/cube/internal/cfen"
+	"github.com/ehrlich-b/cube/internal/cube"
+	"github.com/spf13/cobra"
+)
+
+var reconstructCmd = &cobra.Command{
+	Use:   "reconstruct",
+	Short: "Find moves that turn one CFEN state into another",
+	Long: `Reconstruct finds a move sequence that takes the cube from one state to
+another: it solves the start state, then plays a solution of the end state
+backwards. The moves are not the shortest possible, but applying them to
+the start state gives exactly the end state, including how the cube is
+held.
+
+Both states are CFEN strings of the same size; --from defaults to a solved
+cube of the size of --to.
+
+Examples:
+  cube reconstruct --to "YB|Y7R2/G3WR2WR2/RW2RB2RB2/WO2W2GW2G/B3O2BO2B/O3YG2YG2"
+  cube reconstruct --from "$(cube twist "L D" --cfen)" --to "$(cube twist "R U F'" --cfen)"`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		fromCFEN, _ := cmd.Flags().GetString("from")
+		toCFEN, _ := cmd.Flags().GetString("to")
+		algorithm, _ := cmd.Flags().GetString("algorithm")
+		headless, _ := cmd.Flags().GetBool("headless")
+
+		if toCFEN == "" {
+			return fmt.Errorf("--to is required")
+		}
+		to, err := reconstructState(toCFEN)
+		if err != nil {
+			return fmt.Errorf("--to: %v", err)
+		}
+		from := newCube(to.Size)
+		if fromCFEN != "" {
+			if from, err = reconstructState(fromCFEN); err != nil {
+				return fmt.Errorf("--from: %v", err)
+			}
+		}
+
+		if to.Size == 2 && !cmd.Flags().Changed("algorithm") {
+			algorithm = "optimal2"
+		}
+		solver, err := cube.GetSolver(algorithm)
+		if err != nil {
+			return err
+		}
+
+		result, err := cube.SolveToState(solver, from, to)
+		if err != nil {
+			return err
+		}
+		if headless {
+			fmt.Println(cube.FormatMoves(result.Solution))
+			return nil
+		}
+		if len(result.Solution) == 0 {
+			fmt.Println("The two states are identical.")
+			return nil
+		}
+		fmt.Printf("Moves: %s (%d moves)\n", cube.FormatMoves(result.Solution), result.Steps)
+		return nil
+	},
+}
+
+// reconstructState parses a CFEN state into a cube using the selected
+// move engine
+func reconstructState(state string) (*cube.Cube, error) {
+	parsed, err := cfen.ParseCFEN(state)
+	if err != nil {
+		return nil, fmt.Errorf("invalid CFEN: %v", err)
+	}
+	c, err := parsed.ToCube()
+	if err != nil {
+		return nil, err
+	}
+	c.Engine = moveEngine
+	return c, nil
+}
+
+func init() {
+	reconstructCmd.Flags().String("from", "", "Starting state as a CFEN string (default: solved)")
+	reconstructCmd.Flags().String("to", "", "Ending state as a CFEN string")
+	reconstructCmd.Flags().StringP("algorithm", "a", "kociemba", "Solver used for both states (beginner, cfop, kociemba, optimal2, race; 2x2 cubes default to optimal2)")
+	reconstructCmd.Flags().Bool("headless", false, "Output only space-separated moves for programmatic use")
+	rootCmd.AddCommand(reconstructCmd)
+}
@@ -0,0 +1,214 @@
+package cli
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/ehrlich-b/cube/internal/cube"
+	"github.com/spf13/cobra"
+)
+
+var replCmd = &cobra.Command{
+	Use:   "repl",
+	Short: "Twist a cube interactively",
+	Long: `Open an interactive session holding a cube in memory. Type moves to apply
+them one line at a time; the cube is drawn after every change.
+
+Commands:
+  show              Draw the cube
+  undo [n]          Take back the last move, or the last n moves
+  redo [n]          Apply undone moves again
+  reset             Start again from a solved cube
+  scramble [n]      Apply a random scramble (25 moves by default)
+  solve             Solve the cube with the chosen solver and apply the solution
+  history           List the moves applied since the last reset
+  help              List these commands
+  quit, exit        Leave the session (Ctrl-D works too)
+
+Examples:
+  cube repl
+  cube repl --dimension 2 --color
+  cube repl --algorithm cfop`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dimension, _ := cmd.Flags().GetInt("dimension")
+		algorithm, _ := cmd.Flags().GetString("algorithm")
+		useColor, _ := cmd.Flags().GetBool("color")
+		useLetters, _ := cmd.Flags().GetBool("letters")
+
+		if dimension == 2 && !cmd.Flags().Changed("algorithm") {
+			algorithm = "optimal2"
+		}
+		solver, err := cube.GetSolver(algorithm)
+		if err != nil {
+			return err
+		}
+
+		session := &replSession{
+			out:        cmd.OutOrStdout(),
+			solver:     solver,
+			useColor:   useColor,
+			useUnicode: useColor && !useLetters,
+		}
+		session.reset(dimension)
+		session.run(cmd.InOrStdin())
+		return nil
+	},
+}
+
+// replSession is the state of a cube repl: the cube, which keeps the
+// history of moves applied since the last reset for undo and redo
+type replSession struct {
+	out        io.Writer
+	cube       *cube.Cube
+	solver     cube.Solver
+	useColor   bool
+	useUnicode bool
+}
+
+// run reads commands from in until it ends or the user quits
+func (s *replSession) run(in io.Reader) {
+	fmt.Fprintf(s.out, "%dx%dx%d cube, solving with %s. Type moves, or help for commands.\n",
+		s.cube.Size, s.cube.Size, s.cube.Size, s.solver.Name())
+
+	scanner := bufio.NewScanner(in)
+	for {
+		fmt.Fprint(s.out, "cube> ")
+		if !scanner.Scan() {
+			fmt.Fprintln(s.out)
+			return
+		}
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		if err := s.execute(line); err != nil {
+			if err == errQuit {
+				return
+			}
+			fmt.Fprintf(s.out, "Error: %v\n", err)
+		}
+	}
+}
+
+// errQuit ends a session
+var errQuit = errors.New("quit")
+
+// execute runs one line: a command, or moves to apply
+func (s *replSession) execute(line string) error {
+	fields := strings.Fields(line)
+	command, args := fields[0], fields[1:]
+
+	switch command {
+	case "quit", "exit":
+		return errQuit
+	case "help":
+		fmt.Fprintln(s.out, "Commands: show, undo [n], redo [n], reset, scramble [n], solve, history, help, quit")
+		fmt.Fprintln(s.out, "Anything else is applied as moves, e.g. R U R' U'")
+	case "show":
+		s.show()
+	case "history":
+		if history := s.cube.History(); len(history) == 0 {
+			fmt.Fprintln(s.out, "No moves applied")
+		} else {
+			fmt.Fprintf(s.out, "%s (%d moves)\n", cube.FormatMoves(history), len(history))
+		}
+	case "reset":
+		s.reset(s.cube.Size)
+		s.show()
+	case "undo", "redo":
+		count, err := countArg(args, 1)
+		if err != nil {
+			return err
+		}
+		step, verb := s.cube.Undo, "Undid"
+		if command == "redo" {
+			step, verb = s.cube.Redo, "Redid"
+		}
+		var moves []cube.Move
+		for len(moves) < count {
+			move, ok := step()
+			if !ok {
+				break
+			}
+			moves = append(moves, move)
+		}
+		if len(moves) == 0 {
+			return fmt.Errorf("nothing to %s", command)
+		}
+		fmt.Fprintf(s.out, "%s %s\n", verb, cube.FormatMoves(moves))
+		s.show()
+	case "scramble":
+		length, err := countArg(args, cube.DefaultScrambleLength)
+		if err != nil {
+			return err
+		}
+		moves, err := cube.GenerateScramble(s.cube.Size, cube.ScrambleOptions{Length: length})
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(s.out, "Scramble: %s\n", cube.FormatMoves(moves))
+		s.apply(moves)
+	case "solve":
+		result, err := cube.SolveVerified(s.solver, s.cube)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(s.out, "Solution: %s (%d moves)\n", cube.FormatMoves(result.Solution), result.Steps)
+		s.apply(result.Solution)
+	default:
+		moves, err := cube.ParseScrambleForSize(line, s.cube.Size)
+		if err != nil {
+			return err
+		}
+		s.apply(moves)
+	}
+	return nil
+}
+
+// reset starts over with a solved cube of the given size
+func (s *replSession) reset(size int) {
+	s.cube = newCube(size)
+	s.cube.EnableHistory()
+}
+
+// apply applies moves to the cube and draws the result
+func (s *replSession) apply(moves []cube.Move) {
+	s.cube.ApplyMoves(moves)
+	s.show()
+}
+
+// show draws the cube and says whether it is solved
+func (s *replSession) show() {
+	fmt.Fprintf(s.out, "\n%s\n", s.cube.UnfoldedString(s.useColor, s.useUnicode))
+	if s.cube.IsSolved() {
+		fmt.Fprintln(s.out, "Solved")
+	}
+}
+
+// countArg parses a command's optional positive count argument
+func countArg(args []string, defaultCount int) (int, error) {
+	if len(args) == 0 {
+		return defaultCount, nil
+	}
+	if len(args) > 1 {
+		return 0, fmt.Errorf("expected at most one number, got %q", strings.Join(args, " "))
+	}
+	count, err := strconv.Atoi(args[0])
+	if err != nil || count < 1 {
+		return 0, fmt.Errorf("expected a positive number, got %q", args[0])
+	}
+	return count, nil
+}
+
+func init() {
+	replCmd.Flags().IntP("dimension", "d", 3, "Cube dimension (2, 3, 4, etc.)")
+	replCmd.Flags().StringP("algorithm", "a", "cfop", "Solver used by solve (beginner, cfop, kociemba, optimal2, race; 2x2 cubes default to optimal2)")
+	replCmd.Flags().BoolP("color", "c", false, "Use colored output (Unicode blocks by default)")
+	replCmd.Flags().Bool("letters", false, "Use letters instead of Unicode blocks when using --color")
+	rootCmd.AddCommand(replCmd)
+}
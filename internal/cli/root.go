@@ -1,22 +1,81 @@
 package cli
 
 import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/ehrlich-b/cube/internal/cube"
 	"github.com/spf13/cobra"
 )
 
+// moveEngine is the engine selected with --engine
+var moveEngine cube.Engine
+
 var rootCmd = &cobra.Command{
 	Use:   "cube",
 	Short: "A flexible Rubik's cube solver",
 	Long: `Cube is a flexible Rubik's cube solver that supports multiple dimensions
 and solving algorithms.`,
 	Version: "1.0.0",
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		name, _ := cmd.Flags().GetString("engine")
+		engine, err := cube.ParseEngine(name)
+		if err != nil {
+			return err
+		}
+		moveEngine = engine
+
+		themeName, _ := cmd.Flags().GetString("theme")
+		scheme, err := cube.ParseColorScheme(themeName)
+		if err != nil {
+			return err
+		}
+		cube.SetColorScheme(scheme)
+
+		// Commands that default --dimension to 0 pick a size of their own,
+		// so only check a dimension the user gave
+		if flag := cmd.Flags().Lookup("dimension"); flag != nil && flag.Changed {
+			dimension, _ := cmd.Flags().GetInt("dimension")
+			if err := cube.ValidateSize(dimension); err != nil {
+				return fmt.Errorf("invalid --dimension: %v", err)
+			}
+		}
+		return nil
+	},
 }
 
 func Execute() error {
 	return rootCmd.Execute()
 }
 
+// newCube creates a solved cube that uses the engine selected with --engine
+func newCube(size int) *cube.Cube {
+	return cube.NewCube(size, cube.WithEngine(moveEngine))
+}
+
+// movesArg returns a move sequence argument as given, or read from standard
+// input when it is "-", so sequences can be piped between commands. Lines
+// of input are joined into one sequence; input with no moves is an error.
+func movesArg(cmd *cobra.Command, arg string) (string, error) {
+	if arg != "-" {
+		return arg, nil
+	}
+	input, err := io.ReadAll(cmd.InOrStdin())
+	if err != nil {
+		return "", fmt.Errorf("failed to read moves from standard input: %v", err)
+	}
+	moves := strings.Join(strings.Fields(string(input)), " ")
+	if moves == "" {
+		return "", fmt.Errorf("no moves on standard input")
+	}
+	return moves, nil
+}
+
 func init() {
+	rootCmd.PersistentFlags().String("engine", "permutation", "Move engine (permutation, geometric)")
+	rootCmd.PersistentFlags().String("theme", "default", "Color theme for colored output (default, colorblind, high-contrast)")
+
 	rootCmd.AddCommand(solveCmd)
 	rootCmd.AddCommand(twistCmd)
 	rootCmd.AddCommand(verifyCmd)
@@ -0,0 +1,70 @@
+package cli
+
+import (
+	"fmt"
+	"math/rand"
+
+	"github.com/ehrlich-b/cube/internal/cube"
+	"github.com/spf13/cobra"
+)
+
+var scrambleCmd = &cobra.Command{
+	Use:   "scramble",
+	Short: "Generate a random scramble",
+	Long: `Generate a random scramble of non-cancelling moves.
+
+Use --difficulty to also print how hard a 3x3 scramble is: Easy (optimal
+solution of at most 5 moves), Medium (6 to 8), or Hard (more than 8). The
+optimal solver only measures solutions up to 8 moves, so Hard scrambles,
+which include nearly every 25-move one, have no exact length.
+
+Examples:
+  cube scramble                              # 25 random moves
+  cube scramble --length 12 --dimension 4
+  cube scramble --seed 42                    # Reproducible scramble
+  cube scramble --length 7 --difficulty`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dimension, _ := cmd.Flags().GetInt("dimension")
+		length, _ := cmd.Flags().GetInt("length")
+		seed, _ := cmd.Flags().GetInt64("seed")
+		showDifficulty, _ := cmd.Flags().GetBool("difficulty")
+
+		if showDifficulty {
+			if dimension != 3 {
+				return fmt.Errorf("difficulty is only supported for 3x3 cubes")
+			}
+		}
+
+		opts := cube.ScrambleOptions{Length: length}
+		if seed != 0 {
+			opts.Rand = rand.New(rand.NewSource(seed))
+		}
+
+		moves, err := cube.GenerateScramble(dimension, opts)
+		if err != nil {
+			return fmt.Errorf("error generating scramble: %v", err)
+		}
+
+		fmt.Println(cube.FormatMoves(moves))
+
+		if showDifficulty {
+			optimal, category := cube.EstimateDifficulty(moves)
+			if optimal == cube.OptimalUnknown {
+				fmt.Printf("Difficulty: %s (optimal solution over %d moves)\n", category, cube.MaxOptimalSearchDepth)
+			} else {
+				fmt.Printf("Difficulty: %s (optimal solution %d moves)\n", category, optimal)
+			}
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	scrambleCmd.Flags().IntP("dimension", "d", 3, "Cube dimension (2, 3, 4, etc.)")
+	scrambleCmd.Flags().IntP("length", "n", 0, "Number of moves (default 25)")
+	scrambleCmd.Flags().Int64("seed", 0, "Random seed for reproducible scrambles (default: random)")
+	scrambleCmd.Flags().Bool("difficulty", false, "Print the scramble's difficulty from its optimal solution length (3x3)")
+	rootCmd.AddCommand(scrambleCmd)
+}
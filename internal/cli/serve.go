@@ -0,0 +1,46 @@
+package cli
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/ehrlich-b/cube/internal/web"
+	"github.com/spf13/cobra"
+)
+
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serve the solver as a JSON API",
+	Long: `Serve the solver as a versioned JSON API:
+
+  POST /api/v1/solve       {"scramble": "R U R' U'", "algorithm": "cfop"}
+  POST /api/v1/scramble    {"dimension": 3, "length": 20, "seed": 42}
+  POST /api/v1/verify      {"algorithm": "R U R' U'", "start": "<cfen>", "target": "<cfen>"}
+  GET  /api/v1/algorithms  ?category=PLL or ?q=sune (top 10 matches; &limit=0 for all)
+  POST /api/v1/animate     {"scramble": "R U", "algorithm": "cfop"}: keyframes of the solve
+  GET  /api/v1/keymap      the virtual cube keyboard layout: {"keymap": {"j": "U", ...}}
+  POST /api/v1/keystrokes  {"keys": "ijkf", "start": "<cfen>"}
+  GET  /ws                 WebSocket: send {"scramble": "R U R' U'"}, receive each
+                           CFOP stage as it completes, then the solution; solves
+                           stop after 30s or when the client disconnects
+  GET  /health             liveness: {"status": "ok"}
+  GET  /ready              readiness: solves small cubes, 503 if that fails
+
+Errors come back as {"error": "...", "kind": "..."} with status 400 for
+bad input and 422 for states that can't be solved.
+
+Examples:
+  cube serve
+  cube serve --addr 127.0.0.1:9000`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		addr, _ := cmd.Flags().GetString("addr")
+		fmt.Printf("Serving the cube API on http://%s/api/v1/\n", addr)
+		return http.ListenAndServe(addr, web.NewAPI())
+	},
+}
+
+func init() {
+	serveCmd.Flags().String("addr", "localhost:8080", "Address to listen on")
+	rootCmd.AddCommand(serveCmd)
+}
@@ -0,0 +1,72 @@
+package cli
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/ehrlich-b/cube/internal/cube"
+	"github.com/spf13/cobra"
+)
+
+var setupCmd = &cobra.Command{
+	Use:   "setup <case-id>",
+	Short: "Show the moves that set up a case on a solved cube",
+	Long: `Setup prints the moves that set up a case on a solved cube: the inverse
+of the case's shortest algorithm. Apply them, then practice solving the
+case. With --auf the setup ends in a U turn, so the case turns up at
+another angle; random picks one of the four.
+
+Examples:
+  cube setup OLL-27
+  cube setup PLL-T --auf random --color
+  cube setup PLL-Ua --auf U2`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeCaseIDs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		caseID := args[0]
+		aufName, _ := cmd.Flags().GetString("auf")
+		useColor, _ := cmd.Flags().GetBool("color")
+		useLetters, _ := cmd.Flags().GetBool("letters")
+
+		auf, err := parseAUF(aufName)
+		if err != nil {
+			return err
+		}
+		setup, err := cube.SetupMovesWithAUF(caseID, auf)
+		if err != nil {
+			return err
+		}
+
+		c := newCube(3)
+		c.ApplyMoves(setup)
+
+		fmt.Printf("Setup: %s\n\n", cube.FormatMoves(setup))
+		fmt.Println(c.UnfoldedString(useColor, useColor && !useLetters))
+		return nil
+	},
+}
+
+// parseAUF reads --auf as clockwise quarter turns of U
+func parseAUF(name string) (int, error) {
+	switch strings.ToLower(name) {
+	case "", "none":
+		return 0, nil
+	case "random":
+		return rand.New(rand.NewSource(time.Now().UnixNano())).Intn(4), nil
+	}
+	for turns := 1; turns < 4; turns++ {
+		if name == cube.FormatMoves(cube.AUFMoves(turns)) {
+			return turns, nil
+		}
+	}
+	return 0, fmt.Errorf("invalid --auf '%s': use none, random, U, U2, or U'", name)
+}
+
+func init() {
+	setupCmd.Flags().String("auf", "none", "U turn after the setup (none, random, U, U2, U')")
+	setupCmd.Flags().BoolP("color", "c", false, "Use colored output (Unicode blocks by default)")
+	setupCmd.Flags().Bool("letters", false, "Use letters instead of Unicode blocks when using --color")
+	rootCmd.AddCommand(setupCmd)
+}
@@ -1,9 +1,12 @@
 package cli
 
 import (
+	"encoding/json"
 	"fmt"
+	"os"
 	"strings"
 
+	"github.com/ehrlich-b/cube/internal/cfen"
 	"github.com/ehrlich-b/cube/internal/cube"
 	"github.com/spf13/cobra"
 )
@@ -18,7 +21,16 @@ Examples:
   cube show "R U R' U'"
   cube show "R U R' U'" --color
   cube show "R U R' U'" --highlight-cross
-  cube show "" --highlight-oll`,
+  cube show "" --highlight-oll
+  cube show "Rw U2 3Fw" -d 5 --color --compact
+  cube show "R U R' U'" --format facelets
+  cube show "R U R' U'" --format iso --color
+
+--format prints the state alone in another form for other tools: cfen,
+facelets (the U R F D L B letter string most solvers read), or json (each
+face's rows of color letters). The default, net, draws the cube unfolded;
+iso draws it in 3D from above the front right corner, showing only the
+Up, Front, and Right faces.`,
 	Args: cobra.MaximumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		scramble := ""
@@ -30,13 +42,15 @@ Examples:
 		useColor, _ := cmd.Flags().GetBool("color")
 		useLetters, _ := cmd.Flags().GetBool("letters")
 		useUnicode := useColor && !useLetters
+		compact, _ := cmd.Flags().GetBool("compact")
 		highlightCross, _ := cmd.Flags().GetBool("highlight-cross")
 		highlightOLL, _ := cmd.Flags().GetBool("highlight-oll")
 		highlightPLL, _ := cmd.Flags().GetBool("highlight-pll")
 		highlightF2L, _ := cmd.Flags().GetBool("highlight-f2l")
+		format, _ := cmd.Flags().GetString("format")
 
 		// Create cube
-		c := cube.NewCube(dimension)
+		c := newCube(dimension)
 
 		// Apply scramble if provided
 		if scramble != "" {
@@ -46,6 +60,19 @@ Examples:
 				return
 			}
 			c.ApplyMoves(moves)
+		}
+
+		if format != "net" && format != "iso" {
+			state, err := formatState(c, format)
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+			fmt.Println(state)
+			return
+		}
+
+		if scramble != "" {
 			fmt.Printf("Cube state after scramble: %s\n\n", scramble)
 		} else {
 			fmt.Println("Solved cube state:")
@@ -63,110 +90,68 @@ Examples:
 			highlightMode = "f2l"
 		}
 
+		draw := func(opts cube.RenderOptions) {
+			if format == "iso" {
+				fmt.Print(c.IsometricString(opts))
+			} else {
+				if err := c.Render(os.Stdout, opts); err != nil {
+					fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+					os.Exit(1)
+				}
+			}
+		}
+
 		// Display cube with highlighting
+		opts := cube.RenderOptions{Color: useColor, Unicode: useUnicode, Compact: compact, Narrow: compact}
 		if highlightMode != "" {
 			fmt.Printf("Highlighting: %s pattern\n\n", strings.ToUpper(highlightMode))
-			displayWithHighlight(c, highlightMode, useColor, useUnicode)
+			displayWithHighlight(c, highlightMode, opts, draw)
 		} else {
-			fmt.Println(c.UnfoldedString(useColor, useUnicode))
+			draw(opts)
+			fmt.Println()
 		}
 	},
 }
 
-func displayWithHighlight(c *cube.Cube, mode string, useColor bool, useUnicode bool) {
-	// Display cube in unfolded cross format with highlighting
-	var sb strings.Builder
-
-	dimColor := "\033[90m" // Dark gray for dimmed pieces
-	resetColor := "\033[0m"
-	dimUnicode := "⬛" // Black square for dimmed pieces
-
-	// Create padding to align top/bottom with front face
-	var leftPadding string
-	if useUnicode {
-		// Unicode blocks are double-width: (c.Size * 2) + 1 space
-		leftPadding = strings.Repeat(" ", (c.Size*2)+1)
-	} else {
-		// Single-width characters: c.Size + 1 space
-		leftPadding = strings.Repeat(" ", c.Size) + " "
+// formatState writes the cube in a --format that isn't a drawing: cfen,
+// facelets, or json
+func formatState(c *cube.Cube, format string) (string, error) {
+	switch format {
+	case "cfen":
+		return cfen.GenerateCFEN(c)
+	case "facelets":
+		return c.Facelets()
+	case "json":
+		data, err := json.Marshal(c)
+		return string(data), err
 	}
+	return "", fmt.Errorf("unknown format %q (use net, iso, cfen, facelets, or json)", format)
+}
 
-	// Top face (Up) - aligned with Front face
-	for row := 0; row < c.Size; row++ {
-		sb.WriteString(leftPadding)
-		for col := 0; col < c.Size; col++ {
-			color := c.Faces[4][row][col] // Up face
-			highlight := shouldHighlight(4, row, col, c.Size, mode)
-
-			if highlight {
-				sb.WriteString(c.FormatSticker(color, useColor, useUnicode))
-			} else {
-				if useUnicode {
-					sb.WriteString(dimUnicode)
-				} else if useColor {
-					sb.WriteString(fmt.Sprintf("%s%s%s", dimColor, color.String(), resetColor))
-				} else {
-					sb.WriteString(".")
-				}
-			}
-		}
-		sb.WriteString("\n")
+func displayWithHighlight(c *cube.Cube, mode string, opts cube.RenderOptions, draw func(cube.RenderOptions)) {
+	highlight := func(face cube.Face, row, col int) bool {
+		return shouldHighlight(int(face), row, col, c.Size, mode)
 	}
-	sb.WriteString("\n")
-
-	// Middle row: Left, Front, Right, Back
-	faces := [4]int{2, 0, 3, 1} // Left, Front, Right, Back
-	for row := 0; row < c.Size; row++ {
-		for i, face := range faces {
-			for col := 0; col < c.Size; col++ {
-				color := c.Faces[face][row][col]
-				highlight := shouldHighlight(face, row, col, c.Size, mode)
-
-				if highlight {
-					sb.WriteString(c.FormatSticker(color, useColor, useUnicode))
-				} else {
-					if useUnicode {
-						sb.WriteString(dimUnicode)
-					} else if useColor {
-						sb.WriteString(fmt.Sprintf("%s%s%s", dimColor, color.String(), resetColor))
-					} else {
-						sb.WriteString(".")
-					}
-				}
-			}
-			if i < 3 { // Add space between faces (but not after the last one)
-				sb.WriteString(" ")
-			}
-		}
-		sb.WriteString("\n")
-	}
-	sb.WriteString("\n")
 
-	// Bottom face (Down) - aligned with Front face
-	for row := 0; row < c.Size; row++ {
-		sb.WriteString(leftPadding)
-		for col := 0; col < c.Size; col++ {
-			color := c.Faces[5][row][col] // Down face
-			highlight := shouldHighlight(5, row, col, c.Size, mode)
-
-			if highlight {
-				sb.WriteString(c.FormatSticker(color, useColor, useUnicode))
-			} else {
-				if useUnicode {
-					sb.WriteString(dimUnicode)
-				} else if useColor {
-					sb.WriteString(fmt.Sprintf("%s%s%s", dimColor, color.String(), resetColor))
-				} else {
-					sb.WriteString(".")
-				}
+	// A 3x3 highlights whole pieces: the ones the stage works on. OLL only
+	// cares which of those stickers show the U color.
+	if stage, err := cube.ParseCFOPStage(mode); err == nil && c.Size == 3 {
+		mask := cube.StageMask(stage)
+		upColor := c.Faces[cube.Up][1][1]
+		highlight = func(face cube.Face, row, col int) bool {
+			if !mask.Covers(cube.Coord{Face: face, Row: row, Col: col}) {
+				return false
 			}
+			return stage != cube.StageOLL || c.Faces[face][row][col] == upColor
 		}
-		sb.WriteString("\n")
 	}
 
-	fmt.Print(sb.String())
+	opts.Highlight = highlight
+	draw(opts)
 }
 
+// shouldHighlight picks stickers by position on cubes other than 3x3,
+// where there is no piece model
 func shouldHighlight(face, row, col, size int, mode string) bool {
 	// Simple highlighting logic - can be made much more sophisticated
 	switch mode {
@@ -232,8 +217,10 @@ func init() {
 	showCmd.Flags().IntP("dimension", "d", 3, "Cube dimension (2, 3, 4, etc.)")
 	showCmd.Flags().BoolP("color", "c", false, "Use colored output (Unicode blocks by default)")
 	showCmd.Flags().Bool("letters", false, "Use letters instead of Unicode blocks when using --color")
+	showCmd.Flags().Bool("compact", false, "One character per sticker and no blank lines, for big cubes")
 	showCmd.Flags().Bool("highlight-cross", false, "Highlight cross pattern")
 	showCmd.Flags().Bool("highlight-oll", false, "Highlight OLL (Orientation of Last Layer)")
 	showCmd.Flags().Bool("highlight-pll", false, "Highlight PLL (Permutation of Last Layer)")
 	showCmd.Flags().Bool("highlight-f2l", false, "Highlight F2L (First Two Layers)")
+	showCmd.Flags().String("format", "net", "Output format (net, iso, cfen, facelets, json)")
 }
@@ -41,7 +41,11 @@ var showAlgCmd = &cobra.Command{
 			fmt.Printf("Description: %s\n", alg.Description)
 		}
 		fmt.Printf("Category: %s\n", alg.Category)
-		fmt.Printf("Moves: %s (%d moves)\n\n", alg.Moves, alg.MoveCount)
+		fmt.Printf("Moves: %s (%d moves)\n", alg.Moves, alg.MoveCount)
+		if triggers := triggerBreakdown(alg.Moves); triggers != "" {
+			fmt.Printf("Triggers: %s\n", triggers)
+		}
+		fmt.Println()
 
 		// TODO: Implement pattern visualization with new Pattern field
 		fmt.Printf("Pattern: %s\n\n", alg.Pattern)
@@ -140,3 +144,17 @@ func init() {
 	showAlgCmd.Flags().String("view", "auto", "View mode: auto, last, full, both")
 	rootCmd.AddCommand(showAlgCmd)
 }
+
+// triggerBreakdown groups an algorithm's moves into known triggers, or
+// returns "" if the moves don't parse or contain no trigger
+func triggerBreakdown(moves string) string {
+	parsed, err := cube.ParseScramble(moves)
+	if err != nil {
+		return ""
+	}
+	groups := cube.GroupTriggers(parsed)
+	if len(groups) == len(parsed) {
+		return ""
+	}
+	return cube.FormatTriggerGroups(groups)
+}
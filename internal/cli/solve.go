@@ -1,7 +1,9 @@
 package cli
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"strings"
 
@@ -16,15 +18,123 @@ var solveCmd = &cobra.Command{
 	Long: `Solve a scrambled cube using the specified algorithm.
 The scramble should be provided as a string of moves.
 
-Use --headless for programmatic output (space-separated moves only).`,
+Use --stage to solve only through one CFOP stage (cross, f2l, oll, pll)
+and show the partially solved cube, e.g. for F2L or last-layer practice.
+
+Use --show-cube to also draw the cube after the solution is applied and
+check that it is solved.
+
+Use --keyframes to export the solve as a JSON animation script: the
+scrambled state followed by each move and the CFEN after it.
+
+Use --stream to print progress as JSON lines while solving with CFOP: one
+{"event":"stage"} line per completed stage, then a {"event":"solution"}
+line (or {"event":"error"}), for front ends that show output as it arrives.
+
+Use --inspect to solve with CFOP from the orientation with the shortest
+cross, the way a speedcuber picks one during inspection. The solution
+starts with the inspection rotations (at most two, such as x y').
+
+Use --algorithm race to run the beginner, CFOP, Kociemba, and 2x2 solvers
+at once and take whichever solution arrives first.
+
+Use --optimize-for with the kociemba or optimal2 solver to search for the
+solution that is shortest in a metric other than htm, e.g. --optimize-for
+qtm to prefer quarter turns over half turns.
+
+Use --trace to print what the solver is doing to stderr: each stage, how
+many states a search examined, which algorithm it picked, and why it fell
+back to another method.
+
+Use --headless for programmatic output (space-separated moves only), or
+--count-only to print just the solution length in the metric chosen with
+--metric (htm by default), e.g. $(cube solve "R U F" --count-only).
+
+Give - as the scramble to read it from standard input, e.g.
+cube scramble | cube solve - --headless.`,
 	Args: cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
-		scramble := args[0]
 		algorithm, _ := cmd.Flags().GetString("algorithm")
 		dimension, _ := cmd.Flags().GetInt("dimension")
 		headless, _ := cmd.Flags().GetBool("headless")
 		useCfenOutput, _ := cmd.Flags().GetBool("cfen")
+		useKeyframes, _ := cmd.Flags().GetBool("keyframes")
 		startCfen, _ := cmd.Flags().GetString("start")
+		stageName, _ := cmd.Flags().GetString("stage")
+		stream, _ := cmd.Flags().GetBool("stream")
+		showCube, _ := cmd.Flags().GetBool("show-cube")
+		trace, _ := cmd.Flags().GetBool("trace")
+		inspect, _ := cmd.Flags().GetBool("inspect")
+		countOnly, _ := cmd.Flags().GetBool("count-only")
+		metricName, _ := cmd.Flags().GetString("metric")
+		verbose, _ := cmd.Flags().GetBool("verbose")
+		optimizeFor, _ := cmd.Flags().GetString("optimize-for")
+
+		if countOnly {
+			// The count is the only output
+			headless = true
+		}
+		metric, err := cube.ParseMetric(metricName)
+		if err != nil {
+			if !headless {
+				fmt.Printf("Error: %v\n", err)
+			}
+			os.Exit(1)
+		}
+		targetMetric := cube.HTM
+		if optimizeFor != "" {
+			if targetMetric, err = cube.ParseMetric(optimizeFor); err != nil {
+				if !headless {
+					fmt.Printf("Error: %v\n", err)
+				}
+				os.Exit(1)
+			}
+		}
+		scramble, err := movesArg(cmd, args[0])
+		if err != nil {
+			if !headless {
+				fmt.Printf("Error: %v\n", err)
+			}
+			os.Exit(1)
+		}
+
+		// Solver traces go to stderr so they never mix with the solution
+		var traceWriter io.Writer
+		if trace {
+			traceWriter = os.Stderr
+		}
+
+		// --stage, --inspect, and --stream always solve with CFOP
+		if cmd.Flags().Changed("algorithm") && algorithm != "cfop" && (stream || stageName != "" || inspect) {
+			if !headless {
+				fmt.Printf("Error: --stage, --inspect, and --stream solve with CFOP, not %s\n", algorithm)
+			}
+			os.Exit(1)
+		}
+
+		var stage cube.CFOPStage
+		if stageName != "" {
+			var err error
+			stage, err = cube.ParseCFOPStage(stageName)
+			if err != nil {
+				if !headless {
+					fmt.Printf("Error: %v\n", err)
+				}
+				os.Exit(1)
+			}
+			algorithm = "cfop"
+		}
+		if inspect {
+			algorithm = "cfop"
+		}
+		if stream {
+			// Streaming reports CFOP stages; all other output is suppressed
+			headless = true
+			algorithm = "cfop"
+			if stageName == "" {
+				stage = cube.StagePLL
+			}
+		}
 
 		// Create cube from starting position
 		var c *cube.Cube
@@ -55,14 +165,30 @@ Use --headless for programmatic output (space-separated moves only).`,
 				}
 				os.Exit(1)
 			}
+			c.Engine = moveEngine
 		} else {
 			// Start with solved cube
-			c = cube.NewCube(dimension)
+			c = newCube(dimension)
+		}
+
+		// 2x2 cubes get the optimal solver unless one was asked for
+		if dimension == 2 && !cmd.Flags().Changed("algorithm") && stageName == "" && !stream {
+			algorithm = "optimal2"
+		}
+
+		if optimizeFor != "" && (stream || stageName != "" || inspect) {
+			if !headless {
+				fmt.Printf("Error: --optimize-for needs a search solver, not CFOP\n")
+			}
+			os.Exit(1)
 		}
 
 		if !headless {
 			fmt.Printf("Solving %dx%dx%d cube with scramble: %s\n", dimension, dimension, dimension, scramble)
 			fmt.Printf("Using algorithm: %s\n", algorithm)
+			if stageName != "" {
+				fmt.Printf("Solving through stage: %s\n", stageName)
+			}
 			if startCfen != "" {
 				fmt.Printf("Starting from CFEN: %s\n", startCfen)
 			}
@@ -70,7 +196,7 @@ Use --headless for programmatic output (space-separated moves only).`,
 
 		// Apply scramble to cube
 		if scramble != "" {
-			moves, err := cube.ParseScramble(scramble)
+			moves, err := cube.ParseScrambleForSize(scramble, c.Size)
 			if err != nil {
 				if !headless {
 					fmt.Printf("Error parsing scramble: %v\n", err)
@@ -79,6 +205,7 @@ Use --headless for programmatic output (space-separated moves only).`,
 			}
 			c.ApplyMoves(moves)
 		}
+		scrambled := c.Clone()
 
 		if !headless {
 			useColor, _ := cmd.Flags().GetBool("color")
@@ -88,21 +215,88 @@ Use --headless for programmatic output (space-separated moves only).`,
 			fmt.Printf("\nCube state after scramble:\n%s\n", c.UnfoldedString(useColor, useUnicode))
 		}
 
+		// A scramble that cancels out (R R') leaves nothing to solve; say so
+		// rather than print an empty solution. Machine-readable output
+		// modes still print their usual empty result.
+		machineOutput := headless || stream || useKeyframes || useCfenOutput || countOnly
+		if !machineOutput && c.IsSolved() {
+			fmt.Println("Cube is already solved, no moves needed.")
+			return
+		}
+
 		// Get solver and solve
-		solver, err := cube.GetSolver(algorithm)
-		if err != nil {
-			if !headless {
-				fmt.Printf("Error getting solver: %v\n", err)
+		var result *cube.SolverResult
+		if stream {
+			solver := &cube.CFOPSolver{
+				OnStage: func(stage cube.CFOPStage, moves []cube.Move) {
+					printSolveEvent(solveEvent{Event: "stage", Stage: stage.String(), Moves: cube.FormatMoves(moves)})
+				},
+				Trace:   traceWriter,
+				Inspect: inspect,
+			}
+			var err error
+			result, err = solver.SolveThrough(c, stage)
+			if err != nil {
+				printSolveEvent(solveEvent{Event: "error", Error: err.Error()})
+				os.Exit(1)
+			}
+			printSolveEvent(solveEvent{Event: "solution", Moves: cube.FormatMoves(result.Solution), Steps: result.Steps})
+			return
+		} else if stageName != "" {
+			var err error
+			result, err = (&cube.CFOPSolver{Trace: traceWriter, Inspect: inspect}).SolveThrough(c, stage)
+			if err != nil {
+				if !headless {
+					fmt.Printf("Error solving cube: %v\n", err)
+				}
+				os.Exit(1)
+			}
+		} else {
+			solver, err := cube.GetSolver(algorithm)
+			if err != nil {
+				if !headless {
+					fmt.Printf("Error getting solver: %v\n", err)
+				}
+				os.Exit(1)
+			}
+			cube.SetTrace(solver, traceWriter)
+			if optimizeFor != "" && !cube.SetTargetMetric(solver, targetMetric) {
+				if !headless {
+					fmt.Printf("Error: --optimize-for needs a search solver (kociemba or optimal2), not %s\n", algorithm)
+				}
+				os.Exit(1)
+			}
+			if cfop, ok := solver.(*cube.CFOPSolver); ok {
+				cfop.Inspect = inspect
+			}
+
+			result, err = cube.SolveVerified(solver, c)
+			if err != nil {
+				if !headless {
+					fmt.Printf("Error solving cube: %v\n", err)
+				}
+				os.Exit(1)
 			}
-			os.Exit(1)
 		}
 
-		result, err := solver.Solve(c)
-		if err != nil {
-			if !headless {
-				fmt.Printf("Error solving cube: %v\n", err)
+		if useKeyframes {
+			// Keyframe output mode: the state after every solution move
+			keyframes, err := cfen.Keyframes(c, result.Solution)
+			if err != nil {
+				if !headless {
+					fmt.Printf("Error generating keyframes: %v\n", err)
+				}
+				os.Exit(1)
 			}
-			os.Exit(1)
+			data, err := json.MarshalIndent(keyframes, "", "  ")
+			if err != nil {
+				if !headless {
+					fmt.Printf("Error encoding keyframes: %v\n", err)
+				}
+				os.Exit(1)
+			}
+			fmt.Println(string(data))
+			return
 		}
 
 		// Apply solution to get final state
@@ -127,24 +321,94 @@ Use --headless for programmatic output (space-separated moves only).`,
 				os.Exit(1)
 			}
 			fmt.Print(cfenStr)
+		} else if countOnly {
+			// Count output mode: only the solution length
+			fmt.Print(cube.CountMetric(result.Solution, metric))
 		} else if headless {
 			// Headless mode: output only the space-separated move list
 			fmt.Print(solutionStr.String())
 		} else {
 			// Normal mode: full output
-			fmt.Printf("Solution: %s\n", solutionStr.String())
+			if inspect {
+				rotations := 0
+				for rotations < len(result.Solution) && result.Solution[rotations].Rotation != cube.NoRotation {
+					rotations++
+				}
+				inspection := cube.FormatMoves(result.Solution[:rotations])
+				if inspection == "" {
+					inspection = "none"
+				}
+				fmt.Printf("Inspection: %s\n", inspection)
+				fmt.Printf("Solution: %s\n", cube.FormatMoves(result.Solution[rotations:]))
+			} else {
+				fmt.Printf("Solution: %s\n", solutionStr.String())
+			}
 			fmt.Printf("Steps: %d\n", result.Steps)
 			fmt.Printf("Time: %v\n", result.Duration)
+			if verbose {
+				fmt.Printf("Search: %d nodes examined, %d states visited\n", result.NodesExamined, result.StatesVisited)
+			}
+
+			if stageName != "" {
+				useColor, _ := cmd.Flags().GetBool("color")
+				useLetters, _ := cmd.Flags().GetBool("letters")
+				useUnicode := useColor && !useLetters
+
+				fmt.Printf("\nCube state after %s:\n%s\n", stageName, c.UnfoldedString(useColor, useUnicode))
+			} else if showCube {
+				useColor, _ := cmd.Flags().GetBool("color")
+				useLetters, _ := cmd.Flags().GetBool("letters")
+				useUnicode := useColor && !useLetters
+
+				// Check the solution on a fresh copy of the scrambled cube
+				check := scrambled.Clone()
+				check.ApplyMoves(result.Solution)
+				fmt.Printf("\nCube state after solution:\n%s\n", check.UnfoldedString(useColor, useUnicode))
+				if check.IsSolved() {
+					fmt.Println("✅ solved")
+				} else {
+					fmt.Println("❌ not solved")
+				}
+				if !check.IsHomeOrientation() {
+					up, front := check.Orientation()
+					fmt.Printf("Held with %s on top and %s in front\n", up, front)
+				}
+			}
 		}
 	},
 }
 
+// solveEvent is one line of --stream output
+type solveEvent struct {
+	Event string `json:"event"` // "stage", "solution", or "error"
+	Stage string `json:"stage,omitempty"`
+	Moves string `json:"moves,omitempty"`
+	Steps int    `json:"steps,omitempty"`
+	Error string `json:"error,omitempty"`
+}
+
+// printSolveEvent writes an event as a single JSON line
+func printSolveEvent(event solveEvent) {
+	data, _ := json.Marshal(event)
+	fmt.Println(string(data))
+}
+
 func init() {
-	solveCmd.Flags().StringP("algorithm", "a", "beginner", "Solving algorithm to use (beginner, cfop, kociemba)")
+	solveCmd.Flags().StringP("algorithm", "a", "beginner", "Solving algorithm to use (beginner, cfop, kociemba, optimal2, race; 2x2 cubes default to optimal2)")
 	solveCmd.Flags().IntP("dimension", "d", 3, "Cube dimension (2, 3, 4, etc.)")
 	solveCmd.Flags().BoolP("color", "c", false, "Use colored output (Unicode blocks by default)")
+	solveCmd.Flags().Bool("show-cube", false, "Also show the cube after the solution and whether it is solved")
 	solveCmd.Flags().Bool("letters", false, "Use letters instead of Unicode blocks when using --color")
 	solveCmd.Flags().Bool("headless", false, "Output only space-separated moves for programmatic use")
+	solveCmd.Flags().Bool("count-only", false, "Output only the solution length")
+	solveCmd.Flags().StringP("metric", "m", "htm", "Move metric for --count-only (htm, qtm, stm, atm, etm)")
+	solveCmd.Flags().String("optimize-for", "", "Metric the kociemba and optimal2 solvers minimize (htm, qtm, stm, atm, etm; default htm)")
 	solveCmd.Flags().Bool("cfen", false, "Output final cube state as CFEN string instead of moves")
+	solveCmd.Flags().Bool("keyframes", false, "Output the solve as JSON keyframes (move and resulting CFEN) for animation")
 	solveCmd.Flags().String("start", "", "Starting cube state as CFEN string (default: solved)")
+	solveCmd.Flags().String("stage", "", "Solve only through this CFOP stage (cross, f2l, oll, pll)")
+	solveCmd.Flags().Bool("inspect", false, "Solve with CFOP from the orientation with the shortest cross")
+	solveCmd.Flags().Bool("trace", false, "Print solver progress and decisions to stderr")
+	solveCmd.Flags().BoolP("verbose", "v", false, "Also print how much searching the solver did")
+	solveCmd.Flags().Bool("stream", false, "Stream CFOP stage progress and the solution as JSON lines")
 }
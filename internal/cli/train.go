@@ -0,0 +1,142 @@
+package cli
+
+import (
+	"fmt"
+	"math/rand"
+	"os"
+	"time"
+
+	"github.com/ehrlich-b/cube/internal/cube"
+	"github.com/spf13/cobra"
+)
+
+var trainCmd = &cobra.Command{
+	Use:   "train <category>",
+	Short: "Practice recognizing a random case from an algorithm category",
+	Long: `Train picks a random algorithm from a category and sets up the case it
+solves by applying the algorithm's inverse to a solved cube. Solve the case
+yourself, then run the same command again with --reveal to see the
+algorithm.
+
+Each case is chosen from a seed, which is printed with the case; pass it
+back with --seed to see the same case again.
+
+With --reveal, --session and --time record how long the case took in a
+practice log, creating it if needed, and print your stats for the case.
+cube coach reads the same log.
+
+Examples:
+  cube train OLL --color
+  cube train PLL --seed 1234 --reveal
+  cube train PLL --seed 1234 --reveal --session times.txt --time 2.41`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		category := args[0]
+		seed, _ := cmd.Flags().GetInt64("seed")
+		reveal, _ := cmd.Flags().GetBool("reveal")
+		useColor, _ := cmd.Flags().GetBool("color")
+		useLetters, _ := cmd.Flags().GetBool("letters")
+		useUnicode := useColor && !useLetters
+		sessionFile, _ := cmd.Flags().GetString("session")
+		seconds, _ := cmd.Flags().GetFloat64("time")
+
+		if reveal && seed == 0 {
+			return fmt.Errorf("--reveal needs the --seed printed with the case")
+		}
+		if (sessionFile != "" || seconds != 0) && !reveal {
+			return fmt.Errorf("--session and --time record a solve, so they need --reveal")
+		}
+		if (sessionFile == "") != (seconds == 0) {
+			return fmt.Errorf("--session and --time must be given together")
+		}
+		if seconds < 0 {
+			return fmt.Errorf("--time must be positive")
+		}
+		if seed == 0 {
+			seed = time.Now().UnixNano()
+		}
+
+		algorithms := cube.GetByCategory(category)
+		if len(algorithms) == 0 {
+			return fmt.Errorf("no algorithms in category '%s'", category)
+		}
+
+		alg := algorithms[rand.New(rand.NewSource(seed)).Intn(len(algorithms))]
+		moves, err := cube.ParseScramble(alg.Moves)
+		if err != nil {
+			return fmt.Errorf("failed to parse algorithm moves: %w", err)
+		}
+
+		c := newCube(3)
+		c.ApplyInverseMoves(moves)
+
+		fmt.Printf("%s case (seed %d):\n\n", alg.Category, seed)
+		fmt.Println(c.UnfoldedString(useColor, useUnicode))
+
+		if !reveal {
+			fmt.Printf("Reveal the algorithm with: cube train %s --seed %d --reveal\n", category, seed)
+			return nil
+		}
+
+		fmt.Printf("Case: %s", alg.Name)
+		if alg.CaseID != "" {
+			fmt.Printf(" (%s)", alg.CaseID)
+		}
+		fmt.Println()
+		fmt.Printf("Algorithm: %s\n", alg.Moves)
+
+		if sessionFile != "" {
+			caseID := alg.CaseID
+			if caseID == "" {
+				caseID = alg.Name
+			}
+			stat, err := recordAttempt(sessionFile, cube.Attempt{CaseID: caseID, Time: time.Duration(seconds * float64(time.Second))})
+			if err != nil {
+				return err
+			}
+			fmt.Printf("\n%s: %d attempts, mean %.2fs, best %.2fs; %s mean %.2fs\n",
+				stat.CaseID, stat.Attempts, stat.Mean.Seconds(), stat.Best.Seconds(), stat.Set, stat.SetMean.Seconds())
+		}
+		return nil
+	},
+}
+
+// recordAttempt appends attempt to the session log at path and returns the
+// case's stats over the whole log
+func recordAttempt(path string, attempt cube.Attempt) (cube.CaseStat, error) {
+	session := &cube.Session{}
+	if file, err := os.Open(path); err == nil {
+		session, err = cube.ParseSession(file)
+		file.Close()
+		if err != nil {
+			return cube.CaseStat{}, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+	} else if !os.IsNotExist(err) {
+		return cube.CaseStat{}, err
+	}
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return cube.CaseStat{}, err
+	}
+	if _, err := fmt.Fprintln(file, attempt); err != nil {
+		file.Close()
+		return cube.CaseStat{}, err
+	}
+	if err := file.Close(); err != nil {
+		return cube.CaseStat{}, err
+	}
+
+	session.Record(attempt.CaseID, attempt.Time)
+	return session.CaseStats(attempt.CaseID), nil
+}
+
+func init() {
+	trainCmd.Flags().Int64("seed", 0, "Random seed that picks the case (default: random)")
+	trainCmd.Flags().Bool("reveal", false, "Show the algorithm that solves the case")
+	trainCmd.Flags().BoolP("color", "c", false, "Use colored output (Unicode blocks by default)")
+	trainCmd.Flags().Bool("letters", false, "Use letters instead of Unicode blocks when using --color")
+	trainCmd.Flags().String("session", "", "Practice log to record the solve in (with --reveal and --time)")
+	trainCmd.Flags().Float64("time", 0, "Seconds the case took to solve (with --reveal and --session)")
+	rootCmd.AddCommand(trainCmd)
+}
@@ -16,16 +16,60 @@ var twistCmd = &cobra.Command{
 This command does not solve the cube - it just applies the moves and shows
 the result. Perfect for learning algorithms, exploring patterns, and visualization.
 
+Use --invert to apply the inverse of the sequence and --repeat N to apply it
+N times. --reverse applies the moves back to front without inverting them
+(R U' F2 becomes F2 U' R), which is not the same as --invert: the inverse
+also flips each quarter turn (F2 U R') and undoes the sequence, while the
+reversed sequence generally doesn't. --find-order prints how many repetitions return the cube to its
+starting state (the order of the algorithm). --skip-unknown drops moves
+that don't parse, such as Mw or 2x, with a warning instead of failing.
+
+Use --count-only to print just the number of moves applied, after --invert
+and --repeat, in the metric chosen with --metric (htm by default).
+
+Give - as the moves to read them from standard input.
+
 Examples:
   cube twist "R U R' U'"
   cube twist "F R U' R' F'" --color
-  cube twist "Rw Uw Fw" --dimension 4`,
+  cube twist "Rw Uw Fw" --dimension 4
+  cube twist "R U R' U'" --invert
+  cube twist "R U2 R' U' R U' R'" --reverse
+  cube twist "R U R' U'" --repeat 6
+  cube twist "R U" --find-order
+  cube twist "R Mw U" --skip-unknown
+  cube twist "R U R' U'" --repeat 6 --count-only --metric qtm
+  cube scramble | cube twist -`,
 	Args: cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
-		moves := args[0]
+		moves, err := movesArg(cmd, args[0])
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
 		dimension, _ := cmd.Flags().GetInt("dimension")
 		useCfenOutput, _ := cmd.Flags().GetBool("cfen")
 		startCfen, _ := cmd.Flags().GetString("start")
+		invert, _ := cmd.Flags().GetBool("invert")
+		reverse, _ := cmd.Flags().GetBool("reverse")
+		repeat, _ := cmd.Flags().GetInt("repeat")
+		findOrder, _ := cmd.Flags().GetBool("find-order")
+		skipUnknown, _ := cmd.Flags().GetBool("skip-unknown")
+		countOnly, _ := cmd.Flags().GetBool("count-only")
+		metricName, _ := cmd.Flags().GetString("metric")
+
+		if repeat < 1 {
+			fmt.Printf("Error: --repeat must be at least 1, got %d\n", repeat)
+			os.Exit(1)
+		}
+		metric, err := cube.ParseMetric(metricName)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		// Only the state or the count is printed in these modes
+		quiet := useCfenOutput || countOnly
 
 		// Create cube from starting position
 		var c *cube.Cube
@@ -50,12 +94,13 @@ Examples:
 				fmt.Printf("Error converting CFEN to cube: %v\n", err)
 				os.Exit(1)
 			}
+			c.Engine = moveEngine
 		} else {
 			// Start with solved cube
-			c = cube.NewCube(dimension)
+			c = newCube(dimension)
 		}
 
-		if !useCfenOutput {
+		if !quiet {
 			fmt.Printf("Applying moves to %dx%dx%d cube: %s\n", dimension, dimension, dimension, moves)
 			if startCfen != "" {
 				fmt.Printf("Starting from CFEN: %s\n", startCfen)
@@ -63,17 +108,58 @@ Examples:
 		}
 
 		// Parse and apply moves
-		parsedMoves, err := cube.ParseScramble(moves)
-		if err != nil {
-			if !useCfenOutput {
-				fmt.Printf("Error parsing moves: %v\n", err)
+		var parsedMoves []cube.Move
+		if skipUnknown {
+			var skipped []*cube.ParseError
+			parsedMoves, skipped = cube.ParseMovesSkipUnknown(moves)
+			for _, parseErr := range skipped {
+				fmt.Fprintf(os.Stderr, "Warning: skipped %v\n", parseErr)
 			}
-			os.Exit(1)
+		} else {
+			var err error
+			parsedMoves, err = cube.ParseScrambleForSize(moves, c.Size)
+			if err != nil {
+				if !quiet {
+					fmt.Printf("Error parsing moves: %v\n", err)
+				}
+				os.Exit(1)
+			}
+		}
+
+		if reverse {
+			parsedMoves = cube.ReverseOrder(parsedMoves)
+		}
+		if invert {
+			parsedMoves = cube.InvertSequence(parsedMoves)
+		}
+
+		// Measure the order from the starting state, before any moves are applied
+		order := 0
+		if findOrder {
+			for _, move := range parsedMoves {
+				if err := move.Validate(c.Size); err != nil {
+					fmt.Printf("Error applying moves: %v\n", err)
+					os.Exit(1)
+				}
+			}
+			order = c.SequenceOrder(parsedMoves)
 		}
 
-		c.ApplyMoves(parsedMoves)
+		for i := 0; i < repeat; i++ {
+			if err := c.ApplyMovesChecked(parsedMoves); err != nil {
+				fmt.Printf("Error applying moves: %v\n", err)
+				os.Exit(1)
+			}
+		}
 
-		if useCfenOutput {
+		if countOnly {
+			// Count output mode: the length of everything applied
+			var applied []cube.Move
+			for i := 0; i < repeat; i++ {
+				applied = append(applied, parsedMoves...)
+			}
+			fmt.Print(cube.CountMetric(applied, metric))
+		} else if useCfenOutput {
 			// CFEN output mode
 			cfenStr, err := cfen.GenerateCFEN(c)
 			if err != nil {
@@ -87,12 +173,34 @@ Examples:
 			useColor, _ := cmd.Flags().GetBool("color")
 			useLetters, _ := cmd.Flags().GetBool("letters")
 			useUnicode := useColor && !useLetters
+			compact, _ := cmd.Flags().GetBool("compact")
 
 			// Display result
-			fmt.Printf("\nCube state after applying moves:\n%s\n", c.UnfoldedString(useColor, useUnicode))
+			fmt.Printf("\nCube state after applying moves:\n")
+			c.Render(os.Stdout, cube.RenderOptions{Color: useColor, Unicode: useUnicode, Compact: compact, Narrow: compact})
+			fmt.Println()
 
 			// Show move count
-			fmt.Printf("Moves applied: %d\n", len(parsedMoves))
+			switch {
+			case invert && reverse:
+				fmt.Printf("Reversed inverse sequence: %s\n", cube.FormatMoves(parsedMoves))
+			case invert:
+				fmt.Printf("Inverse sequence: %s\n", cube.FormatMoves(parsedMoves))
+			case reverse:
+				fmt.Printf("Reversed sequence: %s\n", cube.FormatMoves(parsedMoves))
+			}
+			if repeat > 1 {
+				fmt.Printf("Moves applied: %d (%d x %d)\n", len(parsedMoves)*repeat, len(parsedMoves), repeat)
+			} else {
+				fmt.Printf("Moves applied: %d\n", len(parsedMoves))
+			}
+			if findOrder {
+				if order > 0 {
+					fmt.Printf("Order: %d\n", order)
+				} else {
+					fmt.Printf("Order: more than 10000\n")
+				}
+			}
 
 			// Check if solved
 			if c.IsSolved() {
@@ -108,6 +216,14 @@ func init() {
 	twistCmd.Flags().IntP("dimension", "d", 3, "Cube dimension (2, 3, 4, etc.)")
 	twistCmd.Flags().BoolP("color", "c", false, "Use colored output (Unicode blocks by default)")
 	twistCmd.Flags().Bool("letters", false, "Use letters instead of Unicode blocks when using --color")
+	twistCmd.Flags().Bool("compact", false, "One character per sticker and no blank lines, for big cubes")
 	twistCmd.Flags().Bool("cfen", false, "Output final cube state as CFEN string")
 	twistCmd.Flags().String("start", "", "Starting cube state as CFEN string (default: solved)")
+	twistCmd.Flags().Bool("invert", false, "Apply the inverse of the move sequence")
+	twistCmd.Flags().Bool("reverse", false, "Apply the moves in reverse order without inverting them")
+	twistCmd.Flags().Int("repeat", 1, "Apply the move sequence this many times")
+	twistCmd.Flags().Bool("find-order", false, "Print how many repetitions return the cube to its starting state")
+	twistCmd.Flags().Bool("count-only", false, "Output only the number of moves applied")
+	twistCmd.Flags().StringP("metric", "m", "htm", "Move metric for --count-only (htm, qtm, stm, atm, etm)")
+	twistCmd.Flags().Bool("skip-unknown", false, "Skip moves that don't parse, with a warning, instead of failing")
 }
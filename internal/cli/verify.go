@@ -10,10 +10,17 @@ import (
 )
 
 var verifyCmd = &cobra.Command{
-	Use:   "verify <algorithm>",
+	Use:   "verify <algorithm> [target-cfen]",
 	Short: "Verify an algorithm transforms start state to target state",
 	Long: `Verify that an algorithm correctly transforms a cube from a start state to a target state.
-Both states are specified using CFEN notation with wildcard support.
+Both states are specified using CFEN notation with wildcard support. The
+target may be given as a second argument instead of with --target. Either
+state defaults to solved, so --start alone checks that an algorithm solves
+a case. Give - as the algorithm to read it from standard input.
+
+With --all, verify takes no arguments and instead checks every algorithm
+in the database that has a CFEN pattern, printing a pass/fail summary like
+the verify-database tool. --category limits it to one category.
 
 Examples:
   # Verify Sune algorithm (OLL case)
@@ -26,32 +33,78 @@ Examples:
     --start "YB|Y9/?9/?9/W9/?9/?9" \
     --target "YB|Y9/R9/B9/W9/O9/G9"
 
+  # Verify Sune solves its case (target defaults to solved)
+  cube verify --start "LL:YB|RYBY5O/G2Y/GBY/BR2/O2Y" "R U R' U R U2 R'"
+
+  # Target as a positional argument: Sune orients the last layer
+  cube verify --start "LL:YB|RYBY5O/G2Y/GBY/BR2/O2Y" "R U R' U R U2 R'" "YB|Y9/?9/?9/W9/?9/?9"
+
   # Verify simple inverse (defaults to solved start/target)
   cube verify "R U R' U' U R U' R'"  # Should solve to default state
 
   # Verify F2L pair insertion
   cube verify "U R U' R'" \
     --start "YB|?Y?YYY?Y?/?9/?9/W9/?9/?9" \
-    --target "YB|?Y?YYY?Y?/??R??R??R/??B??B??B/W9/??O??O??O/??G??G??G"`,
-	Args: cobra.ExactArgs(1),
-	Run: func(cmd *cobra.Command, args []string) {
-		algorithm := args[0]
+    --target "YB|?Y?YYY?Y?/??R??R??R/??B??B??B/W9/??O??O??O/??G??G??G"
 
+  # Verify every algorithm pattern in the database
+  cube verify --all
+  cube verify --all --category PLL`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if all, _ := cmd.Flags().GetBool("all"); all {
+			return cobra.NoArgs(cmd, args)
+		}
+		return cobra.RangeArgs(1, 2)(cmd, args)
+	},
+	Run: func(cmd *cobra.Command, args []string) {
 		// Get flags
 		startCFEN, _ := cmd.Flags().GetString("start")
 		targetCFEN, _ := cmd.Flags().GetString("target")
 		verbose, _ := cmd.Flags().GetBool("verbose")
 		headless, _ := cmd.Flags().GetBool("headless")
+
+		if all, _ := cmd.Flags().GetBool("all"); all {
+			category, _ := cmd.Flags().GetString("category")
+			if !verifyDatabase(category, verbose, headless) {
+				os.Exit(1)
+			}
+			return
+		}
+
+		algorithm, err := movesArg(cmd, args[0])
+		if err != nil {
+			if !headless {
+				fmt.Printf("Error: %v\n", err)
+			}
+			os.Exit(1)
+		}
+
+		if len(args) == 2 {
+			if targetCFEN != "" {
+				if !headless {
+					fmt.Printf("Error: target given both as an argument and with --target\n")
+				}
+				os.Exit(1)
+			}
+			targetCFEN = args[1]
+		}
 		useColor, _ := cmd.Flags().GetBool("color")
 		useLetters, _ := cmd.Flags().GetBool("letters")
 		useUnicode := useColor && !useLetters
 
-		// Default to solved cube if not specified
+		// Default to a solved cube the size of the other state, or a 3x3
+		size := 3
+		for _, state := range []string{startCFEN, targetCFEN} {
+			if parsed, err := cfen.ParseCFEN(state); err == nil {
+				size = parsed.Dimension
+				break
+			}
+		}
 		if startCFEN == "" {
-			startCFEN = "YB|Y9/R9/B9/W9/O9/G9" // 3x3 solved
+			startCFEN = cfen.SolvedCFEN(size)
 		}
 		if targetCFEN == "" {
-			targetCFEN = "YB|Y9/R9/B9/W9/O9/G9" // 3x3 solved
+			targetCFEN = cfen.SolvedCFEN(size)
 		}
 
 		// Parse start CFEN
@@ -153,6 +206,92 @@ Examples:
 	},
 }
 
+// verifyDatabase checks that every algorithm with a pattern, optionally
+// only those in category, turns a solved cube into its pattern, and reports
+// whether they all do. Unless headless, it prints each result and a summary.
+func verifyDatabase(category string, verbose, headless bool) bool {
+	printf := func(format string, args ...interface{}) {
+		if !headless {
+			fmt.Printf(format, args...)
+		}
+	}
+
+	algorithms := cube.GetAllAlgorithms()
+	if category != "" {
+		algorithms = cube.GetByCategory(category)
+	}
+	var toVerify []cube.Algorithm
+	for _, alg := range algorithms {
+		if alg.Pattern != "" {
+			toVerify = append(toVerify, alg)
+		}
+	}
+
+	if len(toVerify) == 0 {
+		if category != "" {
+			printf("No algorithms with CFEN patterns found in category '%s'\n", category)
+		} else {
+			printf("No algorithms with CFEN patterns found in database\n")
+		}
+		return true
+	}
+
+	total := len(toVerify)
+	passed, failed, errored := 0, 0, 0
+	printf("Verifying %d algorithms", total)
+	if category != "" {
+		printf(" in category '%s'", category)
+	}
+	printf("...\n\n")
+
+	startCFEN := cfen.SolvedCFEN(3)
+	for i, alg := range toVerify {
+		printf("[%d/%d] Testing %s (%s)...", i+1, total, alg.Name, alg.CaseID)
+
+		err := cfen.VerifyAlgorithm(alg, startCFEN, alg.Pattern)
+		switch {
+		case err == nil:
+			passed++
+			printf(" ✅ PASS\n")
+		case err == cfen.ErrVerificationFailed:
+			failed++
+			printf(" ❌ FAIL\n")
+			if verbose {
+				printf("    Reason: Algorithm does not achieve target state\n")
+			}
+		default:
+			errored++
+			printf(" ⚠️  ERROR\n")
+			if verbose {
+				printf("    Reason: %v\n", err)
+			}
+		}
+
+		if verbose {
+			printf("    Algorithm: %s\n", alg.Moves)
+			printf("    Start:     %s\n", startCFEN)
+			printf("    Target:    %s\n", alg.Pattern)
+			printf("\n")
+		}
+	}
+
+	percent := func(n int) float64 { return float64(n) / float64(total) * 100 }
+	printf("\n=== Verification Summary ===\n")
+	printf("Total algorithms tested: %d\n", total)
+	printf("✅ Passed: %d (%.1f%%)\n", passed, percent(passed))
+	printf("❌ Failed: %d (%.1f%%)\n", failed, percent(failed))
+	if errored > 0 {
+		printf("⚠️  Errors: %d (%.1f%%)\n", errored, percent(errored))
+	}
+
+	if passed == total {
+		printf("\n🎉 All algorithms verified successfully!\n")
+		return true
+	}
+	printf("\n⚠️  Some algorithms failed verification. Use --verbose for details.\n")
+	return false
+}
+
 func init() {
 	verifyCmd.Flags().String("start", "", "Starting CFEN state (defaults to solved)")
 	verifyCmd.Flags().String("target", "", "Target CFEN state (defaults to solved)")
@@ -160,4 +299,6 @@ func init() {
 	verifyCmd.Flags().Bool("headless", false, "Exit with code 0 for pass, 1 for fail (no output)")
 	verifyCmd.Flags().BoolP("color", "c", false, "Use colored output")
 	verifyCmd.Flags().Bool("letters", false, "Use colored letters instead of blocks")
+	verifyCmd.Flags().Bool("all", false, "Verify every algorithm pattern in the database instead of one algorithm")
+	verifyCmd.Flags().String("category", "", "With --all, only verify algorithms in this category")
 }
@@ -1,6 +1,9 @@
 package cube
 
 import (
+	"errors"
+	"fmt"
+	"strings"
 	"testing"
 )
 
@@ -24,6 +27,9 @@ func TestAdvancedMoveNotationParsing(t *testing.T) {
 		{"Rw'", Move{Face: Right, Wide: true, Clockwise: false, WideDepth: 2}},
 		{"Fw2", Move{Face: Front, Wide: true, Clockwise: true, Double: true, WideDepth: 2}},
 		{"Lw", Move{Face: Left, Wide: true, Clockwise: true, WideDepth: 2}},
+		{"r", Move{Face: Right, Wide: true, Clockwise: true}},
+		{"f'", Move{Face: Front, Wide: true, Clockwise: false}},
+		{"u2", Move{Face: Up, Wide: true, Clockwise: true, Double: true}},
 
 		// Layer moves
 		{"2R", Move{Face: Right, Layer: 1, Clockwise: true}}, // 2R = second layer from right
@@ -141,6 +147,55 @@ func TestMiddleSliceMoves3x3(t *testing.T) {
 	}
 }
 
+// Test that slice moves turn the same way as their reference faces: M follows
+// L, E follows D, and S follows F
+func TestSliceDirectionsMatchWideMoves(t *testing.T) {
+	tests := []struct {
+		wide   string
+		equiv  string
+		reason string
+	}{
+		{"Rw", "R M'", "M follows L"},
+		{"Rw'", "R' M", "M follows L"},
+		{"Lw", "L M", "M follows L"},
+		{"Uw", "U E'", "E follows D"},
+		{"Uw'", "U' E", "E follows D"},
+		{"Dw", "D E", "E follows D"},
+		{"Fw", "F S", "S follows F"},
+		{"Fw'", "F' S'", "S follows F"},
+		{"Bw", "B S'", "S follows F"},
+		{"Rw2", "R2 M2", "M follows L"},
+	}
+
+	// Start from a scrambled cube so every sticker position is distinguishable
+	scramble, _ := ParseScramble("R U F' L2 D B' R' U2 F")
+
+	for _, tt := range tests {
+		t.Run(tt.wide+" == "+tt.equiv, func(t *testing.T) {
+			wide, err := ParseScramble(tt.wide)
+			if err != nil {
+				t.Fatalf("Failed to parse %s: %v", tt.wide, err)
+			}
+			equiv, err := ParseScramble(tt.equiv)
+			if err != nil {
+				t.Fatalf("Failed to parse %s: %v", tt.equiv, err)
+			}
+
+			a := NewCube(3)
+			a.ApplyMoves(scramble)
+			a.ApplyMoves(wide)
+
+			b := NewCube(3)
+			b.ApplyMoves(scramble)
+			b.ApplyMoves(equiv)
+
+			if a.String() != b.String() {
+				t.Errorf("%s and %s differ (%s):\n%s\nvs\n%s", tt.wide, tt.equiv, tt.reason, a, b)
+			}
+		})
+	}
+}
+
 // Test wide moves on 4x4 cube
 func TestWideMoves4x4(t *testing.T) {
 	cube := NewCube(4)
@@ -194,6 +249,53 @@ func TestLayerMoves5x5(t *testing.T) {
 	}
 }
 
+// TestWideMoves5x5 checks which front face columns (or rows, for U and D)
+// each wide turn moves on a 5x5: Rw takes two layers, and 3Rw reaches the
+// center slice
+func TestWideMoves5x5(t *testing.T) {
+	tests := []struct {
+		move  string
+		lines []int
+	}{
+		{"Rw", []int{3, 4}},
+		{"r", []int{3, 4}},
+		{"3Rw", []int{2, 3, 4}},
+		{"3r'", []int{2, 3, 4}},
+		{"4Rw", []int{1, 2, 3, 4}},
+		{"Lw", []int{0, 1}},
+		{"3Lw", []int{0, 1, 2}},
+		{"Uw", []int{0, 1}},
+		{"3Uw", []int{0, 1, 2}},
+		{"3Dw2", []int{2, 3, 4}},
+	}
+
+	for _, tt := range tests {
+		for _, engine := range []Engine{PermutationEngine, GeometricEngine} {
+			c := NewCube(5, WithEngine(engine))
+			c.ApplyMoves(mustParseForSize(t, tt.move, 5))
+
+			horizontal := strings.ContainsAny(tt.move, "UD")
+			var changed []int
+			for i := 0; i < 5; i++ {
+				sticker := c.Faces[Front][0][i]
+				if horizontal {
+					sticker = c.Faces[Front][i][0]
+				}
+				if sticker != Blue {
+					changed = append(changed, i)
+				}
+			}
+			if fmt.Sprint(changed) != fmt.Sprint(tt.lines) {
+				t.Errorf("%s engine: %s changed front face lines %v, want %v", engine, tt.move, changed, tt.lines)
+			}
+		}
+	}
+
+	if _, err := ParseScrambleForSize("5Rw", 5); err == nil {
+		t.Error("5Rw should be rejected on a 5x5")
+	}
+}
+
 // Test cube rotations
 func TestCubeRotations(t *testing.T) {
 	cube := NewCube(3)
@@ -206,18 +308,18 @@ func TestCubeRotations(t *testing.T) {
 	xMove := Move{Rotation: X_Rotation, Clockwise: true}
 	cube.ApplyMove(xMove)
 
-	// After x rotation: F→D, U→F, B→U, D→B
-	if cube.Faces[Down][0][0] != originalFront {
-		t.Error("After x rotation, Down face should contain original Front")
+	// After x rotation (follows R): F→U, U→B, B→D, D→F
+	if cube.Faces[Up][0][0] != originalFront {
+		t.Error("After x rotation, Up face should contain original Front")
 	}
-	if cube.Faces[Front][0][0] != originalUp {
-		t.Error("After x rotation, Front face should contain original Up")
+	if cube.Faces[Back][0][0] != originalUp {
+		t.Error("After x rotation, Back face should contain original Up")
 	}
-	if cube.Faces[Up][0][0] != originalBack {
-		t.Error("After x rotation, Up face should contain original Back")
+	if cube.Faces[Down][0][0] != originalBack {
+		t.Error("After x rotation, Down face should contain original Back")
 	}
-	if cube.Faces[Back][0][0] != originalDown {
-		t.Error("After x rotation, Back face should contain original Down")
+	if cube.Faces[Front][0][0] != originalDown {
+		t.Error("After x rotation, Front face should contain original Down")
 	}
 }
 
@@ -279,6 +381,129 @@ func TestSliceMovesEvenCubes(t *testing.T) {
 	}
 }
 
+func TestParseMoveRejectsExoticNotation(t *testing.T) {
+	for _, notation := range []string{"Mw", "2Mw", "Ew'", "2M", "xw", "3y2", "Q", "R3"} {
+		_, err := ParseMove(notation)
+		var parseErr *ParseError
+		if !errors.As(err, &parseErr) || parseErr.Token != notation {
+			t.Errorf("ParseMove(%q) error = %v, want a ParseError naming the move", notation, err)
+		}
+	}
+
+	// Lowercase wide moves and doubled primes are accepted
+	for _, notation := range []string{"u", "r2'", "Rw2", "3Rw'"} {
+		if _, err := ParseMove(notation); err != nil {
+			t.Errorf("ParseMove(%q) error = %v", notation, err)
+		}
+	}
+}
+
+// TestParseMoveModifierOrder enumerates every arrangement of up to two
+// modifiers after a few kinds of move
+func TestParseMoveModifierOrder(t *testing.T) {
+	suffixes := map[string]string{
+		"":    "",
+		"'":   "'",
+		"2":   "2",
+		"2'":  "2",
+		"'2":  "2",
+		"''":  "",
+		"22":  "",
+		"2'2": "",
+		"'2'": "",
+	}
+
+	for _, base := range []string{"R", "Rw", "r", "3Rw", "2R", "M", "x"} {
+		for suffix, want := range suffixes {
+			notation := base + suffix
+			move, err := ParseMove(notation)
+			if want == "" && suffix != "" {
+				var parseErr *ParseError
+				if !errors.As(err, &parseErr) || parseErr.Token != notation {
+					t.Errorf("ParseMove(%q) error = %v, want a ParseError naming the move", notation, err)
+				}
+				continue
+			}
+			if err != nil {
+				t.Errorf("ParseMove(%q) error = %v", notation, err)
+				continue
+			}
+
+			plain, _ := ParseMove(base)
+			wantMove := plain
+			wantMove.Clockwise = want != "'"
+			wantMove.Double = want == "2"
+			if move != wantMove {
+				t.Errorf("ParseMove(%q) = %+v, want %+v (%s%s)", notation, move, wantMove, base, want)
+			}
+		}
+	}
+}
+
+func TestParseMovesSkipUnknown(t *testing.T) {
+	moves, skipped := ParseMovesSkipUnknown("R Mw U (x) r2' Q")
+	if got := FormatMoves(moves); got != "R U Rw2" {
+		t.Errorf("moves = %q, want R U Rw2", got)
+	}
+
+	want := map[int]string{2: "Mw", 4: "(x)", 6: "Q"}
+	if len(skipped) != len(want) {
+		t.Fatalf("skipped %d moves, want %d: %v", len(skipped), len(want), skipped)
+	}
+	for _, err := range skipped {
+		if want[err.Position] != err.Token {
+			t.Errorf("skipped %q at position %d", err.Token, err.Position)
+		}
+	}
+
+	if moves, skipped := ParseMovesSkipUnknown("  "); len(moves) != 0 || len(skipped) != 0 {
+		t.Errorf("ParseMovesSkipUnknown(blank) = %v, %v", moves, skipped)
+	}
+}
+
+func TestParseScrambleForSize(t *testing.T) {
+	tests := []struct {
+		sequence string
+		size     int
+		reject   string // token that should be rejected, or "" to accept
+	}{
+		{"R U M' x2", 3, ""},
+		{"2R 2L'", 3, ""},
+		{"3R", 3, "3R"},
+		{"3R 3Lw", 4, ""},
+		{"R 4R", 4, "4R"},
+		{"R U M", 4, "M"},
+		{"E2 S'", 5, ""},
+		{"Rw", 2, "Rw"},
+		{"Rw 3Fw", 3, "3Fw"},
+		{"r U r'", 3, ""},
+		{"y R 2U", 2, "2U"},
+		{"0R", 5, "0R"},
+	}
+
+	for _, tt := range tests {
+		moves, err := ParseScrambleForSize(tt.sequence, tt.size)
+		if tt.reject == "" {
+			if err != nil {
+				t.Errorf("ParseScrambleForSize(%q, %d) error = %v", tt.sequence, tt.size, err)
+			} else if len(moves) != len(strings.Fields(tt.sequence)) {
+				t.Errorf("ParseScrambleForSize(%q, %d) returned %d moves", tt.sequence, tt.size, len(moves))
+			}
+			continue
+		}
+
+		var parseErr *ParseError
+		if !errors.As(err, &parseErr) || parseErr.Token != tt.reject {
+			t.Errorf("ParseScrambleForSize(%q, %d) error = %v, want a ParseError for %s", tt.sequence, tt.size, err, tt.reject)
+		}
+	}
+
+	// Parsing errors come through unchanged
+	if _, err := ParseScrambleForSize("R Q", 3); err == nil || !strings.Contains(err.Error(), "Q") {
+		t.Errorf("ParseScrambleForSize(R Q) error = %v", err)
+	}
+}
+
 // Performance test for advanced moves
 func BenchmarkAdvancedMoveParsing(b *testing.B) {
 	notations := []string{"M", "Rw", "2R", "x", "M'", "Fw2", "3L'", "y2"}
@@ -0,0 +1,274 @@
+package cube
+
+import (
+	"log"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// algorithmIndex is a read-only view of the combined algorithm database,
+// built once on first use. Lookups consult the index instead of rebuilding
+// and rescanning the full algorithm list on every call.
+//
+// The index snapshots AlgorithmDatabase and ImportedAlgorithms the first
+// time it is needed; both are treated as immutable after package init.
+// Each entry's MoveCount is recomputed from its Moves as it is indexed, so
+// hand-maintained counts can't drift, and entries that can't be used (see
+// ValidateDatabase) are skipped with a warning.
+type algorithmIndex struct {
+	all []Algorithm
+
+	// Lowercased searchable fields, parallel to all
+	lowerName        []string
+	lowerCaseID      []string
+	lowerCategory    []string
+	lowerDescription []string
+	lowerRecognition []string
+
+	// Positions into all, kept in database order
+	byName     map[string][]int // lowercased name
+	byCaseID   map[string][]int // lowercased case ID
+	byCategory map[string][]int // uppercased category
+	byMoves    map[string][]int // move string in normalized notation
+
+	// Sorted distinct values for shell completion
+	names      []string
+	caseIDs    []string
+	categories []string
+}
+
+var (
+	algIndexOnce sync.Once
+	algIndex     *algorithmIndex
+)
+
+// getAlgorithmIndex returns the shared algorithm index, building it on first use
+func getAlgorithmIndex() *algorithmIndex {
+	algIndexOnce.Do(func() {
+		algIndex = buildAlgorithmIndex(AlgorithmDatabase, ImportedAlgorithms)
+	})
+	return algIndex
+}
+
+func buildAlgorithmIndex(sources ...[]Algorithm) *algorithmIndex {
+	total := 0
+	for _, src := range sources {
+		total += len(src)
+	}
+
+	idx := &algorithmIndex{
+		all:              make([]Algorithm, 0, total),
+		lowerName:        make([]string, 0, total),
+		lowerCaseID:      make([]string, 0, total),
+		lowerCategory:    make([]string, 0, total),
+		lowerDescription: make([]string, 0, total),
+		lowerRecognition: make([]string, 0, total),
+		byName:           make(map[string][]int),
+		byCaseID:         make(map[string][]int),
+		byCategory:       make(map[string][]int),
+		byMoves:          make(map[string][]int),
+	}
+
+	for _, src := range sources {
+		for _, alg := range src {
+			if err := checkAlgorithm(alg); err != nil {
+				log.Printf("warning: skipping algorithm %s: %v", describeAlgorithm(alg), err)
+				continue
+			}
+			alg.MoveCount = derivedMoveCount(alg)
+
+			i := len(idx.all)
+			idx.all = append(idx.all, alg)
+
+			name := strings.ToLower(alg.Name)
+			caseID := strings.ToLower(alg.CaseID)
+			idx.lowerName = append(idx.lowerName, name)
+			idx.lowerCaseID = append(idx.lowerCaseID, caseID)
+			idx.lowerCategory = append(idx.lowerCategory, strings.ToLower(alg.Category))
+			idx.lowerDescription = append(idx.lowerDescription, strings.ToLower(alg.Description))
+			idx.lowerRecognition = append(idx.lowerRecognition, strings.ToLower(alg.Recognition))
+
+			idx.byName[name] = append(idx.byName[name], i)
+			idx.byCaseID[caseID] = append(idx.byCaseID[caseID], i)
+			category := strings.ToUpper(alg.Category)
+			idx.byCategory[category] = append(idx.byCategory[category], i)
+			moves := normalizeMoves(alg.Moves)
+			idx.byMoves[moves] = append(idx.byMoves[moves], i)
+		}
+	}
+
+	idx.names = distinctSorted(idx.all, func(alg Algorithm) string { return alg.Name })
+	idx.caseIDs = distinctSorted(idx.all, func(alg Algorithm) string { return alg.CaseID })
+	idx.categories = distinctSorted(idx.all, func(alg Algorithm) string { return alg.Category })
+
+	return idx
+}
+
+// derivedMoveCount returns the number of moves in alg.Moves, warning when a
+// hand-written MoveCount disagrees. The moves must parse.
+func derivedMoveCount(alg Algorithm) int {
+	moves, _ := ParseScramble(alg.Moves)
+	if alg.MoveCount != 0 && alg.MoveCount != len(moves) {
+		log.Printf("warning: algorithm %q (%s) has MoveCount %d but %d moves; using %d",
+			alg.Name, alg.CaseID, alg.MoveCount, len(moves), len(moves))
+	}
+	return len(moves)
+}
+
+// normalizeMoves rewrites a move sequence in the notation FormatMoves
+// uses, so spacing and spellings like r and Rw compare equal. Sequences
+// that don't parse only have their spacing normalized.
+func normalizeMoves(moves string) string {
+	parsed, err := ParseScramble(moves)
+	if err != nil {
+		return strings.Join(strings.Fields(moves), " ")
+	}
+	return FormatMoves(parsed)
+}
+
+// distinctSorted returns the non-empty values of field across algs, sorted
+// and with case-insensitive duplicates removed (the first spelling wins)
+func distinctSorted(algs []Algorithm, field func(Algorithm) string) []string {
+	seen := make(map[string]bool)
+	var values []string
+	for _, alg := range algs {
+		value := field(alg)
+		key := strings.ToLower(value)
+		if value == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		values = append(values, value)
+	}
+	sort.Strings(values)
+	return values
+}
+
+// collect copies the algorithms at the given positions so callers can't
+// mutate the shared index. Returns nil when there are no positions.
+func (idx *algorithmIndex) collect(positions []int) []Algorithm {
+	if len(positions) == 0 {
+		return nil
+	}
+	results := make([]Algorithm, len(positions))
+	for i, pos := range positions {
+		results[i] = idx.all[pos]
+	}
+	return results
+}
+
+// GetByName returns all algorithms whose name matches exactly (case-insensitive)
+func GetByName(name string) []Algorithm {
+	idx := getAlgorithmIndex()
+	return idx.collect(idx.byName[strings.ToLower(strings.TrimSpace(name))])
+}
+
+// GetByCaseID returns all algorithms with the given case ID (case-insensitive)
+func GetByCaseID(caseID string) []Algorithm {
+	idx := getAlgorithmIndex()
+	return idx.collect(idx.byCaseID[strings.ToLower(strings.TrimSpace(caseID))])
+}
+
+// ShortestAlgorithmFor returns the algorithm for a case ID (such as
+// "OLL-27") with the fewest moves in metric. Ties go to the entry listed
+// first in the database. It returns false if the case has no algorithm
+// whose moves parse.
+func ShortestAlgorithmFor(caseID string, metric Metric) (Algorithm, bool) {
+	var best Algorithm
+	bestCount := -1
+	for _, alg := range GetByCaseID(caseID) {
+		moves, err := ParseScramble(alg.Moves)
+		if err != nil {
+			continue
+		}
+		if count := CountMetric(moves, metric); bestCount < 0 || count < bestCount {
+			best, bestCount = alg, count
+		}
+	}
+	return best, bestCount >= 0
+}
+
+// GetByMoveCount returns the algorithms with between min and max moves,
+// inclusive, in metric, in database order. If categories are given, only
+// algorithms in one of them (case-insensitive) are included, so
+// GetByMoveCount(0, 11, HTM, "PLL", "CFOP-PLL") lists every PLL under 12
+// moves. Algorithms whose moves don't parse are left out.
+func GetByMoveCount(min, max int, metric Metric, categories ...string) []Algorithm {
+	wanted := make(map[string]bool, len(categories))
+	for _, category := range categories {
+		wanted[strings.ToUpper(strings.TrimSpace(category))] = true
+	}
+
+	var algs []Algorithm
+	for _, alg := range getAlgorithmIndex().all {
+		if len(wanted) > 0 && !wanted[strings.ToUpper(alg.Category)] {
+			continue
+		}
+		moves, err := ParseScramble(alg.Moves)
+		if err != nil {
+			continue
+		}
+		if count := CountMetric(moves, metric); count >= min && count <= max {
+			algs = append(algs, alg)
+		}
+	}
+	return algs
+}
+
+// LookupExact returns the algorithms whose name or case ID matches query
+// exactly (case-insensitive), in database order
+func LookupExact(query string) []Algorithm {
+	idx := getAlgorithmIndex()
+	key := strings.ToLower(strings.TrimSpace(query))
+	if key == "" {
+		return nil
+	}
+
+	positions := append([]int(nil), idx.byName[key]...)
+	for _, pos := range idx.byCaseID[key] {
+		if !containsInt(positions, pos) {
+			positions = append(positions, pos)
+		}
+	}
+	sort.Ints(positions)
+	return idx.collect(positions)
+}
+
+func containsInt(values []int, value int) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// AlgorithmNames returns the distinct algorithm names in the database, sorted
+func AlgorithmNames() []string {
+	return append([]string(nil), getAlgorithmIndex().names...)
+}
+
+// CaseIDs returns the distinct case IDs in the database (OLL-27, PLL-T, ...), sorted
+func CaseIDs() []string {
+	return append([]string(nil), getAlgorithmIndex().caseIDs...)
+}
+
+// AlgorithmCategories returns the distinct algorithm categories in the database, sorted
+func AlgorithmCategories() []string {
+	return append([]string(nil), getAlgorithmIndex().categories...)
+}
+
+// AlgorithmsByCategory returns every algorithm in the database grouped by
+// category, each group in database order. Keys are spelled as
+// AlgorithmCategories spells them. The grouping is built once with the
+// rest of the index and is safe to use from several goroutines; each call
+// returns a fresh map of copies, so callers may modify it freely.
+func AlgorithmsByCategory() map[string][]Algorithm {
+	idx := getAlgorithmIndex()
+	byCategory := make(map[string][]Algorithm, len(idx.categories))
+	for _, category := range idx.categories {
+		byCategory[category] = idx.collect(idx.byCategory[strings.ToUpper(category)])
+	}
+	return byCategory
+}
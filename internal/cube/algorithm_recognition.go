@@ -0,0 +1,141 @@
+package cube
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Generated recognition
+//
+// Many algorithms in the database have no Recognition text. The case an
+// algorithm solves is the state its inverse leaves on a solved 3x3, and
+// counting which pieces that state has out of place or turned in place
+// gives a rough description of it, such as "two adjacent corners twisted,
+// edges solved". It says nothing about colors or which layer, but it
+// beats an empty string.
+
+var numberWords = [...]string{"zero", "one", "two", "three", "four", "five", "six",
+	"seven", "eight", "nine", "ten", "eleven", "twelve"}
+
+// HasRecognition reports whether the algorithm has recognition text of
+// its own. The imported database marks unknown recognition as "UNK".
+func (a *Algorithm) HasRecognition() bool {
+	recognition := strings.TrimSpace(a.Recognition)
+	return recognition != "" && recognition != "UNK"
+}
+
+// GenerateRecognition describes the case the algorithm solves from its
+// effect on a 3x3: which corners and edges are swapped, cycled, twisted,
+// or flipped. It returns "" if the moves don't parse.
+func (a *Algorithm) GenerateRecognition() string {
+	moves, err := ParseScramble(a.Moves)
+	if err != nil {
+		return ""
+	}
+	c := NewCube(3)
+	c.ApplyMoves(InvertSequence(moves))
+
+	corners, edges := c.CornerPermutation(), c.EdgePermutation()
+	cornerTwist, edgeFlip := c.CornerOrientation(), c.EdgeOrientation()
+	parts := describePieces("corners", "twisted", cornerNames[:], corners[:], cornerTwist[:])
+	parts = append(parts, describePieces("edges", "flipped", edgeNames[:], edges[:], edgeFlip[:])...)
+	return strings.Join(parts, ", ")
+}
+
+// describePieces describes one kind of piece: how the pieces are permuted,
+// then how many are turned in place (twisted corners or flipped edges)
+func describePieces(kind, turned string, names []string, perm, orientation []int) []string {
+	var turnedPositions []int
+	for pos, o := range orientation {
+		if o != 0 {
+			turnedPositions = append(turnedPositions, pos)
+		}
+	}
+	cycles := permutationCycles(perm)
+	if len(cycles) == 0 && len(turnedPositions) == 0 {
+		return []string{kind + " solved"}
+	}
+
+	var parts []string
+	if len(cycles) > 0 {
+		parts = append(parts, describePermutation(kind, names, cycles))
+	}
+	if len(turnedPositions) > 0 {
+		parts = append(parts, fmt.Sprintf("%s %s%s", countWord(len(turnedPositions)),
+			arrangement(names, turnedPositions), kind+" "+turned))
+	} else {
+		parts = append(parts, kind+" oriented")
+	}
+	return parts
+}
+
+// describePermutation describes the cycles pieces travel along: a single
+// swap or cycle, pairs of swaps, or just how many pieces move
+func describePermutation(kind string, names []string, cycles [][]int) string {
+	if len(cycles) == 1 {
+		cycle := cycles[0]
+		if len(cycle) == 2 {
+			return fmt.Sprintf("two %s%s swapped", arrangement(names, cycle), kind)
+		}
+		return fmt.Sprintf("%s %s cycled", countWord(len(cycle)), kind)
+	}
+
+	moved := 0
+	swaps := true
+	for _, cycle := range cycles {
+		moved += len(cycle)
+		swaps = swaps && len(cycle) == 2
+	}
+	if swaps {
+		return fmt.Sprintf("%s pairs of %s swapped", countWord(len(cycles)), kind)
+	}
+	return fmt.Sprintf("%s %s permuted", countWord(moved), kind)
+}
+
+// arrangement returns "adjacent ", "diagonal ", or "opposite " for a pair
+// of positions that share a face, and "" otherwise. Corners are adjacent
+// if they share an edge and diagonal if they share only a face; edges are
+// adjacent if they share a corner and opposite across a face.
+func arrangement(names []string, positions []int) string {
+	if len(positions) != 2 {
+		return ""
+	}
+	a, b := names[positions[0]], names[positions[1]]
+
+	var shared, others []byte
+	for i := 0; i < len(a); i++ {
+		if strings.IndexByte(b, a[i]) >= 0 {
+			shared = append(shared, a[i])
+		} else {
+			others = append(others, a[i])
+		}
+	}
+
+	switch {
+	case len(a) == 3 && len(shared) == 2:
+		return "adjacent "
+	case len(a) == 3 && len(shared) == 1:
+		return "diagonal "
+	case len(a) == 2 && len(shared) == 1:
+		other := strings.Trim(b, string(shared))
+		if isOppositeFaceLetter(others[0], other[0]) {
+			return "opposite "
+		}
+		return "adjacent "
+	}
+	return ""
+}
+
+// isOppositeFaceLetter reports whether two face letters name opposite faces
+func isOppositeFaceLetter(a, b byte) bool {
+	pair := string([]byte{a, b})
+	return strings.Contains("UD DU FB BF RL LR", pair)
+}
+
+// countWord spells out a piece count
+func countWord(n int) string {
+	if n < len(numberWords) {
+		return numberWords[n]
+	}
+	return fmt.Sprint(n)
+}
@@ -0,0 +1,36 @@
+package cube
+
+import "testing"
+
+func TestGenerateRecognition(t *testing.T) {
+	tests := []struct {
+		name, moves, want string
+	}{
+		{"T-Perm", "R U R' U' R' F R2 U' R' U' R U R' F'",
+			"two adjacent corners swapped, corners oriented, two opposite edges swapped, edges oriented"},
+		{"H-Perm", "M2 U M2 U2 M2 U M2",
+			"corners solved, two pairs of edges swapped, edges oriented"},
+		{"Aa-Perm", "x R' U R' D2 R U' R' D2 R2 x'",
+			"three corners cycled, corners oriented, edges solved"},
+		{"H OLL", "R U2 R' U' R U R' U' R U' R'",
+			"four corners twisted, three edges cycled, edges oriented"},
+		{"rotation", "y", "corners solved, edges solved"},
+		{"bad moves", "R Q", ""},
+	}
+
+	for _, tt := range tests {
+		alg := Algorithm{Name: tt.name, Moves: tt.moves}
+		if got := alg.GenerateRecognition(); got != tt.want {
+			t.Errorf("%s: GenerateRecognition() = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestHasRecognition(t *testing.T) {
+	for recognition, want := range map[string]bool{"": false, "UNK": false, " ": false, "Headlights": true} {
+		alg := Algorithm{Recognition: recognition}
+		if got := alg.HasRecognition(); got != want {
+			t.Errorf("HasRecognition() with %q = %v, want %v", recognition, got, want)
+		}
+	}
+}
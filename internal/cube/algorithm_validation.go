@@ -0,0 +1,73 @@
+package cube
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Database validation
+//
+// The database is Go source today, but entries may come from imports
+// later, and one bad entry shouldn't take lookups down with it. The index
+// skips entries that can't be used (no name, category, or moves, or moves
+// that don't parse) with a warning, and ValidateDatabase lists every
+// problem for tools that want to report them.
+
+// ValidateDatabase checks every entry of AlgorithmDatabase and
+// ImportedAlgorithms and returns one error per problem: a missing name,
+// category, or move sequence, moves that don't parse, or a MoveCount that
+// disagrees with the moves. It returns nil for a clean database. Entries
+// with any problem but a wrong MoveCount are left out of lookups.
+func ValidateDatabase() []error {
+	var problems []error
+	for _, src := range []struct {
+		name string
+		algs []Algorithm
+	}{
+		{"AlgorithmDatabase", AlgorithmDatabase},
+		{"ImportedAlgorithms", ImportedAlgorithms},
+	} {
+		for i, alg := range src.algs {
+			if err := checkAlgorithm(alg); err != nil {
+				problems = append(problems, fmt.Errorf("%s[%d] %s: %w", src.name, i, describeAlgorithm(alg), err))
+				continue
+			}
+			moves, _ := ParseScramble(alg.Moves)
+			if alg.MoveCount != 0 && alg.MoveCount != len(moves) {
+				problems = append(problems, fmt.Errorf("%s[%d] %s: MoveCount %d but %d moves",
+					src.name, i, describeAlgorithm(alg), alg.MoveCount, len(moves)))
+			}
+		}
+	}
+	return problems
+}
+
+// checkAlgorithm returns why an entry can't be used, or nil if it can
+func checkAlgorithm(alg Algorithm) error {
+	var missing []string
+	if strings.TrimSpace(alg.Name) == "" {
+		missing = append(missing, "name")
+	}
+	if strings.TrimSpace(alg.Category) == "" {
+		missing = append(missing, "category")
+	}
+	if strings.TrimSpace(alg.Moves) == "" {
+		missing = append(missing, "moves")
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("missing %s", strings.Join(missing, ", "))
+	}
+
+	if _, err := ParseScramble(alg.Moves); err != nil {
+		return fmt.Errorf("invalid moves %q: %w", alg.Moves, err)
+	}
+	return nil
+}
+
+// describeAlgorithm names an entry in a problem report
+func describeAlgorithm(alg Algorithm) string {
+	if alg.CaseID != "" {
+		return fmt.Sprintf("%q (%s)", alg.Name, alg.CaseID)
+	}
+	return fmt.Sprintf("%q", alg.Name)
+}
@@ -0,0 +1,56 @@
+package cube
+
+import (
+	"bytes"
+	"log"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestValidateDatabase(t *testing.T) {
+	for _, err := range ValidateDatabase() {
+		t.Error(err)
+	}
+}
+
+func TestCheckAlgorithm(t *testing.T) {
+	tests := []struct {
+		alg  Algorithm
+		want string // substring of the error, or "" for none
+	}{
+		{Algorithm{Name: "Sune", Category: "OLL", Moves: "R U R' U R U2 R'"}, ""},
+		{Algorithm{Category: "OLL", Moves: "R U"}, "missing name"},
+		{Algorithm{Name: "Empty"}, "missing category, moves"},
+		{Algorithm{Name: "Bad", Category: "OLL", Moves: "R Q U"}, "invalid moves"},
+	}
+
+	for _, tt := range tests {
+		err := checkAlgorithm(tt.alg)
+		switch {
+		case tt.want == "" && err != nil:
+			t.Errorf("checkAlgorithm(%+v) error = %v", tt.alg, err)
+		case tt.want != "" && (err == nil || !strings.Contains(err.Error(), tt.want)):
+			t.Errorf("checkAlgorithm(%+v) error = %v, want one containing %q", tt.alg, err, tt.want)
+		}
+	}
+}
+
+func TestIndexSkipsBadEntries(t *testing.T) {
+	var logged bytes.Buffer
+	log.SetOutput(&logged)
+	defer log.SetOutput(os.Stderr)
+
+	idx := buildAlgorithmIndex([]Algorithm{
+		{Name: "Good", Category: "Test", Moves: "R U"},
+		{Name: "Bad", Category: "Test", Moves: "R Q"},
+		{Name: "Nameless"},
+	})
+
+	if len(idx.all) != 1 || idx.all[0].Name != "Good" {
+		t.Errorf("index holds %d algorithms, want just Good", len(idx.all))
+	}
+	if strings.Count(logged.String(), "skipping") != 2 {
+		t.Errorf("expected two warnings, got %q", logged.String())
+	}
+}
@@ -48,11 +48,15 @@ type Algorithm struct {
 	Related []string // IDs of related algorithms
 }
 
-// GetAllAlgorithms returns all algorithms (original database + imported)
+// GetAllAlgorithms returns all algorithms (original database + imported).
+// The returned slice is a copy and may be modified by the caller.
 func GetAllAlgorithms() []Algorithm {
-	var allAlgs []Algorithm
-	allAlgs = append(allAlgs, AlgorithmDatabase...)
-	allAlgs = append(allAlgs, ImportedAlgorithms...)
+	idx := getAlgorithmIndex()
+	if len(idx.all) == 0 {
+		return nil
+	}
+	allAlgs := make([]Algorithm, len(idx.all))
+	copy(allAlgs, idx.all)
 	return allAlgs
 }
 
@@ -120,6 +124,27 @@ var AlgorithmDatabase = []Algorithm{
 		Recognition: "F2L pair building/breaking trigger",
 		Related:     []string{"TRIG-2", "TRIG-3"},
 	},
+	// 4x4 Parity Cases (no 3x3 pattern; see HasOLLParity and HasPLLParity)
+	{
+		Name:        "4x4 OLL Parity",
+		CaseID:      "PARITY-OLL",
+		Category:    "Parity",
+		Moves:       "2R2 B2 U2 2L U2 2R' U2 2R U2 F2 2R F2 2L' B2 2R2",
+		MoveCount:   15,
+		Description: "Flips the front last layer edge pair on a reduced 4x4",
+		Recognition: "Odd number of flipped edge pairs after F2L",
+		Related:     []string{"PARITY-PLL"},
+	},
+	{
+		Name:        "4x4 PLL Parity",
+		CaseID:      "PARITY-PLL",
+		Category:    "Parity",
+		Moves:       "2R2 U2 2R2 Uw2 2R2 2U2",
+		MoveCount:   6,
+		Description: "Swaps the front and back last layer edge pairs on a reduced 4x4",
+		Recognition: "Last layer oriented but corners and edges need different swap counts",
+		Related:     []string{"PARITY-OLL"},
+	},
 	// TODO: Temporarily commenting out remaining algorithms while refactoring structure
 	/*
 		{
@@ -579,6 +604,16 @@ var AlgorithmDatabase = []Algorithm{
 
 // LookupAlgorithm searches for algorithms by name or moves with improved scoring
 func LookupAlgorithm(query string) []Algorithm {
+	return LookupAlgorithmN(query, 0, 0)
+}
+
+// LookupAlgorithmN is LookupAlgorithm limited to the limit best matches
+// scoring at least minScore. A limit of 0 or less returns all matches.
+//
+// Scores add up across fields: an exact name match is worth 100, an exact
+// case ID 90, a name prefix 80, a name substring 60, a case ID substring 50,
+// a category 40, a description 30, and recognition text 25.
+func LookupAlgorithmN(query string, limit int, minScore int) []Algorithm {
 	query = strings.ToLower(strings.TrimSpace(query))
 	if query == "" {
 		return []Algorithm{}
@@ -591,12 +626,13 @@ func LookupAlgorithm(query string) []Algorithm {
 
 	var scored []ScoredAlgorithm
 
-	for _, alg := range GetAllAlgorithms() {
+	idx := getAlgorithmIndex()
+	for i, alg := range idx.all {
 		score := 0
-		lowerName := strings.ToLower(alg.Name)
-		lowerCaseID := strings.ToLower(alg.CaseID)
-		lowerDescription := strings.ToLower(alg.Description)
-		lowerRecognition := strings.ToLower(alg.Recognition)
+		lowerName := idx.lowerName[i]
+		lowerCaseID := idx.lowerCaseID[i]
+		lowerDescription := idx.lowerDescription[i]
+		lowerRecognition := idx.lowerRecognition[i]
 
 		// Exact name match gets highest score
 		if lowerName == query {
@@ -623,12 +659,12 @@ func LookupAlgorithm(query string) []Algorithm {
 		}
 
 		// Category match
-		if strings.Contains(strings.ToLower(alg.Category), query) {
+		if strings.Contains(idx.lowerCategory[i], query) {
 			score += 40
 		}
 
 		// Only include results with some relevance
-		if score > 0 {
+		if score > 0 && score >= minScore {
 			scored = append(scored, ScoredAlgorithm{alg, score})
 		}
 	}
@@ -642,6 +678,10 @@ func LookupAlgorithm(query string) []Algorithm {
 		return scored[i].Score > scored[j].Score
 	})
 
+	if limit > 0 && len(scored) > limit {
+		scored = scored[:limit]
+	}
+
 	// Convert back to []Algorithm
 	var results []Algorithm
 	for _, s := range scored {
@@ -665,13 +705,14 @@ func FuzzyLookupAlgorithm(query string) []Algorithm {
 
 	var scored []ScoredAlgorithm
 
-	for _, alg := range GetAllAlgorithms() {
+	idx := getAlgorithmIndex()
+	for i, alg := range idx.all {
 		score := 0.0
 
 		// Calculate fuzzy match scores
-		nameScore := fuzzyMatchScore(query, strings.ToLower(alg.Name))
-		caseIDScore := fuzzyMatchScore(query, strings.ToLower(alg.CaseID))
-		descScore := fuzzyMatchScore(query, strings.ToLower(alg.Description))
+		nameScore := fuzzyMatchScore(query, idx.lowerName[i])
+		caseIDScore := fuzzyMatchScore(query, idx.lowerCaseID[i])
+		descScore := fuzzyMatchScore(query, idx.lowerDescription[i])
 
 		// Weight the scores
 		score = nameScore*3.0 + caseIDScore*2.5 + descScore*1.0
@@ -736,32 +777,18 @@ func fuzzyMatchScore(query, text string) float64 {
 	return float64(overlap) / float64(len(query))
 }
 
-// LookupByMoves finds algorithms that exactly match the given moves
+// LookupByMoves finds algorithms whose moves match the given sequence.
+// Both are normalized first, so "r U R'" finds an algorithm stored as
+// "Rw U R'" and extra spacing doesn't matter.
 func LookupByMoves(moves string) []Algorithm {
-	moves = strings.TrimSpace(moves)
-	var results []Algorithm
-
-	for _, alg := range GetAllAlgorithms() {
-		if alg.Moves == moves {
-			results = append(results, alg)
-		}
-	}
-
-	return results
+	idx := getAlgorithmIndex()
+	return idx.collect(idx.byMoves[normalizeMoves(moves)])
 }
 
-// GetByCategory returns all algorithms in a given category
+// GetByCategory returns all algorithms in a given category (case-insensitive)
 func GetByCategory(category string) []Algorithm {
-	category = strings.ToUpper(strings.TrimSpace(category))
-	var results []Algorithm
-
-	for _, alg := range GetAllAlgorithms() {
-		if alg.Category == category {
-			results = append(results, alg)
-		}
-	}
-
-	return results
+	idx := getAlgorithmIndex()
+	return idx.collect(idx.byCategory[strings.ToUpper(strings.TrimSpace(category))])
 }
 
 // CalculateMoveCount returns the number of moves in an algorithm string
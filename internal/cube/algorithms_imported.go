@@ -558,7 +558,7 @@ var ImportedAlgorithms = []Algorithm{
 		Name:        "Runway",
 		CaseID:      "OLL-1",
 		Category:    "CFOP-OLL",
-		Moves:       "R U2 R'2 F R F' U'2 R' F R F'",
+		Moves:       "R U2 R2 F R F' U2 R' F R F'",
 		MoveCount:   11,
 		Description: "Orients edges and corners (dot case)",
 		Recognition: "No top-layer edges oriented (dot)",
@@ -639,7 +639,7 @@ var ImportedAlgorithms = []Algorithm{
 		Name:        "Pi (Bruno)",
 		CaseID:      "OLL-22",
 		Category:    "CFOP-OLL",
-		Moves:       "R U2 R'2 U' R2 U' R'2 U2 R",
+		Moves:       "R U2 R2 U' R2 U' R2 U2 R",
 		MoveCount:   9,
 		Description: "Orients all corners (edges oriented)",
 		Recognition: "All edges oriented, Pi-shape (two opposite corners oriented):contentReference[oaicite:5]{index=5}",
@@ -720,7 +720,7 @@ var ImportedAlgorithms = []Algorithm{
 		Name:        "City",
 		CaseID:      "OLL-34",
 		Category:    "CFOP-OLL",
-		Moves:       "R U R'2 U' R' F R U R U' F'",
+		Moves:       "R U R2 U' R' F R U R U' F'",
 		MoveCount:   11,
 		Description: "Orients two corners",
 		Recognition: "Edges oriented, “C” shape (city)",
@@ -783,7 +783,7 @@ var ImportedAlgorithms = []Algorithm{
 		Name:        "Awkward Fish",
 		CaseID:      "OLL-41",
 		Category:    "CFOP-OLL",
-		Moves:       "R U R' U R U'2 R' F R U R' U' F'",
+		Moves:       "R U R' U R U2 R' F R U R' U' F'",
 		MoveCount:   13,
 		Description: "Orients one corner",
 		Recognition: "Edges oriented, “awkward” fish pattern",
@@ -846,7 +846,7 @@ var ImportedAlgorithms = []Algorithm{
 		Name:        "Right Back Squeezy",
 		CaseID:      "OLL-49",
 		Category:    "CFOP-OLL",
-		Moves:       "Rw U' Rw'2 U Rw2 U Rw'2 U' Rw",
+		Moves:       "Rw U' Rw2 U Rw2 U Rw2 U' Rw",
 		MoveCount:   9,
 		Description: "Orients edges (L-case)",
 		Recognition: "No corners oriented, requires wide moves",
@@ -864,7 +864,7 @@ var ImportedAlgorithms = []Algorithm{
 		Name:        "Right Front Squeezy",
 		CaseID:      "OLL-50",
 		Category:    "CFOP-OLL",
-		Moves:       "Rw' U Rw2 U' Rw'2 U' Rw2 U Rw'",
+		Moves:       "Rw' U Rw2 U' Rw2 U' Rw2 U Rw'",
 		MoveCount:   9,
 		Description: "Orients edges (L-case)",
 		Recognition: "No corners oriented, wide moves mirror",
@@ -900,7 +900,7 @@ var ImportedAlgorithms = []Algorithm{
 		Name:        "Anti-Frying Pan",
 		CaseID:      "OLL-54",
 		Category:    "CFOP-OLL",
-		Moves:       "Rw U R' U R U' R' U R U'2 Rw'",
+		Moves:       "Rw U R' U R U' R' U R U2 Rw'",
 		MoveCount:   11,
 		Description: "Orients edges (T-case)",
 		Recognition: "Edges oriented, frying pan mirror",
@@ -909,7 +909,7 @@ var ImportedAlgorithms = []Algorithm{
 		Name:        "Highway",
 		CaseID:      "OLL-55",
 		Category:    "CFOP-OLL",
-		Moves:       "y R' F R U R U' R'2 F' R2 U' R' U R U R'",
+		Moves:       "y R' F R U R U' R2 F' R2 U' R' U R U R'",
 		MoveCount:   16,
 		Description: "Orients edges (I-case)",
 		Recognition: "Edges oriented, line through top pattern",
@@ -936,7 +936,7 @@ var ImportedAlgorithms = []Algorithm{
 		Name:        "Streetlights",
 		CaseID:      "OLL-6",
 		Category:    "CFOP-OLL",
-		Moves:       "y' Rw U R' U R U2 Rw'2 U' R U' R' U2 Rw",
+		Moves:       "y' Rw U R' U R U2 Rw2 U' R U' R' U2 Rw",
 		MoveCount:   14,
 		Description: "Orients edges and corners (dot case)",
 		Recognition: "No edges oriented, two opposite corner blocks",
@@ -945,7 +945,7 @@ var ImportedAlgorithms = []Algorithm{
 		Name:        "Butterfly",
 		CaseID:      "OLL-7",
 		Category:    "CFOP-OLL",
-		Moves:       "Rw' R U R U R' U' Rw R'2 F R F'",
+		Moves:       "Rw' R U R U R' U' Rw R2 F R F'",
 		MoveCount:   12,
 		Description: "Orients edges and corners (dot case)",
 		Recognition: "No edges oriented, “Butterfly” pattern",
@@ -1143,7 +1143,7 @@ var ImportedAlgorithms = []Algorithm{
 		Name:        "Z Permutation",
 		CaseID:      "PLL-Z",
 		Category:    "CFOP-PLL",
-		Moves:       "M'2 U M'2 U M' U2 M'2 U2 M'",
+		Moves:       "M2 U M2 U M' U2 M2 U2 M'",
 		MoveCount:   9,
 		Description: "Swaps two edges sets (adjacent swap)",
 		Recognition: "Two diagonal edge swaps (zigzag pattern), corners solved",
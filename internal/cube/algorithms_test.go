@@ -0,0 +1,437 @@
+package cube
+
+import (
+	"bytes"
+	"log"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// naiveAllAlgorithms mirrors the original unindexed GetAllAlgorithms
+func naiveAllAlgorithms() []Algorithm {
+	var allAlgs []Algorithm
+	allAlgs = append(allAlgs, AlgorithmDatabase...)
+	allAlgs = append(allAlgs, ImportedAlgorithms...)
+	for i := range allAlgs {
+		allAlgs[i].MoveCount = allAlgs[i].CalculateMoveCount()
+	}
+	return allAlgs
+}
+
+func TestIndexDerivesMoveCount(t *testing.T) {
+	var logged bytes.Buffer
+	log.SetOutput(&logged)
+	defer log.SetOutput(os.Stderr)
+
+	idx := buildAlgorithmIndex([]Algorithm{
+		{Name: "Wrong", Category: "Test", Moves: "R U R' U'", MoveCount: 3},
+		{Name: "Missing", Category: "Test", Moves: "R U"},
+		{Name: "Right", Category: "Test", Moves: "R U2", MoveCount: 2},
+	})
+
+	for i, want := range []int{4, 2, 2} {
+		if got := idx.all[i].MoveCount; got != want {
+			t.Errorf("%s: MoveCount = %d, want %d", idx.all[i].Name, got, want)
+		}
+	}
+	if !strings.Contains(logged.String(), `"Wrong"`) {
+		t.Errorf("expected a warning for the wrong count, got %q", logged.String())
+	}
+	if strings.Count(logged.String(), "warning") != 1 {
+		t.Errorf("expected exactly one warning, got %q", logged.String())
+	}
+}
+
+func TestGetAllAlgorithmsMatchesDatabase(t *testing.T) {
+	got := GetAllAlgorithms()
+	want := naiveAllAlgorithms()
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("GetAllAlgorithms() returned %d algorithms, want %d in database order", len(got), len(want))
+	}
+
+	// Callers own the returned slice
+	got[0].Name = "mutated"
+	if GetAllAlgorithms()[0].Name == "mutated" {
+		t.Error("GetAllAlgorithms() should return a copy of the index")
+	}
+}
+
+func TestGetByCategoryMatchesScan(t *testing.T) {
+	for _, category := range []string{"OLL", "pll", " CFOP-F2L ", "nonexistent"} {
+		want := []Algorithm(nil)
+		upper := strings.ToUpper(strings.TrimSpace(category))
+		for _, alg := range naiveAllAlgorithms() {
+			if alg.Category == upper {
+				want = append(want, alg)
+			}
+		}
+
+		got := GetByCategory(category)
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("GetByCategory(%q) returned %d algorithms, want %d", category, len(got), len(want))
+		}
+	}
+}
+
+// TestGetByCategoryIgnoresCase checks that categories stored in mixed case,
+// such as Trigger and Parity, are found however the query is spelled, as
+// "cube lookup --category Trigger" promises
+func TestGetByCategoryIgnoresCase(t *testing.T) {
+	for _, stored := range []string{"Trigger", "Parity"} {
+		var want []Algorithm
+		for _, alg := range naiveAllAlgorithms() {
+			if alg.Category == stored {
+				want = append(want, alg)
+			}
+		}
+		if len(want) == 0 {
+			t.Fatalf("no %s algorithms in the database", stored)
+		}
+
+		for _, query := range []string{stored, strings.ToLower(stored), strings.ToUpper(stored), " " + stored + " "} {
+			if got := GetByCategory(query); !reflect.DeepEqual(got, want) {
+				t.Errorf("GetByCategory(%q) returned %d algorithms, want %d", query, len(got), len(want))
+			}
+		}
+	}
+}
+
+func TestCompletionLists(t *testing.T) {
+	lists := []struct {
+		name   string
+		values []string
+		field  func(Algorithm) string
+	}{
+		{"AlgorithmNames", AlgorithmNames(), func(alg Algorithm) string { return alg.Name }},
+		{"CaseIDs", CaseIDs(), func(alg Algorithm) string { return alg.CaseID }},
+		{"AlgorithmCategories", AlgorithmCategories(), func(alg Algorithm) string { return alg.Category }},
+	}
+
+	for _, list := range lists {
+		if !sort.StringsAreSorted(list.values) {
+			t.Errorf("%s() is not sorted", list.name)
+		}
+
+		seen := make(map[string]bool)
+		for _, value := range list.values {
+			key := strings.ToLower(value)
+			if value == "" || seen[key] {
+				t.Errorf("%s() has empty or duplicate value %q", list.name, value)
+			}
+			seen[key] = true
+		}
+
+		// Every algorithm's value must be offered
+		for _, alg := range naiveAllAlgorithms() {
+			if value := list.field(alg); value != "" && !seen[strings.ToLower(value)] {
+				t.Errorf("%s() is missing %q", list.name, value)
+			}
+		}
+	}
+}
+
+func TestLookupByMovesMatchesScan(t *testing.T) {
+	for _, moves := range []string{"R U R' U R U2 R'", " R U R' U' ", "R R R R", "R  U R'  U R U2 R'"} {
+		want := []Algorithm(nil)
+		normalized := normalizeMoves(moves)
+		for _, alg := range naiveAllAlgorithms() {
+			if normalizeMoves(alg.Moves) == normalized {
+				want = append(want, alg)
+			}
+		}
+
+		got := LookupByMoves(moves)
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("LookupByMoves(%q) returned %d algorithms, want %d", moves, len(got), len(want))
+		}
+	}
+}
+
+func TestNormalizeMoves(t *testing.T) {
+	tests := map[string]string{
+		"R  U R'":  "R U R'",
+		"r U r'":   "Rw U Rw'",
+		" F2 ":     "F2",
+		"R Q  U":   "R Q U",
+		"(R U R')": "(R U R')",
+	}
+	for moves, want := range tests {
+		if got := normalizeMoves(moves); got != want {
+			t.Errorf("normalizeMoves(%q) = %q, want %q", moves, got, want)
+		}
+	}
+}
+
+func TestLookupExact(t *testing.T) {
+	for _, query := range []string{"sune", "OLL-27", " oll-27 "} {
+		algs := LookupExact(query)
+		if len(algs) == 0 {
+			t.Errorf("LookupExact(%q) found nothing", query)
+		}
+		for _, alg := range algs {
+			if !strings.EqualFold(alg.Name, strings.TrimSpace(query)) && !strings.EqualFold(alg.CaseID, strings.TrimSpace(query)) {
+				t.Errorf("LookupExact(%q) returned %s (%s)", query, alg.Name, alg.CaseID)
+			}
+		}
+	}
+
+	for _, query := range []string{"sun", "OLL-99", ""} {
+		if algs := LookupExact(query); len(algs) != 0 {
+			t.Errorf("LookupExact(%q) = %d algorithms, want none", query, len(algs))
+		}
+	}
+}
+
+func TestShortestAlgorithmFor(t *testing.T) {
+	tests := []struct {
+		caseID string
+		metric Metric
+		want   string
+	}{
+		{"OLL-27", HTM, "R U R' U R U2 R'"},
+		{"pll-t", HTM, "R U R' F' R U R' U' R' F R2 U' R'"},
+		{"PLL-T", QTM, "R U R' F' R U R' U' R' F R2 U' R'"},
+	}
+	for _, tt := range tests {
+		alg, ok := ShortestAlgorithmFor(tt.caseID, tt.metric)
+		if !ok || alg.Moves != tt.want {
+			t.Errorf("ShortestAlgorithmFor(%q, %s) = %q, %v, want %q", tt.caseID, tt.metric, alg.Moves, ok, tt.want)
+		}
+	}
+
+	for _, caseID := range CaseIDs() {
+		best, ok := ShortestAlgorithmFor(caseID, STM)
+		if !ok {
+			continue
+		}
+		bestMoves, _ := ParseScramble(best.Moves)
+		for _, alg := range GetByCaseID(caseID) {
+			if moves, err := ParseScramble(alg.Moves); err == nil && CountMetric(moves, STM) < CountMetric(bestMoves, STM) {
+				t.Errorf("%s: %q is shorter than the chosen %q", caseID, alg.Moves, best.Moves)
+			}
+		}
+	}
+
+	if _, ok := ShortestAlgorithmFor("OLL-99", HTM); ok {
+		t.Error("ShortestAlgorithmFor(OLL-99) found an algorithm")
+	}
+}
+
+func TestGetByMoveCount(t *testing.T) {
+	contains := func(algs []Algorithm, moves string) bool {
+		for _, alg := range algs {
+			if alg.Moves == moves {
+				return true
+			}
+		}
+		return false
+	}
+	const sune = "R U R' U R U2 R'"
+	const uaPerm = "R U' R U R U R U' R' U' R2"
+	const tPerm = "R U R' U' R' F R2 U' R' U' R U R' F'"
+
+	// Every PLL under 12 moves
+	short := GetByMoveCount(0, 11, HTM, "PLL", "CFOP-PLL")
+	if !contains(short, uaPerm) || contains(short, tPerm) {
+		t.Errorf("PLLs under 12 HTM: Ua-perm included %v, T-perm included %v", contains(short, uaPerm), contains(short, tPerm))
+	}
+	for _, alg := range short {
+		moves, _ := ParseScramble(alg.Moves)
+		if alg.Category != "PLL" && alg.Category != "CFOP-PLL" {
+			t.Errorf("%s (%s) is not a PLL", alg.Name, alg.Category)
+		}
+		if count := CountMetric(moves, HTM); count > 11 {
+			t.Errorf("%s has %d moves, want at most 11", alg.Name, count)
+		}
+	}
+
+	// The metric decides the count: Sune is 7 HTM but 8 QTM
+	if !contains(GetByMoveCount(7, 7, HTM, "oll"), sune) {
+		t.Error("Sune missing from 7 HTM OLL algorithms")
+	}
+	if contains(GetByMoveCount(7, 7, QTM, "OLL"), sune) || !contains(GetByMoveCount(8, 8, QTM, "OLL"), sune) {
+		t.Error("Sune should have 8 QTM moves")
+	}
+
+	if got, all := len(GetByMoveCount(0, 1000, HTM)), len(GetAllAlgorithms()); got != all {
+		t.Errorf("GetByMoveCount over every length returned %d of %d algorithms", got, all)
+	}
+	if got := GetByMoveCount(10, 5, HTM); len(got) != 0 {
+		t.Errorf("GetByMoveCount(10, 5) returned %d algorithms", len(got))
+	}
+	if got := GetByMoveCount(0, 1000, HTM, "NOPE"); len(got) != 0 {
+		t.Errorf("GetByMoveCount for an unknown category returned %d algorithms", len(got))
+	}
+}
+
+func TestGetByCaseID(t *testing.T) {
+	algs := GetByCaseID("oll-27")
+	if len(algs) == 0 {
+		t.Fatal("GetByCaseID(\"oll-27\") should find Sune")
+	}
+	for _, alg := range algs {
+		if !strings.EqualFold(alg.CaseID, "OLL-27") {
+			t.Errorf("GetByCaseID(\"oll-27\") returned %s", alg.CaseID)
+		}
+	}
+
+	if algs := GetByName("SUNE"); len(algs) == 0 || algs[0].Name != "Sune" {
+		t.Errorf("GetByName(\"SUNE\") = %v, want Sune first", algs)
+	}
+
+	if algs := GetByCaseID("no-such-case"); algs != nil {
+		t.Errorf("GetByCaseID for unknown case should return nil, got %d results", len(algs))
+	}
+}
+
+func TestLookupAlgorithmOrdering(t *testing.T) {
+	// Sune should outrank Anti-Sune on an exact name match
+	results := LookupAlgorithm("sune")
+	if len(results) < 2 {
+		t.Fatalf("LookupAlgorithm(\"sune\") returned %d results, want at least 2", len(results))
+	}
+	if results[0].Name != "Sune" {
+		t.Errorf("LookupAlgorithm(\"sune\")[0] = %s, want Sune", results[0].Name)
+	}
+
+	if results := LookupAlgorithm("   "); len(results) != 0 {
+		t.Errorf("LookupAlgorithm on blank query should return nothing, got %d", len(results))
+	}
+}
+
+func TestLookupAlgorithmN(t *testing.T) {
+	all := LookupAlgorithm("r")
+	if len(all) <= 10 {
+		t.Fatalf("LookupAlgorithm(\"r\") returned %d results, want a broad match", len(all))
+	}
+
+	// The limit keeps the best matches in the same order
+	limited := LookupAlgorithmN("r", 10, 0)
+	if !reflect.DeepEqual(limited, all[:10]) {
+		t.Errorf("LookupAlgorithmN(\"r\", 10, 0) is not the top 10 of LookupAlgorithm")
+	}
+
+	if got := LookupAlgorithmN("r", 0, 0); !reflect.DeepEqual(got, all) {
+		t.Errorf("LookupAlgorithmN with limit 0 returned %d results, want all %d", len(got), len(all))
+	}
+
+	// An exact name match scores at least 100, so a high threshold drops
+	// weaker matches but keeps Sune first
+	strong := LookupAlgorithmN("sune", 0, 100)
+	if len(strong) == 0 || strong[0].Name != "Sune" {
+		t.Fatalf("LookupAlgorithmN(\"sune\", 0, 100) should start with Sune, got %v", strong)
+	}
+	if len(strong) >= len(LookupAlgorithm("sune")) {
+		t.Errorf("min score 100 kept all %d matches", len(strong))
+	}
+	if got := LookupAlgorithmN("sune", 0, 1000); len(got) != 0 {
+		t.Errorf("LookupAlgorithmN with an unreachable min score returned %d results", len(got))
+	}
+}
+
+func BenchmarkGetAllAlgorithms(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_ = GetAllAlgorithms()
+	}
+}
+
+func BenchmarkLookupAlgorithm(b *testing.B) {
+	queries := []string{"sune", "OLL-27", "t-perm", "pll", "corner"}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = LookupAlgorithm(queries[i%len(queries)])
+	}
+}
+
+func BenchmarkLookupByMoves(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_ = LookupByMoves("R U R' U R U2 R'")
+	}
+}
+
+func BenchmarkGetByCategory(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_ = GetByCategory("CFOP-OLL")
+	}
+}
+
+func TestAlgorithmsByCategory(t *testing.T) {
+	byCategory := AlgorithmsByCategory()
+
+	categories := make([]string, 0, len(byCategory))
+	total := 0
+	for category, algs := range byCategory {
+		categories = append(categories, category)
+		total += len(algs)
+		if want := GetByCategory(category); !reflect.DeepEqual(algs, want) {
+			t.Errorf("AlgorithmsByCategory()[%q] has %d algorithms, GetByCategory has %d", category, len(algs), len(want))
+		}
+	}
+	sort.Strings(categories)
+	if want := AlgorithmCategories(); !reflect.DeepEqual(categories, want) {
+		t.Errorf("AlgorithmsByCategory() keys = %v, want %v", categories, want)
+	}
+	if all := len(GetAllAlgorithms()); total != all {
+		t.Errorf("AlgorithmsByCategory() holds %d algorithms, want all %d", total, all)
+	}
+
+	// Callers get their own copy
+	byCategory["PLL"][0].Moves = "R"
+	delete(byCategory, "OLL")
+	again := AlgorithmsByCategory()
+	if again["PLL"][0].Moves == "R" || len(again["OLL"]) == 0 {
+		t.Error("modifying the returned map changed the next call's result")
+	}
+
+	// Concurrent first use and reads are safe (run with -race)
+	done := make(chan bool)
+	for i := 0; i < 8; i++ {
+		go func() {
+			done <- len(AlgorithmsByCategory()["PLL"]) > 0
+		}()
+	}
+	for i := 0; i < 8; i++ {
+		if !<-done {
+			t.Error("concurrent AlgorithmsByCategory() call missing PLL")
+		}
+	}
+}
+
+// BenchmarkCategoryLookup groups the database by category three ways: one
+// AlgorithmsByCategory call, a GetByCategory call per category, and the
+// scan of every algorithm per category that callers would otherwise write
+func BenchmarkCategoryLookup(b *testing.B) {
+	categories := AlgorithmCategories()
+
+	b.Run("AlgorithmsByCategory", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			byCategory := AlgorithmsByCategory()
+			for _, category := range categories {
+				_ = byCategory[category]
+			}
+		}
+	})
+	b.Run("GetByCategory", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for _, category := range categories {
+				_ = GetByCategory(category)
+			}
+		}
+	})
+	b.Run("scan", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for _, category := range categories {
+				var algs []Algorithm
+				for _, alg := range GetAllAlgorithms() {
+					if strings.EqualFold(alg.Category, category) {
+						algs = append(algs, alg)
+					}
+				}
+				_ = algs
+			}
+		}
+	})
+}
@@ -0,0 +1,56 @@
+package cube
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// AssertSolves fails t unless solution solves a 3x3 scrambled with
+// scramble, listing the stickers left out of place and drawing the cube
+func AssertSolves(t testing.TB, scramble, solution string) {
+	t.Helper()
+	c := NewCube(3)
+	for _, sequence := range []string{scramble, solution} {
+		moves, err := ParseScramble(sequence)
+		if err != nil {
+			t.Fatalf("ParseScramble(%q) error = %v", sequence, err)
+		}
+		c.ApplyMoves(moves)
+	}
+	if c.IsSolved() {
+		return
+	}
+
+	diffs, _ := DiffCubes(NewCube(3), c)
+	lines := make([]string, len(diffs))
+	for i, diff := range diffs {
+		lines[i] = fmt.Sprintf("  %s %d,%d: %s, want %s", diff.Face, diff.Row, diff.Col, diff.B, diff.A)
+	}
+	t.Errorf("%q then %q leaves %d stickers out of place:\n%s\n%s",
+		scramble, solution, len(diffs), strings.Join(lines, "\n"), c.UnfoldedString(false, false))
+}
+
+func TestAssertSolves(t *testing.T) {
+	AssertSolves(t, "R U R' U'", "U R U' R'")
+	AssertSolves(t, "", "")
+	AssertSolves(t, "R", "x R' x'")
+
+	recorder := &failRecorder{TB: t}
+	AssertSolves(recorder, "R U", "R'")
+	if !recorder.failed || !strings.Contains(recorder.message, "out of place") {
+		t.Errorf("AssertSolves passed a solution that doesn't solve: %q", recorder.message)
+	}
+}
+
+// failRecorder records a failure instead of failing the test
+type failRecorder struct {
+	testing.TB
+	failed  bool
+	message string
+}
+
+func (r *failRecorder) Errorf(format string, args ...interface{}) {
+	r.failed = true
+	r.message = fmt.Sprintf(format, args...)
+}
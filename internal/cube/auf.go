@@ -0,0 +1,78 @@
+package cube
+
+import "strings"
+
+// AUF
+//
+// A last layer case can turn up at any of four U-face angles, and a stored
+// algorithm only solves it from one. Turning U before the algorithm (the
+// pre-AUF, "adjust U face") lines the case up, and for PLL a second U turn
+// afterwards lines the solved layer up with the rest of the cube.
+
+// AUFMoves returns the U turn for a number of clockwise quarter turns:
+// none for 0, U for 1, U2 for 2, and U' for 3
+func AUFMoves(quarterTurns int) []Move {
+	switch ((quarterTurns % 4) + 4) % 4 {
+	case 1:
+		return []Move{{Face: Up, Clockwise: true}}
+	case 2:
+		return []Move{{Face: Up, Clockwise: true, Double: true}}
+	case 3:
+		return []Move{{Face: Up}}
+	}
+	return nil
+}
+
+// aufOrder lists quarter turn counts cheapest first in QTM: none, U, U',
+// then U2
+var aufOrder = [4]int{0, 1, 3, 2}
+
+// BestAUF finds the U turns to make before and after alg so it solves the
+// case on c, a 3x3, as clockwise quarter turns for AUFMoves. An OLL
+// algorithm (any category containing "OLL") only has to orient the last
+// layer, so its post-AUF is always 0; any other algorithm has to solve the
+// cube. Of the adjustments that work, BestAUF picks the fewest quarter
+// turns. It reports false if none works, if the moves don't parse, or if
+// c isn't a 3x3. c is not modified.
+func BestAUF(c *Cube, alg Algorithm) (pre, post int, ok bool) {
+	moves, err := ParseScramble(alg.Moves)
+	if c.Size != 3 || err != nil {
+		return 0, 0, false
+	}
+
+	done := (*Cube).IsSolved
+	if strings.Contains(strings.ToUpper(alg.Category), "OLL") {
+		done = func(c *Cube) bool { return isF2LSolved(c) && isLastLayerOriented(c) }
+	}
+
+	bestCost := -1
+	for _, preTurns := range aufOrder {
+		before := c.Clone()
+		before.ApplyMoves(AUFMoves(preTurns))
+		before.ApplyMoves(moves)
+		for _, postTurns := range aufOrder {
+			cost := aufCost(preTurns) + aufCost(postTurns)
+			if ok && cost >= bestCost {
+				continue
+			}
+			after := before.Clone()
+			after.ApplyMoves(AUFMoves(postTurns))
+			if done(after) {
+				bestCost = cost
+				pre, post, ok = preTurns, postTurns, true
+			}
+		}
+	}
+	return pre, post, ok
+}
+
+// aufCost counts the quarter turns in an AUF
+func aufCost(quarterTurns int) int {
+	if quarterTurns == 2 {
+		return 2
+	}
+	if quarterTurns == 0 {
+		return 0
+	}
+	return 1
+}
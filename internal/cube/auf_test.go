@@ -0,0 +1,68 @@
+package cube
+
+import "testing"
+
+func TestBestAUF(t *testing.T) {
+	tPerm := Algorithm{Category: "PLL", Moves: "R U R' U' R' F R2 U' R' U' R U R' F'"}
+	sune := Algorithm{Category: "OLL", Moves: "R U R' U R U2 R'"}
+
+	tests := []struct {
+		name      string
+		alg       Algorithm
+		setup     string // Applied to a solved cube to make the case
+		pre, post int
+	}{
+		{"T-Perm as stored", tPerm, "F R U' R' U R U R2 F' R U R U' R'", 0, 0},
+		{"T-Perm turned", tPerm, "F R U' R' U R U R2 F' R U R U' R' U", 3, 0},
+		{"T-Perm, layer off", tPerm, "U2 F R U' R' U R U R2 F' R U R U' R' U'", 1, 2},
+		{"Sune turned", sune, "R U2 R' U' R U' R' U2", 2, 0},
+		{"Sune, layer off", sune, "U R U2 R' U' R U' R'", 0, 0},
+	}
+
+	for _, tt := range tests {
+		c := NewCube(3)
+		setup, err := ParseScramble(tt.setup)
+		if err != nil {
+			t.Fatal(err)
+		}
+		c.ApplyMoves(setup)
+
+		pre, post, ok := BestAUF(c, tt.alg)
+		if !ok || pre != tt.pre || post != tt.post {
+			t.Errorf("%s: BestAUF() = %d, %d, %v, want %d, %d", tt.name, pre, post, ok, tt.pre, tt.post)
+			continue
+		}
+
+		moves, _ := ParseScramble(tt.alg.Moves)
+		c.ApplyMoves(AUFMoves(pre))
+		c.ApplyMoves(moves)
+		c.ApplyMoves(AUFMoves(post))
+		if tt.alg.Category == "PLL" && !c.IsSolved() {
+			t.Errorf("%s: the adjusted algorithm doesn't solve the case", tt.name)
+		}
+	}
+}
+
+func TestBestAUFFails(t *testing.T) {
+	tPerm := Algorithm{Category: "PLL", Moves: "R U R' U' R' F R2 U' R' U' R U R' F'"}
+
+	wrongCase := NewCube(3)
+	wrongCase.ApplyMoves([]Move{{Face: Right, Clockwise: true}})
+	if _, _, ok := BestAUF(wrongCase, tPerm); ok {
+		t.Error("BestAUF found an adjustment for a case the algorithm doesn't solve")
+	}
+	if _, _, ok := BestAUF(NewCube(4), tPerm); ok {
+		t.Error("BestAUF should only work on a 3x3")
+	}
+	if _, _, ok := BestAUF(NewCube(3), Algorithm{Moves: "R Q"}); ok {
+		t.Error("BestAUF should fail for moves that don't parse")
+	}
+}
+
+func TestAUFMoves(t *testing.T) {
+	for turns, want := range map[int]string{0: "", 1: "U", 2: "U2", 3: "U'", -1: "U'", 5: "U"} {
+		if got := FormatMoves(AUFMoves(turns)); got != want {
+			t.Errorf("AUFMoves(%d) = %q, want %q", turns, got, want)
+		}
+	}
+}
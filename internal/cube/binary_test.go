@@ -0,0 +1,107 @@
+package cube
+
+import (
+	"encoding"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+var (
+	_ encoding.BinaryMarshaler   = (*Cube)(nil)
+	_ encoding.BinaryUnmarshaler = (*Cube)(nil)
+)
+
+func TestBinaryRoundTrip(t *testing.T) {
+	for size := 2; size <= 7; size++ {
+		moves, err := GenerateScramble(size, ScrambleOptions{Length: 30, Rand: rand.New(rand.NewSource(int64(size)))})
+		if err != nil {
+			t.Fatal(err)
+		}
+		c := NewCube(size)
+		c.ApplyMoves(moves)
+		checkBinaryRoundTrip(t, c)
+	}
+}
+
+func TestBinaryRoundTripState(t *testing.T) {
+	scheme := map[Face]Color{Front: Red, Back: Orange, Left: Green, Right: Blue, Up: White, Down: Yellow}
+	custom, err := NewCubeWithScheme(3, scheme)
+	if err != nil {
+		t.Fatal(err)
+	}
+	moves, _ := ParseScramble("R U x y' F")
+	custom.ApplyMoves(moves)
+	checkBinaryRoundTrip(t, custom)
+
+	// Wildcard stickers survive too
+	pattern := NewCube(3)
+	pattern.Faces[Front][0][0] = Grey
+	pattern.Faces[Down][2][2] = Grey
+	checkBinaryRoundTrip(t, pattern)
+}
+
+func TestBinarySize(t *testing.T) {
+	data, err := NewCube(3).MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Version, size, flags, and 54 stickers at two per byte
+	if len(data) != 3+27 {
+		t.Errorf("3x3 encodes to %d bytes, want 30", len(data))
+	}
+}
+
+func TestUnmarshalBinaryErrors(t *testing.T) {
+	data, err := NewCube(3).MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	badColor := append([]byte{}, data...)
+	badColor[len(badColor)-1] = 0xff
+
+	tests := map[string][]byte{
+		"empty":         nil,
+		"bad version":   append([]byte{9}, data[1:]...),
+		"bad size":      {binaryVersion, 1, 0},
+		"unknown flags": {binaryVersion, 3, 0x80},
+		"truncated":     data[:len(data)-1],
+		"trailing":      append(append([]byte{}, data...), 0),
+		"bad color":     badColor,
+	}
+	for name, blob := range tests {
+		if err := new(Cube).UnmarshalBinary(blob); err == nil {
+			t.Errorf("%s: UnmarshalBinary succeeded", name)
+		}
+	}
+}
+
+// checkBinaryRoundTrip encodes c, decodes it into a new cube, and checks
+// the two hold the same state
+func checkBinaryRoundTrip(t *testing.T, c *Cube) {
+	t.Helper()
+	data, err := c.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary() error = %v", err)
+	}
+
+	var decoded Cube
+	if err := decoded.UnmarshalBinary(data); err != nil {
+		t.Fatalf("UnmarshalBinary() error = %v", err)
+	}
+
+	if decoded.Size != c.Size || !reflect.DeepEqual(decoded.Faces, c.Faces) {
+		t.Errorf("%dx%d stickers differ after a round trip", c.Size, c.Size)
+	}
+	if !reflect.DeepEqual(decoded.colors, c.colors) || !reflect.DeepEqual(decoded.facing, c.facing) {
+		t.Errorf("%dx%d scheme or facing differ after a round trip", c.Size, c.Size)
+	}
+
+	// The decoded cube keeps working
+	moves, _ := ParseScramble("R U")
+	c.ApplyMoves(moves)
+	decoded.ApplyMoves(moves)
+	if !reflect.DeepEqual(decoded.Faces, c.Faces) {
+		t.Errorf("%dx%d cubes differ after the same moves", c.Size, c.Size)
+	}
+}
@@ -0,0 +1,39 @@
+package cube
+
+import "errors"
+
+// Solve cancellation
+//
+// The beginner, CFOP, and Kociemba solvers each have a Cancel channel.
+// Once it is closed, the solve in progress stops at its next check and
+// returns ErrSolveCancelled. The searches check it as they count their
+// effort (see searchStats), every cancelCheckInterval nodes or states, so
+// a cancelled solve stops within a few milliseconds and an unset channel
+// costs nothing.
+
+// ErrSolveCancelled is returned by a solve stopped through its Cancel channel
+var ErrSolveCancelled = errors.New("solve cancelled")
+
+// cancelCheckInterval is how many nodes or states a search counts between
+// checks of its Cancel channel
+const cancelCheckInterval = 1024
+
+// solveCancelled is the panic value that unwinds a cancelled search out of
+// its recursion; trackSearch recovers it into ErrSolveCancelled
+type solveCancelled struct{}
+
+// SetCancel sets the cancel channel of any of this package's solvers that
+// can stop partway through a search, and reports whether solver supports it
+func SetCancel(solver Solver, cancel <-chan struct{}) bool {
+	switch s := solver.(type) {
+	case *BeginnerSolver:
+		s.Cancel = cancel
+	case *CFOPSolver:
+		s.Cancel = cancel
+	case *KociembaSolver:
+		s.Cancel = cancel
+	default:
+		return false
+	}
+	return true
+}
@@ -0,0 +1,64 @@
+package cube
+
+import (
+	"strconv"
+	"strings"
+)
+
+// CanonicalForm returns a key that is the same for c and every whole-cube
+// rotation of it, and different for any state that isn't one of those
+// rotations. The key is the size followed by every sticker's color letter,
+// taken from the rotation whose stickers sort first, e.g. "3:BBB...". Use
+// it to group states regardless of how the cube is held.
+func (c *Cube) CanonicalForm() string {
+	return c.canonicalKey(func(color Color) byte {
+		return color.String()[0]
+	})
+}
+
+// CanonicalPattern is like CanonicalForm, but also ignores which colors
+// are which: two cubes share a pattern if a rotation and a consistent
+// swap of colors turns one into the other, as with the same OLL case on
+// cubes with different color schemes. Colors are lettered a, b, c, ... in
+// the order they first appear, and the Grey wildcard stays "."; the key
+// compares wildcards as stickers of their own.
+func (c *Cube) CanonicalPattern() string {
+	return c.canonicalKey(nil)
+}
+
+// canonicalKey returns the smallest key over the 24 rotations of c.
+// letter names each color; if it is nil, colors are lettered in the order
+// they first appear in each rotation.
+func (c *Cube) canonicalKey(letter func(Color) byte) string {
+	best := ""
+	for _, rotation := range orientationRotations {
+		rotated := c.Clone()
+		rotated.ApplyMoves(rotation)
+
+		names := make(map[Color]byte)
+		var key strings.Builder
+		key.WriteString(strconv.Itoa(c.Size) + ":")
+		for face := range rotated.Faces {
+			for _, row := range rotated.Faces[face] {
+				for _, color := range row {
+					switch {
+					case letter != nil:
+						key.WriteByte(letter(color))
+					case color == Grey:
+						key.WriteByte('.')
+					default:
+						if _, ok := names[color]; !ok {
+							names[color] = byte('a' + len(names))
+						}
+						key.WriteByte(names[color])
+					}
+				}
+			}
+		}
+
+		if best == "" || key.String() < best {
+			best = key.String()
+		}
+	}
+	return best
+}
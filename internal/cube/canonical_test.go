@@ -0,0 +1,71 @@
+package cube
+
+import "testing"
+
+func TestCanonicalFormRotations(t *testing.T) {
+	// A T-perm held every way has one canonical form
+	c := NewCube(3)
+	moves, _ := ParseMoves("R U R' U' R' F R2 U' R' U' R U R' F'")
+	c.ApplyMoves(moves)
+	want := c.CanonicalForm()
+
+	for _, rotation := range orientationRotations {
+		rotated := c.Clone()
+		rotated.ApplyMoves(rotation)
+		if got := rotated.CanonicalForm(); got != want {
+			t.Errorf("after %s: CanonicalForm() = %s, want %s", FormatMoves(rotation), got, want)
+		}
+	}
+
+	// A different state, and the same state on another size, differ
+	other := NewCube(3)
+	moves, _ = ParseMoves("R U R' U R U2 R'")
+	other.ApplyMoves(moves)
+	if other.CanonicalForm() == want {
+		t.Error("Sune and T-perm share a canonical form")
+	}
+	if NewCube(3).CanonicalForm() == NewCube(4).CanonicalForm() {
+		t.Error("solved 3x3 and 4x4 share a canonical form")
+	}
+}
+
+func TestCanonicalFormSolved(t *testing.T) {
+	want := NewCube(4).CanonicalForm()
+	c := NewCube(4)
+	moves, _ := ParseMoves("x y2 z'")
+	c.ApplyMoves(moves)
+	if got := c.CanonicalForm(); got != want {
+		t.Errorf("rotated solved 4x4 = %s, want %s", got, want)
+	}
+}
+
+func TestCanonicalPatternIgnoresColors(t *testing.T) {
+	moves, _ := ParseMoves("R U R' U R U2 R'")
+
+	standard := NewCube(3)
+	standard.ApplyMoves(moves)
+
+	japanese, err := NewCubeWithScheme(3, map[Face]Color{
+		Up: White, Down: Blue, Front: Red, Back: Orange, Right: Green, Left: Yellow,
+	})
+	if err != nil {
+		t.Fatalf("NewCubeWithScheme() error = %v", err)
+	}
+	japanese.ApplyMoves(moves)
+	japanese.ApplyMoves([]Move{{Rotation: X_Rotation, Clockwise: true}})
+
+	if standard.CanonicalForm() == japanese.CanonicalForm() {
+		t.Error("CanonicalForm() should tell the color schemes apart")
+	}
+	if standard.CanonicalPattern() != japanese.CanonicalPattern() {
+		t.Errorf("CanonicalPattern() differs across color schemes:\n%s\n%s", standard.CanonicalPattern(), japanese.CanonicalPattern())
+	}
+
+	// The mirror case is a different pattern
+	antiSune, _ := ParseMoves("L' U' L U' L' U2 L")
+	mirror := NewCube(3)
+	mirror.ApplyMoves(antiSune)
+	if mirror.CanonicalPattern() == standard.CanonicalPattern() {
+		t.Error("Sune and its mirror share a pattern")
+	}
+}
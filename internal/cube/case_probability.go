@@ -0,0 +1,57 @@
+package cube
+
+import "sync"
+
+// Case probability
+//
+// A last layer case comes up as often as the number of last layer states
+// it covers. Setting a case up and turning U before and after it gives
+// every state of the case, so counting the distinct states those turns
+// reach, out of the 216 orientations (OLL) or 288 permutations (PLL) of
+// the last layer, gives the chance of the case in a solve. Sune covers 4
+// of the 216 orientations, 1/54; the T perm 16 of the 288 permutations,
+// 1/18.
+
+const (
+	ollStates = 27 * 8 // corner twists times edge flips
+	pllStates = 288    // 4! corner times 4! edge permutations, even parity only
+)
+
+var (
+	caseProbabilityOnce sync.Once
+	caseProbabilities   map[string]float64
+)
+
+// CaseProbability returns the chance that a solve comes to the given OLL
+// or PLL case, such as 1/54 for OLL-27. It reports false for any other
+// case ID.
+func CaseProbability(caseID string) (float64, bool) {
+	caseProbabilityOnce.Do(func() {
+		caseProbabilities = make(map[string]float64)
+		addCaseProbabilities(ollReferenceCases, ollKey, ollStates)
+		addCaseProbabilities(pllReferenceCases, pllKey, pllStates)
+	})
+	p, ok := caseProbabilities[caseID]
+	return p, ok
+}
+
+// addCaseProbabilities counts the last layer states each case covers
+func addCaseProbabilities(cases []referenceCase, key func(*Cube) string, total int) {
+	for _, rc := range cases {
+		moves, err := ParseScramble(rc.Moves)
+		if err != nil {
+			continue
+		}
+		states := make(map[string]bool)
+		for pre := 0; pre < 4; pre++ {
+			c := NewCube(3)
+			c.ApplyMoves(AUFMoves(pre))
+			c.ApplyInverseMoves(moves)
+			for post := 0; post < 4; post++ {
+				states[key(c)] = true
+				c.ApplyMove(Move{Face: Up, Clockwise: true})
+			}
+		}
+		caseProbabilities[rc.CaseID] = float64(len(states)) / float64(total)
+	}
+}
@@ -0,0 +1,46 @@
+package cube
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCaseProbability(t *testing.T) {
+	tests := []struct {
+		caseID string
+		want   float64
+	}{
+		{"OLL-27", 1.0 / 54},
+		{"OLL-21", 1.0 / 108},
+		{"OLL-20", 1.0 / 216},
+		{"PLL-T", 1.0 / 18},
+		{"PLL-Aa", 1.0 / 18},
+		{"PLL-H", 1.0 / 72},
+		{"PLL-E", 1.0 / 36},
+	}
+	for _, tt := range tests {
+		if got, ok := CaseProbability(tt.caseID); !ok || math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("CaseProbability(%s) = %v, %v, want %v", tt.caseID, got, ok, tt.want)
+		}
+	}
+	if _, ok := CaseProbability("F2L-1"); ok {
+		t.Error("CaseProbability(F2L-1) should report false")
+	}
+
+	// Every state belongs to exactly one case, except the solved one for
+	// OLL and the four U turns of the solved layer for PLL
+	for set, ids := range map[string][]string{"OLL": OLLCaseIDs(), "PLL": PLLCaseIDs()} {
+		states, skips := ollStates, 1
+		if set == "PLL" {
+			states, skips = pllStates, 4
+		}
+		sum := 0.0
+		for _, id := range ids {
+			p, _ := CaseProbability(id)
+			sum += p
+		}
+		if want := float64(states-skips) / float64(states); math.Abs(sum-want) > 1e-9 {
+			t.Errorf("%s probabilities sum to %v, want %v", set, sum, want)
+		}
+	}
+}
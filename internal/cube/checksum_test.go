@@ -0,0 +1,63 @@
+package cube
+
+import (
+	"strings"
+	"testing"
+)
+
+// AssertUnmodified runs fn and fails t if it changed c, comparing c's
+// checksum before and after. name says what fn does in the failure.
+func AssertUnmodified(t testing.TB, c *Cube, name string, fn func()) {
+	t.Helper()
+	before := c.Checksum()
+	fn()
+	if c.Checksum() != before {
+		t.Errorf("%s modified the cube, which is now:\n%s", name, c.UnfoldedString(false, false))
+	}
+}
+
+func TestChecksum(t *testing.T) {
+	a, b := NewCube(3), NewCube(3)
+	if a.Checksum() != b.Checksum() {
+		t.Error("solved cubes have different checksums")
+	}
+
+	moves, _ := ParseScramble("R U R' U'")
+	a.ApplyMoves(moves)
+	if a.Checksum() == b.Checksum() {
+		t.Error("scrambled and solved cubes have the same checksum")
+	}
+	if a.Clone().Checksum() != a.Checksum() {
+		t.Error("a clone has a different checksum")
+	}
+	a.ApplyMoves(InvertSequence(moves))
+	if a.Checksum() != b.Checksum() {
+		t.Error("undoing a scramble doesn't restore the checksum")
+	}
+
+	// Every face turn of a 3x3 gives a different checksum
+	seen := map[uint64]string{b.Checksum(): "solved"}
+	for _, move := range faceTurns {
+		c := NewCube(3)
+		c.ApplyMove(move)
+		if other, ok := seen[c.Checksum()]; ok {
+			t.Errorf("%s and %s have the same checksum", move, other)
+		}
+		seen[c.Checksum()] = move.String()
+	}
+
+	if NewCube(2).Checksum() == NewCube(3).Checksum() {
+		t.Error("2x2 and 3x3 have the same checksum")
+	}
+}
+
+func TestAssertUnmodified(t *testing.T) {
+	c := NewCube(3)
+	AssertUnmodified(t, c, "Clone", func() { c.Clone().ApplyMove(Move{Face: Right, Clockwise: true}) })
+
+	recorder := &failRecorder{TB: t}
+	AssertUnmodified(recorder, c, "ApplyMove", func() { c.ApplyMove(Move{Face: Right, Clockwise: true}) })
+	if !recorder.failed || !strings.Contains(recorder.message, "ApplyMove modified the cube") {
+		t.Errorf("AssertUnmodified missed a change: %q", recorder.message)
+	}
+}
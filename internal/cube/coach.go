@@ -0,0 +1,215 @@
+package cube
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Coaching
+//
+// A practice session records how long each OLL or PLL case took to solve.
+// RecommendAlgorithms turns that into a list of cases to drill next, worth
+// most where a case both comes up often and is slow.
+//
+// A case's score is its probability (CaseProbability) times its slowness:
+// its mean time over the mean of every attempt in its set, OLL or PLL, so
+// a case taking twice the set's average has slowness 2. A case of a
+// practiced set with no attempts yet gets unpracticedSlowness, on the
+// grounds that a case never drilled is slow to recognize. Sets with no
+// attempts at all are left out.
+
+// unpracticedSlowness is the slowness given a case with no attempts
+const unpracticedSlowness = 2.0
+
+// Attempt is one timed solve of a case
+type Attempt struct {
+	CaseID string
+	Time   time.Duration
+}
+
+// String formats the attempt as a session log line, such as "OLL-27 1.84"
+func (a Attempt) String() string {
+	return fmt.Sprintf("%s %s", a.CaseID, strconv.FormatFloat(a.Time.Seconds(), 'f', -1, 64))
+}
+
+// Session is a log of practice attempts
+type Session struct {
+	Attempts []Attempt
+}
+
+// Record adds an attempt to the session
+func (s *Session) Record(caseID string, t time.Duration) {
+	s.Attempts = append(s.Attempts, Attempt{CaseID: caseID, Time: t})
+}
+
+// ParseSession reads a session log: one attempt per line, a case ID and a
+// time in seconds such as "OLL-27 1.84". Blank lines and lines starting
+// with # are skipped.
+func ParseSession(r io.Reader) (*Session, error) {
+	session := &Session{}
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		fields := strings.Fields(text)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("line %d: expected a case ID and a time in seconds, got %q", line, text)
+		}
+		seconds, err := strconv.ParseFloat(fields[1], 64)
+		if err != nil || seconds <= 0 {
+			return nil, fmt.Errorf("line %d: invalid time %q", line, fields[1])
+		}
+		session.Record(fields[0], time.Duration(seconds*float64(time.Second)))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+// WriteTo writes the session as a log ParseSession reads back, one attempt
+// per line in the order they were recorded
+func (s *Session) WriteTo(w io.Writer) (int64, error) {
+	var written int64
+	for _, attempt := range s.Attempts {
+		n, err := fmt.Fprintln(w, attempt)
+		written += int64(n)
+		if err != nil {
+			return written, err
+		}
+	}
+	return written, nil
+}
+
+// CaseStat summarizes a session's attempts at one case against the rest of
+// its set: the cases sharing its prefix, such as PLL for PLL-Gd
+type CaseStat struct {
+	CaseID   string
+	Set      string
+	Attempts int
+	Best     time.Duration // zero with no attempts
+	Last     time.Duration // zero with no attempts
+	Mean     time.Duration // zero with no attempts
+	SetMean  time.Duration // mean of every attempt in the set, zero with none
+	Behind   time.Duration // Mean minus SetMean, zero with no attempts
+}
+
+// caseSet returns the set a case ID belongs to: the part before its first
+// dash, or the whole ID if it has none
+func caseSet(caseID string) string {
+	set, _, _ := strings.Cut(caseID, "-")
+	return set
+}
+
+// CaseStats summarizes the session's attempts at caseID
+func (s *Session) CaseStats(caseID string) CaseStat {
+	stat := CaseStat{CaseID: caseID, Set: caseSet(caseID)}
+	var total, setTotal time.Duration
+	setCount := 0
+	for _, attempt := range s.Attempts {
+		if caseSet(attempt.CaseID) == stat.Set {
+			setTotal += attempt.Time
+			setCount++
+		}
+		if attempt.CaseID != caseID {
+			continue
+		}
+		if stat.Attempts == 0 || attempt.Time < stat.Best {
+			stat.Best = attempt.Time
+		}
+		stat.Last = attempt.Time
+		total += attempt.Time
+		stat.Attempts++
+	}
+	if setCount > 0 {
+		stat.SetMean = setTotal / time.Duration(setCount)
+	}
+	if stat.Attempts > 0 {
+		stat.Mean = total / time.Duration(stat.Attempts)
+		stat.Behind = stat.Mean - stat.SetMean
+	}
+	return stat
+}
+
+// Weaknesses returns the stats of every attempted case slower on average
+// than its set, furthest behind first
+func (s *Session) Weaknesses() []CaseStat {
+	var weaknesses []CaseStat
+	seen := make(map[string]bool)
+	for _, attempt := range s.Attempts {
+		if seen[attempt.CaseID] {
+			continue
+		}
+		seen[attempt.CaseID] = true
+		if stat := s.CaseStats(attempt.CaseID); stat.Behind > 0 {
+			weaknesses = append(weaknesses, stat)
+		}
+	}
+
+	sort.SliceStable(weaknesses, func(i, j int) bool {
+		return weaknesses[i].Behind > weaknesses[j].Behind
+	})
+	return weaknesses
+}
+
+// Recommendation is a case to drill and why
+type Recommendation struct {
+	CaseID      string
+	Probability float64       // chance of the case coming up in a solve
+	Attempts    int           // attempts in the session
+	MeanTime    time.Duration // zero with no attempts
+	Slowness    float64       // mean time over the set's mean time
+	Score       float64       // Probability times Slowness
+}
+
+// RecommendAlgorithms scores every case of each set, OLL or PLL, that the
+// session has attempts for, as described above, and returns them highest
+// score first. Attempts at other cases are ignored.
+func RecommendAlgorithms(s *Session) []Recommendation {
+	var recommendations []Recommendation
+	for _, set := range []struct {
+		prefix string
+		ids    []string
+	}{{"OLL-", OLLCaseIDs()}, {"PLL-", PLLCaseIDs()}} {
+		totals := make(map[string]time.Duration)
+		counts := make(map[string]int)
+		var setTotal time.Duration
+		setCount := 0
+		for _, attempt := range s.Attempts {
+			if _, ok := CaseProbability(attempt.CaseID); !ok || !strings.HasPrefix(attempt.CaseID, set.prefix) {
+				continue
+			}
+			totals[attempt.CaseID] += attempt.Time
+			counts[attempt.CaseID]++
+			setTotal += attempt.Time
+			setCount++
+		}
+		if setCount == 0 {
+			continue
+		}
+		setMean := setTotal / time.Duration(setCount)
+
+		for _, caseID := range set.ids {
+			probability, _ := CaseProbability(caseID)
+			rec := Recommendation{CaseID: caseID, Probability: probability, Attempts: counts[caseID], Slowness: unpracticedSlowness}
+			if rec.Attempts > 0 {
+				rec.MeanTime = totals[caseID] / time.Duration(rec.Attempts)
+				rec.Slowness = float64(rec.MeanTime) / float64(setMean)
+			}
+			rec.Score = rec.Probability * rec.Slowness
+			recommendations = append(recommendations, rec)
+		}
+	}
+
+	sort.SliceStable(recommendations, func(i, j int) bool {
+		return recommendations[i].Score > recommendations[j].Score
+	})
+	return recommendations
+}
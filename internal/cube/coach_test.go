@@ -0,0 +1,142 @@
+package cube
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseSession(t *testing.T) {
+	session, err := ParseSession(strings.NewReader("# Monday\nPLL-T 2.5\n\nOLL-27  1.25\n"))
+	if err != nil {
+		t.Fatalf("ParseSession() error = %v", err)
+	}
+	want := []Attempt{{"PLL-T", 2500 * time.Millisecond}, {"OLL-27", 1250 * time.Millisecond}}
+	if len(session.Attempts) != len(want) {
+		t.Fatalf("ParseSession() = %v, want %v", session.Attempts, want)
+	}
+	for i := range want {
+		if session.Attempts[i] != want[i] {
+			t.Errorf("attempt %d = %v, want %v", i, session.Attempts[i], want[i])
+		}
+	}
+
+	for _, bad := range []string{"PLL-T", "PLL-T fast", "PLL-T -1", "PLL-T 2 3"} {
+		if _, err := ParseSession(strings.NewReader(bad)); err == nil {
+			t.Errorf("ParseSession(%q) should fail", bad)
+		}
+	}
+}
+
+func TestRecommendAlgorithms(t *testing.T) {
+	// T is slow and common, H is slower but rare, Ua is fast
+	session := &Session{}
+	for i := 0; i < 3; i++ {
+		session.Record("PLL-T", 3*time.Second)
+		session.Record("PLL-H", 4*time.Second)
+		session.Record("PLL-Ua", 1*time.Second)
+	}
+	session.Record("F2L-1", time.Minute)
+
+	recommendations := RecommendAlgorithms(session)
+	if len(recommendations) != len(PLLCaseIDs()) {
+		t.Fatalf("got %d recommendations, want one per PLL case (%d)", len(recommendations), len(PLLCaseIDs()))
+	}
+
+	byCase := make(map[string]Recommendation)
+	for i, rec := range recommendations {
+		byCase[rec.CaseID] = rec
+		if i > 0 && rec.Score > recommendations[i-1].Score {
+			t.Errorf("recommendations out of order at %s", rec.CaseID)
+		}
+		if math.Abs(rec.Score-rec.Probability*rec.Slowness) > 1e-12 {
+			t.Errorf("%s: score %v isn't probability %v times slowness %v", rec.CaseID, rec.Score, rec.Probability, rec.Slowness)
+		}
+	}
+
+	// The set's mean is 8/3 seconds
+	tests := []struct {
+		caseID   string
+		attempts int
+		slowness float64
+	}{
+		{"PLL-T", 3, 3 / (8.0 / 3)},
+		{"PLL-H", 3, 4 / (8.0 / 3)},
+		{"PLL-Ua", 3, 1 / (8.0 / 3)},
+		{"PLL-Z", 0, unpracticedSlowness},
+	}
+	for _, tt := range tests {
+		rec := byCase[tt.caseID]
+		if rec.Attempts != tt.attempts || math.Abs(rec.Slowness-tt.slowness) > 1e-9 {
+			t.Errorf("%s: %d attempts, slowness %v, want %d, %v", tt.caseID, rec.Attempts, rec.Slowness, tt.attempts, tt.slowness)
+		}
+	}
+	if byCase["PLL-T"].Score <= byCase["PLL-H"].Score {
+		t.Error("a common slow case should outrank a rare slower one")
+	}
+
+	if got := RecommendAlgorithms(&Session{}); len(got) != 0 {
+		t.Errorf("empty session gave %d recommendations", len(got))
+	}
+}
+
+func TestCaseStats(t *testing.T) {
+	session := &Session{}
+	session.Record("PLL-Gd", 5*time.Second)
+	session.Record("PLL-T", 2*time.Second)
+	session.Record("PLL-Gd", 3*time.Second)
+	session.Record("OLL-27", 10*time.Second)
+
+	stat := session.CaseStats("PLL-Gd")
+	want := CaseStat{
+		CaseID:   "PLL-Gd",
+		Set:      "PLL",
+		Attempts: 2,
+		Best:     3 * time.Second,
+		Last:     3 * time.Second,
+		Mean:     4 * time.Second,
+		SetMean:  10 * time.Second / 3,
+		Behind:   4*time.Second - 10*time.Second/3,
+	}
+	if stat != want {
+		t.Errorf("CaseStats(PLL-Gd) = %+v, want %+v", stat, want)
+	}
+
+	// A case with no attempts still reports its set's mean
+	if stat := session.CaseStats("PLL-H"); stat.Attempts != 0 || stat.Mean != 0 || stat.Behind != 0 || stat.SetMean != want.SetMean {
+		t.Errorf("CaseStats(PLL-H) = %+v", stat)
+	}
+
+	weaknesses := session.Weaknesses()
+	if len(weaknesses) != 1 || weaknesses[0] != want {
+		t.Errorf("Weaknesses() = %+v, want just PLL-Gd", weaknesses)
+	}
+}
+
+func TestSessionWriteToRoundTrips(t *testing.T) {
+	session := &Session{}
+	session.Record("PLL-T", 2410*time.Millisecond)
+	session.Record("OLL-27", 3*time.Second)
+
+	var b strings.Builder
+	if _, err := session.WriteTo(&b); err != nil {
+		t.Fatalf("WriteTo() error = %v", err)
+	}
+	if got, want := b.String(), "PLL-T 2.41\nOLL-27 3\n"; got != want {
+		t.Errorf("WriteTo() wrote %q, want %q", got, want)
+	}
+
+	read, err := ParseSession(strings.NewReader(b.String()))
+	if err != nil {
+		t.Fatalf("ParseSession() error = %v", err)
+	}
+	if len(read.Attempts) != len(session.Attempts) {
+		t.Fatalf("read back %v, want %v", read.Attempts, session.Attempts)
+	}
+	for i := range session.Attempts {
+		if read.Attempts[i] != session.Attempts[i] {
+			t.Errorf("attempt %d read back as %v, want %v", i, read.Attempts[i], session.Attempts[i])
+		}
+	}
+}
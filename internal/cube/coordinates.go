@@ -0,0 +1,166 @@
+package cube
+
+// Cubie coordinates for the 3x3
+//
+// Corners and edges are numbered in the standard order used by two-phase
+// solvers:
+//
+//	Corners: URF UFL ULB UBR DFR DLF DBL DRB
+//	Edges:   UR UF UL UB DR DF DL DB FR FL BL BR
+//
+// Each position lists its stickers starting with the U or D sticker (F or B
+// for the middle layer edges), and corners then go clockwise. Pieces are
+// identified by matching sticker colors to the center colors, so the
+// accessors work in any cube orientation.
+
+// cornerFacelets lists the stickers of each corner position
+var cornerFacelets = [8][3]Coord{
+	{{Up, 2, 2}, {Right, 0, 0}, {Front, 0, 2}},   // URF
+	{{Up, 2, 0}, {Front, 0, 0}, {Left, 0, 2}},    // UFL
+	{{Up, 0, 0}, {Left, 0, 0}, {Back, 0, 2}},     // ULB
+	{{Up, 0, 2}, {Back, 0, 0}, {Right, 0, 2}},    // UBR
+	{{Down, 0, 2}, {Front, 2, 2}, {Right, 2, 0}}, // DFR
+	{{Down, 0, 0}, {Left, 2, 2}, {Front, 2, 0}},  // DLF
+	{{Down, 2, 0}, {Back, 2, 2}, {Left, 2, 0}},   // DBL
+	{{Down, 2, 2}, {Right, 2, 2}, {Back, 2, 0}},  // DRB
+}
+
+// edgeFacelets lists the stickers of each edge position
+var edgeFacelets = [12][2]Coord{
+	{{Up, 1, 2}, {Right, 0, 1}},    // UR
+	{{Up, 2, 1}, {Front, 0, 1}},    // UF
+	{{Up, 1, 0}, {Left, 0, 1}},     // UL
+	{{Up, 0, 1}, {Back, 0, 1}},     // UB
+	{{Down, 1, 2}, {Right, 2, 1}},  // DR
+	{{Down, 0, 1}, {Front, 2, 1}},  // DF
+	{{Down, 1, 0}, {Left, 2, 1}},   // DL
+	{{Down, 2, 1}, {Back, 2, 1}},   // DB
+	{{Front, 1, 2}, {Right, 1, 0}}, // FR
+	{{Front, 1, 0}, {Left, 1, 2}},  // FL
+	{{Back, 1, 2}, {Left, 1, 0}},   // BL
+	{{Back, 1, 0}, {Right, 1, 2}},  // BR
+}
+
+// CornerPermutation returns, for each corner position, the index of the
+// corner piece in it. Positions that do not hold a valid corner are -1.
+// Only 3x3 cubes are supported; other sizes return all -1.
+func (c *Cube) CornerPermutation() [8]int {
+	var perm [8]int
+	for pos := range cornerFacelets {
+		perm[pos], _ = c.cornerAt(pos)
+	}
+	return perm
+}
+
+// CornerOrientation returns the twist of each corner position: 0 if its U/D
+// colored sticker is on the U or D face, 1 if it is one sticker clockwise
+// from there, and 2 if counter-clockwise. On a valid cube the sum is a
+// multiple of 3. Only 3x3 cubes are supported; other sizes return all 0.
+func (c *Cube) CornerOrientation() [8]int {
+	var orientation [8]int
+	for pos := range cornerFacelets {
+		_, orientation[pos] = c.cornerAt(pos)
+	}
+	return orientation
+}
+
+// EdgePermutation returns, for each edge position, the index of the edge
+// piece in it. Positions that do not hold a valid edge are -1. Only 3x3
+// cubes are supported; other sizes return all -1.
+func (c *Cube) EdgePermutation() [12]int {
+	var perm [12]int
+	for pos := range edgeFacelets {
+		perm[pos], _ = c.edgeAt(pos)
+	}
+	return perm
+}
+
+// EdgeOrientation returns the flip of each edge position: 0 if the edge's
+// first sticker is on the position's first face, 1 otherwise. Quarter turns
+// of F and B flip edges; all other face turns preserve orientation. Only
+// 3x3 cubes are supported; other sizes return all 0.
+func (c *Cube) EdgeOrientation() [12]int {
+	var orientation [12]int
+	for pos := range edgeFacelets {
+		_, orientation[pos] = c.edgeAt(pos)
+	}
+	return orientation
+}
+
+// cornerAt identifies the corner piece at a position and its twist. It
+// returns -1 and 0 if the stickers do not form a corner.
+func (c *Cube) cornerAt(pos int) (int, int) {
+	if c.Size != 3 {
+		return -1, 0
+	}
+
+	var faces [3]Face
+	for i, coord := range cornerFacelets[pos] {
+		face, ok := c.centerFace(c.Faces[coord.Face][coord.Row][coord.Col])
+		if !ok {
+			return -1, 0
+		}
+		faces[i] = face
+	}
+	return cornerPiece(faces)
+}
+
+// cornerPiece identifies a corner from the faces its stickers belong on,
+// listed in cornerFacelets order, and returns the piece and its twist. It
+// returns -1 and 0 if the faces do not form a corner.
+func cornerPiece(faces [3]Face) (int, int) {
+	for twist := 0; twist < 3; twist++ {
+		if faces[twist] != Up && faces[twist] != Down {
+			continue
+		}
+		// Read the piece clockwise from its U/D sticker and compare it with
+		// each home position
+		for piece, home := range cornerFacelets {
+			if home[0].Face == faces[twist] &&
+				home[1].Face == faces[(twist+1)%3] &&
+				home[2].Face == faces[(twist+2)%3] {
+				return piece, twist
+			}
+		}
+		return -1, 0
+	}
+	return -1, 0
+}
+
+// edgeAt identifies the edge piece at a position and its flip. It returns
+// -1 and 0 if the stickers do not form an edge.
+func (c *Cube) edgeAt(pos int) (int, int) {
+	if c.Size != 3 {
+		return -1, 0
+	}
+
+	var faces [2]Face
+	for i, coord := range edgeFacelets[pos] {
+		face, ok := c.centerFace(c.Faces[coord.Face][coord.Row][coord.Col])
+		if !ok {
+			return -1, 0
+		}
+		faces[i] = face
+	}
+
+	for piece, home := range edgeFacelets {
+		switch {
+		case home[0].Face == faces[0] && home[1].Face == faces[1]:
+			return piece, 0
+		case home[0].Face == faces[1] && home[1].Face == faces[0]:
+			return piece, 1
+		}
+	}
+	return -1, 0
+}
+
+// centerFace returns the face whose center has the given color
+func (c *Cube) centerFace(color Color) (Face, bool) {
+	mid := c.Size / 2
+	for face := Front; face <= Down; face++ {
+		if c.Faces[face][mid][mid] == color {
+			return face, true
+		}
+	}
+	return Front, false
+}
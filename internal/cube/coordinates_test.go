@@ -0,0 +1,125 @@
+package cube
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestCoordinatesSolved(t *testing.T) {
+	c := NewCube(3)
+
+	if cp := c.CornerPermutation(); cp != [8]int{0, 1, 2, 3, 4, 5, 6, 7} {
+		t.Errorf("CornerPermutation() = %v, want identity", cp)
+	}
+	if ep := c.EdgePermutation(); ep != [12]int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11} {
+		t.Errorf("EdgePermutation() = %v, want identity", ep)
+	}
+	if co := c.CornerOrientation(); co != [8]int{} {
+		t.Errorf("CornerOrientation() = %v, want all 0", co)
+	}
+	if eo := c.EdgeOrientation(); eo != [12]int{} {
+		t.Errorf("EdgeOrientation() = %v, want all 0", eo)
+	}
+}
+
+func TestCoordinatesKnownMoves(t *testing.T) {
+	tests := []struct {
+		move string
+		cp   [8]int
+		co   [8]int
+		ep   [12]int
+		eo   [12]int
+	}{
+		{
+			move: "U",
+			cp:   [8]int{3, 0, 1, 2, 4, 5, 6, 7},
+			ep:   [12]int{3, 0, 1, 2, 4, 5, 6, 7, 8, 9, 10, 11},
+		},
+		{
+			move: "R",
+			cp:   [8]int{4, 1, 2, 0, 7, 5, 6, 3},
+			co:   [8]int{2, 0, 0, 1, 1, 0, 0, 2},
+			ep:   [12]int{8, 1, 2, 3, 11, 5, 6, 7, 4, 9, 10, 0},
+		},
+		{
+			move: "F",
+			cp:   [8]int{1, 5, 2, 3, 0, 4, 6, 7},
+			co:   [8]int{1, 2, 0, 0, 2, 1, 0, 0},
+			ep:   [12]int{0, 9, 2, 3, 4, 8, 6, 7, 1, 5, 10, 11},
+			eo:   [12]int{0, 1, 0, 0, 0, 1, 0, 0, 1, 1, 0, 0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.move, func(t *testing.T) {
+			c := NewCube(3)
+			moves, err := ParseScramble(tt.move)
+			if err != nil {
+				t.Fatalf("Failed to parse %s: %v", tt.move, err)
+			}
+			c.ApplyMoves(moves)
+
+			if got := c.CornerPermutation(); got != tt.cp {
+				t.Errorf("CornerPermutation() = %v, want %v", got, tt.cp)
+			}
+			if got := c.CornerOrientation(); got != tt.co {
+				t.Errorf("CornerOrientation() = %v, want %v", got, tt.co)
+			}
+			if got := c.EdgePermutation(); got != tt.ep {
+				t.Errorf("EdgePermutation() = %v, want %v", got, tt.ep)
+			}
+			if got := c.EdgeOrientation(); got != tt.eo {
+				t.Errorf("EdgeOrientation() = %v, want %v", got, tt.eo)
+			}
+		})
+	}
+}
+
+// TestCoordinatesInvariants checks the cube laws on random scrambles: corner
+// twist sums to a multiple of 3, edge flip to a multiple of 2, and corner and
+// edge permutations have the same parity
+func TestCoordinatesInvariants(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		c := NewCube(3)
+		c.ApplyMoves(randomMoves(3, 25, rng))
+
+		cp, ep := c.CornerPermutation(), c.EdgePermutation()
+		cornerParity, ok := permutationParity(cp[:])
+		if !ok {
+			t.Fatalf("CornerPermutation() = %v is not a permutation", cp)
+		}
+		edgeParity, ok := permutationParity(ep[:])
+		if !ok {
+			t.Fatalf("EdgePermutation() = %v is not a permutation", ep)
+		}
+		if cornerParity != edgeParity {
+			t.Errorf("Corner and edge permutation parity differ: %v %v", cp, ep)
+		}
+
+		twist := 0
+		for _, o := range c.CornerOrientation() {
+			twist += o
+		}
+		if twist%3 != 0 {
+			t.Errorf("Corner twist sums to %d, want a multiple of 3", twist)
+		}
+
+		flip := 0
+		for _, o := range c.EdgeOrientation() {
+			flip += o
+		}
+		if flip%2 != 0 {
+			t.Errorf("Edge flip sums to %d, want a multiple of 2", flip)
+		}
+	}
+}
+
+func TestCoordinatesUnsupportedSize(t *testing.T) {
+	c := NewCube(4)
+	for _, p := range c.CornerPermutation() {
+		if p != -1 {
+			t.Fatalf("CornerPermutation() on 4x4 = %v, want all -1", c.CornerPermutation())
+		}
+	}
+}
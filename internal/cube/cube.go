@@ -1,10 +1,14 @@
 package cube
 
 import (
+	"encoding/binary"
+	"fmt"
+	"hash/fnv"
 	"strings"
 )
 
-// Face represents a face of the cube
+// Face represents a face of the cube.
+// Faces use the standard single-letter notation U/D/F/B/L/R.
 type Face int
 
 const (
@@ -20,7 +24,29 @@ func (f Face) String() string {
 	return []string{"F", "B", "L", "R", "U", "D"}[f]
 }
 
-// Color represents a sticker color
+// ParseFace converts a single-letter face name (U, D, F, B, L, R) to a Face
+func ParseFace(s string) (Face, error) {
+	switch s {
+	case "F":
+		return Front, nil
+	case "B":
+		return Back, nil
+	case "L":
+		return Left, nil
+	case "R":
+		return Right, nil
+	case "U":
+		return Up, nil
+	case "D":
+		return Down, nil
+	default:
+		return Front, fmt.Errorf("unknown face '%s'", s)
+	}
+}
+
+// Color represents a sticker color.
+// Colors use the single-letter codes W/Y/R/O/B/G, with Grey written as '.'
+// (and accepted as '?') when used as a pattern wildcard.
 type Color int
 
 const (
@@ -37,60 +63,186 @@ func (c Color) String() string {
 	return []string{"W", "Y", "R", "O", "B", "G", "."}[c]
 }
 
-// ColoredString returns a muted colored string representation
-func (c Color) ColoredString() string {
-	// Much more muted colors that won't burn eyes
-	colors := []string{
-		"\033[37mW\033[0m", // Light gray for white
-		"\033[33mY\033[0m", // Muted yellow
-		"\033[31mR\033[0m", // Muted red
-		"\033[35mO\033[0m", // Muted magenta for orange
-		"\033[34mB\033[0m", // Muted blue
-		"\033[32mG\033[0m", // Muted green
-		"\033[90m.\033[0m", // Dark gray for wildcard
+// ParseColor converts a single-letter color code to a Color.
+// Both '?' and '.' parse as the Grey wildcard.
+func ParseColor(r rune) (Color, error) {
+	switch r {
+	case 'W':
+		return White, nil
+	case 'Y':
+		return Yellow, nil
+	case 'R':
+		return Red, nil
+	case 'O':
+		return Orange, nil
+	case 'B':
+		return Blue, nil
+	case 'G':
+		return Green, nil
+	case '?', '.':
+		return Grey, nil
+	default:
+		return White, fmt.Errorf("unknown color character '%c'", r)
 	}
-	return colors[c]
 }
 
-// UnicodeString returns a colored Unicode square representation
+// ColoredString returns an ANSI colored letter from the active color scheme
+func (c Color) ColoredString() string {
+	return activeScheme.Letters[c]
+}
+
+// UnicodeString returns a Unicode glyph from the active color scheme
 func (c Color) UnicodeString() string {
-	squares := []string{"⬜", "🟨", "🟥", "🟧", "🟦", "🟩", "⬛"}
-	return squares[c]
+	return activeScheme.Glyphs[c]
 }
 
 // Cube represents an NxNxN cube
 type Cube struct {
-	Size  int          // Dimension of the cube (3 for 3x3x3)
-	Faces [6][][]Color // Six faces, each Size x Size
+	Size   int          // Dimension of the cube (3 for 3x3x3)
+	Faces  [6][][]Color // Six faces, each Size x Size
+	Engine Engine       // How moves are applied (PermutationEngine by default)
+
+	// Supercube makes IsSolved also require every center to be turned the
+	// right way, as on a picture cube; see supercube.go. Set it on a solved
+	// cube before applying moves, since centers are only tracked while it
+	// is set.
+	Supercube bool
+
+	// centerTwist is how far the center now in each position is turned,
+	// in clockwise quarter turns, while Supercube is set
+	centerTwist [6]int
+
+	// colors is the solved color of each face, set by NewCubeWithScheme;
+	// nil means the standard scheme
+	colors *[6]Color
+
+	// facing is the home face now in each position after x, y, and z
+	// rotations; nil means the cube has not been rotated
+	facing *[6]Face
+
+	// scratch holds the flattened stickers while the permutation engine
+	// applies a move; it is reused so moves don't allocate
+	scratch []Color
+
+	// history records moves for Undo and Redo once EnableHistory is
+	// called; nil means no history is kept
+	history *moveHistory
+}
+
+// standardColors is the solved color of each face in Face order.
+// Canonical orientation: Yellow on top, White on bottom, Blue facing front.
+var standardColors = [6]Color{Blue, Green, Orange, Red, Yellow, White}
+
+// MinSize is the smallest cube size: a 1x1 has no moves worth the name,
+// and the ring and slice index math assumes at least two layers
+const MinSize = 2
+
+// ValidateSize returns an error if size is too small to be a cube. Check
+// sizes that come from users before calling NewCube.
+func ValidateSize(size int) error {
+	if size < MinSize {
+		return fmt.Errorf("cube size must be at least %d, got %d", MinSize, size)
+	}
+	return nil
 }
 
-// NewCube creates a new solved cube of the given size
-func NewCube(size int) *Cube {
-	if size < 2 {
-		size = 2 // Minimum 2x2x2
+// NewCube creates a new solved cube of the given size. Sizes below
+// MinSize are raised to it; use ValidateSize to reject them instead.
+func NewCube(size int, opts ...CubeOption) *Cube {
+	if size < MinSize {
+		size = MinSize
 	}
 
 	cube := &Cube{Size: size}
+	cube.fill(standardColors)
+
+	for _, opt := range opts {
+		opt(cube)
+	}
 
-	// Initialize faces with solved colors
-	// Canonical orientation: Yellow on top, White on bottom, Blue facing front
-	// Face order: Front, Back, Left, Right, Up, Down
-	faceColors := []Color{Blue, Green, Orange, Red, Yellow, White}
+	return cube
+}
 
+// NewCubeWithScheme creates a solved cube whose faces take the colors in
+// scheme instead of the standard ones, such as the Japanese scheme with
+// blue opposite white. Every face needs a color and no two faces may share
+// one.
+func NewCubeWithScheme(size int, scheme map[Face]Color, opts ...CubeOption) (*Cube, error) {
+	var colors [6]Color
+	used := make(map[Color]Face)
+	for face := Front; face <= Down; face++ {
+		color, ok := scheme[face]
+		if !ok {
+			return nil, fmt.Errorf("color scheme has no color for the %s face", face)
+		}
+		if color < White || color > Green {
+			return nil, fmt.Errorf("color scheme gives the %s face invalid color %d", face, int(color))
+		}
+		if other, taken := used[color]; taken {
+			return nil, fmt.Errorf("color scheme gives %s to both the %s and %s faces", color, other, face)
+		}
+		used[color] = face
+		colors[face] = color
+	}
+
+	cube := NewCube(size, opts...)
+	cube.fill(colors)
+	if colors != standardColors {
+		cube.colors = &colors
+	}
+	return cube, nil
+}
+
+// fill colors every sticker of each face with that face's color
+func (c *Cube) fill(colors [6]Color) {
 	for face := 0; face < 6; face++ {
-		cube.Faces[face] = make([][]Color, size)
-		for row := 0; row < size; row++ {
-			cube.Faces[face][row] = make([]Color, size)
-			for col := 0; col < size; col++ {
-				cube.Faces[face][row][col] = faceColors[face]
+		c.Faces[face] = make([][]Color, c.Size)
+		for row := 0; row < c.Size; row++ {
+			c.Faces[face][row] = make([]Color, c.Size)
+			for col := 0; col < c.Size; col++ {
+				c.Faces[face][row][col] = colors[face]
 			}
 		}
 	}
+}
 
-	return cube
+// SolvedColor returns the color face has when the cube is solved
+func (c *Cube) SolvedColor(face Face) Color {
+	if c.colors != nil {
+		return c.colors[face]
+	}
+	return standardColors[face]
+}
+
+// HasStandardScheme reports whether the cube uses the standard colors
+func (c *Cube) HasStandardScheme() bool {
+	return c.colors == nil
 }
 
-// IsSolved checks if the cube is in a solved state
+// Clone returns a deep copy of the cube. The copy keeps no move history,
+// so searches that clone a cube never pay for recording moves.
+func (c *Cube) Clone() *Cube {
+	clone := &Cube{Size: c.Size, Engine: c.Engine, colors: c.colors,
+		Supercube: c.Supercube, centerTwist: c.centerTwist}
+	if c.facing != nil {
+		facing := *c.facing
+		clone.facing = &facing
+	}
+	for face := 0; face < 6; face++ {
+		clone.Faces[face] = make([][]Color, c.Size)
+		for row := 0; row < c.Size; row++ {
+			clone.Faces[face][row] = make([]Color, c.Size)
+			copy(clone.Faces[face][row], c.Faces[face][row])
+		}
+	}
+	return clone
+}
+
+// IsSolved checks if the cube is in a solved state: every face one color.
+// It doesn't care which color is where, so a solved cube held any way up
+// is solved whatever the convention (yellow on top and blue in front, or
+// white and green); use IsSolvedStrict to require a particular placement.
+// A Supercube is only solved if its centers are turned the right way too.
 func (c *Cube) IsSolved() bool {
 	for face := 0; face < 6; face++ {
 		firstColor := c.Faces[face][0][0]
@@ -102,9 +254,50 @@ func (c *Cube) IsSolved() bool {
 			}
 		}
 	}
+	if c.Supercube {
+		return c.centersSolved()
+	}
+	return true
+}
+
+// IsSolvedStrict reports whether the cube is solved with each face the
+// color scheme gives it, such as white on Up and green on Front. A nil
+// scheme means the cube's own colors held the way it started, so a solved
+// cube fails only once it has been rotated.
+func (c *Cube) IsSolvedStrict(scheme map[Face]Color) bool {
+	if !c.IsSolved() {
+		return false
+	}
+	for face := Front; face <= Down; face++ {
+		want, ok := scheme[face]
+		if scheme == nil {
+			want, ok = c.SolvedColor(face), true
+		}
+		if !ok || c.Faces[face][0][0] != want {
+			return false
+		}
+	}
 	return true
 }
 
+// Checksum returns a 64-bit FNV-1a hash of the cube's size and stickers.
+// Cubes with the same stickers have the same checksum, so taking one before
+// an operation and comparing after catches a cube changed by accident.
+// History, orientation tracking, and the engine are not included.
+func (c *Cube) Checksum() uint64 {
+	buf := binary.LittleEndian.AppendUint64(make([]byte, 0, 8+6*c.Size*c.Size), uint64(c.Size))
+	for face := range c.Faces {
+		for _, row := range c.Faces[face] {
+			for _, color := range row {
+				buf = append(buf, byte(color))
+			}
+		}
+	}
+	h := fnv.New64a()
+	h.Write(buf)
+	return h.Sum64()
+}
+
 // String returns a string representation of the cube
 func (c *Cube) String() string {
 	return c.StringWithColor(false)
@@ -118,76 +311,13 @@ func (c *Cube) StringWithColor(useColor bool) string {
 // UnfoldedString returns the cube in an unfolded cross layout
 func (c *Cube) UnfoldedString(useColor bool, useUnicode bool) string {
 	var sb strings.Builder
-
-	// Create padding to align top/bottom with front face
-	var leftPadding string
-	if useUnicode {
-		// Unicode blocks are double-width: (c.Size * 2) + 1 space
-		leftPadding = strings.Repeat(" ", (c.Size*2)+1)
-	} else {
-		// Single-width characters: c.Size + 1 space
-		leftPadding = strings.Repeat(" ", c.Size) + " "
-	}
-
-	// Top face (Up) - aligned with Front face
-	for row := 0; row < c.Size; row++ {
-		sb.WriteString(leftPadding)
-		for col := 0; col < c.Size; col++ {
-			sb.WriteString(c.FormatSticker(c.Faces[Up][row][col], useColor, useUnicode))
-		}
-		sb.WriteString("\n")
-	}
-	sb.WriteString("\n")
-
-	// Middle row: Left, Front, Right, Back
-	for row := 0; row < c.Size; row++ {
-		// Left face
-		for col := 0; col < c.Size; col++ {
-			sb.WriteString(c.FormatSticker(c.Faces[Left][row][col], useColor, useUnicode))
-		}
-		sb.WriteString(" ") // Space between faces
-
-		// Front face
-		for col := 0; col < c.Size; col++ {
-			sb.WriteString(c.FormatSticker(c.Faces[Front][row][col], useColor, useUnicode))
-		}
-		sb.WriteString(" ") // Space between faces
-
-		// Right face
-		for col := 0; col < c.Size; col++ {
-			sb.WriteString(c.FormatSticker(c.Faces[Right][row][col], useColor, useUnicode))
-		}
-		sb.WriteString(" ") // Space between faces
-
-		// Back face
-		for col := 0; col < c.Size; col++ {
-			sb.WriteString(c.FormatSticker(c.Faces[Back][row][col], useColor, useUnicode))
-		}
-		sb.WriteString("\n")
-	}
-	sb.WriteString("\n")
-
-	// Bottom face (Down) - aligned with Front face
-	for row := 0; row < c.Size; row++ {
-		sb.WriteString(leftPadding)
-		for col := 0; col < c.Size; col++ {
-			sb.WriteString(c.FormatSticker(c.Faces[Down][row][col], useColor, useUnicode))
-		}
-		sb.WriteString("\n")
-	}
-
+	c.Render(&sb, RenderOptions{Color: useColor, Unicode: useUnicode}) // A strings.Builder never fails
 	return sb.String()
 }
 
 // FormatSticker returns the appropriate representation for a sticker
 func (c *Cube) FormatSticker(color Color, useColor bool, useUnicode bool) string {
-	if useUnicode {
-		return color.UnicodeString()
-	} else if useColor {
-		return color.ColoredString()
-	} else {
-		return color.String()
-	}
+	return activeScheme.Format(color, useColor, useUnicode)
 }
 
 // MonoString returns a single-width colored block for perfect monospace alignment
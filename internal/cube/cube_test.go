@@ -49,6 +49,121 @@ func TestCubeIsSolved(t *testing.T) {
 	}
 }
 
+func TestDegenerateSizes(t *testing.T) {
+	for _, size := range []int{-1, 0, 1} {
+		if err := ValidateSize(size); err == nil {
+			t.Errorf("ValidateSize(%d) should fail", size)
+		}
+		if c := NewCube(size); c.Size != MinSize || !c.IsSolved() {
+			t.Errorf("NewCube(%d) = %dx%d, want a solved %dx%d", size, c.Size, c.Size, MinSize, MinSize)
+		}
+		if _, err := GenerateScramble(size, ScrambleOptions{}); err == nil {
+			t.Errorf("GenerateScramble(%d) should fail", size)
+		}
+	}
+
+	if err := ValidateSize(2); err != nil {
+		t.Errorf("ValidateSize(2) error = %v", err)
+	}
+	c := NewCube(2)
+	moves, _ := ParseScramble("R U F' D2 x")
+	c.ApplyMoves(moves)
+	c.ApplyInverseMoves(moves)
+	if c.Size != 2 || !c.IsSolved() {
+		t.Error("2x2 should be solved after a sequence and its inverse")
+	}
+}
+
+func TestIsSolvedRotated(t *testing.T) {
+	// A solved cube is solved held any way up, on odd and even sizes
+	for _, size := range []int{2, 3, 4} {
+		for _, rotation := range orientationRotations {
+			c := NewCube(size)
+			c.ApplyMoves(rotation)
+			if !c.IsSolved() {
+				t.Errorf("%dx%d after %s: IsSolved() = false", size, size, FormatMoves(rotation))
+			}
+			if home := len(rotation) == 0; c.IsSolvedStrict(nil) != home {
+				t.Errorf("%dx%d after %s: IsSolvedStrict(nil) = %v, want %v", size, size, FormatMoves(rotation), !home, home)
+			}
+		}
+	}
+
+	// x2 turns yellow-top blue-front into white-top green-front
+	c := NewCube(3)
+	moves, _ := ParseMoves("R U x2 D' R'")
+	c.ApplyMoves(moves)
+	whiteGreen := map[Face]Color{Up: White, Down: Yellow, Front: Green, Back: Blue, Right: Red, Left: Orange}
+	if !c.IsSolved() || !c.IsSolvedStrict(whiteGreen) {
+		t.Error("R U x2 D' R' should leave a cube solved white on top, green in front")
+	}
+	if c.IsSolvedStrict(nil) {
+		t.Error("IsSolvedStrict(nil) = true after x2")
+	}
+	c.ApplyMoves([]Move{{Face: Right, Clockwise: true}})
+	if c.IsSolvedStrict(whiteGreen) {
+		t.Error("IsSolvedStrict() = true on an unsolved cube")
+	}
+
+	// A scheme missing a face never matches
+	if NewCube(3).IsSolvedStrict(map[Face]Color{Up: Yellow}) {
+		t.Error("IsSolvedStrict() matched an incomplete scheme")
+	}
+}
+
+// japaneseScheme puts blue opposite white
+var japaneseScheme = map[Face]Color{
+	Up: White, Down: Blue, Front: Red, Back: Orange, Right: Green, Left: Yellow,
+}
+
+func TestNewCubeWithScheme(t *testing.T) {
+	c, err := NewCubeWithScheme(3, japaneseScheme)
+	if err != nil {
+		t.Fatalf("NewCubeWithScheme() error = %v", err)
+	}
+	if !c.IsSolved() {
+		t.Error("New Japanese-scheme cube should be solved")
+	}
+	for face, color := range japaneseScheme {
+		if c.Faces[face][1][1] != color || c.SolvedColor(face) != color {
+			t.Errorf("%s face = %s, want %s", face, c.Faces[face][1][1], color)
+		}
+	}
+	if c.HasStandardScheme() {
+		t.Error("Japanese-scheme cube reports the standard scheme")
+	}
+
+	moves, _ := ParseScramble("R U R' U'")
+	c.ApplyMoves(moves)
+	if c.IsSolved() {
+		t.Error("Cube should not be solved after R U R' U'")
+	}
+	c.ApplyInverseMoves(moves)
+	if !c.IsSolved() {
+		t.Error("Cube should be solved after undoing R U R' U'")
+	}
+	if c.Clone().SolvedColor(Up) != White {
+		t.Error("Clone should keep the color scheme")
+	}
+
+	standard := map[Face]Color{Front: Blue, Back: Green, Left: Orange, Right: Red, Up: Yellow, Down: White}
+	if c, err := NewCubeWithScheme(3, standard); err != nil || !c.HasStandardScheme() || c.String() != NewCube(3).String() {
+		t.Errorf("NewCubeWithScheme(standard) = %v, %v, want the standard cube", c, err)
+	}
+}
+
+func TestNewCubeWithSchemeErrors(t *testing.T) {
+	missing := map[Face]Color{Up: White, Down: Blue, Front: Red, Back: Orange, Right: Green}
+	duplicate := map[Face]Color{Up: White, Down: Blue, Front: Red, Back: Orange, Right: Green, Left: Green}
+	wildcard := map[Face]Color{Up: White, Down: Blue, Front: Red, Back: Orange, Right: Green, Left: Grey}
+
+	for name, scheme := range map[string]map[Face]Color{"missing": missing, "duplicate": duplicate, "wildcard": wildcard} {
+		if _, err := NewCubeWithScheme(3, scheme); err == nil {
+			t.Errorf("NewCubeWithScheme(%s) should fail", name)
+		}
+	}
+}
+
 func TestParseMove(t *testing.T) {
 	tests := []struct {
 		notation string
@@ -226,3 +341,37 @@ func TestAllFacesRotate(t *testing.T) {
 		})
 	}
 }
+
+func TestParseColor(t *testing.T) {
+	for _, c := range []Color{White, Yellow, Red, Orange, Blue, Green, Grey} {
+		got, err := ParseColor(rune(c.String()[0]))
+		if err != nil || got != c {
+			t.Errorf("ParseColor(%q) = %v, %v; want %v", c.String(), got, err, c)
+		}
+	}
+
+	if got, err := ParseColor('?'); err != nil || got != Grey {
+		t.Errorf("ParseColor('?') = %v, %v; want Grey", got, err)
+	}
+
+	for _, r := range []rune{'w', 'X', ' ', '*'} {
+		if _, err := ParseColor(r); err == nil {
+			t.Errorf("ParseColor(%q) should error", r)
+		}
+	}
+}
+
+func TestParseFace(t *testing.T) {
+	for _, f := range []Face{Front, Back, Left, Right, Up, Down} {
+		got, err := ParseFace(f.String())
+		if err != nil || got != f {
+			t.Errorf("ParseFace(%q) = %v, %v; want %v", f.String(), got, err, f)
+		}
+	}
+
+	for _, s := range []string{"", "u", "M", "x", "UR"} {
+		if _, err := ParseFace(s); err == nil {
+			t.Errorf("ParseFace(%q) should error", s)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package cube
+
+import (
+	"fmt"
+	"strings"
+)
+
+// AnalyzeCycles applies moves to a solved 3x3 and returns how they permute
+// the corner and edge positions, as disjoint cycles of position indexes
+// (see cornerNames and edgeNames). In each cycle the piece at the first
+// position moves to the second, the second to the third, and the last back
+// to the first. Each cycle starts at its lowest position, cycles are in
+// order of their first position, and pieces that stay put are left out,
+// even if they are twisted or flipped in place. Positions are measured
+// against the centers, so a rotation alone moves no pieces.
+func AnalyzeCycles(moves []Move) (cornerCycles, edgeCycles [][]int) {
+	c := NewCube(3)
+	c.ApplyMoves(moves)
+
+	corners := c.CornerPermutation()
+	edges := c.EdgePermutation()
+	return permutationCycles(corners[:]), permutationCycles(edges[:])
+}
+
+// permutationCycles splits a permutation, given as the piece in each
+// position, into the cycles pieces travel along
+func permutationCycles(perm []int) [][]int {
+	// where[piece] is the position the piece now occupies
+	where := make([]int, len(perm))
+	for pos, piece := range perm {
+		where[piece] = pos
+	}
+
+	cycles := [][]int{}
+	visited := make([]bool, len(perm))
+	for start := range perm {
+		if visited[start] || where[start] == start {
+			continue
+		}
+		var cycle []int
+		for pos := start; !visited[pos]; pos = where[pos] {
+			visited[pos] = true
+			cycle = append(cycle, pos)
+		}
+		cycles = append(cycles, cycle)
+	}
+	return cycles
+}
+
+// DescribeCycles writes each cycle from AnalyzeCycles as a line such as
+// "3-cycle of edges: UF → UR → UL", corners first
+func DescribeCycles(cornerCycles, edgeCycles [][]int) []string {
+	var lines []string
+	describe := func(kind string, names []string, cycles [][]int) {
+		for _, cycle := range cycles {
+			labels := make([]string, len(cycle))
+			for i, pos := range cycle {
+				labels[i] = names[pos]
+			}
+			lines = append(lines, fmt.Sprintf("%d-cycle of %s: %s", len(cycle), kind, strings.Join(labels, " → ")))
+		}
+	}
+	describe("corners", cornerNames[:], cornerCycles)
+	describe("edges", edgeNames[:], edgeCycles)
+	return lines
+}
+
+// DescribeTurnedInPlace lists the pieces moves leave in their own position
+// but twisted or flipped, which AnalyzeCycles leaves out, as lines such as
+// "Edges flipped in place: UF UB", corners first
+func DescribeTurnedInPlace(moves []Move) []string {
+	c := NewCube(3)
+	c.ApplyMoves(moves)
+
+	var lines []string
+	describe := func(kind string, names []string, perm, orientation []int) {
+		var labels []string
+		for pos, piece := range perm {
+			if piece == pos && orientation[pos] != 0 {
+				labels = append(labels, names[pos])
+			}
+		}
+		if len(labels) > 0 {
+			lines = append(lines, fmt.Sprintf("%s in place: %s", kind, strings.Join(labels, " ")))
+		}
+	}
+	corners, twist := c.CornerPermutation(), c.CornerOrientation()
+	edges, flip := c.EdgePermutation(), c.EdgeOrientation()
+	describe("Corners twisted", cornerNames[:], corners[:], twist[:])
+	describe("Edges flipped", edgeNames[:], edges[:], flip[:])
+	return lines
+}
@@ -0,0 +1,70 @@
+package cube
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAnalyzeCycles(t *testing.T) {
+	tests := []struct {
+		name           string
+		moves          string
+		corners, edges [][]int
+	}{
+		{"T-perm", "R U R' U' R' F R2 U' R' U' R U R' F'", [][]int{{0, 3}}, [][]int{{0, 2}}},
+		{"Ua-perm", "R U' R U R U R U' R' U' R2", [][]int{}, [][]int{{0, 2, 1}}},
+		{"H-perm", "M2 U M2 U2 M2 U M2", [][]int{}, [][]int{{0, 2}, {1, 3}}},
+		{"U turn", "U", [][]int{{0, 1, 2, 3}}, [][]int{{0, 1, 2, 3}}},
+		{"Rotation", "y x'", [][]int{}, [][]int{}},
+		{"Nothing", "", [][]int{}, [][]int{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			moves, _ := ParseMoves(tt.moves)
+			corners, edges := AnalyzeCycles(moves)
+			if !reflect.DeepEqual(corners, tt.corners) || !reflect.DeepEqual(edges, tt.edges) {
+				t.Errorf("AnalyzeCycles() = %v, %v, want %v, %v", corners, edges, tt.corners, tt.edges)
+			}
+		})
+	}
+}
+
+func TestDescribeCycles(t *testing.T) {
+	moves, _ := ParseMoves("R U R' U' R' F R2 U' R' U' R U R' F'")
+	got := DescribeCycles(AnalyzeCycles(moves))
+	want := []string{"2-cycle of corners: URF → UBR", "2-cycle of edges: UR → UL"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("DescribeCycles(T-perm) = %q, want %q", got, want)
+	}
+
+	// The piece at UF moves to UL on a U turn
+	moves, _ = ParseMoves("U")
+	corners, edges := AnalyzeCycles(moves)
+	if got := DescribeCycles(corners, edges)[1]; got != "4-cycle of edges: UR → UF → UL → UB" {
+		t.Errorf("DescribeCycles(U) edges = %q", got)
+	}
+}
+
+func TestDescribeTurnedInPlace(t *testing.T) {
+	tests := []struct {
+		name  string
+		moves string
+		want  []string
+	}{
+		{"Superflip", "U R2 F B R B2 R U2 L B2 R U' D' R2 F R' L B2 U2 F2", []string{
+			"Edges flipped in place: UR UF UL UB DR DF DL DB FR FL BL BR"}},
+		{"Corner twist", "R' D' R D R' D' R D U R' D' R D R' D' R D R' D' R D R' D' R D U'", []string{
+			"Corners twisted in place: URF UBR"}},
+		{"T-perm", "R U R' U' R' F R2 U' R' U' R U R' F'", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			moves, _ := ParseMoves(tt.moves)
+			if got := DescribeTurnedInPlace(moves); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DescribeTurnedInPlace(%s) = %q, want %q", tt.moves, got, tt.want)
+			}
+		})
+	}
+}
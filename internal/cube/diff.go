@@ -0,0 +1,41 @@
+package cube
+
+import "fmt"
+
+// StickerDiff is a sticker that differs between two cubes
+type StickerDiff struct {
+	Coord
+	A, B Color // The sticker's color on each cube
+}
+
+// DiffCubes lists the stickers that differ between two cubes of the same
+// size, in face, row, and column order
+func DiffCubes(a, b *Cube) ([]StickerDiff, error) {
+	if a.Size != b.Size {
+		return nil, fmt.Errorf("can't compare a %dx%d with a %dx%d", a.Size, a.Size, b.Size, b.Size)
+	}
+
+	var diffs []StickerDiff
+	for face := Front; face <= Down; face++ {
+		for row := 0; row < a.Size; row++ {
+			for col := 0; col < a.Size; col++ {
+				if colorA, colorB := a.Faces[face][row][col], b.Faces[face][row][col]; colorA != colorB {
+					diffs = append(diffs, StickerDiff{Coord: Coord{face, row, col}, A: colorA, B: colorB})
+				}
+			}
+		}
+	}
+	return diffs, nil
+}
+
+// DiffHighlight returns a RenderOptions.Highlight function that draws the
+// stickers in diffs normally and dims the rest
+func DiffHighlight(diffs []StickerDiff) func(face Face, row, col int) bool {
+	changed := make(map[Coord]bool, len(diffs))
+	for _, diff := range diffs {
+		changed[diff.Coord] = true
+	}
+	return func(face Face, row, col int) bool {
+		return changed[Coord{face, row, col}]
+	}
+}
@@ -0,0 +1,37 @@
+package cube
+
+import "testing"
+
+func TestDiffCubes(t *testing.T) {
+	a, b := NewCube(3), NewCube(3)
+	if diffs, err := DiffCubes(a, b); err != nil || len(diffs) != 0 {
+		t.Fatalf("DiffCubes(solved, solved) = %v, %v", diffs, err)
+	}
+
+	// On a solved cube R recolors only the 12 stickers around the R face
+	b.ApplyMove(Move{Face: Right, Clockwise: true})
+	diffs, err := DiffCubes(a, b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(diffs) != 12 {
+		t.Errorf("R changed %d stickers, want 12", len(diffs))
+	}
+	for _, diff := range diffs {
+		if diff.A != a.Faces[diff.Face][diff.Row][diff.Col] || diff.B != b.Faces[diff.Face][diff.Row][diff.Col] {
+			t.Errorf("%+v does not match the cubes", diff)
+		}
+		if diff.Face == Right || diff.Face == Left {
+			t.Errorf("%+v: R should not change the colors on the R or L face of a solved cube", diff)
+		}
+	}
+
+	highlight := DiffHighlight(diffs)
+	if !highlight(Up, 0, 2) || highlight(Up, 0, 0) {
+		t.Error("DiffHighlight() should mark exactly the changed stickers")
+	}
+
+	if _, err := DiffCubes(NewCube(3), NewCube(4)); err == nil {
+		t.Error("DiffCubes() should reject cubes of different sizes")
+	}
+}
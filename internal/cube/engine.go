@@ -0,0 +1,258 @@
+package cube
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Engine selects how a cube applies moves
+type Engine int
+
+const (
+	// PermutationEngine applies cached sticker permutations built from the
+	// ring tables in ring_generators.go. It is the default.
+	PermutationEngine Engine = iota
+
+	// GeometricEngine places every sticker in 3D space and rotates the
+	// stickers of the turning layers about the move's axis. It is derived
+	// directly from the cube's geometry rather than hand-written ring
+	// tables, which makes it a useful reference for the permutation engine.
+	GeometricEngine
+)
+
+// String returns the engine name used by ParseEngine and the CLI
+func (e Engine) String() string {
+	switch e {
+	case PermutationEngine:
+		return "permutation"
+	case GeometricEngine:
+		return "geometric"
+	default:
+		return fmt.Sprintf("Engine(%d)", int(e))
+	}
+}
+
+// ParseEngine converts an engine name ("permutation" or "geometric") to an Engine
+func ParseEngine(name string) (Engine, error) {
+	switch strings.ToLower(name) {
+	case "permutation":
+		return PermutationEngine, nil
+	case "geometric":
+		return GeometricEngine, nil
+	default:
+		return PermutationEngine, fmt.Errorf("unknown engine: %s (available: permutation, geometric)", name)
+	}
+}
+
+// CubeOption configures a cube created by NewCube
+type CubeOption func(*Cube)
+
+// WithEngine selects the move engine for a new cube
+func WithEngine(engine Engine) CubeOption {
+	return func(c *Cube) {
+		c.Engine = engine
+	}
+}
+
+// point3 is a sticker position in doubled coordinates: cubie centers sit at
+// odd offsets from the middle of the cube (or even offsets for odd N) and
+// sticker faces lie on the planes at ±N
+type point3 struct{ x, y, z int }
+
+// Move axes for the geometric engine. Positive quarter turns are clockwise
+// looking at the R, U, and F faces respectively.
+const (
+	axisX = iota
+	axisY
+	axisZ
+)
+
+// geometricKey is the cache key for geometric engine permutations
+type geometricKey struct {
+	N    int
+	Move Move
+}
+
+var geometricCache = make(map[geometricKey]Permutation)
+var geometricCacheMu sync.RWMutex
+
+// applyGeometricMove applies a move using the geometric engine
+func (c *Cube) applyGeometricMove(move Move) {
+	key := geometricKey{c.Size, move}
+
+	geometricCacheMu.RLock()
+	perm, ok := geometricCache[key]
+	geometricCacheMu.RUnlock()
+
+	if !ok {
+		perm = generateGeometricPermutation(c.Size, move)
+		geometricCacheMu.Lock()
+		geometricCache[key] = perm
+		geometricCacheMu.Unlock()
+	}
+
+	applyPermutation(c, perm)
+}
+
+// generateGeometricPermutation builds the sticker permutation for a move by
+// rotating each sticker in the turning layers about the move's axis
+func generateGeometricPermutation(N int, move Move) Permutation {
+	axis, inLayer, quarterTurns := geometricTurn(N, move)
+
+	perm := make(Permutation, 6*N*N)
+	for src := range perm {
+		face, row, col := indexToCoord(src, N)
+		p := stickerPosition(face, row, col, N)
+
+		if !inLayer(layerCoordinate(p, axis, N)) {
+			perm[src] = src
+			continue
+		}
+		for i := 0; i < quarterTurns; i++ {
+			p = rotatePoint(p, axis)
+		}
+		face, row, col = positionSticker(p, N)
+		perm[src] = stickerIndex(face, row, col, N)
+	}
+	return perm
+}
+
+// geometricTurn describes a move as an axis, a predicate selecting the
+// turning layers by their coordinate along that axis, and a number of
+// clockwise quarter turns (0-3) about the positive axis
+func geometricTurn(N int, move Move) (int, func(int) bool, int) {
+	turns := 1
+	if move.Double {
+		turns = 2
+	} else if !move.Clockwise {
+		turns = -1
+	}
+	all := func(int) bool { return true }
+	middle := func(v int) bool { return v == 0 }
+
+	var axis int
+	var inLayer func(int) bool
+	switch {
+	case move.Rotation != NoRotation:
+		// x follows R, y follows U, z follows F
+		rotationAxis, ok := map[RotationType]int{X_Rotation: axisX, Y_Rotation: axisY, Z_Rotation: axisZ}[move.Rotation]
+		if !ok {
+			panic(fmt.Sprintf("no geometric turn for rotation type %d", move.Rotation))
+		}
+		axis, inLayer = rotationAxis, all
+	case move.Slice == M_Slice:
+		axis, inLayer, turns = axisX, middle, -turns // M follows L
+	case move.Slice == E_Slice:
+		axis, inLayer, turns = axisY, middle, -turns // E follows D
+	case move.Slice == S_Slice:
+		axis, inLayer = axisZ, middle // S follows F
+	case move.Slice != NoSlice:
+		panic(fmt.Sprintf("no geometric turn for slice type %d", move.Slice))
+	default:
+		sign := 1
+		switch move.Face {
+		case Right:
+			axis = axisX
+		case Left:
+			axis, sign = axisX, -1
+		case Up:
+			axis = axisY
+		case Down:
+			axis, sign = axisY, -1
+		case Front:
+			axis = axisZ
+		case Back:
+			axis, sign = axisZ, -1
+		default:
+			panic(fmt.Sprintf("no geometric turn for face %d", move.Face))
+		}
+
+		// Layers are counted inward from the turning face, starting at 0
+		first, last := 0, 0
+		if move.Wide {
+			last = move.WideDepth - 1
+			if move.WideDepth <= 0 {
+				last = 1
+			}
+		} else {
+			first, last = move.Layer, move.Layer
+		}
+		inLayer = func(v int) bool {
+			depth := ((N - 1) - sign*v) / 2
+			return depth >= first && depth <= last
+		}
+		turns *= sign
+	}
+
+	return axis, inLayer, ((turns % 4) + 4) % 4
+}
+
+// stickerPosition returns the position of a sticker in doubled coordinates
+// with x toward R, y toward U, and z toward F
+func stickerPosition(face Face, row, col, N int) point3 {
+	up := func(i int) int { return 2*i - (N - 1) }   // Index increases with the axis
+	down := func(i int) int { return (N - 1) - 2*i } // Index decreases with the axis
+
+	switch face {
+	case Up:
+		return point3{up(col), N, up(row)}
+	case Down:
+		return point3{up(col), -N, down(row)}
+	case Front:
+		return point3{up(col), down(row), N}
+	case Back:
+		return point3{down(col), down(row), -N}
+	case Left:
+		return point3{-N, down(row), up(col)}
+	default: // Right
+		return point3{N, down(row), down(col)}
+	}
+}
+
+// positionSticker is the inverse of stickerPosition
+func positionSticker(p point3, N int) (Face, int, int) {
+	up := func(v int) int { return (v + N - 1) / 2 }
+	down := func(v int) int { return (N - 1 - v) / 2 }
+
+	switch {
+	case p.y == N:
+		return Up, up(p.z), up(p.x)
+	case p.y == -N:
+		return Down, down(p.z), up(p.x)
+	case p.z == N:
+		return Front, down(p.y), up(p.x)
+	case p.z == -N:
+		return Back, down(p.y), down(p.x)
+	case p.x == -N:
+		return Left, down(p.y), up(p.z)
+	default: // p.x == N
+		return Right, down(p.y), down(p.z)
+	}
+}
+
+// layerCoordinate returns the coordinate of the cubie carrying the sticker
+// along the axis. Stickers on a face perpendicular to the axis belong to the
+// outermost layer.
+func layerCoordinate(p point3, axis, N int) int {
+	v := [3]int{p.x, p.y, p.z}[axis]
+	if v > N-1 {
+		return N - 1
+	}
+	if v < -(N - 1) {
+		return -(N - 1)
+	}
+	return v
+}
+
+// rotatePoint turns a point one quarter clockwise about the positive axis
+func rotatePoint(p point3, axis int) point3 {
+	switch axis {
+	case axisX:
+		return point3{p.x, p.z, -p.y}
+	case axisY:
+		return point3{-p.z, p.y, p.x}
+	default:
+		return point3{p.y, -p.x, p.z}
+	}
+}
@@ -0,0 +1,66 @@
+package cube
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestParseEngine(t *testing.T) {
+	for _, engine := range []Engine{PermutationEngine, GeometricEngine} {
+		parsed, err := ParseEngine(engine.String())
+		if err != nil {
+			t.Fatalf("ParseEngine(%q) failed: %v", engine.String(), err)
+		}
+		if parsed != engine {
+			t.Errorf("ParseEngine(%q) = %v, want %v", engine.String(), parsed, engine)
+		}
+	}
+
+	if _, err := ParseEngine("legacy"); err == nil {
+		t.Error("Expected error for unknown engine")
+	}
+}
+
+func TestWithEngine(t *testing.T) {
+	if engine := NewCube(3).Engine; engine != PermutationEngine {
+		t.Errorf("Default engine = %v, want %v", engine, PermutationEngine)
+	}
+
+	c := NewCube(3, WithEngine(GeometricEngine))
+	if c.Engine != GeometricEngine {
+		t.Errorf("Engine = %v, want %v", c.Engine, GeometricEngine)
+	}
+	if clone := c.Clone(); clone.Engine != GeometricEngine {
+		t.Errorf("Clone engine = %v, want %v", clone.Engine, GeometricEngine)
+	}
+}
+
+// TestEnginesAgree applies the same random face turns with both engines and
+// checks that they produce the same state
+func TestEnginesAgree(t *testing.T) {
+	faces := []Face{Front, Back, Left, Right, Up, Down}
+	rng := rand.New(rand.NewSource(1))
+
+	for N := 2; N <= 6; N++ {
+		for seq := 0; seq < 20; seq++ {
+			permutation := NewCube(N)
+			geometric := NewCube(N, WithEngine(GeometricEngine))
+
+			for i := 0; i < 30; i++ {
+				move := Move{Face: faces[rng.Intn(len(faces))]}
+				switch rng.Intn(3) {
+				case 0:
+					move.Clockwise = true
+				case 1:
+					move.Double = true
+				}
+
+				permutation.ApplyMove(move)
+				geometric.ApplyMove(move)
+				if permutation.String() != geometric.String() {
+					t.Fatalf("%dx%d: engines disagree after move %d (%s) of sequence %d", N, N, i+1, move.String(), seq)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,307 @@
+package cube
+
+import (
+	"sort"
+	"sync"
+)
+
+// Algorithm equivalence classes
+//
+// Two algorithms are equivalent when one can be turned into the other by
+// inverting it, mirroring it through one of the three middle planes, adding
+// U turns before or after it (AUF), or conjugating it with a y rotation.
+// Equivalence is decided by the state each algorithm produces on a 3x3, not
+// by names or move strings, so big-cube algorithms are compared by their
+// effect on a 3x3.
+
+// caseKeys maps the canonical state key of every database algorithm to the
+// case IDs that produce it, built once on first use
+var (
+	caseKeysOnce sync.Once
+	caseKeys     map[string][]string
+)
+
+// EquivalenceClass returns the sorted case IDs of database algorithms that
+// are equivalent to alg, including alg's own case ID if it is in the
+// database. It returns nil if alg's moves don't parse.
+func EquivalenceClass(alg Algorithm) []string {
+	moves, err := ParseScramble(alg.Moves)
+	if err != nil {
+		return nil
+	}
+
+	caseKeysOnce.Do(buildCaseKeys)
+
+	seen := make(map[string]bool)
+	var class []string
+	for _, variant := range algorithmVariants(moves) {
+		for _, caseID := range caseKeys[canonicalCaseKey(variant)] {
+			if !seen[caseID] {
+				seen[caseID] = true
+				class = append(class, caseID)
+			}
+		}
+	}
+	sort.Strings(class)
+	return class
+}
+
+// EffectKey returns a key identifying the state moves produce on a solved
+// 3x3, up to AUF and y rotation. Two algorithms with the same key have the
+// same piece-masked pattern once those are undone, so they are the same
+// algorithm written differently. Unlike EquivalenceClass, mirrors and
+// inverses are kept apart: they solve different cases.
+func EffectKey(moves []Move) string {
+	return canonicalCaseKey(moves)
+}
+
+// MirrorEffectKeys returns the EffectKey of moves mirrored through each of
+// the M, E, and S planes. An algorithm whose EffectKey is among them solves
+// a mirror image of the case moves solves.
+func MirrorEffectKeys(moves []Move) []string {
+	keys := make([]string, 0, 3)
+	for _, axis := range []int{axisX, axisY, axisZ} {
+		keys = append(keys, canonicalCaseKey(mirrorMoves(moves, axis)))
+	}
+	return keys
+}
+
+// buildCaseKeys indexes every database algorithm with a case ID by its
+// canonical state key
+func buildCaseKeys() {
+	caseKeys = make(map[string][]string)
+	for _, alg := range GetAllAlgorithms() {
+		if alg.CaseID == "" {
+			continue
+		}
+		moves, err := ParseScramble(alg.Moves)
+		if err != nil {
+			continue
+		}
+		key := canonicalCaseKey(moves)
+		caseKeys[key] = append(caseKeys[key], alg.CaseID)
+	}
+}
+
+// algorithmVariants returns moves, its inverse, and the mirrors of both
+// through the M, E, and S planes
+func algorithmVariants(moves []Move) [][]Move {
+	variants := [][]Move{moves, InvertSequence(moves)}
+	for _, base := range variants[:2] {
+		for _, axis := range []int{axisX, axisY, axisZ} {
+			variants = append(variants, mirrorMoves(base, axis))
+		}
+	}
+	return variants
+}
+
+// mirrorMoves reflects a move sequence through the middle plane
+// perpendicular to axis (axisX mirrors left/right, so R becomes L'). Turns
+// about the mirror axis swap to the opposite layer and keep their direction
+// in space; all other turns keep their layer and reverse direction.
+func mirrorMoves(moves []Move, axis int) []Move {
+	mirrored := make([]Move, len(moves))
+	for i, move := range moves {
+		if moveAxis(move) == axis {
+			if move.Slice == NoSlice && move.Rotation == NoRotation {
+				move.Face = oppositeFace(move.Face)
+				move.Clockwise = !move.Clockwise
+			}
+		} else {
+			move.Clockwise = !move.Clockwise
+		}
+		if move.Double {
+			move.Clockwise = true
+		}
+		mirrored[i] = move
+	}
+	return mirrored
+}
+
+// yFaceCycle lists the side faces in the order conjugating by y relabels
+// them: y R y' turns B, y B y' turns L, and so on
+var yFaceCycle = [4]Face{Right, Back, Left, Front}
+
+// conjugateY rewrites y^turns moves y^-turns, the moves performed after
+// turning the cube by y, without any rotations. Each y rotation inside
+// moves is absorbed by relabeling the moves after it, so only face and
+// slice turns are left, followed by whatever net y rotation moves leaves
+// the cube in.
+func conjugateY(moves []Move, turns int) []Move {
+	orientation := ((turns % 4) + 4) % 4
+	net := 0
+	result := make([]Move, 0, len(moves))
+	for _, move := range moves {
+		if move.Rotation == Y_Rotation {
+			net += yQuarterTurns(move)
+			orientation = (orientation + yQuarterTurns(move)) % 4
+			continue
+		}
+		result = append(result, relabelY(move, orientation))
+	}
+	return append(result, quarterTurnsOf(Move{Rotation: Y_Rotation}, net)...)
+}
+
+// yQuarterTurns returns how many clockwise quarter turns (0-3) a y rotation makes
+func yQuarterTurns(move Move) int {
+	switch {
+	case move.Double:
+		return 2
+	case move.Clockwise:
+		return 1
+	default:
+		return 3
+	}
+}
+
+// relabelY returns the move equal to y^turns move y^-turns. U, D, E, and y
+// are unchanged; the other moves follow their face around yFaceCycle.
+func relabelY(move Move, turns int) Move {
+	shift := func(face Face) Face {
+		for i, f := range yFaceCycle {
+			if f == face {
+				return yFaceCycle[(i+turns)%4]
+			}
+		}
+		return face
+	}
+
+	// x, z, M, and S turn like a side face; relabel that face and turn the
+	// rotation or slice that follows (or opposes) the new one
+	var follows Face
+	switch {
+	case move.Rotation == X_Rotation:
+		follows = Right
+	case move.Rotation == Z_Rotation, move.Slice == S_Slice:
+		follows = Front
+	case move.Slice == M_Slice:
+		follows = Left
+	case move.Rotation == NoRotation && move.Slice == NoSlice:
+		move.Face = shift(move.Face)
+		return move
+	default:
+		return move
+	}
+
+	rotation := move.Rotation != NoRotation
+	reversed := false
+	switch shift(follows) {
+	case Right:
+		move.Rotation, move.Slice, reversed = X_Rotation, M_Slice, !rotation
+	case Left:
+		move.Rotation, move.Slice, reversed = X_Rotation, M_Slice, rotation
+	case Front:
+		move.Rotation, move.Slice = Z_Rotation, S_Slice
+	case Back:
+		move.Rotation, move.Slice, reversed = Z_Rotation, S_Slice, true
+	}
+	if rotation {
+		move.Slice = NoSlice
+	} else {
+		move.Rotation = NoRotation
+	}
+	if reversed && !move.Double {
+		move.Clockwise = !move.Clockwise
+	}
+	return move
+}
+
+// moveAxis returns the axis a move turns about
+func moveAxis(move Move) int {
+	switch {
+	case move.Slice == M_Slice, move.Rotation == X_Rotation:
+		return axisX
+	case move.Slice == E_Slice, move.Rotation == Y_Rotation:
+		return axisY
+	case move.Slice == S_Slice, move.Rotation == Z_Rotation:
+		return axisZ
+	}
+	switch move.Face {
+	case Right, Left:
+		return axisX
+	case Up, Down:
+		return axisY
+	default:
+		return axisZ
+	}
+}
+
+// canonicalCaseKey returns the smallest state string over every y
+// conjugation and pre- and post-AUF of moves applied to a solved 3x3, with
+// the result turned back to the standard orientation
+func canonicalCaseKey(moves []Move) string {
+	best := ""
+	for y := 0; y < 4; y++ {
+		conjugated := make([]Move, 0, len(moves)+2)
+		conjugated = append(conjugated, quarterTurnsOf(Move{Rotation: Y_Rotation}, y)...)
+		conjugated = append(conjugated, moves...)
+		conjugated = append(conjugated, quarterTurnsOf(Move{Rotation: Y_Rotation}, 4-y)...)
+
+		for pre := 0; pre < 4; pre++ {
+			for post := 0; post < 4; post++ {
+				c := NewCube(3)
+				c.ApplyMoves(quarterTurnsOf(Move{Face: Up}, pre))
+				c.ApplyMoves(conjugated)
+				c.ApplyMoves(quarterTurnsOf(Move{Face: Up}, post))
+				standardOrientation(c)
+
+				if key := c.String(); best == "" || key < best {
+					best = key
+				}
+			}
+		}
+	}
+	return best
+}
+
+// quarterTurnsOf returns the single move that turns move n clockwise
+// quarter turns (mod 4), or nothing for a multiple of 4
+func quarterTurnsOf(move Move, n int) []Move {
+	switch n % 4 {
+	case 1:
+		move.Clockwise = true
+	case 2:
+		move.Double = true
+		move.Clockwise = true
+	case 3:
+		move.Clockwise = false
+	default:
+		return nil
+	}
+	return []Move{move}
+}
+
+// orientationRotations are the 24 whole-cube rotations, as a rotation that
+// brings a face to the top followed by a y turn
+var orientationRotations = func() [][]Move {
+	tops := [][]Move{
+		nil,
+		{{Rotation: X_Rotation, Clockwise: true}},
+		{{Rotation: X_Rotation, Clockwise: true, Double: true}},
+		{{Rotation: X_Rotation}},
+		{{Rotation: Z_Rotation, Clockwise: true}},
+		{{Rotation: Z_Rotation}},
+	}
+	var rotations [][]Move
+	for _, top := range tops {
+		for y := 0; y < 4; y++ {
+			rotation := append(append([]Move(nil), top...), quarterTurnsOf(Move{Rotation: Y_Rotation}, y)...)
+			rotations = append(rotations, rotation)
+		}
+	}
+	return rotations
+}()
+
+// standardOrientation rotates a 3x3 so its centers are in solved positions,
+// undoing any rotations an algorithm contains
+func standardOrientation(c *Cube) {
+	solved := NewCube(3)
+	for _, rotation := range orientationRotations {
+		rotated := c.Clone()
+		rotated.ApplyMoves(rotation)
+		if rotated.Faces[Up][1][1] == solved.Faces[Up][1][1] && rotated.Faces[Front][1][1] == solved.Faces[Front][1][1] {
+			*c = *rotated
+			return
+		}
+	}
+}
@@ -0,0 +1,197 @@
+package cube
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestEquivalenceClass(t *testing.T) {
+	tests := []struct {
+		caseID string
+		want   []string // Must all be in the class
+	}{
+		{"OLL-27", []string{"OLL-26", "OLL-27"}}, // Anti-Sune is the inverse of Sune
+		{"PLL-Ua", []string{"PLL-Ua", "PLL-Ub"}}, // Inverses
+		{"PLL-Aa", []string{"PLL-Aa", "PLL-Ab"}}, // Mirrors
+		{"PLL-Ja", []string{"PLL-Ja", "PLL-Jb"}}, // Algorithms with rotations and wide moves
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.caseID, func(t *testing.T) {
+			algs := GetByCaseID(tt.caseID)
+			if len(algs) == 0 {
+				t.Fatalf("No algorithm with case ID %s", tt.caseID)
+			}
+
+			class := EquivalenceClass(algs[0])
+			members := make(map[string]bool)
+			for _, caseID := range class {
+				members[caseID] = true
+			}
+			for _, want := range tt.want {
+				if !members[want] {
+					t.Errorf("EquivalenceClass(%s) = %v, missing %s", tt.caseID, class, want)
+				}
+			}
+		})
+	}
+}
+
+func TestEquivalenceClassIsSymmetric(t *testing.T) {
+	sune := EquivalenceClass(GetByCaseID("OLL-27")[0])
+	antiSune := EquivalenceClass(GetByCaseID("OLL-26")[0])
+	if !reflect.DeepEqual(sune, antiSune) {
+		t.Errorf("Sune class %v differs from Anti-Sune class %v", sune, antiSune)
+	}
+
+	// An unrelated case stays out of the class
+	for _, caseID := range sune {
+		if caseID == "PLL-T" {
+			t.Errorf("Sune class should not contain PLL-T: %v", sune)
+		}
+	}
+}
+
+func TestEquivalenceClassFindsTransformedAlgorithm(t *testing.T) {
+	// Sune written with a y rotation, a pre-AUF, and a post-AUF
+	alg := Algorithm{Moves: "U y R U R' U R U2 R' y' U2"}
+	class := EquivalenceClass(alg)
+
+	found := false
+	for _, caseID := range class {
+		if caseID == "OLL-27" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("EquivalenceClass(%s) = %v, want it to contain OLL-27", alg.Moves, class)
+	}
+
+	if class := EquivalenceClass(Algorithm{Moves: "not a move"}); class != nil {
+		t.Errorf("EquivalenceClass of unparseable moves = %v, want nil", class)
+	}
+}
+
+func TestMirrorMoves(t *testing.T) {
+	moves, _ := ParseScramble("R U R' U' M x y2")
+	want, _ := ParseScramble("L' U' L U M x y2")
+	if got := mirrorMoves(moves, axisX); !reflect.DeepEqual(got, want) {
+		t.Errorf("mirrorMoves(R U R' U' M x y2) = %v, want %v", got, want)
+	}
+
+	// Mirroring twice restores the sequence
+	for _, axis := range []int{axisX, axisY, axisZ} {
+		if got := mirrorMoves(mirrorMoves(moves, axis), axis); !reflect.DeepEqual(got, moves) {
+			t.Errorf("Mirroring twice about axis %d = %v, want %v", axis, got, moves)
+		}
+	}
+}
+
+func TestEffectKey(t *testing.T) {
+	key := func(alg string) string {
+		moves, err := ParseScramble(alg)
+		if err != nil {
+			t.Fatalf("ParseScramble(%q) error = %v", alg, err)
+		}
+		return EffectKey(moves)
+	}
+
+	sune := key("R U R' U R U2 R'")
+	for _, same := range []string{
+		"U R U R' U R U2 R'",    // Pre-AUF
+		"R U R' U R U2 R' U2",   // Post-AUF
+		"y F U F' U F U2 F' y'", // Conjugated by y
+	} {
+		if key(same) != sune {
+			t.Errorf("EffectKey(%q) should match Sune", same)
+		}
+	}
+	for _, different := range []string{
+		"L' U' L U' L' U2 L", // Mirror
+		"R U2 R' U' R U' R'", // Inverse
+	} {
+		if key(different) == sune {
+			t.Errorf("EffectKey(%q) should differ from Sune", different)
+		}
+	}
+}
+
+func TestMirrorEffectKeys(t *testing.T) {
+	key := func(alg string) string {
+		moves, err := ParseScramble(alg)
+		if err != nil {
+			t.Fatalf("ParseScramble(%q) error = %v", alg, err)
+		}
+		return EffectKey(moves)
+	}
+	contains := func(keys []string, key string) bool {
+		for _, k := range keys {
+			if k == key {
+				return true
+			}
+		}
+		return false
+	}
+
+	sune, err := ParseScramble("R U R' U R U2 R'")
+	if err != nil {
+		t.Fatal(err)
+	}
+	mirrors := MirrorEffectKeys(sune)
+	if len(mirrors) != 3 {
+		t.Fatalf("MirrorEffectKeys() returned %d keys, want 3", len(mirrors))
+	}
+	for _, mirror := range []string{
+		"L' U' L U' L' U2 L",     // Left Sune, mirrored left to right
+		"U L' U' L U' L' U2 L U", // The same with AUF
+	} {
+		if !contains(mirrors, key(mirror)) {
+			t.Errorf("%q should be a mirror of Sune", mirror)
+		}
+	}
+	for _, other := range []string{
+		"R U R' U R U2 R'",   // Sune itself
+		"R U2 R' U' R U' R'", // Anti-Sune, its inverse
+	} {
+		if contains(mirrors, key(other)) {
+			t.Errorf("%q should not be a mirror of Sune", other)
+		}
+	}
+}
+
+func TestConjugateY(t *testing.T) {
+	setup, _ := ParseScramble("R U F' L2 D B' R2 U' F")
+	algorithms := []string{"R", "L'", "F2", "B", "U'", "D", "Rw", "Lw'", "Dw", "M", "E'", "S2", "x", "y'", "z", "F2L-25", "F2L-39", "PLL-Ja"}
+
+	for _, text := range algorithms {
+		if algs := GetByCaseID(text); len(algs) > 0 {
+			text = algs[0].Moves
+		}
+		moves, err := ParseScramble(text)
+		if err != nil {
+			t.Fatalf("ParseScramble(%q) error = %v", text, err)
+		}
+
+		for turns := 0; turns < 4; turns++ {
+			want := NewCube(3)
+			want.ApplyMoves(setup)
+			want.ApplyMoves(quarterTurnsOf(Move{Rotation: Y_Rotation}, turns))
+			want.ApplyMoves(moves)
+			want.ApplyMoves(quarterTurnsOf(Move{Rotation: Y_Rotation}, 4-turns))
+
+			rewritten := conjugateY(moves, turns)
+			got := NewCube(3)
+			got.ApplyMoves(setup)
+			got.ApplyMoves(rewritten)
+
+			if got.String() != want.String() {
+				t.Errorf("conjugateY(%q, %d) = %s does not match the conjugate", text, turns, FormatMoves(rewritten))
+			}
+			for _, move := range rewritten[:len(rewritten)-1] {
+				if move.Rotation == Y_Rotation {
+					t.Errorf("conjugateY(%q, %d) = %s has a y rotation before the end", text, turns, FormatMoves(rewritten))
+				}
+			}
+		}
+	}
+}
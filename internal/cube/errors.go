@@ -0,0 +1,78 @@
+package cube
+
+import (
+	"fmt"
+	"time"
+)
+
+// Error types
+//
+// Parsing and solving return these types (possibly wrapped) so callers can
+// tell bad input from a cube that can't be solved from a search that gave
+// up, using errors.As.
+
+// ParseError reports move notation that could not be parsed
+type ParseError struct {
+	Token    string // The move that failed to parse
+	Position int    // 1-based index of Token in the sequence, or 0 for a single move
+	Reason   string
+}
+
+func (e *ParseError) Error() string {
+	if e.Position == 0 {
+		return e.Reason
+	}
+	return fmt.Sprintf("error parsing move '%s' at position %d: %s", e.Token, e.Position, e.Reason)
+}
+
+// UnsolvableError reports a cube a solver cannot work on: a size it doesn't
+// support, or stickers that don't form a reachable state
+type UnsolvableError struct {
+	Reason string
+}
+
+func (e *UnsolvableError) Error() string {
+	return e.Reason
+}
+
+// SearchLimitError reports a search that stopped at its move, state, or
+// time limit without finding a solution. The cube may still be solvable.
+type SearchLimitError struct {
+	Search    string        // What was searched for, e.g. "OLL"; empty for a whole solve
+	MaxDepth  int           // Move limit that was reached, or 0
+	MaxStates int           // State limit that was exceeded, or 0
+	Timeout   time.Duration // Time limit that was reached, or 0
+	Examined  int           // Nodes examined, if the search counts them
+}
+
+func (e *SearchLimitError) Error() string {
+	subject := "solution"
+	if e.Search != "" {
+		subject = e.Search + " solution"
+	}
+
+	var msg string
+	switch {
+	case e.MaxStates > 0:
+		msg = fmt.Sprintf("search for %s exceeded maximum states (%d)", subject, e.MaxStates)
+	case e.Timeout > 0:
+		msg = fmt.Sprintf("no %s found within %v", subject, e.Timeout)
+	default:
+		msg = fmt.Sprintf("no %s found within %d moves", subject, e.MaxDepth)
+	}
+	if e.Examined > 0 {
+		msg += fmt.Sprintf(" (examined %d nodes)", e.Examined)
+	}
+	return msg
+}
+
+// WrongSolutionError reports a solver bug: a solution that, applied to the
+// cube it was found for, doesn't solve it
+type WrongSolutionError struct {
+	Solver   string // Name of the solver
+	Solution []Move // The solution it returned
+}
+
+func (e *WrongSolutionError) Error() string {
+	return fmt.Sprintf("%s solver returned a solution that doesn't solve the cube: %s", e.Solver, FormatMoves(e.Solution))
+}
@@ -0,0 +1,37 @@
+package cube
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseErrorDetails(t *testing.T) {
+	_, err := ParseScramble("R U Q2 F")
+	var parseErr *ParseError
+	if !errors.As(err, &parseErr) {
+		t.Fatalf("ParseScramble error = %v, want *ParseError", err)
+	}
+	if parseErr.Token != "Q2" || parseErr.Position != 3 {
+		t.Errorf("ParseError token/position = %q/%d, want Q2/3", parseErr.Token, parseErr.Position)
+	}
+
+	_, err = ParseMove("Q")
+	if !errors.As(err, &parseErr) || parseErr.Position != 0 {
+		t.Errorf("ParseMove error = %v, want *ParseError without a position", err)
+	}
+}
+
+func TestSolverErrorTypes(t *testing.T) {
+	for _, solver := range []Solver{&BeginnerSolver{}, &CFOPSolver{}, &KociembaSolver{}, &Optimal2x2Solver{}} {
+		_, err := solver.Solve(NewCube(5))
+		var unsolvable *UnsolvableError
+		if !errors.As(err, &unsolvable) {
+			t.Errorf("%s on a 5x5: error = %v, want *UnsolvableError", solver.Name(), err)
+		}
+	}
+
+	err := error(&SearchLimitError{Search: "OLL", MaxDepth: 8})
+	if got, want := err.Error(), "no OLL solution found within 8 moves"; got != want {
+		t.Errorf("SearchLimitError = %q, want %q", got, want)
+	}
+}
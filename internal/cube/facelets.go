@@ -0,0 +1,151 @@
+package cube
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Facelet strings and JSON
+//
+// A facelet string is the format most other cube software reads: one
+// letter per sticker, naming the face whose center has that sticker's
+// color, with the faces in the order U R F D L B and each face read row by
+// row as it appears on the net. A solved 3x3 is "UUUUUUUUURRRRRRRRR...".
+// The JSON form keeps the colors instead: the size, and each face's rows
+// of color letters keyed by face letter.
+
+// faceletOrder is the order faces appear in a facelet string
+var faceletOrder = [6]Face{Up, Right, Front, Down, Left, Back}
+
+// Facelets returns the cube as a facelet string. A face's letter goes to
+// the color of its center, or on even cubes the color it has when solved
+// as the cube is held now. It returns an error if a sticker has a color no
+// face has, such as the Grey wildcard.
+func (c *Cube) Facelets() (string, error) {
+	letters := make(map[Color]string)
+	for _, face := range faceletOrder {
+		color := c.SolvedColor(c.FaceAt(face))
+		if c.Size%2 == 1 {
+			mid := c.Size / 2
+			color = c.Faces[face][mid][mid]
+		}
+		if color == Grey {
+			return "", fmt.Errorf("the %s center is a wildcard, which a facelet string can't hold", face)
+		}
+		if other, taken := letters[color]; taken {
+			return "", fmt.Errorf("the %s and %s centers are both %s", other, face, color)
+		}
+		letters[color] = face.String()
+	}
+
+	var sb strings.Builder
+	for _, face := range faceletOrder {
+		for row := 0; row < c.Size; row++ {
+			for col := 0; col < c.Size; col++ {
+				color := c.Faces[face][row][col]
+				letter, ok := letters[color]
+				if !ok {
+					return "", fmt.Errorf("the %s sticker at row %d col %d is %s, which no face has", face, row+1, col+1, color)
+				}
+				sb.WriteString(letter)
+			}
+		}
+	}
+	return sb.String(), nil
+}
+
+// ParseFacelets builds a cube from a facelet string, coloring each face's
+// stickers with the standard colors. The string's length gives the size:
+// 54 letters for a 3x3, 24 for a 2x2, and so on. Letters name faces, not
+// colors, so a cube whose centers were moved by slices or rotations comes
+// back with its colors relabeled to match its centers.
+func ParseFacelets(s string) (*Cube, error) {
+	s = strings.TrimSpace(s)
+	size := 2
+	for 6*size*size < len(s) {
+		size++
+	}
+	if 6*size*size != len(s) {
+		return nil, fmt.Errorf("facelet string has %d letters; a cube has 6 times a square number, at least 24", len(s))
+	}
+
+	c := NewCube(size)
+	i := 0
+	for _, face := range faceletOrder {
+		for row := 0; row < size; row++ {
+			for col := 0; col < size; col++ {
+				letter, err := ParseFace(s[i : i+1])
+				if err != nil {
+					return nil, fmt.Errorf("facelet %d: %v", i+1, err)
+				}
+				c.Faces[face][row][col] = c.SolvedColor(letter)
+				i++
+			}
+		}
+	}
+	return c, nil
+}
+
+// cubeJSON is the JSON form of a cube
+type cubeJSON struct {
+	Size  int                   `json:"size"`
+	Faces map[string][][]string `json:"faces"` // Rows of color letters by face letter
+}
+
+// MarshalJSON encodes the cube as its size and the color letters of each
+// face, e.g. {"size":3,"faces":{"F":[["B","B","B"],...],...}}
+func (c *Cube) MarshalJSON() ([]byte, error) {
+	data := cubeJSON{Size: c.Size, Faces: make(map[string][][]string)}
+	for face := Front; face <= Down; face++ {
+		rows := make([][]string, c.Size)
+		for row := range rows {
+			rows[row] = make([]string, c.Size)
+			for col, color := range c.Faces[face][row] {
+				rows[row][col] = color.String()
+			}
+		}
+		data.Faces[face.String()] = rows
+	}
+	return json.Marshal(data)
+}
+
+// UnmarshalJSON decodes a cube written by MarshalJSON. Every face must be
+// present with size rows of size color letters.
+func (c *Cube) UnmarshalJSON(b []byte) error {
+	var data cubeJSON
+	if err := json.Unmarshal(b, &data); err != nil {
+		return err
+	}
+	if err := ValidateSize(data.Size); err != nil {
+		return err
+	}
+
+	decoded := NewCube(data.Size)
+	for face := Front; face <= Down; face++ {
+		rows, ok := data.Faces[face.String()]
+		if !ok {
+			return fmt.Errorf("no %s face", face)
+		}
+		if len(rows) != data.Size {
+			return fmt.Errorf("%s face has %d rows, want %d", face, len(rows), data.Size)
+		}
+		for row, letters := range rows {
+			if len(letters) != data.Size {
+				return fmt.Errorf("%s face row %d has %d stickers, want %d", face, row+1, len(letters), data.Size)
+			}
+			for col, letter := range letters {
+				if len(letter) != 1 {
+					return fmt.Errorf("%s face row %d col %d: unknown color %q", face, row+1, col+1, letter)
+				}
+				color, err := ParseColor(rune(letter[0]))
+				if err != nil {
+					return fmt.Errorf("%s face row %d col %d: %v", face, row+1, col+1, err)
+				}
+				decoded.Faces[face][row][col] = color
+			}
+		}
+	}
+	*c = *decoded
+	return nil
+}
@@ -0,0 +1,106 @@
+package cube
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestFaceletsSolved(t *testing.T) {
+	got, err := NewCube(3).Facelets()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := strings.Repeat("U", 9) + strings.Repeat("R", 9) + strings.Repeat("F", 9) +
+		strings.Repeat("D", 9) + strings.Repeat("L", 9) + strings.Repeat("B", 9)
+	if got != want {
+		t.Errorf("Facelets() = %s, want %s", got, want)
+	}
+
+	// After R the U face's right column shows the F color
+	c := NewCube(3)
+	c.ApplyMove(Move{Face: Right, Clockwise: true})
+	got, _ = c.Facelets()
+	if up := got[:9]; up != "UUFUUFUUF" {
+		t.Errorf("U face after R = %s, want UUFUUFUUF", up)
+	}
+}
+
+func TestFormatsRoundTrip(t *testing.T) {
+	tests := []struct {
+		size     int
+		scramble string
+	}{
+		{2, "R U F' R2"},
+		{3, ""},
+		{3, "R U R' U' F2 D L' B"},
+		{4, "Rw U2 F' 2L D"},
+		{5, "Rw U 2F' L2 2D"},
+	}
+
+	for _, tt := range tests {
+		c := NewCube(tt.size)
+		moves, err := ParseScrambleForSize(tt.scramble, tt.size)
+		if err != nil {
+			t.Fatal(err)
+		}
+		c.ApplyMoves(moves)
+
+		facelets, err := c.Facelets()
+		if err != nil {
+			t.Errorf("%dx%d %q: Facelets() error = %v", tt.size, tt.size, tt.scramble, err)
+		} else if parsed, err := ParseFacelets(facelets); err != nil {
+			t.Errorf("%dx%d %q: ParseFacelets() error = %v", tt.size, tt.size, tt.scramble, err)
+		} else if parsed.String() != c.String() {
+			t.Errorf("%dx%d %q: facelets don't round-trip", tt.size, tt.size, tt.scramble)
+		}
+
+		data, err := json.Marshal(c)
+		if err != nil {
+			t.Errorf("%dx%d %q: json.Marshal() error = %v", tt.size, tt.size, tt.scramble, err)
+			continue
+		}
+		var decoded Cube
+		if err := json.Unmarshal(data, &decoded); err != nil {
+			t.Errorf("%dx%d %q: json.Unmarshal() error = %v", tt.size, tt.size, tt.scramble, err)
+		} else if decoded.String() != c.String() {
+			t.Errorf("%dx%d %q: JSON doesn't round-trip", tt.size, tt.size, tt.scramble)
+		}
+	}
+}
+
+func TestParseFaceletsErrors(t *testing.T) {
+	for _, s := range []string{
+		"",
+		"UUU",
+		strings.Repeat("U", 53),
+		strings.Repeat("U", 53) + "X",
+	} {
+		if _, err := ParseFacelets(s); err == nil {
+			t.Errorf("ParseFacelets(%q) succeeded", s)
+		}
+	}
+
+	masked := NewCube(3)
+	masked.Faces[Up][1][1] = Grey
+	if _, err := masked.Facelets(); err == nil {
+		t.Error("Facelets() with a wildcard center succeeded")
+	}
+	masked = NewCube(3)
+	masked.Faces[Up][0][0] = Grey
+	if _, err := masked.Facelets(); err == nil {
+		t.Error("Facelets() with a wildcard sticker succeeded")
+	}
+
+	var c Cube
+	for _, data := range []string{
+		`{"size":1}`,
+		`{"size":2,"faces":{}}`,
+		`{"size":2,"faces":{"F":[["B","B"]],"B":[],"L":[],"R":[],"U":[],"D":[]}}`,
+		`not json`,
+	} {
+		if err := json.Unmarshal([]byte(data), &c); err == nil {
+			t.Errorf("json.Unmarshal(%s) succeeded", data)
+		}
+	}
+}
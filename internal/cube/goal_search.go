@@ -0,0 +1,74 @@
+package cube
+
+import "time"
+
+// Goal search
+//
+// The solvers each build in their own idea of done: solved, a cross, a G1
+// subgroup. SolveToGoal takes the goal as a function instead, so a caller
+// can stop at any partial state, such as a masked CFEN pattern for just
+// the cross or just OLL. It is a plain iterative deepening search over the
+// 18 face turns with no heuristic, so it finds a shortest sequence but is
+// only practical for goals a handful of moves away.
+
+// SolveToGoal returns a shortest sequence of face turns, at most maxDepth
+// long, after which goal reports true for the cube. If the cube already
+// meets the goal the solution is empty. It returns a *SearchLimitError if
+// no sequence within maxDepth reaches the goal. Like Solver.Solve, it
+// never modifies c; goal sees a working copy that it must not modify.
+func SolveToGoal(c *Cube, goal func(*Cube) bool, maxDepth int) (*SolverResult, error) {
+	return SolveToGoalInMetric(c, goal, maxDepth, HTM)
+}
+
+// SolveToGoalInMetric is SolveToGoal with length counted in metric rather
+// than in face turns: it returns a sequence that is shortest in metric,
+// costing at most maxCost. In QTM, say, it prefers R U R' to R2 U2, which
+// SolveToGoal treats as the same length.
+func SolveToGoalInMetric(c *Cube, goal func(*Cube) bool, maxCost int, metric Metric) (*SolverResult, error) {
+	start := time.Now()
+	work := c.Clone()
+	path := make([]Move, 0, maxCost)
+	stats := &searchStats{}
+
+	for cost := 0; cost <= maxCost; cost++ {
+		if solution, found := goalSearch(work, goal, metric, path, cost, stats); found {
+			return &SolverResult{
+				Solution:      append([]Move{}, solution...),
+				Steps:         len(solution),
+				Duration:      time.Since(start),
+				NodesExamined: stats.nodes,
+			}, nil
+		}
+	}
+	return nil, &SearchLimitError{MaxDepth: maxCost, Examined: stats.nodes}
+}
+
+// goalSearch looks for the goal past path at a cost of exactly
+// remainingCost more, applying and undoing moves on c rather than copying
+// it at every node. Anything cheaper was ruled out by an earlier, smaller
+// search, so only the states that use up the whole budget need the goal
+// test; in ATM, moves that cost nothing can still follow them.
+func goalSearch(c *Cube, goal func(*Cube) bool, metric Metric, path []Move, remainingCost int, stats *searchStats) ([]Move, bool) {
+	stats.node()
+	if remainingCost == 0 && goal(c) {
+		return path, true
+	}
+
+	var prev *Move
+	if len(path) > 0 {
+		prev = &path[len(path)-1]
+	}
+	for _, move := range faceTurns {
+		cost := MoveCost(move, prev, metric)
+		if cost > remainingCost || (prev != nil && RedundantAfter(*prev, move)) {
+			continue
+		}
+		c.ApplyMove(move)
+		solution, found := goalSearch(c, goal, metric, append(path, move), remainingCost-cost, stats)
+		c.ApplyInverseMove(move)
+		if found {
+			return solution, true
+		}
+	}
+	return nil, false
+}
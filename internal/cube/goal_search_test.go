@@ -0,0 +1,64 @@
+package cube
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSolveToGoal(t *testing.T) {
+	c := NewCube(3)
+	moves, _ := ParseScramble("R U F")
+	c.ApplyMoves(moves)
+
+	var result *SolverResult
+	var err error
+	AssertUnmodified(t, c, "SolveToGoal", func() { result, err = SolveToGoal(c, (*Cube).IsSolved, 3) })
+	if err != nil {
+		t.Fatalf("SolveToGoal() error = %v", err)
+	}
+	if got := FormatMoves(result.Solution); got != "F' U' R'" {
+		t.Errorf("SolveToGoal() = %s, want F' U' R'", got)
+	}
+
+	var limit *SearchLimitError
+	if _, err := SolveToGoal(c, (*Cube).IsSolved, 2); !errors.As(err, &limit) || limit.MaxDepth != 2 {
+		t.Errorf("SolveToGoal() past its depth error = %v, want a SearchLimitError at 2 moves", err)
+	}
+
+	result, err = SolveToGoal(NewCube(3), (*Cube).IsSolved, 0)
+	if err != nil || len(result.Solution) != 0 {
+		t.Errorf("SolveToGoal() on a solved cube = %v, %v, want an empty solution", result, err)
+	}
+}
+
+func TestSolveToGoalInMetric(t *testing.T) {
+	c := NewCube(3)
+	moves, _ := ParseScramble("R L U")
+	c.ApplyMoves(moves)
+
+	// L' R' is one axial turn, so the solution fits in 2 ATM but not 2 HTM
+	if _, err := SolveToGoal(c, (*Cube).IsSolved, 2); err == nil {
+		t.Error("SolveToGoal() found a 2-move solution to R L U")
+	}
+	result, err := SolveToGoalInMetric(c, (*Cube).IsSolved, 2, ATM)
+	if err != nil {
+		t.Fatalf("SolveToGoalInMetric(ATM) error = %v", err)
+	}
+	if got := CountMetric(result.Solution, ATM); got != 2 {
+		t.Errorf("SolveToGoalInMetric(ATM) = %s, %d ATM, want 2", FormatMoves(result.Solution), got)
+	}
+	c.ApplyMoves(result.Solution)
+	if !c.IsSolved() {
+		t.Errorf("SolveToGoalInMetric(ATM) = %s does not solve R L U", FormatMoves(result.Solution))
+	}
+
+	// In QTM a half turn costs two, so R2 is out of reach at cost 1
+	c = NewCube(3)
+	c.ApplyMove(Move{Face: Right, Double: true})
+	if _, err := SolveToGoalInMetric(c, (*Cube).IsSolved, 1, QTM); err == nil {
+		t.Error("SolveToGoalInMetric(QTM) solved R2 in one quarter turn")
+	}
+	if _, err := SolveToGoalInMetric(c, (*Cube).IsSolved, 2, QTM); err != nil {
+		t.Errorf("SolveToGoalInMetric(QTM) on R2 error = %v", err)
+	}
+}
@@ -0,0 +1,80 @@
+package cube
+
+// Move history
+//
+// A cube can record the moves applied to it so an interactive front end
+// can take them back and replay them. History is off until EnableHistory
+// is called; a cube without it, including every Clone, pays only a nil
+// check per move.
+
+// moveHistory holds the moves applied to a cube and the ones undone since
+type moveHistory struct {
+	done   []Move
+	undone []Move
+}
+
+// record notes a newly applied move, which ends any redo
+func (h *moveHistory) record(move Move) {
+	h.done = append(h.done, move)
+	h.undone = h.undone[:0]
+}
+
+// EnableHistory starts recording every move applied to c, so Undo and
+// Redo can take them back and replay them. It does nothing if history is
+// already on.
+func (c *Cube) EnableHistory() {
+	if c.history == nil {
+		c.history = &moveHistory{}
+	}
+}
+
+// DisableHistory stops recording moves and forgets the history
+func (c *Cube) DisableHistory() {
+	c.history = nil
+}
+
+// History returns the moves applied since history was enabled, less any
+// undone, oldest first
+func (c *Cube) History() []Move {
+	if c.history == nil {
+		return nil
+	}
+	return append([]Move{}, c.history.done...)
+}
+
+// Undo takes back the last recorded move by applying its inverse, and
+// returns that move. It reports false if history is off or there is
+// nothing to undo.
+func (c *Cube) Undo() (Move, bool) {
+	h := c.history
+	if h == nil || len(h.done) == 0 {
+		return Move{}, false
+	}
+
+	move := h.done[len(h.done)-1]
+	c.history = nil
+	c.ApplyInverseMove(move)
+	c.history = h
+
+	h.done = h.done[:len(h.done)-1]
+	h.undone = append(h.undone, move)
+	return move, true
+}
+
+// Redo applies the last undone move again and returns it. It reports false
+// if history is off or nothing has been undone since the last new move.
+func (c *Cube) Redo() (Move, bool) {
+	h := c.history
+	if h == nil || len(h.undone) == 0 {
+		return Move{}, false
+	}
+
+	move := h.undone[len(h.undone)-1]
+	c.history = nil
+	c.ApplyMove(move)
+	c.history = h
+
+	h.undone = h.undone[:len(h.undone)-1]
+	h.done = append(h.done, move)
+	return move, true
+}
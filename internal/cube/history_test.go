@@ -0,0 +1,79 @@
+package cube
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestUndoRedo(t *testing.T) {
+	scramble, err := GenerateScramble(3, ScrambleOptions{Length: 20, Rand: rand.New(rand.NewSource(5))})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := NewCube(3)
+	c.EnableHistory()
+	c.ApplyMoves(scramble)
+	scrambled := c.String()
+
+	if got := FormatMoves(c.History()); got != FormatMoves(scramble) {
+		t.Errorf("History() = %s, want %s", got, FormatMoves(scramble))
+	}
+
+	for i := len(scramble) - 1; i >= 0; i-- {
+		move, ok := c.Undo()
+		if !ok || move != scramble[i] {
+			t.Fatalf("Undo() = %s, %v, want %s", move, ok, scramble[i])
+		}
+	}
+	if !c.IsSolved() {
+		t.Error("cube is not solved after undoing the whole scramble")
+	}
+	if _, ok := c.Undo(); ok {
+		t.Error("Undo() succeeded with nothing to undo")
+	}
+
+	for range scramble {
+		if _, ok := c.Redo(); !ok {
+			t.Fatal("Redo() failed")
+		}
+	}
+	if c.String() != scrambled {
+		t.Error("redoing the whole scramble did not restore the scrambled state")
+	}
+	if _, ok := c.Redo(); ok {
+		t.Error("Redo() succeeded with nothing to redo")
+	}
+
+	// A new move ends the redo
+	c.Undo()
+	c.ApplyMove(Move{Face: Up, Clockwise: true})
+	if _, ok := c.Redo(); ok {
+		t.Error("Redo() succeeded after a new move")
+	}
+	if len(c.History()) != len(scramble) {
+		t.Errorf("History() has %d moves, want %d", len(c.History()), len(scramble))
+	}
+}
+
+func TestHistoryOff(t *testing.T) {
+	c := NewCube(3)
+	c.ApplyMove(Move{Face: Right, Clockwise: true})
+	if _, ok := c.Undo(); ok {
+		t.Error("Undo() succeeded without history")
+	}
+	if c.History() != nil {
+		t.Error("History() is not nil without history")
+	}
+
+	c.EnableHistory()
+	c.ApplyMove(Move{Face: Up, Clockwise: true})
+	if clone := c.Clone(); clone.History() != nil {
+		t.Error("Clone() copied the history")
+	}
+
+	c.DisableHistory()
+	if _, ok := c.Undo(); ok {
+		t.Error("Undo() succeeded after DisableHistory")
+	}
+}
@@ -0,0 +1,159 @@
+package cube
+
+import "sync"
+
+// Inspection
+//
+// Speedcubers use inspection to pick the orientation to solve from, and
+// for CFOP that mostly means the one with the easiest cross. The solvers
+// always build the cross on the D face, so trying the 24 whole-cube
+// rotations tries every cross color in every position.
+//
+// Cross lengths come from a table of the distance of every placement of
+// the four D edges from solved: each edge has 12 positions and 2 flips,
+// and a breadth first search over the 18 face turns fills all 24^4
+// placements (the reachable ones need at most 8 moves).
+
+const (
+	crossSlots = 24 // Position and flip of one edge
+	crossSpace = crossSlots * crossSlots * crossSlots * crossSlots
+)
+
+var (
+	crossTableOnce sync.Once
+	crossMove      [18][crossSlots]int
+	crossDistance  []int8
+)
+
+// CrossLength returns the fewest half-turn metric face turns that solve
+// the cross on c's D face: the four edges matching the D center home and
+// unflipped, measured against the centers. It reports false if c is not a
+// 3x3 or its D edges can't be identified. The distance table (about 330 KB)
+// is built on first use.
+func CrossLength(c *Cube) (int, bool) {
+	if c.Size != 3 {
+		return 0, false
+	}
+
+	perm, orientation := c.EdgePermutation(), c.EdgeOrientation()
+	var slots [4]int
+	found := 0
+	for pos, piece := range perm {
+		if piece >= 4 && piece < 8 {
+			slots[piece-4] = pos*2 + orientation[pos]
+			found++
+		}
+	}
+	if found != 4 {
+		return 0, false
+	}
+
+	crossTableOnce.Do(buildCrossTable)
+	dist := crossDistance[encodeCross(slots)]
+	return int(dist), dist >= 0
+}
+
+// BestCrossOrientation tries the 24 whole-cube rotations of a 3x3 and
+// returns the one (at most two moves, such as "x y'") that leaves the
+// shortest cross on the D face, with that cross's length. Ties go to the
+// rotation with the fewest moves, so a cube whose cross is already as short
+// as any gets no rotation. It reports false if c is not a valid 3x3.
+func BestCrossOrientation(c *Cube) (rotation []Move, crossLength int, ok bool) {
+	crossLength = -1
+	for _, candidate := range orientationRotations {
+		rotated := c.Clone()
+		rotated.ApplyMoves(candidate)
+		length, valid := CrossLength(rotated)
+		if !valid {
+			continue
+		}
+		if crossLength < 0 || length < crossLength || (length == crossLength && len(candidate) < len(rotation)) {
+			rotation, crossLength = candidate, length
+		}
+	}
+	if crossLength < 0 {
+		return nil, 0, false
+	}
+	return append([]Move{}, rotation...), crossLength, true
+}
+
+// encodeCross packs the slots of the four D edges, in piece order, into a
+// table index; the solved cross is crossSolved
+func encodeCross(slots [4]int) int {
+	return ((slots[0]*crossSlots+slots[1])*crossSlots+slots[2])*crossSlots + slots[3]
+}
+
+// crossSolved is the index of the solved cross: DR, DF, DL, and DB home
+// and unflipped
+var crossSolved = encodeCross([4]int{4 * 2, 5 * 2, 6 * 2, 7 * 2})
+
+// buildCrossTable derives each face turn's effect on an edge slot from the
+// move engine and runs the breadth first search from the solved cross
+func buildCrossTable() {
+	for m, move := range faceTurns {
+		c := NewCube(3)
+		c.ApplyMove(move)
+		perm, orientation := c.EdgePermutation(), c.EdgeOrientation()
+
+		// The edge that was at perm[pos] is now at pos, flipped by
+		// orientation[pos]
+		for pos, from := range perm {
+			for flip := 0; flip < 2; flip++ {
+				crossMove[m][from*2+flip] = pos*2 + (flip ^ orientation[pos])
+			}
+		}
+	}
+
+	distance := make([]int8, crossSpace)
+	for i := range distance {
+		distance[i] = -1
+	}
+	distance[crossSolved] = 0
+	frontier := []int{crossSolved}
+	for depth := int8(1); len(frontier) > 0; depth++ {
+		var next []int
+		for _, index := range frontier {
+			var slots [4]int
+			for i, rest := 3, index; i >= 0; i-- {
+				slots[i] = rest % crossSlots
+				rest /= crossSlots
+			}
+			for m := range faceTurns {
+				var moved [4]int
+				for i, slot := range slots {
+					moved[i] = crossMove[m][slot]
+				}
+				if j := encodeCross(moved); distance[j] < 0 {
+					distance[j] = depth
+					next = append(next, j)
+				}
+			}
+		}
+		frontier = next
+	}
+
+	crossDistance = distance
+}
+
+// recolorToStandard repaints c in the standard color scheme as it sits, so
+// each center gets its face's standard color and every other sticker the
+// color of the center it matches. A rotated cube then looks unrotated to
+// solvers that compare against a solved cube, and their solutions still
+// apply to the original.
+func recolorToStandard(c *Cube) {
+	colorFace := make(map[Color]Face)
+	center := c.Size / 2
+	for face := Front; face <= Down; face++ {
+		colorFace[c.Faces[face][center][center]] = face
+	}
+	for face := range c.Faces {
+		for row := range c.Faces[face] {
+			for col, color := range c.Faces[face][row] {
+				if home, ok := colorFace[color]; ok {
+					c.Faces[face][row][col] = standardColors[home]
+				}
+			}
+		}
+	}
+	c.colors = nil
+}
@@ -0,0 +1,102 @@
+package cube
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// bruteForceCrossLength searches every face turn sequence up to maxDepth
+// for the shortest one that solves the cross, or returns -1
+func bruteForceCrossLength(c *Cube, maxDepth int) int {
+	frontier := []*Cube{c}
+	for depth := 0; depth <= maxDepth; depth++ {
+		var next []*Cube
+		for _, state := range frontier {
+			if (WhiteCrossPattern{}).Matches(state) {
+				return depth
+			}
+			for _, move := range faceTurns {
+				neighbor := state.Clone()
+				neighbor.ApplyMove(move)
+				next = append(next, neighbor)
+			}
+		}
+		frontier = next
+	}
+	return -1
+}
+
+func TestCrossLength(t *testing.T) {
+	rng := rand.New(rand.NewSource(2388))
+	for i := 0; i < 15; i++ {
+		scramble, _ := GenerateScramble(3, ScrambleOptions{Length: 3, Rand: rng})
+		c := NewCube(3)
+		c.ApplyMoves(scramble)
+
+		got, ok := CrossLength(c)
+		if !ok {
+			t.Fatalf("%s: CrossLength() failed", FormatMoves(scramble))
+		}
+		if want := bruteForceCrossLength(c, 3); got != want {
+			t.Errorf("%s: CrossLength() = %d, want %d", FormatMoves(scramble), got, want)
+		}
+	}
+
+	if _, ok := CrossLength(NewCube(4)); ok {
+		t.Error("CrossLength(4x4) should fail")
+	}
+}
+
+func TestBestCrossOrientation(t *testing.T) {
+	rotation, length, ok := BestCrossOrientation(NewCube(3))
+	if !ok || len(rotation) != 0 || length != 0 {
+		t.Errorf("BestCrossOrientation(solved) = %q, %d, %v", FormatMoves(rotation), length, ok)
+	}
+
+	// R leaves the L face's cross solved; holding it on D needs a z'
+	c := NewCube(3)
+	c.ApplyMove(Move{Face: Right, Clockwise: true})
+	rotation, length, _ = BestCrossOrientation(c)
+	if FormatMoves(rotation) != "z'" || length != 0 {
+		t.Errorf("BestCrossOrientation(R) = %q, %d, want z' and 0", FormatMoves(rotation), length)
+	}
+
+	rng := rand.New(rand.NewSource(2388))
+	for i := 0; i < 10; i++ {
+		scramble, _ := GenerateScramble(3, ScrambleOptions{Rand: rng})
+		c := NewCube(3)
+		c.ApplyMoves(scramble)
+
+		rotation, length, ok := BestCrossOrientation(c)
+		unrotated, _ := CrossLength(c)
+		if !ok || length > unrotated {
+			t.Errorf("%s: best cross %d is longer than the unrotated %d", FormatMoves(scramble), length, unrotated)
+		}
+		c.ApplyMoves(rotation)
+		if got, _ := CrossLength(c); got != length {
+			t.Errorf("%s: after %s the cross takes %d moves, want %d", FormatMoves(scramble), FormatMoves(rotation), got, length)
+		}
+	}
+}
+
+func TestCFOPSolverInspect(t *testing.T) {
+	c := NewCube(3)
+	c.ApplyMove(Move{Face: Right, Clockwise: true})
+
+	result, err := (&CFOPSolver{Inspect: true}).Solve(c)
+	if err != nil {
+		t.Fatalf("Solve() error = %v", err)
+	}
+	if got := FormatMoves(result.Solution); got != "z' U'" {
+		t.Errorf("solution = %s, want z' U'", got)
+	}
+	c.ApplyMoves(result.Solution)
+	if !c.IsSolved() {
+		t.Error("inspected solution doesn't solve the cube")
+	}
+
+	result, err = (&CFOPSolver{Inspect: true}).SolveThrough(NewCube(3), StageCross)
+	if err != nil || len(result.Solution) != 0 {
+		t.Errorf("SolveThrough(solved, cross) = %v, %v", result, err)
+	}
+}
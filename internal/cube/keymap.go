@@ -0,0 +1,93 @@
+package cube
+
+import (
+	"fmt"
+	"unicode/utf8"
+)
+
+// Keymaps
+//
+// Virtual cube programs let a solver turn the cube from the keyboard, one
+// key per move, with the index and middle fingers of each hand on the
+// home row turning R, U, F, and their inverses much as the fingers would
+// on a real cube. defaultKeymapNotation is the layout csTimer and most
+// other virtual cubes use.
+
+// defaultKeymapNotation is the default keymap, with moves in notation
+var defaultKeymapNotation = map[rune]string{
+	'i': "R", 'k': "R'",
+	'j': "U", 'f': "U'",
+	'h': "F", 'g': "F'",
+	'd': "L", 'e': "L'",
+	's': "D", 'l': "D'",
+	'w': "B", 'o': "B'",
+	'u': "r", 'm': "r'",
+	'v': "l", 'r': "l'",
+	't': "x", 'y': "x", 'b': "x'", 'n': "x'",
+	';': "y", 'a': "y'",
+	'p': "z", 'q': "z'",
+	'5': "M", '6': "M", 'x': "M'", '.': "M'",
+}
+
+// DefaultKeymap returns the standard virtual cube keymap: j and f turn U
+// and U', i and k turn R and R', and so on. The map is a fresh copy the
+// caller may change.
+func DefaultKeymap() map[rune]Move {
+	keymap := make(map[rune]Move, len(defaultKeymapNotation))
+	for key, notation := range defaultKeymapNotation {
+		move, err := ParseMove(notation)
+		if err != nil {
+			panic(fmt.Sprintf("default keymap: %v", err))
+		}
+		keymap[key] = move
+	}
+	return keymap
+}
+
+// ParseKeymap builds a keymap from single-character keys and the moves
+// they turn in notation, such as {"j": "U", "f": "U'"}
+func ParseKeymap(notation map[string]string) (map[rune]Move, error) {
+	keymap := make(map[rune]Move, len(notation))
+	for key, moveNotation := range notation {
+		r, size := utf8.DecodeRuneInString(key)
+		if size == 0 || size != len(key) {
+			return nil, fmt.Errorf("keymap key %q must be a single character", key)
+		}
+		move, err := ParseMove(moveNotation)
+		if err != nil {
+			return nil, fmt.Errorf("keymap key %q: %w", key, err)
+		}
+		keymap[r] = move
+	}
+	return keymap, nil
+}
+
+// FormatKeymap returns a keymap as single-character keys and the moves
+// they turn in notation, the form ParseKeymap reads
+func FormatKeymap(keymap map[rune]Move) map[string]string {
+	notation := make(map[string]string, len(keymap))
+	for key, move := range keymap {
+		notation[string(key)] = move.String()
+	}
+	return notation
+}
+
+// MovesFromKeystrokes turns typed keys into the moves keymap gives them,
+// in order. Spaces are ignored; any other key without a move returns a
+// *ParseError naming it.
+func MovesFromKeystrokes(keys string, keymap map[rune]Move) ([]Move, error) {
+	var moves []Move
+	position := 0
+	for _, key := range keys {
+		position++
+		if key == ' ' {
+			continue
+		}
+		move, ok := keymap[key]
+		if !ok {
+			return nil, &ParseError{Token: string(key), Position: position, Reason: "no move for this key"}
+		}
+		moves = append(moves, move)
+	}
+	return moves, nil
+}
@@ -0,0 +1,61 @@
+package cube
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestMovesFromKeystrokes(t *testing.T) {
+	keymap := DefaultKeymap()
+
+	// Sexy move, then its inverse
+	moves, err := MovesFromKeystrokes("ijkf jifk", keymap)
+	if err != nil {
+		t.Fatalf("MovesFromKeystrokes() error = %v", err)
+	}
+	if got := FormatMoves(moves); got != "R U R' U' U R U' R'" {
+		t.Errorf("MovesFromKeystrokes() = %s", got)
+	}
+	c := NewCube(3)
+	c.ApplyMoves(moves)
+	if !c.IsSolved() {
+		t.Error("sexy move and its inverse should cancel")
+	}
+
+	// Every default key turns the move csTimer gives it
+	for keys, want := range map[string]string{"hg": "F F'", "de": "L L'", "sl": "D D'", "wo": "B B'",
+		"um": "Rw Rw'", "vr": "Lw Lw'", "tb": "x x'", ";a": "y y'", "pq": "z z'", "5x": "M M'"} {
+		moves, err := MovesFromKeystrokes(keys, keymap)
+		if err != nil || FormatMoves(moves) != want {
+			t.Errorf("MovesFromKeystrokes(%q) = %s, %v, want %s", keys, FormatMoves(moves), err, want)
+		}
+	}
+
+	_, err = MovesFromKeystrokes("ij!", keymap)
+	var parseErr *ParseError
+	if !errors.As(err, &parseErr) || parseErr.Token != "!" || parseErr.Position != 3 {
+		t.Errorf("MovesFromKeystrokes(ij!) error = %v, want a ParseError for ! at 3", err)
+	}
+}
+
+func TestParseKeymap(t *testing.T) {
+	keymap, err := ParseKeymap(map[string]string{"a": "R2", "b": "Uw'"})
+	if err != nil {
+		t.Fatalf("ParseKeymap() error = %v", err)
+	}
+	moves, _ := MovesFromKeystrokes("ab", keymap)
+	if got := FormatMoves(moves); got != "R2 Uw'" {
+		t.Errorf("moves = %s, want R2 Uw'", got)
+	}
+
+	round, err := ParseKeymap(FormatKeymap(DefaultKeymap()))
+	if err != nil || len(round) != len(DefaultKeymap()) {
+		t.Errorf("ParseKeymap(FormatKeymap(default)) = %d keys, %v", len(round), err)
+	}
+
+	for _, bad := range []map[string]string{{"ab": "R"}, {"": "R"}, {"a": "Q"}} {
+		if _, err := ParseKeymap(bad); err == nil {
+			t.Errorf("ParseKeymap(%v) should fail", bad)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package cube
+
+import "fmt"
+
+// Last layer algorithms
+//
+// An algorithm's category says what it is meant to solve, not what it
+// does. A last layer trainer needs algorithms that really leave the first
+// two layers alone, so these functions check each algorithm's effect on a
+// solved 3x3 instead of trusting the category, and list the OLL and PLL
+// entries that fail the check, which are almost always mistyped moves.
+
+// lastLayerCategories are the categories whose algorithms should only
+// change the last layer of a 3x3
+var lastLayerCategories = map[string]bool{
+	"OLL":      true,
+	"PLL":      true,
+	"CFOP-OLL": true,
+	"CFOP-PLL": true,
+}
+
+// AffectsOnlyLastLayer reports whether the algorithm, applied to a solved
+// 3x3, leaves the first two layers solved. Layers are judged against the
+// centers, so rotations and balanced wide moves are fine. It returns false
+// if the moves don't parse or don't apply to a 3x3.
+func (a *Algorithm) AffectsOnlyLastLayer() bool {
+	moves, err := ParseScrambleForSize(a.Moves, 3)
+	if err != nil {
+		return false
+	}
+	for _, move := range moves {
+		if move.Layer > 0 {
+			return false
+		}
+	}
+	c := NewCube(3)
+	c.ApplyMoves(moves)
+	return isF2LSolved(c)
+}
+
+// LastLayerAlgorithms returns every algorithm in the database, whatever
+// its category, that only changes the last layer (see
+// AffectsOnlyLastLayer), in database order
+func LastLayerAlgorithms() []Algorithm {
+	var algs []Algorithm
+	for _, alg := range GetAllAlgorithms() {
+		if alg.AffectsOnlyLastLayer() {
+			algs = append(algs, alg)
+		}
+	}
+	return algs
+}
+
+// LastLayerMismatches returns one error for each OLL or PLL algorithm in
+// the database that disturbs the first two layers, or nil if there are
+// none
+func LastLayerMismatches() []error {
+	var problems []error
+	for _, alg := range GetAllAlgorithms() {
+		if lastLayerCategories[alg.Category] && !alg.AffectsOnlyLastLayer() {
+			problems = append(problems, fmt.Errorf("%s: %s algorithm %q disturbs the first two layers",
+				describeAlgorithm(alg), alg.Category, alg.Moves))
+		}
+	}
+	return problems
+}
@@ -0,0 +1,62 @@
+package cube
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestAffectsOnlyLastLayer(t *testing.T) {
+	tests := []struct {
+		moves string
+		want  bool
+	}{
+		{"R U R' U R U2 R'", true},                        // Sune
+		{"M2 U M2 U2 M2 U M2", true},                      // H-perm
+		{"y2 R U R' U' R' F R2 U' R' U' R U R' F'", true}, // T-perm from the back
+		{"r U R' U' r' F R F'", true},                     // OLL 33
+		{"R U R' U'", false},
+		{"R U R' U' Rw'", false}, // Centers left turned
+		{"2R2 U2 2R2", false},    // Inner layers don't apply to a 3x3
+		{"R Q", false},
+	}
+
+	for _, tt := range tests {
+		alg := Algorithm{Moves: tt.moves}
+		if got := alg.AffectsOnlyLastLayer(); got != tt.want {
+			t.Errorf("AffectsOnlyLastLayer(%q) = %v, want %v", tt.moves, got, tt.want)
+		}
+	}
+}
+
+func TestLastLayerAlgorithms(t *testing.T) {
+	algs := LastLayerAlgorithms()
+	if len(algs) == 0 {
+		t.Fatal("LastLayerAlgorithms() returned nothing")
+	}
+	categories := make(map[string]bool)
+	for _, alg := range algs {
+		categories[alg.Category] = true
+		if !alg.AffectsOnlyLastLayer() {
+			t.Errorf("%s (%s) disturbs the first two layers", alg.Name, alg.Moves)
+		}
+	}
+	for _, category := range []string{"CFOP-OLL", "CFOP-PLL"} {
+		if !categories[category] {
+			t.Errorf("no %s algorithms among the last layer algorithms", category)
+		}
+	}
+}
+
+// TestLastLayerMismatches checks the report only flags OLL and PLL
+// entries; which entries it flags depends on the state of the database
+func TestLastLayerMismatches(t *testing.T) {
+	for _, err := range LastLayerMismatches() {
+		msg := err.Error()
+		if !strings.Contains(msg, "OLL algorithm") && !strings.Contains(msg, "PLL algorithm") {
+			t.Errorf("mismatch for a non last layer category: %v", err)
+		}
+		if strings.Contains(msg, "(OLL-27)") {
+			t.Errorf("Sune flagged as disturbing the first two layers: %v", err)
+		}
+	}
+}
@@ -0,0 +1,231 @@
+package cube
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Metric selects how moves are counted
+type Metric int
+
+const (
+	// HTM (half-turn metric) counts any turn of an outer block as one move,
+	// half turns included. Slice and inner-layer turns count two, since
+	// they equal two outer turns and a rotation.
+	HTM Metric = iota
+
+	// QTM (quarter-turn metric) counts like HTM but half turns count two
+	QTM
+
+	// STM (slice-turn metric) counts every layer turn as one move
+	STM
+
+	// ATM (axial-turn metric) counts a run of consecutive turns about the
+	// same axis as one move, so R L' and M2 each count one
+	ATM
+
+	// ETM (execution-turn metric) counts every move as written, rotations
+	// included
+	ETM
+)
+
+// metricNames lists the metric names ParseMetric accepts, in Metric order
+var metricNames = []string{"htm", "qtm", "stm", "atm", "etm"}
+
+// metricAliases are other names for a metric. FMC calls HTM the face-turn
+// metric (FTM): rotations free, wide turns counted as face turns.
+var metricAliases = map[string]Metric{"ftm": HTM}
+
+// Metrics returns every metric, in the order ParseMetric lists them
+func Metrics() []Metric {
+	metrics := make([]Metric, len(metricNames))
+	for i := range metrics {
+		metrics[i] = Metric(i)
+	}
+	return metrics
+}
+
+// String returns the metric's upper-case abbreviation
+func (m Metric) String() string {
+	if m < 0 || int(m) >= len(metricNames) {
+		return fmt.Sprintf("Metric(%d)", int(m))
+	}
+	return strings.ToUpper(metricNames[m])
+}
+
+// ParseMetric converts a metric name such as "htm" or "QTM" to a Metric
+func ParseMetric(name string) (Metric, error) {
+	for i, metricName := range metricNames {
+		if strings.EqualFold(name, metricName) {
+			return Metric(i), nil
+		}
+	}
+	if metric, ok := metricAliases[strings.ToLower(name)]; ok {
+		return metric, nil
+	}
+	return HTM, fmt.Errorf("unknown metric: %s (available: %s)", name, strings.Join(metricNames, ", "))
+}
+
+// CountMetric counts moves in the given metric. Rotations are free in
+// every metric but ETM; wide turns count like outer face turns.
+func CountMetric(moves []Move, metric Metric) int {
+	count := 0
+	for i, move := range moves {
+		var prev *Move
+		if i > 0 {
+			prev = &moves[i-1]
+		}
+		count += MoveCost(move, prev, metric)
+	}
+	return count
+}
+
+// MoveCost is how much move adds to a sequence's count in metric when it
+// follows prev, or starts the sequence if prev is nil. Only ATM looks at
+// prev: a turn about the same axis as the one before it is free. Summing
+// MoveCost over a sequence gives CountMetric, so a search can keep a
+// running cost instead of recounting its whole path.
+func MoveCost(move Move, prev *Move, metric Metric) int {
+	if move.Rotation != NoRotation {
+		if metric == ETM {
+			return 1
+		}
+		return 0
+	}
+
+	switch metric {
+	case ATM:
+		// An axial run doesn't continue past a rotation
+		if prev != nil && prev.Rotation == NoRotation && moveAxis(*prev) == moveAxis(move) {
+			return 0
+		}
+		return 1
+	case ETM:
+		return 1
+	}
+
+	turns := 1
+	if move.Double && metric == QTM {
+		turns = 2
+	}
+	if IsSliceTurn(move) && metric != STM {
+		turns *= 2
+	}
+	return turns
+}
+
+// SetTargetMetric sets the metric that any of this package's search-based
+// solvers find shortest solutions in, and reports whether solver is one
+func SetTargetMetric(solver Solver, metric Metric) bool {
+	switch s := solver.(type) {
+	case *KociembaSolver:
+		s.TargetMetric = metric
+	case *Optimal2x2Solver:
+		s.TargetMetric = metric
+	default:
+		return false
+	}
+	return true
+}
+
+// IsSliceTurn reports whether move turns an inner layer without the outer
+// face next to it: M, E, S, or a numbered layer turn such as 2R. Wide turns
+// include their outer face and are not slice turns.
+func IsSliceTurn(move Move) bool {
+	if move.Rotation != NoRotation {
+		return false
+	}
+	return move.Slice != NoSlice || (!move.Wide && move.Layer > 0)
+}
+
+// CountRegrips estimates how many times the hands must regrip to execute
+// moves, for comparing how comfortable algorithms of the same length are.
+// It is a heuristic with three rules, each adding one regrip:
+//
+//   - every rotation (x, y, z), since the whole cube changes hands
+//   - every switch between right-hand turns (R, Rw, r, 2R) and left-hand
+//     turns (L, Lw, l, 2L); the first side turn sets the hand for free,
+//     and a rotation resets it
+//   - every run of B, D, E, or S turns, which are hard to reach from the
+//     home grip; consecutive turns of the same layer are one run
+//
+// U, F, and M turns are finger tricks from the home grip and never count.
+func CountRegrips(moves []Move) int {
+	const (
+		noHand = iota
+		rightHand
+		leftHand
+	)
+
+	regrips := 0
+	hand := noHand
+	inRun := false // whether the previous move was an awkward turn
+	var run Move   // the previous awkward turn
+	for _, move := range moves {
+		if move.Rotation != NoRotation {
+			regrips++
+			hand = noHand
+			inRun = false
+			continue
+		}
+
+		awkward := move.Slice == E_Slice || move.Slice == S_Slice ||
+			(move.Slice == NoSlice && (move.Face == Back || move.Face == Down))
+		if awkward {
+			if !inRun || run.Face != move.Face || run.Slice != move.Slice {
+				regrips++
+			}
+			inRun, run = true, move
+			continue
+		}
+		inRun = false
+
+		if move.Slice != NoSlice {
+			continue
+		}
+		switch move.Face {
+		case Right:
+			if hand == leftHand {
+				regrips++
+			}
+			hand = rightHand
+		case Left:
+			if hand == rightHand {
+				regrips++
+			}
+			hand = leftHand
+		}
+	}
+	return regrips
+}
+
+// MoveGroup returns the faces moves turn, in order of first use, so
+// "R U R' F" gives R, U, F. Wide and numbered layer turns count as their
+// face; slice turns and rotations turn no face and are left out.
+func MoveGroup(moves []Move) []Face {
+	var faces []Face
+	seen := make(map[Face]bool)
+	for _, move := range moves {
+		if move.Slice != NoSlice || move.Rotation != NoRotation || seen[move.Face] {
+			continue
+		}
+		seen[move.Face] = true
+		faces = append(faces, move.Face)
+	}
+	return faces
+}
+
+// IsTwoGen reports whether moves are all R and U turns of the outer
+// layers, the <R,U> group that is fastest to execute with no regrips.
+// Wide turns, slices, and rotations fall outside it.
+func IsTwoGen(moves []Move) bool {
+	for _, move := range moves {
+		if move.Slice != NoSlice || move.Rotation != NoRotation || move.Wide || move.Layer > 0 {
+			return false
+		}
+		if move.Face != Right && move.Face != Up {
+			return false
+		}
+	}
+	return true
+}
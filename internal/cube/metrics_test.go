@@ -0,0 +1,130 @@
+package cube
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCountMetric(t *testing.T) {
+	tests := []struct {
+		moves                   string
+		htm, qtm, stm, atm, etm int
+	}{
+		{"", 0, 0, 0, 0, 0},
+		{"R U R' U'", 4, 4, 4, 4, 4},
+		{"R2 U2", 2, 4, 2, 2, 2},
+		{"M2 U M2 U2 M2 U M2", 11, 20, 7, 7, 7}, // H-Perm
+		{"x R U y'", 2, 2, 2, 2, 4},
+		{"Rw U Rw'", 3, 3, 3, 3, 3},
+		{"2R U", 3, 3, 2, 2, 2},
+		{"r U R' U' r' F R F'", 8, 8, 8, 8, 8}, // Wide OLL from the database
+		{"R L' U2 R' L", 5, 6, 5, 3, 5},
+		{"R M' R'", 4, 4, 3, 1, 3},
+		{"R x R", 2, 2, 2, 2, 3},
+		{"E S' Uw2", 5, 6, 3, 3, 3},
+	}
+
+	for _, tt := range tests {
+		moves, err := ParseScramble(tt.moves)
+		if err != nil {
+			t.Fatalf("ParseScramble(%q) error = %v", tt.moves, err)
+		}
+		for metric, want := range map[Metric]int{HTM: tt.htm, QTM: tt.qtm, STM: tt.stm, ATM: tt.atm, ETM: tt.etm} {
+			if got := CountMetric(moves, metric); got != want {
+				t.Errorf("CountMetric(%q, %s) = %d, want %d", tt.moves, metric, got, want)
+			}
+		}
+	}
+}
+
+func TestParseMetric(t *testing.T) {
+	for _, metric := range Metrics() {
+		if got, err := ParseMetric(metric.String()); err != nil || got != metric {
+			t.Errorf("ParseMetric(%q) = %v, %v", metric.String(), got, err)
+		}
+	}
+	if got, err := ParseMetric("FTM"); err != nil || got != HTM {
+		t.Errorf("ParseMetric(\"FTM\") = %v, %v, want HTM", got, err)
+	}
+	if _, err := ParseMetric("xtm"); err == nil {
+		t.Error("ParseMetric(\"xtm\") should fail")
+	}
+}
+
+func TestIsSliceTurn(t *testing.T) {
+	tests := map[string]bool{
+		"M": true, "E2": true, "S'": true, "2R": true, "3Lw": false,
+		"R": false, "Rw": false, "r": false, "x": false,
+	}
+	for notation, want := range tests {
+		move, err := ParseMove(notation)
+		if err != nil {
+			t.Fatalf("ParseMove(%q) error = %v", notation, err)
+		}
+		if got := IsSliceTurn(move); got != want {
+			t.Errorf("IsSliceTurn(%s) = %v, want %v", notation, got, want)
+		}
+	}
+}
+
+func TestCountRegrips(t *testing.T) {
+	tests := []struct {
+		moves string
+		want  int
+	}{
+		{"R U R' U'", 0},
+		{"M2 U M2 U2 M2 U M2", 0},
+		{"R U R' U' L' U' L U", 1},      // right hand to left
+		{"R U L' U R' U' L", 3},         // back and forth
+		{"y R U R'", 1},                 // rotation
+		{"R U R' y L' U' L", 1},         // the rotation resets the hand
+		{"R U2 R' D R U2 R' D'", 2},     // two separate D runs
+		{"R2 D R' U2 R D' R' U2 R'", 2}, // D, then D' after an R
+		{"B2 B' R", 1},                  // one B run
+		{"R B' R F2 R' B R F2 R2", 2},   // A-perm style B turns
+		{"", 0},
+	}
+	for _, tt := range tests {
+		moves, err := ParseMoves(tt.moves)
+		if err != nil {
+			t.Fatalf("ParseMoves(%q) error = %v", tt.moves, err)
+		}
+		if got := CountRegrips(moves); got != tt.want {
+			t.Errorf("CountRegrips(%q) = %d, want %d", tt.moves, got, tt.want)
+		}
+	}
+}
+
+func TestMoveGroup(t *testing.T) {
+	tests := []struct {
+		moves  string
+		group  string
+		twoGen bool
+	}{
+		{"R U R' U R U2 R'", "R U", true},
+		{"U R U' R'", "U R", true},
+		{"R U R' F' R U R' U' R' F R2 U' R'", "R U F", false},
+		{"r U R' U'", "R U", false},
+		{"2R U2", "R U", false},
+		{"M2 U M2 U2 M2 U M2", "U", false},
+		{"y R U R'", "R U", false},
+		{"", "", true},
+	}
+
+	for _, tt := range tests {
+		moves, err := ParseScramble(tt.moves)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var faces []string
+		for _, face := range MoveGroup(moves) {
+			faces = append(faces, face.String())
+		}
+		if got := strings.Join(faces, " "); got != tt.group {
+			t.Errorf("MoveGroup(%q) = %s, want %s", tt.moves, got, tt.group)
+		}
+		if got := IsTwoGen(moves); got != tt.twoGen {
+			t.Errorf("IsTwoGen(%q) = %v, want %v", tt.moves, got, tt.twoGen)
+		}
+	}
+}
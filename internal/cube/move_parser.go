@@ -7,11 +7,19 @@ import (
 )
 
 // ParseMove parses a move from advanced notation
-// Supports: R, U', F2, 2R, Rw, 2Fw, M, E', S2, x, y', z2
+// Supports: R, U', F2, 2R, Rw, 2Fw, M, E', S2, x, y', z2, and the SiGN
+// wide turns r and 3r
+//
+// A move ends in at most one 2 and at most one ', in either order. Half
+// turns are the same in both directions, so R2' and R'2, which some
+// algorithm sheets write, both parse as R2. Repeated modifiers, as in R''
+// or R22, are errors.
+// Errors are of type *ParseError.
 func ParseMove(notation string) (Move, error) {
 	notation = strings.TrimSpace(notation)
+	token := notation
 	if len(notation) == 0 {
-		return Move{}, fmt.Errorf("empty move notation")
+		return Move{}, &ParseError{Reason: "empty move notation"}
 	}
 
 	move := Move{Clockwise: true} // Default to clockwise
@@ -20,28 +28,41 @@ func ParseMove(notation string) (Move, error) {
 	for len(notation) > 0 {
 		lastChar := notation[len(notation)-1]
 		if lastChar == '\'' {
+			if !move.Clockwise {
+				return Move{}, &ParseError{Token: token, Reason: "repeated ' modifier"}
+			}
 			move.Clockwise = false
-			notation = notation[:len(notation)-1]
 		} else if lastChar == '2' {
+			if move.Double {
+				return Move{}, &ParseError{Token: token, Reason: "repeated 2 modifier"}
+			}
 			move.Double = true
-			notation = notation[:len(notation)-1]
 		} else {
 			break
 		}
+		notation = notation[:len(notation)-1]
+	}
+	if move.Double {
+		move.Clockwise = true // R2' is R2
 	}
 
 	if len(notation) == 0 {
-		return Move{}, fmt.Errorf("invalid move notation")
+		return Move{}, &ParseError{Token: token, Reason: "invalid move notation"}
 	}
 
-	// Check for wide moves (w suffix)
+	// Check for wide moves (w suffix, or a lowercase face as in r U R' or 3r)
 	if strings.HasSuffix(notation, "w") {
 		move.Wide = true
 		notation = notation[:len(notation)-1]
+	} else if last := notation[len(notation)-1]; strings.IndexByte("rludfb", last) >= 0 &&
+		strings.Trim(notation[:len(notation)-1], "0123456789") == "" {
+		move.Wide = true
+		notation = strings.ToUpper(notation)
 	}
 
 	// Check for numbered moves (starts with digit)
-	if len(notation) > 0 && notation[0] >= '0' && notation[0] <= '9' {
+	numbered := len(notation) > 0 && notation[0] >= '0' && notation[0] <= '9'
+	if numbered {
 		// Extract number
 		numStr := ""
 		i := 0
@@ -52,7 +73,7 @@ func ParseMove(notation string) (Move, error) {
 		if len(numStr) > 0 {
 			num, err := strconv.Atoi(numStr)
 			if err != nil {
-				return Move{}, fmt.Errorf("invalid number in move: %s", numStr)
+				return Move{}, &ParseError{Token: token, Reason: "invalid number in move: " + numStr}
 			}
 			if move.Wide {
 				move.WideDepth = num
@@ -64,19 +85,12 @@ func ParseMove(notation string) (Move, error) {
 	}
 
 	// Parse the face/slice/rotation
+	if face, err := ParseFace(notation); err == nil {
+		move.Face = face
+		return move, nil
+	}
+
 	switch notation {
-	case "R":
-		move.Face = Right
-	case "L":
-		move.Face = Left
-	case "U":
-		move.Face = Up
-	case "D":
-		move.Face = Down
-	case "F":
-		move.Face = Front
-	case "B":
-		move.Face = Back
 	case "M":
 		move.Slice = M_Slice
 	case "E":
@@ -90,13 +104,23 @@ func ParseMove(notation string) (Move, error) {
 	case "z":
 		move.Rotation = Z_Rotation
 	default:
-		return Move{}, fmt.Errorf("unknown move notation: %s", notation)
+		return Move{}, &ParseError{Token: token, Reason: "unknown move notation: " + notation}
+	}
+
+	// Slices and rotations already have a fixed depth; notations like Mw
+	// or 2x mean different things in different sources
+	if move.Wide {
+		return Move{}, &ParseError{Token: token, Reason: "unsupported move: " + notation + " can't be a wide move"}
+	}
+	if numbered {
+		return Move{}, &ParseError{Token: token, Reason: "unsupported move: " + notation + " can't take a layer number"}
 	}
 
 	return move, nil
 }
 
-// ParseMoves parses a sequence of moves from a string
+// ParseMoves parses a sequence of moves from a string. A bad move is
+// reported as a *ParseError with its position in the sequence.
 func ParseMoves(sequence string) ([]Move, error) {
 	sequence = strings.TrimSpace(sequence)
 	if len(sequence) == 0 {
@@ -107,10 +131,11 @@ func ParseMoves(sequence string) ([]Move, error) {
 	parts := strings.Fields(sequence)
 	moves := make([]Move, 0, len(parts))
 
-	for _, part := range parts {
+	for i, part := range parts {
 		move, err := ParseMove(part)
 		if err != nil {
-			return nil, fmt.Errorf("error parsing move '%s': %v", part, err)
+			parseErr := err.(*ParseError)
+			return nil, &ParseError{Token: part, Position: i + 1, Reason: parseErr.Reason}
 		}
 		moves = append(moves, move)
 	}
@@ -118,11 +143,84 @@ func ParseMoves(sequence string) ([]Move, error) {
 	return moves, nil
 }
 
+// ParseMovesSkipUnknown parses a sequence like ParseMoves, but drops the
+// moves that don't parse instead of failing. Each dropped move is returned
+// as a *ParseError with its position in the original sequence, so callers
+// can warn about it.
+func ParseMovesSkipUnknown(sequence string) (moves []Move, skipped []*ParseError) {
+	moves = []Move{}
+	for i, part := range strings.Fields(sequence) {
+		move, err := ParseMove(part)
+		if err != nil {
+			parseErr := err.(*ParseError)
+			skipped = append(skipped, &ParseError{Token: part, Position: i + 1, Reason: parseErr.Reason})
+			continue
+		}
+		moves = append(moves, move)
+	}
+	return moves, skipped
+}
+
 // ParseScramble is an alias for ParseMoves for backward compatibility
 func ParseScramble(sequence string) ([]Move, error) {
 	return ParseMoves(sequence)
 }
 
+// ParseScrambleForSize parses a sequence like ParseScramble, then rejects
+// moves an N x N cube can't make: numbered layers past the cube's inner
+// layers (3R on a 3x3), wide turns as deep as the whole cube (Rw on a
+// 2x2), and slice turns on even cubes, which have no middle layer. The
+// error is a *ParseError naming the move and its position.
+func ParseScrambleForSize(sequence string, size int) ([]Move, error) {
+	moves, err := ParseMoves(sequence)
+	if err != nil {
+		return nil, err
+	}
+
+	tokens := strings.Fields(sequence)
+	for i, move := range moves {
+		if reason := unsupportedOnSize(move, size); reason != "" {
+			return nil, &ParseError{Token: tokens[i], Position: i + 1, Reason: reason}
+		}
+	}
+	return moves, nil
+}
+
+// unsupportedOnSize returns why move can't be made on a size x size cube,
+// or "" if it can
+func unsupportedOnSize(move Move, size int) string {
+	switch {
+	case move.Rotation != NoRotation:
+		return ""
+	case move.Slice != NoSlice:
+		if size%2 == 0 {
+			return fmt.Sprintf("slice moves need a middle layer, which a %dx%d doesn't have", size, size)
+		}
+	case move.Wide:
+		depth := move.WideDepth
+		if depth <= 0 {
+			depth = 2
+		}
+		if depth >= size {
+			return fmt.Sprintf("a %d-layer wide turn needs at least a %dx%d", depth, depth+1, depth+1)
+		}
+	case move.Layer < 0:
+		return "layer numbers start at 1"
+	case move.Layer >= size-1:
+		return fmt.Sprintf("layer %d needs at least a %dx%d", move.Layer+1, move.Layer+2, move.Layer+2)
+	}
+	return ""
+}
+
+// FormatMoves joins moves into space-separated notation, the inverse of ParseMoves
+func FormatMoves(moves []Move) string {
+	parts := make([]string, len(moves))
+	for i, move := range moves {
+		parts[i] = move.String()
+	}
+	return strings.Join(parts, " ")
+}
+
 // String returns a string representation of the move
 func (m Move) String() string {
 	var result string
@@ -151,8 +249,11 @@ func (m Move) String() string {
 		// Handle face moves with layer/wide notation
 
 		// Add layer number prefix for numbered layer moves (2R, 3L, etc.)
+		// and the depth of numbered wide moves (3Rw)
 		if m.Layer > 0 {
 			result += fmt.Sprintf("%d", m.Layer+1) // Convert back to 1-indexed
+		} else if m.Wide && m.WideDepth > 0 {
+			result += fmt.Sprintf("%d", m.WideDepth)
 		}
 
 		// Add face letter
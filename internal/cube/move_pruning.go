@@ -0,0 +1,64 @@
+package cube
+
+// Search pruning
+//
+// A search that tries every move after every other explores each state
+// many times over. Two rules cut that down without losing any state:
+// never turn the same layers twice in a row, since the two turns combine
+// into one (or cancel), and of two moves that commute, such as R and L,
+// only try them in one order. The first rule only holds when the search
+// can make the combined turn in one move: a search over quarter turns
+// needs R R to reach R2. The searches in this package share these rules
+// through RedundantAfter, for searches over every turn of each layer, and
+// RedundantAfterIn, for searches over a smaller move set.
+
+// MovesCommute reports whether applying a then b leaves the cube the same
+// as b then a. Moves commute exactly when they turn about the same axis:
+// R, L, M, Rw, and x all commute with each other, but not with U.
+func MovesCommute(a, b Move) bool {
+	return moveAxis(a) == moveAxis(b)
+}
+
+// RedundantAfter reports whether a search that has just made prev can
+// skip next: either next turns the same layers as prev, so the pair
+// combines into a single move, or the two commute and next comes first in
+// the canonical order (alphabetical by notation, so L R is searched but
+// not R L). It assumes the search can make every turn of each layer; use
+// RedundantAfterIn when it can't.
+func RedundantAfter(prev, next Move) bool {
+	if !MovesCommute(prev, next) {
+		return false
+	}
+	prevLayers, nextLayers := turnedLayers(prev), turnedLayers(next)
+	return prevLayers == nextLayers || nextLayers.String() < prevLayers.String()
+}
+
+// RedundantAfterIn is RedundantAfter for a search over moves: next turning
+// the same layers as prev is only skipped when the pair cancels or moves
+// has the single turn the pair combines into
+func RedundantAfterIn(prev, next Move, moves []Move) bool {
+	if !RedundantAfter(prev, next) {
+		return false
+	}
+	layers := turnedLayers(prev)
+	if turnedLayers(next) != layers {
+		return true
+	}
+	combined := (moveToQuarterTurns(prev) + moveToQuarterTurns(next)) % 4
+	if combined == 0 {
+		return true
+	}
+	for _, move := range moves {
+		if turnedLayers(move) == layers && moveToQuarterTurns(move) == combined {
+			return true
+		}
+	}
+	return false
+}
+
+// turnedLayers returns move as a clockwise quarter turn, so moves that
+// differ only in direction compare equal
+func turnedLayers(move Move) Move {
+	move.Clockwise, move.Double = true, false
+	return move
+}
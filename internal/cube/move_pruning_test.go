@@ -0,0 +1,111 @@
+package cube
+
+import "testing"
+
+func TestMovesCommuteAndRedundantAfter(t *testing.T) {
+	tests := []struct {
+		prev, next string
+		commute    bool
+		redundant  bool
+	}{
+		{"R", "R", true, true},
+		{"R", "R'", true, true},
+		{"R2", "R", true, true},
+		{"L", "R", true, false},
+		{"R", "L", true, true},
+		{"D", "U'", true, false},
+		{"U2", "D", true, true},
+		{"B", "F2", true, false},
+		{"F", "B", true, true},
+		{"M", "R", true, false},
+		{"R", "M", true, true},
+		{"R", "x", true, false},
+		{"x", "R", true, true},
+		{"R", "U", false, false},
+		{"U", "R", false, false},
+		{"F", "M", false, false},
+		{"E", "y", true, false},
+	}
+
+	for _, tt := range tests {
+		prev, err := ParseMove(tt.prev)
+		if err != nil {
+			t.Fatal(err)
+		}
+		next, err := ParseMove(tt.next)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got := MovesCommute(prev, next); got != tt.commute {
+			t.Errorf("MovesCommute(%s, %s) = %v, want %v", tt.prev, tt.next, got, tt.commute)
+		}
+		if got := RedundantAfter(prev, next); got != tt.redundant {
+			t.Errorf("RedundantAfter(%s, %s) = %v, want %v", tt.prev, tt.next, got, tt.redundant)
+		}
+
+		// MovesCommute agrees with the cube
+		a, b := NewCube(3), NewCube(3)
+		a.ApplyMoves([]Move{prev, next})
+		b.ApplyMoves([]Move{next, prev})
+		if same := a.String() == b.String(); same != tt.commute {
+			t.Errorf("%s %s and %s %s give the same state: %v, want %v", tt.prev, tt.next, tt.next, tt.prev, same, tt.commute)
+		}
+	}
+}
+
+// reachable returns every state within depth moves of solved, searching
+// with redundant moves skipped when redundant is not nil
+func reachable(moves []Move, depth int, redundant func(prev, next Move) bool) map[string]bool {
+	seen := make(map[string]bool)
+	var walk func(c *Cube, last *Move, depth int)
+	walk = func(c *Cube, last *Move, depth int) {
+		seen[c.String()] = true
+		if depth == 0 {
+			return
+		}
+		for _, move := range moves {
+			if redundant != nil && last != nil && redundant(*last, move) {
+				continue
+			}
+			next := c.Clone()
+			next.ApplyMove(move)
+			move := move
+			walk(next, &move, depth-1)
+		}
+	}
+	walk(NewCube(3), nil, depth)
+	return seen
+}
+
+// TestRedundantAfterKeepsEveryState checks that pruning loses no state:
+// searching with RedundantAfter reaches everything within three face turns
+// that searching without it does
+func TestRedundantAfterKeepsEveryState(t *testing.T) {
+	all, pruned := reachable(faceTurns, 3, nil), reachable(faceTurns, 3, RedundantAfter)
+	if len(pruned) != len(all) {
+		t.Errorf("pruned search reached %d states, unpruned %d", len(pruned), len(all))
+	}
+}
+
+// TestRedundantAfterInKeepsEveryState checks the same for move sets that
+// lack some turns, where R R is the only way to make R2
+func TestRedundantAfterInKeepsEveryState(t *testing.T) {
+	moveSets := map[string]string{
+		"quarter turns": "R R' L L' U U' D D' F F' B B'",
+		"OLL search":    "R R' U U' F F' L L' R2 F2",
+		"phase 2":       "U U' U2 D D' D2 R2 L2 F2 B2",
+	}
+
+	for name, notation := range moveSets {
+		moves, err := ParseScramble(notation)
+		if err != nil {
+			t.Fatal(err)
+		}
+		redundant := func(prev, next Move) bool { return RedundantAfterIn(prev, next, moves) }
+		all, pruned := reachable(moves, 4, nil), reachable(moves, 4, redundant)
+		if len(pruned) != len(all) {
+			t.Errorf("%s: pruned search reached %d states, unpruned %d", name, len(pruned), len(all))
+		}
+	}
+}
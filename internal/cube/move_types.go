@@ -50,6 +50,10 @@ const (
 	MoveZ // z rotation
 )
 
+// unsupportedMoveType marks a move that has no permutation, such as one
+// with an out-of-range face. generatePermutation panics on it.
+const unsupportedMoveType MoveType = -1
+
 // Coord represents a sticker coordinate
 type Coord struct {
 	Face Face
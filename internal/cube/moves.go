@@ -1,11 +1,28 @@
 package cube
 
+import "fmt"
+
 // ApplyMove applies a single move to the cube
 func (c *Cube) ApplyMove(move Move) {
+	if c.history != nil {
+		c.history.record(move)
+	}
+	if move.Rotation != NoRotation {
+		c.trackRotation(move)
+	}
+	if c.Supercube {
+		c.trackCenters(move)
+	}
+
+	if c.Engine == GeometricEngine {
+		c.applyGeometricMove(move)
+		return
+	}
+
 	moveType, quarterTurns := moveToMoveType(move)
-	layers := getAffectedLayers(move, c.Size)
+	from, to := getAffectedLayers(move, c.Size)
 
-	for _, layer := range layers {
+	for layer := from; layer < to; layer++ {
 		perm := getPermutation(c.Size, moveType, layer, quarterTurns)
 		applyPermutation(c, perm)
 	}
@@ -18,6 +35,172 @@ func (c *Cube) ApplyMoves(moves []Move) {
 	}
 }
 
+// ApplyInverseMove undoes a move by turning the same layers the other way
+func (c *Cube) ApplyInverseMove(move Move) {
+	c.ApplyMove(invertMove(move))
+}
+
+// ApplyInverseMoves undoes a sequence of moves. It is equivalent to
+// applying InvertSequence(moves) without building the inverted slice.
+func (c *Cube) ApplyInverseMoves(moves []Move) {
+	for i := len(moves) - 1; i >= 0; i-- {
+		c.ApplyInverseMove(moves[i])
+	}
+}
+
+// ApplyMoveDelta applies a move and returns the stickers whose color
+// changed, in face, row, column order. A sticker that moved but was replaced
+// by one of the same color is not included, so a renderer can repaint just
+// the returned stickers.
+func (c *Cube) ApplyMoveDelta(move Move) []Coord {
+	before := c.Clone()
+	c.ApplyMove(move)
+
+	var changed []Coord
+	for face := 0; face < 6; face++ {
+		for row := 0; row < c.Size; row++ {
+			for col := 0; col < c.Size; col++ {
+				if c.Faces[face][row][col] != before.Faces[face][row][col] {
+					changed = append(changed, Coord{Face(face), row, col})
+				}
+			}
+		}
+	}
+	return changed
+}
+
+// ApplyMoveChecked applies a move after checking that it is supported on a
+// cube of this size. Unlike ApplyMove, which turns nothing for moves the
+// cube can't perform (such as M on a 4x4), it reports them as an error.
+func (c *Cube) ApplyMoveChecked(move Move) error {
+	if err := move.Validate(c.Size); err != nil {
+		return err
+	}
+	c.ApplyMove(move)
+	return nil
+}
+
+// ApplyMovesChecked checks every move before applying any of them, so the
+// cube is left untouched if the sequence contains an unsupported move
+func (c *Cube) ApplyMovesChecked(moves []Move) error {
+	for _, move := range moves {
+		if err := move.Validate(c.Size); err != nil {
+			return err
+		}
+	}
+	c.ApplyMoves(moves)
+	return nil
+}
+
+// Validate reports whether the move can be performed on an NxNxN cube
+func (m Move) Validate(N int) error {
+	switch {
+	case m.Slice != NoSlice:
+		if m.Slice < M_Slice || m.Slice > S_Slice {
+			return fmt.Errorf("unknown slice type %d", m.Slice)
+		}
+		if m.Wide || m.Layer != 0 || m.Rotation != NoRotation {
+			return fmt.Errorf("slice move %s cannot be combined with layer, wide, or rotation modifiers", m.String())
+		}
+		if N%2 == 0 {
+			return fmt.Errorf("slice move %s has no middle layer on a %dx%d cube", m.String(), N, N)
+		}
+	case m.Rotation != NoRotation:
+		if m.Rotation < X_Rotation || m.Rotation > Z_Rotation {
+			return fmt.Errorf("unknown rotation type %d", m.Rotation)
+		}
+		if m.Wide || m.Layer != 0 {
+			return fmt.Errorf("rotation %s cannot be combined with layer or wide modifiers", m.String())
+		}
+	default:
+		if m.Face < Front || m.Face > Down {
+			return fmt.Errorf("unknown face %d", m.Face)
+		}
+		if m.Wide && m.Layer != 0 {
+			return fmt.Errorf("move %s cannot be both a wide and a layer move", m.String())
+		}
+		if m.Layer < 0 || m.Layer >= N {
+			return fmt.Errorf("layer %d is out of range on a %dx%d cube", m.Layer+1, N, N)
+		}
+		if m.Wide && (m.WideDepth < 0 || m.WideDepth > N) {
+			return fmt.Errorf("wide move depth %d is out of range on a %dx%d cube", m.WideDepth, N, N)
+		}
+	}
+	return nil
+}
+
+// InvertSequence returns the sequence that undoes moves: reversed, with each
+// quarter turn flipped. Double moves are their own inverse.
+func InvertSequence(moves []Move) []Move {
+	inverse := make([]Move, 0, len(moves))
+	for i := len(moves) - 1; i >= 0; i-- {
+		inverse = append(inverse, invertMove(moves[i]))
+	}
+	return inverse
+}
+
+// ReverseOrder returns moves in reverse order, each turned the same way
+// as before: R U' F2 becomes F2 U' R. Unlike InvertSequence, it does not
+// flip the quarter turns, so the result generally doesn't undo moves; it is
+// the same sequence executed back to front.
+func ReverseOrder(moves []Move) []Move {
+	reversed := make([]Move, 0, len(moves))
+	for i := len(moves) - 1; i >= 0; i-- {
+		reversed = append(reversed, moves[i])
+	}
+	return reversed
+}
+
+// invertMove returns the move that undoes move
+func invertMove(move Move) Move {
+	if !move.Double {
+		move.Clockwise = !move.Clockwise
+	}
+	return move
+}
+
+// maxSequenceOrder bounds the search in SequenceOrder. The largest order of
+// any 3x3 sequence is 1260; bigger cubes can exceed it, but rarely for
+// sequences anyone would repeat by hand.
+const maxSequenceOrder = 10000
+
+// SequenceOrder returns how many times moves must be applied to c before it
+// returns to its current state, or 0 if that takes more than 10000
+// repetitions. The empty sequence has order 1. c is not modified.
+func (c *Cube) SequenceOrder(moves []Move) int {
+	stickers := 6 * c.Size * c.Size
+	work := c.Clone()
+	for order := 1; order <= maxSequenceOrder; order++ {
+		work.ApplyMoves(moves)
+		if matchingStickers(work, c) == stickers {
+			return order
+		}
+	}
+	return 0
+}
+
+// SequenceOrder returns how many times moves must be applied to a solved
+// 3x3 to bring it back to the same state: 2 for the T-Perm, 3 for the
+// A-Perm, and 1 for the empty sequence. Rotations count, so x y has order
+// 3. It returns an error if the order is more than 10000.
+func SequenceOrder(moves []Move) (int, error) {
+	order := NewCube(3).SequenceOrder(moves)
+	if order == 0 {
+		return 0, fmt.Errorf("sequence order exceeds %d", maxSequenceOrder)
+	}
+	return order, nil
+}
+
+// IsIdentity reports whether moves leave a solved 3x3 solved, such as
+// R R' or (R U R' U')6: a sequence that does nothing. Rotations don't
+// count as changes, so a sequence that only turns the whole cube is an
+// identity too.
+func IsIdentity(moves []Move) bool {
+	c := NewCube(3)
+	c.ApplyMoves(moves)
+	return c.IsSolved()
+}
+
 // moveToMoveType converts a Move struct to MoveType and determines quarter turns
 func moveToMoveType(move Move) (MoveType, int) {
 	var moveType MoveType
@@ -33,7 +216,7 @@ func moveToMoveType(move Move) (MoveType, int) {
 		case S_Slice:
 			moveType = MoveS
 		default:
-			return MoveR, 0 // Default fallback
+			return unsupportedMoveType, 0
 		}
 	} else if move.Rotation != NoRotation {
 		// Handle cube rotations
@@ -45,7 +228,7 @@ func moveToMoveType(move Move) (MoveType, int) {
 		case Z_Rotation:
 			moveType = MoveZ
 		default:
-			return MoveR, 0 // Default fallback
+			return unsupportedMoveType, 0
 		}
 	} else {
 		// Handle face moves
@@ -63,7 +246,7 @@ func moveToMoveType(move Move) (MoveType, int) {
 		case Back:
 			moveType = MoveB
 		default:
-			return MoveR, 0 // Default fallback
+			return unsupportedMoveType, 0
 		}
 	}
 
@@ -79,23 +262,21 @@ func moveToMoveType(move Move) (MoveType, int) {
 	return moveType, quarterTurns
 }
 
-// getAffectedLayers determines which layers are affected by a move
-func getAffectedLayers(move Move, N int) []int {
+// getAffectedLayers determines which layers are affected by a move, as
+// the range of layer indexes [from, to). It returns a range rather than a
+// slice so applying a move doesn't allocate.
+func getAffectedLayers(move Move, N int) (from, to int) {
 	// Handle slice moves
 	if move.Slice != NoSlice {
 		if N%2 == 0 {
-			return []int{} // Slice moves undefined for even cubes
+			return 0, 0 // Slice moves undefined for even cubes
 		}
-		return []int{N / 2} // Middle layer
+		return N / 2, N/2 + 1 // Middle layer
 	}
 
 	// Handle cube rotations (affect all layers)
 	if move.Rotation != NoRotation {
-		layers := make([]int, N)
-		for i := 0; i < N; i++ {
-			layers[i] = i
-		}
-		return layers
+		return 0, N
 	}
 
 	// Handle face moves
@@ -105,16 +286,12 @@ func getAffectedLayers(move Move, N int) []int {
 		if depth <= 0 {
 			depth = 2
 		}
-		layers := make([]int, depth)
-		for i := 0; i < depth; i++ {
-			layers[i] = i
-		}
-		return layers
+		return 0, depth
 	} else if move.Layer > 0 {
 		// Layer moves (2R, 3L, etc.) affect only the specified layer
-		return []int{move.Layer}
+		return move.Layer, move.Layer + 1
 	} else {
 		// Regular moves affect only outer layer (standard cubing convention)
-		return []int{0}
+		return 0, 1
 	}
 }
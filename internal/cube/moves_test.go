@@ -275,6 +275,19 @@ func TestEmptyScramble(t *testing.T) {
 	}
 }
 
+func TestApplyMoveDoesNotAllocate(t *testing.T) {
+	moves, err := ParseScramble("R U' F2 Rw M x 2L")
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := NewCube(5)
+	c.ApplyMoves(moves) // fill the permutation cache and scratch buffer
+
+	if allocs := testing.AllocsPerRun(100, func() { c.ApplyMoves(moves) }); allocs != 0 {
+		t.Errorf("ApplyMoves allocated %.0f times per run, want 0", allocs)
+	}
+}
+
 // Benchmark move application performance
 func BenchmarkSingleMove(b *testing.B) {
 	cube := NewCube(3)
@@ -392,32 +405,19 @@ func TestCircuitFuzzing(t *testing.T) {
 	t.Logf("🧪 Testing %d curated circuit sequences...", testCount)
 
 	for i, scrambleStr := range sequences {
-		cube := NewCube(3) // Always use 3x3 cubes
-		originalState := cube.String()
-
-		// Apply sequence repeatedly until it cycles back to solved (or we hit max)
-		cycleFound := false
-		for cycle := 1; cycle <= maxCycleLength; cycle++ {
-			// Apply the sequence
-			scrambleMoves, err := ParseScramble(scrambleStr)
-			if err != nil {
-				t.Fatalf("Failed to parse sequence %s: %v", scrambleStr, err)
-			}
-			cube.ApplyMoves(scrambleMoves)
-
-			// Check if we're back to solved
-			if cube.String() == originalState {
-				t.Logf("✅ Sequence '%s' has cycle order %d", scrambleStr, cycle)
-				cycleFound = true
-				break
-			}
+		scrambleMoves, err := ParseScramble(scrambleStr)
+		if err != nil {
+			t.Fatalf("Failed to parse sequence %s: %v", scrambleStr, err)
 		}
 
-		if !cycleFound {
+		// Apply sequence repeatedly until it cycles back to solved (or we hit max)
+		order, err := SequenceOrder(scrambleMoves)
+		if err != nil || order > maxCycleLength {
 			failureCount++
 			t.Errorf("❌ CIRCUIT FAILURE: Sequence '%s' did not cycle within %d applications!",
 				scrambleStr, maxCycleLength)
-			t.Errorf("Final cube state: %s", cube.String())
+		} else {
+			t.Logf("✅ Sequence '%s' has cycle order %d", scrambleStr, order)
 		}
 
 		// Progress reporting
@@ -579,6 +579,298 @@ func TestSingleMoveFourFold(t *testing.T) {
 	}
 }
 
+func TestSequenceOrderFunc(t *testing.T) {
+	tests := map[string]int{
+		"":                                     1,
+		"R U R' U' R' F R2 U' R' U' R U R' F'": 2, // T-Perm
+		"x R' U R' D2 R U' R' D2 R2 x'":        3, // Aa-Perm
+		"M2 U M2 U2 M2 U M2":                   2, // H-Perm
+		"R U":                                  105,
+		"x y":                                  3, // Rotations count as moves
+	}
+	for notation, want := range tests {
+		moves, err := ParseScramble(notation)
+		if err != nil {
+			t.Fatalf("ParseScramble(%q) error = %v", notation, err)
+		}
+		if got, err := SequenceOrder(moves); err != nil || got != want {
+			t.Errorf("SequenceOrder(%q) = %d, %v, want %d", notation, got, err, want)
+		}
+	}
+}
+
+func TestReverseOrder(t *testing.T) {
+	moves, err := ParseScramble("R U' F2 x")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := FormatMoves(ReverseOrder(moves)); got != "x F2 U' R" {
+		t.Errorf("ReverseOrder() = %q, want %q", got, "x F2 U' R")
+	}
+	if got := FormatMoves(InvertSequence(moves)); got != "x' F2 U R'" {
+		t.Errorf("InvertSequence() = %q, want %q", got, "x' F2 U R'")
+	}
+	if FormatMoves(moves) != "R U' F2 x" {
+		t.Error("ReverseOrder() modified its input")
+	}
+	if len(ReverseOrder(nil)) != 0 {
+		t.Error("ReverseOrder(nil) is not empty")
+	}
+}
+
+func TestIsIdentity(t *testing.T) {
+	tests := map[string]bool{
+		"":     true,
+		"R R'": true,
+		"R U R' U' R U R' U' R U R' U' R U R' U' R U R' U' R U R' U'": true,
+		"x y'":                                 true,
+		"M2 U2 M2 U2":                          false,
+		"R":                                    false,
+		"R U R' U' R' F R2 U' R' U' R U R' F'": false, // T-Perm
+	}
+	for notation, want := range tests {
+		moves, err := ParseScramble(notation)
+		if err != nil {
+			t.Fatalf("ParseScramble(%q) error = %v", notation, err)
+		}
+		if got := IsIdentity(moves); got != want {
+			t.Errorf("IsIdentity(%q) = %v, want %v", notation, got, want)
+		}
+	}
+}
+
+func TestApplyInverseMoves(t *testing.T) {
+	rng := rand.New(rand.NewSource(3))
+	for size := 2; size <= 5; size++ {
+		for trial := 0; trial < 20; trial++ {
+			moves := randomMoves(size, 25, rng)
+			c := NewCube(size)
+			c.ApplyMoves(moves)
+			c.ApplyInverseMoves(moves)
+			if !c.IsSolved() {
+				t.Fatalf("%dx%d: %s then its inverse is not the identity", size, size, FormatMoves(moves))
+			}
+
+			// Matches applying InvertSequence
+			a, b := NewCube(size), NewCube(size)
+			a.ApplyInverseMoves(moves)
+			b.ApplyMoves(InvertSequence(moves))
+			if a.String() != b.String() {
+				t.Fatalf("%dx%d: ApplyInverseMoves(%s) differs from InvertSequence", size, size, FormatMoves(moves))
+			}
+		}
+	}
+
+	c := NewCube(3)
+	c.ApplyMove(Move{Face: Right, Clockwise: true})
+	c.ApplyInverseMove(Move{Face: Right, Clockwise: true})
+	if !c.IsSolved() {
+		t.Error("R then ApplyInverseMove(R) is not the identity")
+	}
+}
+
+func TestApplyMoveDelta(t *testing.T) {
+	c := NewCube(3)
+	if changed := c.ApplyMoveDelta(Move{Face: Right, Clockwise: true}); len(changed) != 12 {
+		t.Errorf("R on a solved cube changed %d stickers, want 12", len(changed))
+	}
+
+	// Repainting only the changed stickers keeps a copy in sync
+	moves, _ := ParseScramble("U F' L2 D B R' M y Rw")
+	c = NewCube(4)
+	rendered := c.Clone()
+	for _, move := range moves {
+		for _, coord := range c.ApplyMoveDelta(move) {
+			rendered.Faces[coord.Face][coord.Row][coord.Col] = c.Faces[coord.Face][coord.Row][coord.Col]
+		}
+		if rendered.String() != c.String() {
+			t.Fatalf("after %s the repainted copy differs from the cube", move)
+		}
+	}
+}
+
+// TestEveryParsedMoveTurnsSomething - Every notation ParseMove accepts must
+// either change a solved cube or be rejected by ApplyMoveChecked, on both
+// engines; nothing may silently turn nothing
+func TestEveryParsedMoveTurnsSomething(t *testing.T) {
+	for N := 2; N <= 6; N++ {
+		var bases []string
+		for _, face := range []string{"R", "L", "U", "D", "F", "B"} {
+			bases = append(bases, face, face+"w")
+			for k := 0; k <= N+1; k++ {
+				bases = append(bases, fmt.Sprintf("%d%s", k, face), fmt.Sprintf("%d%sw", k, face))
+			}
+		}
+		bases = append(bases, "M", "E", "S", "x", "y", "z")
+
+		for _, base := range bases {
+			for _, suffix := range []string{"", "'", "2"} {
+				notation := base + suffix
+				move, err := ParseMove(notation)
+				if err != nil {
+					t.Fatalf("ParseMove(%q) failed: %v", notation, err)
+				}
+
+				for _, engine := range []Engine{PermutationEngine, GeometricEngine} {
+					c := NewCube(N, WithEngine(engine))
+					solved := c.String()
+
+					if err := c.ApplyMoveChecked(move); err != nil {
+						if c.String() != solved {
+							t.Errorf("%dx%d %s (%v): rejected move changed the cube", N, N, notation, engine)
+						}
+						continue
+					}
+					if c.String() == solved {
+						t.Errorf("%dx%d %s (%v): move was accepted but changed nothing", N, N, notation, engine)
+					}
+				}
+			}
+		}
+	}
+
+	// Spot-check what is rejected
+	rejected := []struct {
+		notation string
+		N        int
+	}{
+		{"M", 4}, {"E'", 2}, {"4R", 3}, {"0R", 3}, {"4Rw", 3},
+	}
+	for _, tt := range rejected {
+		move, err := ParseMove(tt.notation)
+		if err != nil {
+			t.Fatalf("ParseMove(%q) failed: %v", tt.notation, err)
+		}
+		if err := NewCube(tt.N).ApplyMoveChecked(move); err == nil {
+			t.Errorf("%dx%d %s: expected ApplyMoveChecked to fail", tt.N, tt.N, tt.notation)
+		}
+	}
+
+	// A malformed move must not silently turn nothing
+	for _, engine := range []Engine{PermutationEngine, GeometricEngine} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%v: ApplyMove with an unknown face should panic", engine)
+				}
+			}()
+			NewCube(3, WithEngine(engine)).ApplyMove(Move{Face: Face(9), Clockwise: true})
+		}()
+	}
+}
+
+func TestSequenceOrder(t *testing.T) {
+	tests := []struct {
+		moves string
+		size  int
+		want  int
+	}{
+		{"", 3, 1},
+		{"R", 3, 4},
+		{"R2", 3, 2},
+		{"R U R' U'", 3, 6},
+		{"R U", 3, 105},
+		{"R U2 R' U' R U' R'", 3, 6}, // Sune
+		{"R U", 2, 15},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.moves, func(t *testing.T) {
+			moves, err := ParseScramble(tt.moves)
+			if err != nil {
+				t.Fatalf("ParseScramble(%q) error = %v", tt.moves, err)
+			}
+			c := NewCube(tt.size)
+			var got int
+			AssertUnmodified(t, c, "SequenceOrder()", func() { got = c.SequenceOrder(moves) })
+			if got != tt.want {
+				t.Errorf("SequenceOrder(%q) on %dx%d = %d, want %d", tt.moves, tt.size, tt.size, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestEnginesAgreeOnAdvancedMoves - Differential test: random sequences of
+// face, wide, layer, slice, and rotation moves must leave the permutation and
+// geometric engines in identical states. Any divergence is a bug in one of them.
+func TestEnginesAgreeOnAdvancedMoves(t *testing.T) {
+	rng := rand.New(rand.NewSource(42))
+	faces := []Face{Front, Back, Left, Right, Up, Down}
+	sequencesPerSize := 500
+	movesPerSequence := 20
+
+	randomMove := func(N int) Move {
+		var move Move
+		switch rng.Intn(5) {
+		case 0: // Face turn
+			move.Face = faces[rng.Intn(len(faces))]
+		case 1: // Wide turn, up to the full cube
+			move.Face = faces[rng.Intn(len(faces))]
+			move.Wide = true
+			move.WideDepth = 2 + rng.Intn(N-1)
+		case 2: // Single inner or opposite layer
+			move.Face = faces[rng.Intn(len(faces))]
+			move.Layer = 1 + rng.Intn(N-1)
+		case 3:
+			move.Slice = []SliceType{M_Slice, E_Slice, S_Slice}[rng.Intn(3)]
+		case 4:
+			move.Rotation = []RotationType{X_Rotation, Y_Rotation, Z_Rotation}[rng.Intn(3)]
+		}
+		switch rng.Intn(3) {
+		case 0:
+			move.Clockwise = true
+		case 1:
+			move.Double = true
+		}
+		return move
+	}
+
+	for N := 2; N <= 6; N++ {
+		for seq := 0; seq < sequencesPerSize; seq++ {
+			moves := make([]Move, movesPerSequence)
+			for i := range moves {
+				moves[i] = randomMove(N)
+			}
+
+			permutation := NewCube(N, WithEngine(PermutationEngine))
+			geometric := NewCube(N, WithEngine(GeometricEngine))
+			permutation.ApplyMoves(moves)
+			geometric.ApplyMoves(moves)
+
+			if permutation.String() != geometric.String() {
+				names := make([]string, len(moves))
+				for i, move := range moves {
+					names[i] = move.String()
+				}
+				t.Fatalf("%dx%d: engines disagree on '%s'", N, N, strings.Join(names, " "))
+			}
+		}
+	}
+}
+
+// TestLeftAndBackAlgorithmsPreserveF2L checks L and B against mirrored and
+// rotated last layer algorithms, which must leave the first two layers alone
+func TestLeftAndBackAlgorithmsPreserveF2L(t *testing.T) {
+	algorithms := []string{
+		"L' U' L U' L' U2 L", // Left Sune
+		"B U B' U B U2 B'",   // Sune performed on the left side
+		"L' U2 L U L' U L",   // Left Anti-Sune
+		"B L U L' U' B'",     // Cross OLL performed from the back
+	}
+
+	for _, alg := range algorithms {
+		cube := NewCube(3)
+		moves, err := ParseScramble(alg)
+		if err != nil {
+			t.Fatalf("Failed to parse %s: %v", alg, err)
+		}
+		cube.ApplyMoves(moves)
+		if !isF2LSolved(cube) {
+			t.Errorf("%s disturbed the first two layers:\n%s", alg, cube)
+		}
+	}
+}
+
 // TestTPerm tests the T-Perm algorithm specifically
 func TestTPerm(t *testing.T) {
 	tPerm := "R U R' F' R U R' U' R' F R2 U' R'"
@@ -774,6 +1066,99 @@ func TestTPerm(t *testing.T) {
 	})
 }
 
+// TestSliceMoveIdentities checks each slice against the outer layers and
+// the whole-cube rotation it is made of: x turns every layer like R, so
+// on a 3x3 M (which turns like L) is R L' x'. On a 5x5 the layers next to
+// the faces turn with x too and have to be undone as well.
+func TestSliceMoveIdentities(t *testing.T) {
+	tests := []struct {
+		size        int
+		slice, same string
+	}{
+		{3, "M", "R L' x'"},
+		{3, "M'", "R' L x"},
+		{3, "M2", "R2 L2 x2"},
+		{3, "E", "U D' y'"},
+		{3, "E'", "U' D y"},
+		{3, "S", "F' B z"},
+		{3, "S'", "F B' z'"},
+		{5, "M", "R 2R L' 2L' x'"},
+		{5, "E'", "U' 2U' D 2D y"},
+		{5, "S", "F' 2F' B 2B z"},
+	}
+
+	for _, tt := range tests {
+		for _, engine := range []Engine{PermutationEngine, GeometricEngine} {
+			a, b := NewCube(tt.size, WithEngine(engine)), NewCube(tt.size, WithEngine(engine))
+			a.ApplyMoves(mustParseForSize(t, tt.slice, tt.size))
+			b.ApplyMoves(mustParseForSize(t, tt.same, tt.size))
+			if a.String() != b.String() {
+				t.Errorf("%dx%d %s engine: %s and %s differ", tt.size, tt.size, engine, tt.slice, tt.same)
+			}
+		}
+	}
+}
+
+// sliceFuzzMoves are the moves FuzzSliceMoves picks from: every face
+// turn, slice, and rotation, each in all three directions
+var sliceFuzzMoves = func() []string {
+	var moves []string
+	for _, base := range []string{"R", "L", "U", "D", "F", "B", "M", "E", "S", "x", "y", "z"} {
+		moves = append(moves, base, base+"'", base+"2")
+	}
+	return moves
+}()
+
+// FuzzSliceMoves mixes slices with face turns and rotations, one move per
+// input byte, on a 3x3 and a 5x5. Undoing the sequence must give back a
+// solved cube held the way it started, and the two engines must agree on
+// every state along the way.
+func FuzzSliceMoves(f *testing.F) {
+	f.Add([]byte{18, 0, 19, 1})            // M R M' R'
+	f.Add([]byte{20, 0, 19, 6, 23})        // M2 R M' U E2
+	f.Add([]byte{24, 27, 30, 25, 28, 31})  // S x y S' x' y'
+	f.Add([]byte{1, 19, 8, 22, 34, 2, 26}) // R' M' U2 E' z' R2 S2
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		if len(data) > 64 {
+			data = data[:64]
+		}
+		var names []string
+		for _, b := range data {
+			names = append(names, sliceFuzzMoves[int(b)%len(sliceFuzzMoves)])
+		}
+		sequence := strings.Join(names, " ")
+
+		for _, size := range []int{3, 5} {
+			moves := mustParseForSize(t, sequence, size)
+			perm := NewCube(size)
+			geo := NewCube(size, WithEngine(GeometricEngine))
+			for i, move := range moves {
+				perm.ApplyMove(move)
+				geo.ApplyMove(move)
+				if perm.String() != geo.String() {
+					t.Fatalf("%dx%d: engines disagree after %s", size, size, strings.Join(names[:i+1], " "))
+				}
+			}
+
+			perm.ApplyInverseMoves(moves)
+			if perm.String() != NewCube(size).String() || !perm.IsHomeOrientation() {
+				t.Fatalf("%dx%d: %s followed by its inverse doesn't return to solved", size, size, sequence)
+			}
+		}
+	})
+}
+
+// mustParseForSize parses moves for a cube of the given size or fails
+func mustParseForSize(t *testing.T, moves string, size int) []Move {
+	t.Helper()
+	parsed, err := ParseScrambleForSize(moves, size)
+	if err != nil {
+		t.Fatalf("ParseScrambleForSize(%q, %d) error = %v", moves, size, err)
+	}
+	return parsed
+}
+
 // Helper function to join move strings
 func joinMoves(moves []string) string {
 	result := ""
@@ -785,3 +1170,70 @@ func joinMoves(moves []string) string {
 	}
 	return result
 }
+
+// TestRotationsAllSizes checks x, y, and z on every size: each turns the
+// whole cube, centers and inner layers included, the way a full-depth wide
+// turn does, and four of them or a sequence and its inverse change nothing.
+// (x y z x' y' z' is not one of those: rotations don't commute.)
+func TestRotationsAllSizes(t *testing.T) {
+	rotations := []struct {
+		name string
+		face Face
+		// Where each face's solved color goes: a clockwise x brings the
+		// Down face to Front
+		newFront, newUp Face
+	}{
+		{"x", Right, Down, Front},
+		{"y", Up, Right, Up},
+		{"z", Front, Front, Left},
+	}
+
+	for N := 2; N <= 6; N++ {
+		for _, engine := range []Engine{PermutationEngine, GeometricEngine} {
+			solved := NewCube(N, WithEngine(engine)).String()
+
+			for _, r := range rotations {
+				for _, suffix := range []string{"", "'", "2"} {
+					c := NewCube(N, WithEngine(engine))
+					for i := 0; i < 4; i++ {
+						c.ApplyMoves(mustParseForSize(t, r.name+suffix, N))
+					}
+					if c.String() != solved {
+						t.Errorf("%dx%d %s engine: %s four times changed the cube", N, N, engine, r.name+suffix)
+					}
+				}
+
+				c := NewCube(N, WithEngine(engine))
+				c.ApplyMoves(mustParseForSize(t, r.name, N))
+				wide := NewCube(N, WithEngine(engine))
+				wide.ApplyMove(Move{Face: r.face, Clockwise: true, Wide: true, WideDepth: N})
+				if c.String() != wide.String() {
+					t.Errorf("%dx%d %s engine: %s differs from a %d-layer wide %s turn", N, N, engine, r.name, N, r.face)
+				}
+
+				// Every sticker moved with its face, so each face is still
+				// one color
+				fresh := NewCube(N)
+				for face := Front; face <= Down; face++ {
+					for row := 0; row < N; row++ {
+						for col := 0; col < N; col++ {
+							if c.Faces[face][row][col] != c.Faces[face][0][0] {
+								t.Fatalf("%dx%d %s engine: %s left %s face mixed:\n%s", N, N, engine, r.name, face, c.String())
+							}
+						}
+					}
+				}
+				if c.Faces[Front][0][0] != fresh.Faces[r.newFront][0][0] || c.Faces[Up][0][0] != fresh.Faces[r.newUp][0][0] {
+					t.Errorf("%dx%d %s engine: %s brought the wrong faces to Front and Up", N, N, engine, r.name)
+				}
+			}
+
+			c := NewCube(N, WithEngine(engine))
+			c.ApplyMoves(mustParseForSize(t, "x y z", N))
+			c.ApplyMoves(mustParseForSize(t, "z' y' x'", N))
+			if c.String() != solved {
+				t.Errorf("%dx%d %s engine: x y z z' y' x' changed the cube", N, N, engine)
+			}
+		}
+	}
+}
@@ -0,0 +1,139 @@
+package cube
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// ansiEscape matches the ANSI color codes Render writes around letters
+var ansiEscape = regexp.MustCompile("\x1b\\[[0-9;]*m")
+
+// CubeFromNet parses an unfolded net in the layout Render and
+// UnfoldedString draw back into a cube:
+//
+//	    YYY
+//	    YYY
+//	    BBB
+//
+//	BBW RRR YGG OOO
+//	OOO BBW RRR YGG
+//	OOO BBW RRR YGG
+//
+//	    WWG
+//	    WWG
+//	    WWG
+//
+// Stickers may be plain letters, ANSI colored letters, or the Unicode
+// squares of any color scheme. Blank lines and indentation are ignored, so
+// compact output and hand-typed nets parse too. The cube's size comes from
+// the width of the first row, and every color must appear exactly
+// size*size times.
+// Errors name the line and sticker at fault.
+func CubeFromNet(text string) (*Cube, error) {
+	type netLine struct {
+		number int
+		groups [][]Color
+	}
+
+	var lines []netLine
+	for i, line := range strings.Split(ansiEscape.ReplaceAllString(text, ""), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		parsed := netLine{number: i + 1}
+		for _, field := range fields {
+			group, err := parseNetStickers(field)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %v", i+1, err)
+			}
+			parsed.groups = append(parsed.groups, group)
+		}
+		lines = append(lines, parsed)
+	}
+
+	if len(lines) == 0 {
+		return nil, fmt.Errorf("net is empty")
+	}
+
+	// The first Up row is as wide as the cube
+	size := len(lines[0].groups[0])
+	if size < 2 {
+		return nil, fmt.Errorf("line %d: a cube needs at least 2 stickers per row", lines[0].number)
+	}
+	if len(lines) != 3*size {
+		return nil, fmt.Errorf("net has %d rows of stickers, want %d for a %dx%d", len(lines), 3*size, size, size)
+	}
+
+	c := NewCube(size)
+	counts := make(map[Color]int)
+	readRow := func(line netLine, faces []Face, row int) error {
+		if len(line.groups) != len(faces) {
+			return fmt.Errorf("line %d: found %d faces, want %d", line.number, len(line.groups), len(faces))
+		}
+		for i, face := range faces {
+			group := line.groups[i]
+			if len(group) != size {
+				return fmt.Errorf("line %d: %s face row has %d stickers, want %d", line.number, face, len(group), size)
+			}
+			for col, color := range group {
+				c.Faces[face][row][col] = color
+				counts[color]++
+			}
+		}
+		return nil
+	}
+
+	for row := 0; row < size; row++ {
+		if err := readRow(lines[row], []Face{Up}, row); err != nil {
+			return nil, err
+		}
+		if err := readRow(lines[size+row], []Face{Left, Front, Right, Back}, row); err != nil {
+			return nil, err
+		}
+		if err := readRow(lines[2*size+row], []Face{Down}, row); err != nil {
+			return nil, err
+		}
+	}
+
+	for color := White; color <= Green; color++ {
+		if counts[color] != size*size {
+			return nil, fmt.Errorf("%s appears %d times, want %d on a %dx%d", color, counts[color], size*size, size, size)
+		}
+	}
+	return c, nil
+}
+
+// parseNetStickers reads the stickers of one face row: color letters or
+// scheme glyphs, with no separators
+func parseNetStickers(field string) ([]Color, error) {
+	var stickers []Color
+	for _, r := range field {
+		color, err := ParseColor(r)
+		if err != nil {
+			var ok bool
+			if color, ok = glyphColor(string(r)); !ok {
+				return nil, fmt.Errorf("'%c' is not a sticker color", r)
+			}
+		}
+		if color == Grey {
+			return nil, fmt.Errorf("'%c' is a wildcard; a net needs every sticker's color", r)
+		}
+		stickers = append(stickers, color)
+	}
+	return stickers, nil
+}
+
+// glyphColor returns the color a Unicode square stands for in any of the
+// preset color schemes
+func glyphColor(glyph string) (Color, bool) {
+	for _, scheme := range colorSchemes {
+		for color, g := range scheme.Glyphs {
+			if g == glyph {
+				return Color(color), true
+			}
+		}
+	}
+	return Grey, false
+}
@@ -0,0 +1,95 @@
+package cube
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func TestCubeFromNetRoundTrip(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	options := []RenderOptions{
+		{},
+		{Color: true},
+		{Unicode: true},
+		{Compact: true},
+		{Color: true, Scheme: &HighContrastScheme},
+		{Unicode: true, Scheme: &ColorblindScheme},
+	}
+
+	for size := 2; size <= 5; size++ {
+		c := NewCube(size)
+		scramble, err := GenerateScramble(size, ScrambleOptions{Rand: rng})
+		if err != nil {
+			t.Fatal(err)
+		}
+		c.ApplyMoves(scramble)
+
+		for _, opts := range options {
+			var sb strings.Builder
+			c.Render(&sb, opts)
+			parsed, err := CubeFromNet(sb.String())
+			if err != nil {
+				t.Fatalf("%dx%d %+v: CubeFromNet() error = %v", size, size, opts, err)
+			}
+			if parsed.Size != size || parsed.String() != c.String() {
+				t.Errorf("%dx%d %+v: round trip changed the cube", size, size, opts)
+			}
+		}
+	}
+}
+
+func TestCubeFromNetHandTyped(t *testing.T) {
+	// No indentation or blank lines
+	net := `YYY
+YYY
+YYY
+OOO BBB RRR GGG
+OOO BBB RRR GGG
+OOO BBB RRR GGG
+WWW
+WWW
+WWW`
+	c, err := CubeFromNet(net)
+	if err != nil {
+		t.Fatalf("CubeFromNet() error = %v", err)
+	}
+	if !c.IsSolved() {
+		t.Error("hand-typed solved net did not parse as solved")
+	}
+}
+
+func TestCubeFromNetErrors(t *testing.T) {
+	solved := NewCube(3).UnfoldedString(false, false)
+	lines := strings.Split(solved, "\n")
+
+	edit := func(line int, from, to string) string {
+		edited := append([]string(nil), lines...)
+		edited[line] = strings.Replace(edited[line], from, to, 1)
+		return strings.Join(edited, "\n")
+	}
+
+	tests := []struct {
+		name string
+		net  string
+		want string
+	}{
+		{"empty", "\n\n", "net is empty"},
+		{"missing band", strings.Join(lines[:8], "\n"), "net has 6 rows of stickers, want 9 for a 3x3"},
+		{"unknown letter", edit(0, "Y", "X"), "line 1: 'X' is not a sticker color"},
+		{"wildcard", edit(4, "B", "."), "line 5: '.' is a wildcard"},
+		{"short row", edit(5, "BBB", "BB"), "line 6: F face row has 2 stickers, want 3"},
+		{"missing face", edit(6, " GGG", ""), "line 7: found 3 faces, want 4"},
+		{"wrong count", edit(0, "Y", "W"), "W appears 10 times, want 9 on a 3x3"},
+		{"too narrow", "Y\nY\nY", "line 1: a cube needs at least 2 stickers per row"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := CubeFromNet(tt.net)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("CubeFromNet() error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
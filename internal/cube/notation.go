@@ -0,0 +1,76 @@
+package cube
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Notation styles
+//
+// Sources write wide turns two ways: WCA notation adds a w (Rw, 3Rw) and
+// SiGN, common in older algorithm sheets and on 4x4+ sites, uses a
+// lowercase face (r, 3r). Everything else (face, slice, and layer turns
+// and rotations) is written the same in both. ParseMove reads either.
+
+// NotationStyle is a way of writing wide turns
+type NotationStyle int
+
+const (
+	// WCANotation writes wide turns with a w suffix: Rw, 3Rw'
+	WCANotation NotationStyle = iota
+
+	// SiGNNotation writes wide turns as a lowercase face: r, 3r'
+	SiGNNotation
+)
+
+var notationStyleNames = []string{"wca", "sign"}
+
+// String returns the style's lower-case name
+func (s NotationStyle) String() string {
+	if s < 0 || int(s) >= len(notationStyleNames) {
+		return fmt.Sprintf("NotationStyle(%d)", int(s))
+	}
+	return notationStyleNames[s]
+}
+
+// ParseNotationStyle converts a style name such as "wca" or "SiGN" to a
+// NotationStyle
+func ParseNotationStyle(name string) (NotationStyle, error) {
+	for i, styleName := range notationStyleNames {
+		if strings.EqualFold(name, styleName) {
+			return NotationStyle(i), nil
+		}
+	}
+	return WCANotation, fmt.Errorf("unknown notation style: %s (available: %s)", name, strings.Join(notationStyleNames, ", "))
+}
+
+// FormatSequence writes moves separated by spaces in the given style.
+// FormatMoves is FormatSequence in WCANotation.
+func FormatSequence(moves []Move, style NotationStyle) string {
+	parts := make([]string, len(moves))
+	for i, move := range moves {
+		parts[i] = formatMove(move, style)
+	}
+	return strings.Join(parts, " ")
+}
+
+// formatMove writes one move in the given style
+func formatMove(move Move, style NotationStyle) string {
+	notation := move.String()
+	if style != SiGNNotation || !move.Wide || move.Slice != NoSlice || move.Rotation != NoRotation {
+		return notation
+	}
+	// Rw2 becomes r2, 3Fw' becomes 3f'
+	face := strings.IndexByte(notation, 'w') - 1
+	return notation[:face] + strings.ToLower(notation[face:face+1]) + notation[face+2:]
+}
+
+// ConvertNotation rewrites a move sequence in the given style, e.g.
+// "Rw U R'" to "r U R'" for SiGNNotation. Errors are of type *ParseError.
+func ConvertNotation(s string, to NotationStyle) (string, error) {
+	moves, err := ParseMoves(s)
+	if err != nil {
+		return "", err
+	}
+	return FormatSequence(moves, to), nil
+}
@@ -0,0 +1,60 @@
+package cube
+
+import "testing"
+
+func TestConvertNotation(t *testing.T) {
+	tests := []struct {
+		wca, sign string
+	}{
+		{"R U R' U'", "R U R' U'"},
+		{"Rw U Rw'", "r U r'"},
+		{"Lw2 Uw' Dw Fw Bw2", "l2 u' d f b2"},
+		{"3Rw 3Uw2 2R 3L'", "3r 3u2 2R 3L'"},
+		{"M' E2 S x y' z2", "M' E2 S x y' z2"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		if got, err := ConvertNotation(tt.wca, SiGNNotation); err != nil || got != tt.sign {
+			t.Errorf("ConvertNotation(%q, SiGN) = %q, %v, want %q", tt.wca, got, err, tt.sign)
+		}
+		if got, err := ConvertNotation(tt.sign, WCANotation); err != nil || got != tt.wca {
+			t.Errorf("ConvertNotation(%q, WCA) = %q, %v, want %q", tt.sign, got, err, tt.wca)
+		}
+		// Converting to the style a sequence is already in changes nothing
+		if got, _ := ConvertNotation(tt.sign, SiGNNotation); got != tt.sign {
+			t.Errorf("ConvertNotation(%q, SiGN) = %q, want it unchanged", tt.sign, got)
+		}
+	}
+
+	if _, err := ConvertNotation("R Q", SiGNNotation); err == nil {
+		t.Error("ConvertNotation with a bad move succeeded")
+	}
+}
+
+func TestSiGNWideMovesMatchWCA(t *testing.T) {
+	for _, pair := range [][2]string{{"r U2 3l'", "Rw U2 3Lw'"}, {"2r", "2Rw"}} {
+		a, b := NewCube(5), NewCube(5)
+		sign, err := ParseScramble(pair[0])
+		if err != nil {
+			t.Fatal(err)
+		}
+		wca, _ := ParseScramble(pair[1])
+		a.ApplyMoves(sign)
+		b.ApplyMoves(wca)
+		if a.String() != b.String() {
+			t.Errorf("%s and %s give different states", pair[0], pair[1])
+		}
+	}
+}
+
+func TestParseNotationStyle(t *testing.T) {
+	for name, want := range map[string]NotationStyle{"wca": WCANotation, "SiGN": SiGNNotation} {
+		if got, err := ParseNotationStyle(name); err != nil || got != want {
+			t.Errorf("ParseNotationStyle(%q) = %v, %v, want %v", name, got, err, want)
+		}
+	}
+	if _, err := ParseNotationStyle("qtm"); err == nil {
+		t.Error("ParseNotationStyle(\"qtm\") succeeded")
+	}
+}
@@ -29,7 +29,8 @@ func OptimizeMoves(moves []Move) []Move {
 			lastMove := &optimized[len(optimized)-1]
 
 			// Same face moves can be combined
-			if lastMove.Face == currentMove.Face &&
+			if lastMove.Rotation == NoRotation &&
+				lastMove.Face == currentMove.Face &&
 				lastMove.Wide == currentMove.Wide &&
 				lastMove.Layer == currentMove.Layer &&
 				lastMove.Slice == NoSlice && currentMove.Slice == NoSlice {
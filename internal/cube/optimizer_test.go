@@ -55,6 +55,11 @@ func TestOptimizeMoves(t *testing.T) {
 			input:    "R U R' U'",
 			expected: "R U R' U'",
 		},
+		{
+			name:     "Rotation doesn't combine with the next move",
+			input:    "y F",
+			expected: "y F",
+		},
 		{
 			name:     "Mixed optimization",
 			input:    "R R U U' F F F",
@@ -0,0 +1,64 @@
+package cube
+
+// rotationCycles lists, for each clockwise rotation, the positions whose
+// faces it moves: the face in each position moves to the next one
+var rotationCycles = map[RotationType][4]Face{
+	X_Rotation: {Front, Up, Back, Down},
+	Y_Rotation: {Right, Front, Left, Back},
+	Z_Rotation: {Left, Up, Right, Down},
+}
+
+// homeFacing is the facing of a cube that has not been rotated
+var homeFacing = [6]Face{Front, Back, Left, Right, Up, Down}
+
+// Orientation returns the solved colors of the faces now on top and in
+// front. A new cube starts with its own Up and Front colors; every x, y,
+// and z rotation applied since moves them, so after a solution they tell
+// how the cube ended up held.
+func (c *Cube) Orientation() (up, front Color) {
+	return c.SolvedColor(c.FaceAt(Up)), c.SolvedColor(c.FaceAt(Front))
+}
+
+// FaceAt returns the face, named by where it sat before any rotations,
+// that is now in position
+func (c *Cube) FaceAt(position Face) Face {
+	if c.facing == nil {
+		return position
+	}
+	return c.facing[position]
+}
+
+// IsHomeOrientation reports whether the rotations applied to the cube
+// cancel out, leaving every face where it started
+func (c *Cube) IsHomeOrientation() bool {
+	return c.facing == nil || *c.facing == homeFacing
+}
+
+// trackRotation updates the cube's facing for a whole-cube rotation
+func (c *Cube) trackRotation(move Move) {
+	cycle, ok := rotationCycles[move.Rotation]
+	if !ok {
+		return
+	}
+
+	turns := 1
+	switch {
+	case move.Double:
+		turns = 2
+	case !move.Clockwise:
+		turns = 3
+	}
+
+	if c.facing == nil {
+		facing := homeFacing
+		c.facing = &facing
+	}
+	facing := c.facing
+	for ; turns > 0; turns-- {
+		last := facing[cycle[3]]
+		for i := 3; i > 0; i-- {
+			facing[cycle[i]] = facing[cycle[i-1]]
+		}
+		facing[cycle[0]] = last
+	}
+}
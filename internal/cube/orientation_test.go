@@ -0,0 +1,98 @@
+package cube
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestOrientation(t *testing.T) {
+	tests := []struct {
+		rotations string
+		up, front Color
+	}{
+		{"", Yellow, Blue},
+		{"x", Blue, White},
+		{"x'", Green, Yellow},
+		{"y", Yellow, Red},
+		{"y2", Yellow, Green},
+		{"z", Orange, Blue},
+		{"z'", Red, Blue},
+		{"x y", Blue, Red},
+		{"x2 z2", Yellow, Green},
+		{"R U x F' y' D", Blue, Orange},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.rotations, func(t *testing.T) {
+			for _, size := range []int{2, 3, 4} {
+				c := NewCube(size)
+				moves, err := ParseScramble(tt.rotations)
+				if err != nil {
+					t.Fatalf("ParseScramble(%q) error = %v", tt.rotations, err)
+				}
+				c.ApplyMoves(moves)
+				if up, front := c.Orientation(); up != tt.up || front != tt.front {
+					t.Errorf("%dx%d: Orientation() = %s, %s, want %s, %s", size, size, up, front, tt.up, tt.front)
+				}
+			}
+		})
+	}
+}
+
+// TestOrientationMatchesCenters checks the tracked orientation against the
+// centers of a 3x3 after random rotations on either engine
+func TestOrientationMatchesCenters(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for _, engine := range []Engine{PermutationEngine, GeometricEngine} {
+		c := NewCube(3, WithEngine(engine))
+		for i := 0; i < 200; i++ {
+			move := Move{Rotation: []RotationType{X_Rotation, Y_Rotation, Z_Rotation}[rng.Intn(3)]}
+			switch rng.Intn(3) {
+			case 0:
+				move.Clockwise = true
+			case 1:
+				move.Double = true
+			}
+			c.ApplyMove(move)
+
+			up, front := c.Orientation()
+			if up != c.Faces[Up][1][1] || front != c.Faces[Front][1][1] {
+				t.Fatalf("engine %d after %d rotations: Orientation() = %s, %s, centers are %s, %s",
+					engine, i+1, up, front, c.Faces[Up][1][1], c.Faces[Front][1][1])
+			}
+			for position := Front; position <= Down; position++ {
+				if c.SolvedColor(c.FaceAt(position)) != c.Faces[position][1][1] {
+					t.Fatalf("engine %d: FaceAt(%s) = %s, center is %s", engine, position, c.FaceAt(position), c.Faces[position][1][1])
+				}
+			}
+		}
+	}
+}
+
+func TestOrientationHomeAndClone(t *testing.T) {
+	c := NewCube(3)
+	c.ApplyMoves([]Move{{Rotation: Y_Rotation, Clockwise: true}})
+	if c.IsHomeOrientation() {
+		t.Error("IsHomeOrientation() = true after y")
+	}
+
+	clone := c.Clone()
+	clone.ApplyMoves([]Move{{Rotation: Y_Rotation}})
+	if !clone.IsHomeOrientation() {
+		t.Error("IsHomeOrientation() = false after y y'")
+	}
+	if _, front := c.Orientation(); front != Red {
+		t.Errorf("rotating a clone changed the original's front to %s", front)
+	}
+}
+
+func TestOrientationWithScheme(t *testing.T) {
+	c, err := NewCubeWithScheme(3, japaneseScheme)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.ApplyMove(Move{Rotation: X_Rotation, Clockwise: true})
+	if up, front := c.Orientation(); up != japaneseScheme[Front] || front != japaneseScheme[Down] {
+		t.Errorf("Orientation() = %s, %s after x", up, front)
+	}
+}
@@ -0,0 +1,112 @@
+package cube
+
+// Parity detection for the 4x4 reduction method. After centers are solved
+// and edges paired, the cube is solved like a 3x3, except that the paired
+// edges can end up in states no 3x3 can reach. Both detectors expect a
+// reduced 4x4 with the first two layers solved and report false otherwise.
+
+// HasOLLParity reports whether a reduced 4x4 has an odd number of flipped
+// last layer edges, which no 3x3 OLL algorithm can fix
+func HasOLLParity(c *Cube) bool {
+	if c.Size != 4 || !isF2LSolved(c) {
+		return false
+	}
+
+	up := c.Faces[Up][1][1]
+	oriented := 0
+	for _, dedge := range lastLayerDedges {
+		top := 0
+		for _, wing := range dedge {
+			if c.Faces[wing.Face][wing.Row][wing.Col] == up {
+				top++
+			}
+		}
+		switch top {
+		case 2:
+			oriented++
+		case 1:
+			return false // edges are not paired
+		}
+	}
+	return oriented%2 == 1
+}
+
+// HasPLLParity reports whether an oriented last layer on a reduced 4x4 has
+// corner and edge permutations of different parity, which no 3x3 PLL
+// algorithm can fix
+func HasPLLParity(c *Cube) bool {
+	if c.Size != 4 || !isF2LSolved(c) || !isLastLayerOriented(c) {
+		return false
+	}
+
+	n := len(lastLayerSides)
+	corners := make([]int, n)
+	edges := make([]int, n)
+	for i, face := range lastLayerSides {
+		// The corner shared with the next side is read from this side's
+		// leftmost top sticker
+		corners[i] = lastLayerHome(c, c.Faces[face][0][0])
+
+		edges[i] = lastLayerHome(c, c.Faces[face][0][1])
+		if c.Faces[face][0][2] != c.Faces[face][0][1] {
+			return false // edges are not paired
+		}
+	}
+
+	cornerParity, ok := permutationParity(corners)
+	if !ok {
+		return false
+	}
+	edgeParity, ok := permutationParity(edges)
+	if !ok {
+		return false
+	}
+	return cornerParity != edgeParity
+}
+
+// lastLayerDedges lists the U face coordinates of the two wing stickers of
+// each last layer edge pair on a 4x4, in lastLayerSides order
+var lastLayerDedges = [][2]Coord{
+	{{Up, 3, 1}, {Up, 3, 2}}, // Front
+	{{Up, 1, 0}, {Up, 2, 0}}, // Left
+	{{Up, 0, 1}, {Up, 0, 2}}, // Back
+	{{Up, 1, 3}, {Up, 2, 3}}, // Right
+}
+
+// lastLayerHome returns the index in lastLayerSides of the side whose center
+// has the given color, or -1 if none does
+func lastLayerHome(c *Cube, color Color) int {
+	for i, face := range lastLayerSides {
+		if c.Faces[face][1][1] == color {
+			return i
+		}
+	}
+	return -1
+}
+
+// permutationParity returns 0 for an even permutation and 1 for an odd one.
+// It reports false if perm is not a permutation of 0..len(perm)-1.
+func permutationParity(perm []int) (int, bool) {
+	seen := make([]bool, len(perm))
+	for _, p := range perm {
+		if p < 0 || p >= len(perm) || seen[p] {
+			return 0, false
+		}
+		seen[p] = true
+	}
+
+	parity := 0
+	visited := make([]bool, len(perm))
+	for i := range perm {
+		if visited[i] {
+			continue
+		}
+		length := 0
+		for j := i; !visited[j]; j = perm[j] {
+			visited[j] = true
+			length++
+		}
+		parity += length - 1
+	}
+	return parity % 2, true
+}
@@ -0,0 +1,102 @@
+package cube
+
+import (
+	"testing"
+)
+
+func parityFix(t *testing.T, caseID string) []Move {
+	t.Helper()
+	algs := GetByCaseID(caseID)
+	if len(algs) == 0 {
+		t.Fatalf("%s not found in algorithm database", caseID)
+	}
+	if algs[0].Category != "Parity" {
+		t.Errorf("%s category = %q, want Parity", caseID, algs[0].Category)
+	}
+	moves, err := ParseScramble(algs[0].Moves)
+	if err != nil {
+		t.Fatalf("ParseScramble(%q) error: %v", algs[0].Moves, err)
+	}
+	return moves
+}
+
+func apply4x4(t *testing.T, c *Cube, moves string) {
+	t.Helper()
+	parsed, err := ParseScramble(moves)
+	if err != nil {
+		t.Fatalf("ParseScramble(%q) error: %v", moves, err)
+	}
+	c.ApplyMoves(parsed)
+}
+
+func TestHasOLLParity(t *testing.T) {
+	fix := parityFix(t, "PARITY-OLL")
+
+	c := NewCube(4)
+	c.ApplyInverseMoves(fix)
+	apply4x4(t, c, "U")
+	if !HasOLLParity(c) {
+		t.Fatal("HasOLLParity should detect a single flipped edge pair")
+	}
+	if HasPLLParity(c) {
+		t.Error("HasPLLParity should ignore an unoriented last layer")
+	}
+
+	apply4x4(t, c, "U'")
+	c.ApplyMoves(fix)
+	if !c.IsSolved() {
+		t.Errorf("OLL parity fix did not solve the cube:\n%s", c)
+	}
+
+	// Outer layer turns act on a reduced 4x4 like on a 3x3, so Sune leaves
+	// an ordinary OLL case
+	c = NewCube(4)
+	apply4x4(t, c, "R U R' U R U2 R'")
+	if !isF2LSolved(c) {
+		t.Fatal("Sune should keep F2L solved")
+	}
+	if HasOLLParity(c) {
+		t.Error("HasOLLParity should not fire for a 3x3-style OLL case")
+	}
+}
+
+func TestHasPLLParity(t *testing.T) {
+	fix := parityFix(t, "PARITY-PLL")
+
+	c := NewCube(4)
+	c.ApplyInverseMoves(fix)
+	apply4x4(t, c, "U2")
+	if !HasPLLParity(c) {
+		t.Fatal("HasPLLParity should detect two swapped edge pairs")
+	}
+	if HasOLLParity(c) {
+		t.Error("HasOLLParity should not fire for an oriented last layer")
+	}
+
+	apply4x4(t, c, "U2")
+	c.ApplyMoves(fix)
+	if !c.IsSolved() {
+		t.Errorf("PLL parity fix did not solve the cube:\n%s", c)
+	}
+
+	// A U turn and a T-perm are both ordinary 3x3 PLL states
+	for _, setup := range []string{"U", "R U R' U' R' F R2 U' R' U' R U R' F'"} {
+		c = NewCube(4)
+		apply4x4(t, c, setup)
+		if !isF2LSolved(c) || !isLastLayerOriented(c) {
+			t.Fatalf("%s should leave an oriented last layer", setup)
+		}
+		if HasPLLParity(c) {
+			t.Errorf("HasPLLParity should not fire after %s", setup)
+		}
+	}
+}
+
+func TestParityRequires4x4(t *testing.T) {
+	for _, size := range []int{2, 3, 5} {
+		c := NewCube(size)
+		if HasOLLParity(c) || HasPLLParity(c) {
+			t.Errorf("parity detectors should report false on %dx%d", size, size)
+		}
+	}
+}
@@ -5,14 +5,44 @@ package cube
 // This file implements pattern recognition for common cube solving stages
 // like white cross, first two layers, OLL, PLL, etc.
 
-// Pattern represents a recognizable cube state or partial state
+// Pattern represents a recognizable cube state or partial state, such as
+// a solved cross or F2L slot. The solvers use patterns to decide when a
+// stage is done, and custom solvers and checkers can combine them.
+//
+// The built-in patterns only match 3x3 cubes. They judge pieces against
+// the centers, so they hold however the cube is rotated and whatever its
+// color scheme: the "white" cross is the cross on the Down center.
 type Pattern interface {
+	// Name returns a short display name
 	Name() string
+
+	// Matches reports whether every piece the pattern covers is in its
+	// home position and correctly oriented
 	Matches(cube *Cube) bool
+
+	// Description explains what the pattern requires
 	Description() string
+
+	// CompletionPercent estimates progress toward the pattern from 0 to
+	// 100; it is 100 when Matches is true
 	CompletionPercent(cube *Cube) float64
 }
 
+// crossEdges are the edgeFacelets positions of the Down cross (DR, DF, DL, DB)
+var crossEdges = [4]int{4, 5, 6, 7}
+
+// edgeSolved reports whether the edge at pos is home and not flipped
+func (c *Cube) edgeSolved(pos int) bool {
+	piece, flip := c.edgeAt(pos)
+	return piece == pos && flip == 0
+}
+
+// cornerSolved reports whether the corner at pos is home and not twisted
+func (c *Cube) cornerSolved(pos int) bool {
+	piece, twist := c.cornerAt(pos)
+	return piece == pos && twist == 0
+}
+
 // WhiteCrossPattern checks if the white cross is solved
 type WhiteCrossPattern struct{}
 
@@ -28,22 +58,13 @@ func (p WhiteCrossPattern) Matches(cube *Cube) bool {
 	if cube.Size != 3 {
 		return false
 	}
-	
-	// Check that all four white edges are in correct positions
-	whiteEdges := [][]Color{
-		{White, Blue},   // White-Blue edge (front)
-		{White, Red},    // White-Red edge (right) 
-		{White, Green},  // White-Green edge (back)
-		{White, Orange}, // White-Orange edge (left)
-	}
-	
-	for _, edgeColors := range whiteEdges {
-		edge := cube.GetPieceByColors(edgeColors)
-		if edge == nil || !cube.IsPieceInCorrectPosition(edgeColors) {
+
+	// Each cross edge must be home with its white sticker on the bottom
+	for _, edge := range crossEdges {
+		if !cube.edgeSolved(edge) {
 			return false
 		}
 	}
-	
 	return true
 }
 
@@ -51,18 +72,13 @@ func (p WhiteCrossPattern) CompletionPercent(cube *Cube) float64 {
 	if cube.Size != 3 {
 		return 0.0
 	}
-	
-	whiteEdges := [][]Color{
-		{White, Blue}, {White, Red}, {White, Green}, {White, Orange},
-	}
-	
+
 	correct := 0
-	for _, edgeColors := range whiteEdges {
-		if cube.IsPieceInCorrectPosition(edgeColors) {
+	for _, edge := range crossEdges {
+		if cube.edgeSolved(edge) {
 			correct++
 		}
 	}
-	
 	return float64(correct) / 4.0 * 100.0
 }
 
@@ -84,20 +100,12 @@ func (p WhiteLayerPattern) Matches(cube *Cube) bool {
 		return false
 	}
 	
-	// Check that all four white corners are in correct positions
-	whiteCorners := [][]Color{
-		{White, Blue, Red},    // White-Blue-Red corner
-		{White, Red, Green},   // White-Red-Green corner  
-		{White, Green, Orange}, // White-Green-Orange corner
-		{White, Orange, Blue}, // White-Orange-Blue corner
-	}
-	
-	for _, cornerColors := range whiteCorners {
-		if !cube.IsPieceInCorrectPosition(cornerColors) {
+	// Each bottom corner must be home and untwisted
+	for _, corner := range f2lSlotCorners {
+		if !cube.cornerSolved(corner) {
 			return false
 		}
 	}
-	
 	return true
 }
 
@@ -106,14 +114,9 @@ func (p WhiteLayerPattern) CompletionPercent(cube *Cube) float64 {
 		return 0.0
 	}
 	
-	whiteCorners := [][]Color{
-		{White, Blue, Red}, {White, Red, Green}, 
-		{White, Green, Orange}, {White, Orange, Blue},
-	}
-	
 	correct := 0
-	for _, cornerColors := range whiteCorners {
-		if cube.IsPieceInCorrectPosition(cornerColors) {
+	for _, corner := range f2lSlotCorners {
+		if cube.cornerSolved(corner) {
 			correct++
 		}
 	}
@@ -142,7 +145,7 @@ func (p F2LSlotPattern) Name() string {
 }
 
 func (p F2LSlotPattern) Description() string {
-	return "Corner-edge pair correctly positioned in F2L slot"
+	return "Corner-edge pair solved (placed and oriented) in F2L slot"
 }
 
 func (p F2LSlotPattern) Matches(cube *Cube) bool {
@@ -150,46 +153,38 @@ func (p F2LSlotPattern) Matches(cube *Cube) bool {
 		return false
 	}
 	
-	// Define F2L slot pieces
-	slotCorners := [][]Color{
-		{White, Blue, Red},    // Slot 0: Front-Right corner
-		{White, Red, Green},   // Slot 1: Back-Right corner
-		{White, Green, Orange}, // Slot 2: Back-Left corner
-		{White, Orange, Blue}, // Slot 3: Front-Left corner
-	}
-	
-	slotEdges := [][]Color{
-		{Blue, Red},    // Slot 0: Front-Right edge
-		{Red, Green},   // Slot 1: Back-Right edge  
-		{Green, Orange}, // Slot 2: Back-Left edge
-		{Orange, Blue}, // Slot 3: Front-Left edge
-	}
-	
-	if p.Slot >= len(slotCorners) || p.Slot >= len(slotEdges) {
-		return false
-	}
-	
-	cornerColors := slotCorners[p.Slot]
-	edgeColors := slotEdges[p.Slot]
-	
-	return cube.IsPieceInCorrectPosition(cornerColors) &&
-		   cube.IsPieceInCorrectPosition(edgeColors)
+	// A slot is solved when its corner and edge are home and oriented
+	return cube.cornerSolved(f2lSlotCorners[p.Slot]) && cube.edgeSolved(f2lSlotEdges[p.Slot])
 }
 
+// f2lSlotCorners and f2lSlotEdges give the cornerFacelets and edgeFacelets
+// positions of each F2L slot (FR, BR, BL, FL)
+var f2lSlotCorners = [4]int{4, 7, 6, 5} // DFR, DRB, DBL, DLF
+var f2lSlotEdges = [4]int{8, 11, 10, 9}  // FR, BR, BL, FL
+
 func (p F2LSlotPattern) CompletionPercent(cube *Cube) float64 {
 	if cube.Size != 3 {
 		return 0.0
 	}
 	
-	if p.Matches(cube) {
-		return 100.0
+	if p.Slot < 0 || p.Slot > 3 {
+		return 0.0
 	}
-	
-	// Could add partial completion logic here
-	return 0.0
+
+	// Half for each of the pair's two pieces
+	percent := 0.0
+	if cube.cornerSolved(f2lSlotCorners[p.Slot]) {
+		percent += 50.0
+	}
+	if cube.edgeSolved(f2lSlotEdges[p.Slot]) {
+		percent += 50.0
+	}
+	return percent
 }
 
-// OLLSolvedPattern checks if the last layer orientation is solved (all yellow on top)
+// OLLSolvedPattern checks if the last layer orientation is solved: every
+// Up sticker matches the Up center (all yellow on top). It says nothing
+// about the layers below.
 type OLLSolvedPattern struct{}
 
 func (p OLLSolvedPattern) Name() string {
@@ -205,10 +200,11 @@ func (p OLLSolvedPattern) Matches(cube *Cube) bool {
 		return false
 	}
 	
-	// Check that all stickers on the Up face are yellow
+	// Check that all stickers on the Up face match its center
+	center := cube.Faces[Up][1][1]
 	for row := 0; row < cube.Size; row++ {
 		for col := 0; col < cube.Size; col++ {
-			if cube.Faces[Up][row][col] != Yellow {
+			if cube.Faces[Up][row][col] != center {
 				return false
 			}
 		}
@@ -222,21 +218,21 @@ func (p OLLSolvedPattern) CompletionPercent(cube *Cube) float64 {
 		return 0.0
 	}
 	
-	yellowCount := 0
+	center := cube.Faces[Up][1][1]
+	matching := 0
 	totalStickers := cube.Size * cube.Size
-	
 	for row := 0; row < cube.Size; row++ {
 		for col := 0; col < cube.Size; col++ {
-			if cube.Faces[Up][row][col] == Yellow {
-				yellowCount++
+			if cube.Faces[Up][row][col] == center {
+				matching++
 			}
 		}
 	}
-	
-	return float64(yellowCount) / float64(totalStickers) * 100.0
+	return float64(matching) / float64(totalStickers) * 100.0
 }
 
-// PLLSolvedPattern checks if the last layer is completely solved
+// PLLSolvedPattern checks if the last layer is completely solved. PLL is
+// the final step, so it matches only a solved cube.
 type PLLSolvedPattern struct{}
 
 func (p PLLSolvedPattern) Name() string {
@@ -256,27 +252,29 @@ func (p PLLSolvedPattern) CompletionPercent(cube *Cube) float64 {
 		return 100.0
 	}
 	
-	// Check OLL first
+	// Check F2L and OLL first
 	ollPattern := OLLSolvedPattern{}
 	if !ollPattern.Matches(cube) {
 		return 0.0 // Can't have PLL without OLL
 	}
-	
-	// Count correctly positioned last layer pieces
-	// This is a simplified check - could be more sophisticated
-	lastLayerPieces := [][]Color{
-		{Yellow, Blue}, {Yellow, Red}, {Yellow, Green}, {Yellow, Orange}, // Edges
-		{Yellow, Blue, Red}, {Yellow, Red, Green}, {Yellow, Green, Orange}, {Yellow, Orange, Blue}, // Corners
+	for slot := 0; slot < 4; slot++ {
+		if !(F2LSlotPattern{Slot: slot}).Matches(cube) || !cube.edgeSolved(crossEdges[slot]) {
+			return 0.0
+		}
 	}
 	
+	// Count the last layer pieces already home; the first four corner and
+	// edge positions are the Up layer's
 	correct := 0
-	for _, pieceColors := range lastLayerPieces {
-		if cube.IsPieceInCorrectPosition(pieceColors) {
+	for pos := 0; pos < 4; pos++ {
+		if cube.edgeSolved(pos) {
+			correct++
+		}
+		if cube.cornerSolved(pos) {
 			correct++
 		}
 	}
-	
-	return float64(correct) / float64(len(lastLayerPieces)) * 100.0
+	return float64(correct) / 8.0 * 100.0
 }
 
 // GetAllPatterns returns all available patterns for recognition
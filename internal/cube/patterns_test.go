@@ -0,0 +1,209 @@
+package cube
+
+import (
+	"fmt"
+	"testing"
+)
+
+// disturbance is a constructed change to a solved 3x3: an edge flipped or
+// a corner twisted in place, or two edges or corners swapped
+type disturbance struct {
+	name    string
+	edges   []int // edge positions it changes
+	corners []int // corner positions it changes
+	// upOnly is true when every Up sticker stays on the Up face
+	upOnly bool
+	apply  func(c *Cube)
+}
+
+func swapFacelets(c *Cube, a, b Coord) {
+	c.Faces[a.Face][a.Row][a.Col], c.Faces[b.Face][b.Row][b.Col] =
+		c.Faces[b.Face][b.Row][b.Col], c.Faces[a.Face][a.Row][a.Col]
+}
+
+// allDisturbances lists every edge flip, corner twist, edge swap, and
+// corner swap
+func allDisturbances() []disturbance {
+	var all []disturbance
+	isUp := func(pos int) bool { return pos < 4 }
+
+	for e := 0; e < 12; e++ {
+		e := e
+		all = append(all, disturbance{
+			name:  fmt.Sprintf("flip edge %d", e),
+			edges: []int{e},
+			apply: func(c *Cube) { swapFacelets(c, edgeFacelets[e][0], edgeFacelets[e][1]) },
+		})
+		for other := e + 1; other < 12; other++ {
+			other := other
+			all = append(all, disturbance{
+				name:   fmt.Sprintf("swap edges %d and %d", e, other),
+				edges:  []int{e, other},
+				upOnly: isUp(e) == isUp(other),
+				apply: func(c *Cube) {
+					for i := 0; i < 2; i++ {
+						swapFacelets(c, edgeFacelets[e][i], edgeFacelets[other][i])
+					}
+				},
+			})
+		}
+	}
+
+	for k := 0; k < 8; k++ {
+		k := k
+		for twist := 1; twist <= 2; twist++ {
+			twist := twist
+			all = append(all, disturbance{
+				name:    fmt.Sprintf("twist corner %d by %d", k, twist),
+				corners: []int{k},
+				apply: func(c *Cube) {
+					for t := 0; t < twist; t++ {
+						swapFacelets(c, cornerFacelets[k][0], cornerFacelets[k][1])
+						swapFacelets(c, cornerFacelets[k][0], cornerFacelets[k][2])
+					}
+				},
+			})
+		}
+		for other := k + 1; other < 8; other++ {
+			other := other
+			all = append(all, disturbance{
+				name:    fmt.Sprintf("swap corners %d and %d", k, other),
+				corners: []int{k, other},
+				upOnly:  isUp(k) == isUp(other),
+				apply: func(c *Cube) {
+					for i := 0; i < 3; i++ {
+						swapFacelets(c, cornerFacelets[k][i], cornerFacelets[other][i])
+					}
+				},
+			})
+		}
+	}
+	return all
+}
+
+func touches(positions []int, covered ...int) bool {
+	for _, pos := range positions {
+		for _, c := range covered {
+			if pos == c {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// TestPatternsOnConstructedStates disturbs a solved cube in every single
+// way and checks each pattern matches exactly when none of its pieces moved
+func TestPatternsOnConstructedStates(t *testing.T) {
+	type check struct {
+		pattern Pattern
+		breaks  func(d disturbance) bool
+	}
+	checks := []check{
+		{WhiteCrossPattern{}, func(d disturbance) bool { return touches(d.edges, crossEdges[:]...) }},
+		{WhiteLayerPattern{}, func(d disturbance) bool {
+			return touches(d.edges, crossEdges[:]...) || touches(d.corners, f2lSlotCorners[:]...)
+		}},
+		{OLLSolvedPattern{}, func(d disturbance) bool {
+			return !d.upOnly && (touches(d.edges, 0, 1, 2, 3) || touches(d.corners, 0, 1, 2, 3))
+		}},
+		{PLLSolvedPattern{}, func(d disturbance) bool { return true }},
+	}
+	for slot := 0; slot < 4; slot++ {
+		slot := slot
+		checks = append(checks, check{F2LSlotPattern{Slot: slot}, func(d disturbance) bool {
+			return touches(d.edges, f2lSlotEdges[slot]) || touches(d.corners, f2lSlotCorners[slot])
+		}})
+	}
+
+	solved := NewCube(3)
+	for _, check := range checks {
+		if !check.pattern.Matches(solved) || check.pattern.CompletionPercent(solved) != 100 {
+			t.Errorf("%s: solved cube doesn't match", check.pattern.Name())
+		}
+	}
+
+	for _, d := range allDisturbances() {
+		c := NewCube(3)
+		d.apply(c)
+		for _, check := range checks {
+			want := !check.breaks(d)
+			if got := check.pattern.Matches(c); got != want {
+				t.Errorf("%s after %s: Matches = %v, want %v", check.pattern.Name(), d.name, got, want)
+			}
+			if percent := check.pattern.CompletionPercent(c); !want && percent >= 100 {
+				t.Errorf("%s after %s: CompletionPercent = %.0f without a match", check.pattern.Name(), d.name, percent)
+			}
+		}
+	}
+}
+
+func TestPatternsAfterMoves(t *testing.T) {
+	tests := []struct {
+		moves             string
+		cross, layer, oll bool
+		slots             [4]bool
+		pll               bool
+	}{
+		{"U", true, true, true, [4]bool{true, true, true, true}, false},
+		{"R U R' U'", true, false, false, [4]bool{false, true, true, true}, false},
+		{"F", false, false, false, [4]bool{false, true, true, false}, false},
+		{"x2 y", true, true, true, [4]bool{true, true, true, true}, true},
+		{"R U R' U R U2 R'", true, true, false, [4]bool{true, true, true, true}, false},
+	}
+
+	for _, tt := range tests {
+		c := NewCube(3)
+		moves, err := ParseScramble(tt.moves)
+		if err != nil {
+			t.Fatal(err)
+		}
+		c.ApplyMoves(moves)
+
+		if got := (WhiteCrossPattern{}).Matches(c); got != tt.cross {
+			t.Errorf("%s: cross = %v, want %v", tt.moves, got, tt.cross)
+		}
+		if got := (WhiteLayerPattern{}).Matches(c); got != tt.layer {
+			t.Errorf("%s: layer = %v, want %v", tt.moves, got, tt.layer)
+		}
+		for slot, want := range tt.slots {
+			if got := (F2LSlotPattern{Slot: slot}).Matches(c); got != want {
+				t.Errorf("%s: slot %d = %v, want %v", tt.moves, slot, got, want)
+			}
+		}
+		if got := (OLLSolvedPattern{}).Matches(c); got != tt.oll {
+			t.Errorf("%s: OLL = %v, want %v", tt.moves, got, tt.oll)
+		}
+		if got := (PLLSolvedPattern{}).Matches(c); got != tt.pll {
+			t.Errorf("%s: PLL = %v, want %v", tt.moves, got, tt.pll)
+		}
+	}
+}
+
+func TestPatternsWithScheme(t *testing.T) {
+	c, err := NewCubeWithScheme(3, japaneseScheme)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.ApplyMove(Move{Face: Up, Clockwise: true})
+	for _, pattern := range GetAllPatterns() {
+		_, isPLL := pattern.(PLLSolvedPattern)
+		if got := pattern.Matches(c); got == isPLL {
+			t.Errorf("%s: Matches = %v on a Japanese-scheme cube after U", pattern.Name(), got)
+		}
+	}
+}
+
+func TestPatternsOtherSizes(t *testing.T) {
+	for _, size := range []int{2, 4} {
+		c := NewCube(size)
+		for _, pattern := range GetAllPatterns() {
+			if _, isPLL := pattern.(PLLSolvedPattern); isPLL {
+				continue
+			}
+			if pattern.Matches(c) {
+				t.Errorf("%s matches a %dx%d", pattern.Name(), size, size)
+			}
+		}
+	}
+}
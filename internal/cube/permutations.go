@@ -6,8 +6,8 @@ package cube
 // the current coordinate-based approach. It pre-computes permutations for all moves and
 // applies them via array indexing for potentially better performance.
 //
-// Status: Complete implementation but not used (current system uses rings.go)
-// Activation: Set environment variable CUBE_USE_PERMUTATION_MOVES=true
+// Status: Default move engine (PermutationEngine); see engine.go for the
+// geometric reference engine
 // Performance: Potentially faster for repeated moves, more memory usage
 //
 // This system could be valuable for:
@@ -15,7 +15,10 @@ package cube
 // - Batch move application
 // - Algorithm verification at scale
 
-import "sync"
+import (
+	"fmt"
+	"sync"
+)
 
 // PermKey represents a cache key for permutations
 type PermKey struct {
@@ -31,6 +34,8 @@ var permCacheMu sync.RWMutex
 
 // getPermutation retrieves or generates a permutation from cache
 func getPermutation(N int, moveType MoveType, layer int, quarterTurns int) Permutation {
+	// Equivalent turn counts share one cache entry
+	quarterTurns = ((quarterTurns % 4) + 4) % 4
 	key := PermKey{N, moveType, layer, quarterTurns}
 
 	permCacheMu.RLock()
@@ -80,13 +85,17 @@ func generatePermutation(N int, moveType MoveType, layer int, quarterTurns int)
 	case MoveS:
 		ring = ringS(N, layer)
 	case MoveX:
-		return generateCubeRotationPermutation(N, MoveX, (4-quarterTurns)%4)
+		// Rotations turn every layer, one layer at a time: x follows R,
+		// y follows U, and z follows F
+		return generatePermutation(N, MoveR, layer, quarterTurns)
 	case MoveY:
-		return generateCubeRotationPermutation(N, MoveY, (4-quarterTurns)%4)
+		return generatePermutation(N, MoveU, layer, quarterTurns)
 	case MoveZ:
-		return generateCubeRotationPermutation(N, MoveZ, (4-quarterTurns)%4)
+		return generatePermutation(N, MoveF, layer, quarterTurns)
 	default:
-		return perm // Return identity for unsupported moves for now
+		// Turning nothing would hide the bug, so fail loudly; callers can
+		// use Move.Validate or ApplyMoveChecked to reject bad moves first
+		panic(fmt.Sprintf("no permutation for move type %d", moveType))
 	}
 
 	if ring == nil {
@@ -116,166 +125,12 @@ func generatePermutation(N int, moveType MoveType, layer int, quarterTurns int)
 		}
 	}
 
-	return perm
-}
-
-// generateCubeRotationPermutation creates permutation for cube rotations (x, y, z)
-func generateCubeRotationPermutation(N int, rotationType MoveType, quarterTurns int) Permutation {
-	perm := make(Permutation, 6*N*N)
-	// Initialize identity permutation
-	for i := range perm {
-		perm[i] = i
-	}
-
-	// Define face mappings for each rotation type
-	var faceMappings [][]Face
-
-	switch rotationType {
-	case MoveX:
-		// X rotation: around R face axis
-		// Clockwise: F→D, D→B, B→U, U→F, L rotates CCW, R rotates CW
-		if quarterTurns == 1 {
-			faceMappings = [][]Face{
-				{Front, Down},
-				{Down, Back},
-				{Back, Up},
-				{Up, Front},
-			}
-		} else if quarterTurns == 2 {
-			faceMappings = [][]Face{
-				{Front, Back},
-				{Back, Front},
-				{Up, Down},
-				{Down, Up},
-			}
-		} else { // quarterTurns == 3 (CCW)
-			faceMappings = [][]Face{
-				{Front, Up},
-				{Up, Back},
-				{Back, Down},
-				{Down, Front},
-			}
-		}
-
-	case MoveY:
-		// Y rotation: around U face axis
-		// Clockwise: F→L, L→B, B→R, R→F, U rotates CW, D rotates CCW
-		if quarterTurns == 1 {
-			faceMappings = [][]Face{
-				{Front, Left},
-				{Left, Back},
-				{Back, Right},
-				{Right, Front},
-			}
-		} else if quarterTurns == 2 {
-			faceMappings = [][]Face{
-				{Front, Back},
-				{Back, Front},
-				{Left, Right},
-				{Right, Left},
-			}
-		} else { // quarterTurns == 3 (CCW)
-			faceMappings = [][]Face{
-				{Front, Right},
-				{Right, Back},
-				{Back, Left},
-				{Left, Front},
-			}
-		}
-
-	case MoveZ:
-		// Z rotation: around F face axis
-		// Clockwise: U→L, L→D, D→R, R→U, F rotates CW, B rotates CCW
-		if quarterTurns == 1 {
-			faceMappings = [][]Face{
-				{Up, Left},
-				{Left, Down},
-				{Down, Right},
-				{Right, Up},
-			}
-		} else if quarterTurns == 2 {
-			faceMappings = [][]Face{
-				{Up, Down},
-				{Down, Up},
-				{Left, Right},
-				{Right, Left},
-			}
-		} else { // quarterTurns == 3 (CCW)
-			faceMappings = [][]Face{
-				{Up, Right},
-				{Right, Down},
-				{Down, Left},
-				{Left, Up},
-			}
-		}
-
-	default:
-		return perm // Return identity for unknown rotations
-	}
-
-	// Apply face swaps
-	for _, mapping := range faceMappings {
-		srcFace := mapping[0]
-		dstFace := mapping[1]
-
-		// Copy entire face
-		for row := 0; row < N; row++ {
-			for col := 0; col < N; col++ {
-				srcIdx := stickerIndex(srcFace, row, col, N)
-				dstIdx := stickerIndex(dstFace, row, col, N)
-				perm[srcIdx] = dstIdx
-			}
-		}
-	}
-
-	// Handle face rotations for the axis faces
-	switch rotationType {
-	case MoveX:
-		// Left face rotates CCW, Right face rotates CW
-		leftRotPerm := generateFaceRotationPermutation(N, MoveL, 4-quarterTurns) // CCW
-		rightRotPerm := generateFaceRotationPermutation(N, MoveR, quarterTurns)  // CW
-
-		// Compose permutations
-		for i, dst := range leftRotPerm {
-			if dst != i {
-				perm[i] = dst
-			}
-		}
-		for i, dst := range rightRotPerm {
-			if dst != i {
-				perm[i] = dst
-			}
-		}
-
-	case MoveY:
-		// Up face rotates CW, Down face rotates CCW
-		upRotPerm := generateFaceRotationPermutation(N, MoveU, quarterTurns)     // CW
-		downRotPerm := generateFaceRotationPermutation(N, MoveD, 4-quarterTurns) // CCW
-
-		// Compose permutations
-		for i, dst := range upRotPerm {
-			if dst != i {
-				perm[i] = dst
-			}
-		}
-		for i, dst := range downRotPerm {
-			if dst != i {
-				perm[i] = dst
-			}
-		}
-
-	case MoveZ:
-		// Front face rotates CW, Back face rotates CCW
-		frontRotPerm := generateFaceRotationPermutation(N, MoveF, quarterTurns)  // CW
-		backRotPerm := generateFaceRotationPermutation(N, MoveB, 4-quarterTurns) // CCW
-
-		// Compose permutations
-		for i, dst := range frontRotPerm {
-			if dst != i {
-				perm[i] = dst
-			}
-		}
-		for i, dst := range backRotPerm {
+	// The innermost layer (3R on a 3x3, or the last layer of a full-depth
+	// wide move) carries the opposite face, which turns the other way as
+	// seen from that face
+	if layer == N-1 {
+		faceRotationPerm := generateFaceRotationPermutation(N, oppositeMoveType(moveType), (4-quarterTurns)%4)
+		for i, dst := range faceRotationPerm {
 			if dst != i {
 				perm[i] = dst
 			}
@@ -285,6 +140,26 @@ func generateCubeRotationPermutation(N int, rotationType MoveType, quarterTurns
 	return perm
 }
 
+// oppositeMoveType returns the face move on the opposite side of the cube
+func oppositeMoveType(moveType MoveType) MoveType {
+	switch moveType {
+	case MoveR:
+		return MoveL
+	case MoveL:
+		return MoveR
+	case MoveU:
+		return MoveD
+	case MoveD:
+		return MoveU
+	case MoveF:
+		return MoveB
+	case MoveB:
+		return MoveF
+	default:
+		return moveType
+	}
+}
+
 // generateFaceRotationPermutation creates permutation for rotating face stickers
 func generateFaceRotationPermutation(N int, moveType MoveType, quarterTurns int) Permutation {
 	perm := make(Permutation, 6*N*N)
@@ -368,8 +243,9 @@ func rotateSlice(slice []int, quarterTurns int) []int {
 	if n == 0 {
 		return slice
 	}
-	// Normalize quarterTurns to 0-3 range
-	quarterTurns = quarterTurns % 4
+	// Normalize quarterTurns to 0-3 range; Go's % keeps the sign, so a
+	// negative count needs shifting back up
+	quarterTurns = ((quarterTurns % 4) + 4) % 4
 	shift := (quarterTurns * n / 4) % n
 	result := make([]int, n)
 	for i := range slice {
@@ -378,24 +254,28 @@ func rotateSlice(slice []int, quarterTurns int) []int {
 	return result
 }
 
-// applyPermutation applies a permutation to the cube
+// applyPermutation applies a permutation to the cube. The stickers are
+// flattened into the cube's scratch buffer, permuted into its second half,
+// and copied back; the buffer is reused from move to move, so applying a
+// move doesn't allocate.
 func applyPermutation(cube *Cube, perm Permutation) {
 	N := cube.Size
-	colors := make([]Color, 6*N*N)
+	stickers := 6 * N * N
+	if len(cube.scratch) != 2*stickers {
+		cube.scratch = make([]Color, 2*stickers)
+	}
+	colors, newColors := cube.scratch[:stickers], cube.scratch[stickers:]
 
 	// Flatten cube to linear array
 	idx := 0
 	for face := 0; face < 6; face++ {
 		for row := 0; row < N; row++ {
-			for col := 0; col < N; col++ {
-				colors[idx] = cube.Faces[face][row][col]
-				idx++
-			}
+			copy(colors[idx:idx+N], cube.Faces[face][row])
+			idx += N
 		}
 	}
 
 	// Apply permutation
-	newColors := make([]Color, 6*N*N)
 	for src, dst := range perm {
 		newColors[dst] = colors[src]
 	}
@@ -404,10 +284,8 @@ func applyPermutation(cube *Cube, perm Permutation) {
 	idx = 0
 	for face := 0; face < 6; face++ {
 		for row := 0; row < N; row++ {
-			for col := 0; col < N; col++ {
-				cube.Faces[face][row][col] = newColors[idx]
-				idx++
-			}
+			copy(cube.Faces[face][row], newColors[idx:idx+N])
+			idx += N
 		}
 	}
 }
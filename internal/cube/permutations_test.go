@@ -0,0 +1,149 @@
+package cube
+
+import (
+	"fmt"
+	"math/rand"
+	"sync"
+	"testing"
+)
+
+// resetPermCache empties the permutation cache so the next moves have to
+// generate their permutations
+func resetPermCache() {
+	permCacheMu.Lock()
+	permCache = make(map[PermKey]Permutation)
+	permCacheMu.Unlock()
+}
+
+// randomCacheMove returns a random face, wide, layered, slice, or rotation
+// move for an N x N cube
+func randomCacheMove(N int, rng *rand.Rand) Move {
+	faces := []Face{Front, Back, Left, Right, Up, Down}
+	move := Move{Face: faces[rng.Intn(len(faces))]}
+	switch rng.Intn(5) {
+	case 1:
+		move.Wide = N > 2
+	case 2:
+		move.Layer = rng.Intn(N)
+	case 3:
+		if N%2 == 1 {
+			move.Slice = []SliceType{M_Slice, E_Slice, S_Slice}[rng.Intn(3)]
+		}
+	case 4:
+		move.Rotation = []RotationType{X_Rotation, Y_Rotation, Z_Rotation}[rng.Intn(3)]
+	}
+	switch rng.Intn(3) {
+	case 0:
+		move.Clockwise = true
+	case 1:
+		move.Double = true
+	}
+	return move
+}
+
+// TestPermutationCacheConcurrent has many goroutines fill an empty cache
+// at once; run it with -race. Every cube must still match the geometric
+// engine, and every cached permutation must be a complete bijection.
+func TestPermutationCacheConcurrent(t *testing.T) {
+	resetPermCache()
+
+	const workers = 32
+	var wg sync.WaitGroup
+	errs := make(chan string, workers)
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(seed int64) {
+			defer wg.Done()
+			rng := rand.New(rand.NewSource(seed))
+			for N := 2; N <= 6; N++ {
+				permutation := NewCube(N)
+				geometric := NewCube(N, WithEngine(GeometricEngine))
+				for i := 0; i < 40; i++ {
+					move := randomCacheMove(N, rng)
+					permutation.ApplyMove(move)
+					geometric.ApplyMove(move)
+					if permutation.String() != geometric.String() {
+						errs <- fmt.Sprintf("%dx%d: engines disagree after %s", N, N, move.String())
+						return
+					}
+				}
+			}
+		}(int64(w))
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+
+	permCacheMu.RLock()
+	defer permCacheMu.RUnlock()
+	if len(permCache) == 0 {
+		t.Fatal("no permutations were cached")
+	}
+	for key, perm := range permCache {
+		if len(perm) != 6*key.N*key.N {
+			t.Errorf("%+v: permutation has %d entries, want %d", key, len(perm), 6*key.N*key.N)
+			continue
+		}
+		seen := make([]bool, len(perm))
+		for _, dst := range perm {
+			if dst < 0 || dst >= len(perm) || seen[dst] {
+				t.Errorf("%+v: permutation is not a bijection", key)
+				break
+			}
+			seen[dst] = true
+		}
+	}
+}
+
+func TestRotateSliceNormalizesQuarterTurns(t *testing.T) {
+	slice := []int{0, 1, 2, 3, 4, 5, 6, 7}
+	for turns := -9; turns <= 9; turns++ {
+		want := rotateSlice(slice, ((turns%4)+4)%4)
+		got := rotateSlice(slice, turns)
+		if fmt.Sprint(got) != fmt.Sprint(want) {
+			t.Errorf("rotateSlice(%d) = %v, want %v", turns, got, want)
+		}
+	}
+
+	if got := rotateSlice(slice, -1); fmt.Sprint(got) != fmt.Sprint([]int{6, 7, 0, 1, 2, 3, 4, 5}) {
+		t.Errorf("rotateSlice(-1) = %v, want a three quarter turn", got)
+	}
+}
+
+func TestPermutationQuarterTurnsOutsideRange(t *testing.T) {
+	for N := 2; N <= 4; N++ {
+		for moveType := MoveR; moveType <= MoveZ; moveType++ {
+			for turns := -5; turns <= 7; turns++ {
+				normalized := ((turns % 4) + 4) % 4
+				want := generatePermutation(N, moveType, 0, normalized)
+				if got := generatePermutation(N, moveType, 0, turns); fmt.Sprint(got) != fmt.Sprint(want) {
+					t.Errorf("%dx%d move type %d: %d quarter turns differ from %d", N, N, moveType, turns, normalized)
+				}
+				if got := getPermutation(N, moveType, 0, turns); fmt.Sprint(got) != fmt.Sprint(want) {
+					t.Errorf("%dx%d move type %d: cached %d quarter turns differ from %d", N, N, moveType, turns, normalized)
+				}
+			}
+		}
+	}
+}
+
+func BenchmarkGetPermutationParallel(b *testing.B) {
+	for N := 2; N <= 7; N++ {
+		for moveType := MoveR; moveType <= MoveZ; moveType++ {
+			for turns := 1; turns <= 3; turns++ {
+				getPermutation(N, moveType, 0, turns)
+			}
+		}
+	}
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			getPermutation(2+i%6, MoveType(i%12), 0, 1+i%3)
+			i++
+		}
+	})
+}
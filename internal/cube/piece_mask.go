@@ -0,0 +1,168 @@
+package cube
+
+import "strings"
+
+// cornerNames and edgeNames label the positions in cornerFacelets and
+// edgeFacelets
+var cornerNames = [8]string{"URF", "UFL", "ULB", "UBR", "DFR", "DLF", "DBL", "DRB"}
+var edgeNames = [12]string{"UR", "UF", "UL", "UB", "DR", "DF", "DL", "DB", "FR", "FL", "BL", "BR"}
+
+// PieceMask records which pieces of a 3x3 differ from a reference state. A
+// piece counts as changed if any of its stickers changed, so a corner that
+// stays in place but twists is changed, while a corner is unchanged only if
+// all three of its stickers are.
+type PieceMask struct {
+	Corners [8]bool  // Indexed like CornerPermutation (URF, UFL, ...)
+	Edges   [12]bool // Indexed like EdgePermutation (UR, UF, ...)
+	Centers [6]bool  // Indexed by Face
+}
+
+// GeneratePieceMask compares c against reference piece by piece. Only 3x3
+// cubes are supported; other sizes return an empty mask.
+func GeneratePieceMask(c, reference *Cube) PieceMask {
+	var mask PieceMask
+	if c.Size != 3 || reference.Size != 3 {
+		return mask
+	}
+
+	changed := func(coords ...Coord) bool {
+		for _, coord := range coords {
+			if c.Faces[coord.Face][coord.Row][coord.Col] != reference.Faces[coord.Face][coord.Row][coord.Col] {
+				return true
+			}
+		}
+		return false
+	}
+
+	for pos, facelets := range cornerFacelets {
+		mask.Corners[pos] = changed(facelets[:]...)
+	}
+	for pos, facelets := range edgeFacelets {
+		mask.Edges[pos] = changed(facelets[:]...)
+	}
+	for face := Front; face <= Down; face++ {
+		mask.Centers[face] = changed(Coord{face, 1, 1})
+	}
+	return mask
+}
+
+// Apply returns a copy of c with every sticker of each unchanged piece set
+// to Grey, leaving only the pieces the mask marks as changed
+func (m PieceMask) Apply(c *Cube) *Cube {
+	masked := c.Clone()
+	if c.Size != 3 {
+		return masked
+	}
+
+	grey := func(coords ...Coord) {
+		for _, coord := range coords {
+			masked.Faces[coord.Face][coord.Row][coord.Col] = Grey
+		}
+	}
+
+	for pos, facelets := range cornerFacelets {
+		if !m.Corners[pos] {
+			grey(facelets[:]...)
+		}
+	}
+	for pos, facelets := range edgeFacelets {
+		if !m.Edges[pos] {
+			grey(facelets[:]...)
+		}
+	}
+	for face := Front; face <= Down; face++ {
+		if !m.Centers[face] {
+			grey(Coord{face, 1, 1})
+		}
+	}
+	return masked
+}
+
+// ChangedCorners returns the names of the changed corner positions (URF, ...)
+func (m PieceMask) ChangedCorners() []string {
+	var names []string
+	for pos, changed := range m.Corners {
+		if changed {
+			names = append(names, cornerNames[pos])
+		}
+	}
+	return names
+}
+
+// ChangedEdges returns the names of the changed edge positions (UR, ...)
+func (m PieceMask) ChangedEdges() []string {
+	var names []string
+	for pos, changed := range m.Edges {
+		if changed {
+			names = append(names, edgeNames[pos])
+		}
+	}
+	return names
+}
+
+// String lists the changed pieces, e.g. "corners: URF UFL, edges: UF"
+func (m PieceMask) String() string {
+	corners := strings.Join(m.ChangedCorners(), " ")
+	edges := strings.Join(m.ChangedEdges(), " ")
+	if corners == "" {
+		corners = "none"
+	}
+	if edges == "" {
+		edges = "none"
+	}
+	return "corners: " + corners + ", edges: " + edges
+}
+
+// StageMask returns the pieces a CFOP stage works on: the D edges and
+// center for the cross, the first two layers for F2L, and the U layer for
+// OLL and PLL
+func StageMask(stage CFOPStage) PieceMask {
+	var mask PieceMask
+	switch stage {
+	case StageCross:
+		for pos := 4; pos < 8; pos++ {
+			mask.Edges[pos] = true
+		}
+		mask.Centers[Down] = true
+	case StageF2L:
+		for pos := 4; pos < 8; pos++ {
+			mask.Corners[pos] = true
+		}
+		for pos := 4; pos < 12; pos++ {
+			mask.Edges[pos] = true
+		}
+		for face := Front; face <= Down; face++ {
+			mask.Centers[face] = face != Up
+		}
+	case StageOLL, StagePLL:
+		for pos := 0; pos < 4; pos++ {
+			mask.Corners[pos] = true
+			mask.Edges[pos] = true
+		}
+		mask.Centers[Up] = true
+	}
+	return mask
+}
+
+// Covers reports whether the sticker at coord belongs to a piece the mask
+// marks, using 3x3 coordinates
+func (m PieceMask) Covers(coord Coord) bool {
+	if coord.Row == 1 && coord.Col == 1 {
+		return m.Centers[coord.Face]
+	}
+	for pos, facelets := range cornerFacelets {
+		for _, facelet := range facelets {
+			if facelet == coord {
+				return m.Corners[pos]
+			}
+		}
+	}
+	for pos, facelets := range edgeFacelets {
+		for _, facelet := range facelets {
+			if facelet == coord {
+				return m.Edges[pos]
+			}
+		}
+	}
+	return false
+}
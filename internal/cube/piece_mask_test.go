@@ -0,0 +1,113 @@
+package cube
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGeneratePieceMask(t *testing.T) {
+	tests := []struct {
+		name    string
+		moves   string
+		corners []string
+		edges   []string
+	}{
+		{"Sune", "R U R' U R U2 R'", []string{"URF", "UFL", "ULB", "UBR"}, []string{"UR", "UL", "UB"}},
+		{"Ua-Perm", "R2 U R U R' U' R' U' R' U R'", nil, []string{"UR", "UF", "UL"}},
+		{"Sexy move", "R U R' U'", []string{"URF", "ULB", "UBR", "DFR"}, []string{"UR", "UB", "FR"}},
+		{"Identity", "", nil, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewCube(3)
+			moves, err := ParseScramble(tt.moves)
+			if err != nil {
+				t.Fatalf("Failed to parse %s: %v", tt.moves, err)
+			}
+			c.ApplyMoves(moves)
+
+			mask := GeneratePieceMask(c, NewCube(3))
+			if got := mask.ChangedCorners(); !reflect.DeepEqual(got, tt.corners) {
+				t.Errorf("ChangedCorners() = %v, want %v", got, tt.corners)
+			}
+			if got := mask.ChangedEdges(); !reflect.DeepEqual(got, tt.edges) {
+				t.Errorf("ChangedEdges() = %v, want %v", got, tt.edges)
+			}
+			if mask.Centers != [6]bool{} {
+				t.Errorf("Centers = %v, want none changed", mask.Centers)
+			}
+		})
+	}
+}
+
+func TestPieceMaskApply(t *testing.T) {
+	c := NewCube(3)
+	moves, _ := ParseScramble("R U R' U R U2 R'") // Sune
+	c.ApplyMoves(moves)
+
+	masked := GeneratePieceMask(c, NewCube(3)).Apply(c)
+
+	// The unchanged UF edge is greyed on both of its stickers, even though
+	// the Sune leaves it in place
+	if masked.Faces[Up][2][1] != Grey || masked.Faces[Front][0][1] != Grey {
+		t.Error("Unchanged UF edge should be fully greyed")
+	}
+
+	// A corner is kept whole: the URF corner keeps all three stickers
+	for _, coord := range cornerFacelets[0] {
+		if got := masked.Faces[coord.Face][coord.Row][coord.Col]; got != c.Faces[coord.Face][coord.Row][coord.Col] {
+			t.Errorf("Changed URF corner sticker %v = %v, want %v", coord, got, c.Faces[coord.Face][coord.Row][coord.Col])
+		}
+	}
+
+	// The bottom layer is untouched by the Sune, so it is entirely grey
+	for row := 0; row < 3; row++ {
+		for col := 0; col < 3; col++ {
+			if masked.Faces[Down][row][col] != Grey {
+				t.Errorf("Down[%d][%d] = %v, want Grey", row, col, masked.Faces[Down][row][col])
+			}
+		}
+	}
+
+	// Apply works on a copy
+	if c.Faces[Down][0][0] == Grey {
+		t.Error("Apply should not modify the original cube")
+	}
+}
+
+func TestStageMask(t *testing.T) {
+	tests := []struct {
+		stage    CFOPStage
+		stickers int
+	}{
+		{StageCross, 9}, // 4 edges and the D center
+		{StageF2L, 33},  // 4 corners, 8 edges, and 5 centers
+		{StageOLL, 21},  // The U layer
+		{StagePLL, 21},
+	}
+
+	for _, tt := range tests {
+		mask := StageMask(tt.stage)
+		masked := mask.Apply(NewCube(3))
+
+		covered := 0
+		for face := Front; face <= Down; face++ {
+			for row := 0; row < 3; row++ {
+				for col := 0; col < 3; col++ {
+					coord := Coord{face, row, col}
+					// Covers agrees with the stickers Apply keeps
+					if mask.Covers(coord) != (masked.Faces[face][row][col] != Grey) {
+						t.Errorf("%s: Covers(%v) disagrees with Apply", tt.stage, coord)
+					}
+					if mask.Covers(coord) {
+						covered++
+					}
+				}
+			}
+		}
+		if covered != tt.stickers {
+			t.Errorf("%s: mask covers %d stickers, want %d", tt.stage, covered, tt.stickers)
+		}
+	}
+}
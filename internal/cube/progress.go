@@ -0,0 +1,79 @@
+package cube
+
+import "sync"
+
+// solvedOrientations caches the 24 rotations of a solved cube per size
+var (
+	solvedOrientations   = make(map[int][]*Cube)
+	solvedOrientationsMu sync.Mutex
+)
+
+// SolvedFraction returns the fraction of stickers, from 0 to 1, that match
+// a solved cube. The cube may be held in any orientation: it is compared
+// against all 24 rotations of a solved cube and the best match is used.
+func (c *Cube) SolvedFraction() float64 {
+	best := 0
+	for _, solved := range solvedCubeOrientations(c.Size) {
+		if matches := matchingStickers(c, solved); matches > best {
+			best = matches
+		}
+	}
+	return float64(best) / float64(6*c.Size*c.Size)
+}
+
+// SolvedPieces counts the 3x3 edges and corners that are in their solved
+// position and orientation relative to the centers. Other sizes return 0, 0.
+func (c *Cube) SolvedPieces() (edges, corners int) {
+	if c.Size != 3 {
+		return 0, 0
+	}
+
+	cp, co := c.CornerPermutation(), c.CornerOrientation()
+	for pos := range cp {
+		if cp[pos] == pos && co[pos] == 0 {
+			corners++
+		}
+	}
+
+	ep, eo := c.EdgePermutation(), c.EdgeOrientation()
+	for pos := range ep {
+		if ep[pos] == pos && eo[pos] == 0 {
+			edges++
+		}
+	}
+	return edges, corners
+}
+
+// matchingStickers counts the stickers that are the same color on both cubes
+func matchingStickers(a, b *Cube) int {
+	matches := 0
+	for face := 0; face < 6; face++ {
+		for row := 0; row < a.Size; row++ {
+			for col := 0; col < a.Size; col++ {
+				if a.Faces[face][row][col] == b.Faces[face][row][col] {
+					matches++
+				}
+			}
+		}
+	}
+	return matches
+}
+
+// solvedCubeOrientations returns a solved cube of the given size in each of
+// its 24 orientations
+func solvedCubeOrientations(size int) []*Cube {
+	solvedOrientationsMu.Lock()
+	defer solvedOrientationsMu.Unlock()
+
+	if cubes, ok := solvedOrientations[size]; ok {
+		return cubes
+	}
+
+	cubes := make([]*Cube, len(orientationRotations))
+	for i, rotation := range orientationRotations {
+		cubes[i] = NewCube(size)
+		cubes[i].ApplyMoves(rotation)
+	}
+	solvedOrientations[size] = cubes
+	return cubes
+}
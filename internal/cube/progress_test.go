@@ -0,0 +1,63 @@
+package cube
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSolvedFraction(t *testing.T) {
+	tests := []struct {
+		name  string
+		size  int
+		moves string
+		want  float64
+	}{
+		{"Solved", 3, "", 1},
+		{"Rotated solved cube", 3, "x y2 z'", 1},
+		{"R", 3, "R", 42.0 / 54.0},
+		{"R then rotation", 3, "R y", 42.0 / 54.0},
+		{"4x4 R", 4, "R", 80.0 / 96.0},
+		{"Rotated 4x4", 4, "x y", 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewCube(tt.size)
+			moves, err := ParseScramble(tt.moves)
+			if err != nil {
+				t.Fatalf("Failed to parse %s: %v", tt.moves, err)
+			}
+			c.ApplyMoves(moves)
+
+			if got := c.SolvedFraction(); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("SolvedFraction() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSolvedPieces(t *testing.T) {
+	tests := []struct {
+		moves          string
+		edges, corners int
+	}{
+		{"", 12, 8},
+		{"y x'", 12, 8},
+		{"R", 8, 4},
+		{"R U R' U'", 9, 4},
+		{"R2 U R U R' U' R' U' R' U R'", 9, 8}, // Ua-Perm cycles three edges
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.moves, func(t *testing.T) {
+			c := NewCube(3)
+			moves, _ := ParseScramble(tt.moves)
+			c.ApplyMoves(moves)
+
+			edges, corners := c.SolvedPieces()
+			if edges != tt.edges || corners != tt.corners {
+				t.Errorf("SolvedPieces() = %d edges, %d corners, want %d, %d", edges, corners, tt.edges, tt.corners)
+			}
+		})
+	}
+}
@@ -0,0 +1,403 @@
+package cube
+
+// recognition.go - OLL/PLL case recognition for 3x3 last layer states
+//
+// Cases are recognized by comparing a normalized sticker signature of the
+// last layer against signatures generated from a fixed reference table of
+// standard algorithms. Recognition never looks at database CaseIDs, so it
+// can be used to check that the algorithm database is labelled correctly.
+
+import (
+	"strings"
+	"sync"
+)
+
+// referenceCase is a canonical case ID with a known algorithm that solves it
+type referenceCase struct {
+	CaseID string
+	Moves  string
+}
+
+// Reference algorithms are written with R/U/F/D face turns, Rw/Fw wide turns,
+// M/S slices and y rotations only. L- and B-handed algorithms are replaced by
+// a y or y2 conjugate, which sets up the same case seen from another side, and
+// the B2 in the A perms is written as y R2 y'.
+
+// ollReferenceCases lists the 57 OLL cases with a standard solving algorithm
+var ollReferenceCases = []referenceCase{
+	{"OLL-1", "R U2 R2 F R F' U2 R' F R F'"},
+	{"OLL-2", "F R U R' U' F' Fw R U R' U' Fw'"},
+	{"OLL-3", "Fw R U R' U' Fw' U' F R U R' U' F'"},
+	{"OLL-4", "Fw R U R' U' Fw' U F R U R' U' F'"},
+	{"OLL-5", "Rw' U2 R U R' U Rw"},
+	{"OLL-6", "Rw U2 R' U' R U' Rw'"},
+	{"OLL-7", "Rw U R' U R U2 Rw'"},
+	{"OLL-8", "Rw' U' R U' R' U2 Rw"},
+	{"OLL-9", "R U R' U' R' F R2 U R' U' F'"},
+	{"OLL-10", "R U R' U R' F R F' R U2 R'"},
+	{"OLL-11", "Rw U R' U R' F R F' R U2 Rw'"},
+	{"OLL-12", "M' R' U' R U' R' U2 R U' M"},
+	{"OLL-13", "F U R U' R2 F' R U R U' R'"},
+	{"OLL-14", "R' F R U R' F' R F U' F'"},
+	{"OLL-15", "Rw' U' Rw R' U' R U Rw' U Rw"},
+	{"OLL-16", "Rw U Rw' R U R' U' Rw U' Rw'"},
+	{"OLL-17", "R U R' U R' F R F' U2 R' F R F'"},
+	{"OLL-18", "Rw U R' U R U2 Rw2 U' R U' R' U2 Rw"},
+	{"OLL-19", "M U R U R' U' M' R' F R F'"},
+	{"OLL-20", "Rw U R' U' M2 U R U' R' U' M'"},
+	{"OLL-21", "R U2 R' U' R U R' U' R U' R'"},
+	{"OLL-22", "R U2 R2 U' R2 U' R2 U2 R"},
+	{"OLL-23", "R2 D' R U2 R' D R U2 R"},
+	{"OLL-24", "Rw U R' U' Rw' F R F'"},
+	{"OLL-25", "F' Rw U R' U' Rw' F R"},
+	{"OLL-26", "R U2 R' U' R U' R'"},
+	{"OLL-27", "R U R' U R U2 R'"},
+	{"OLL-28", "Rw U R' U' M U R U' R'"},
+	{"OLL-29", "R U R' U' R U' R' F' U' F R U R'"},
+	{"OLL-30", "F R' F R2 U' R' U' R U R' F2"},
+	{"OLL-31", "R' U' F U R U' R' F' R"},
+	{"OLL-32", "S R U R' U' R' F R Fw'"},
+	{"OLL-33", "R U R' U' R' F R F'"},
+	{"OLL-34", "R U R2 U' R' F R U R U' F'"},
+	{"OLL-35", "R U2 R2 F R F' R U2 R'"},
+	{"OLL-36", "F' U' F U' F' U F U F R' F' R"},
+	{"OLL-37", "F R' F' R U R U' R'"},
+	{"OLL-38", "R U R' U R U' R' U' R' F R F'"},
+	{"OLL-39", "F R' F' U' F U R U' F'"},
+	{"OLL-40", "R' F R U R' U' F' U R"},
+	{"OLL-41", "R U R' U R U2 R' F R U R' U' F'"},
+	{"OLL-42", "R' U' R U' R' U2 R F R U R' U' F'"},
+	{"OLL-43", "Rw' F' U' F U Rw"},
+	{"OLL-44", "Fw R U R' U' Fw'"},
+	{"OLL-45", "F R U R' U' F'"},
+	{"OLL-46", "R' U' R' F R F' U R"},
+	{"OLL-47", "R' U' R' F R F' R' F R F' U R"},
+	{"OLL-48", "F R U R' U' R U R' U' F'"},
+	{"OLL-49", "Rw U' Rw2 U Rw2 U Rw2 U' Rw"},
+	{"OLL-50", "Rw' U Rw2 U' Rw2 U' Rw2 U Rw'"},
+	{"OLL-51", "Fw R U R' U' R U R' U' Fw'"},
+	{"OLL-52", "R' F' U' F U' R U R' U R"},
+	{"OLL-53", "Rw' U2 R U R' U' R U R' U Rw"},
+	{"OLL-54", "Rw U2 R' U' R U R' U' R U' Rw'"},
+	{"OLL-55", "R' F R U R U' R2 F' R2 U' R' U R U R'"},
+	{"OLL-56", "Rw' U' Rw U' R' U R U' R' U R Rw' U Rw"},
+	{"OLL-57", "R U R' U' M' U R U' Rw'"},
+}
+
+// pllReferenceCases lists the 21 PLL cases with a standard solving algorithm
+var pllReferenceCases = []referenceCase{
+	{"PLL-Aa", "R' F R' y R2 y' R F' R' y R2 y' R2"},
+	{"PLL-Ab", "R2 y R2 y' R F R' y R2 y' R F' R"},
+	{"PLL-E", "R2 U R' U' y R U R' U' R U R' U' R U R' y' R U' R2"},
+	{"PLL-F", "R' U' F' R U R' U' R' F R2 U' R' U' R U R' U R"},
+	{"PLL-Ga", "R2 U R' U R' U' R U' R2 D U' R' U R D'"},
+	{"PLL-Gb", "R' U' R U D' R2 U R' U R U' R U' R2 D"},
+	{"PLL-Gc", "R2 U' R U' R U R' U R2 D' U R U' R' D"},
+	{"PLL-Gd", "R U R' U' D R2 U' R U' R' U R' U R2 D'"},
+	{"PLL-H", "M2 U M2 U2 M2 U M2"},
+	{"PLL-Ja", "R2 D R D' R F2 Rw' F Rw F2"},
+	{"PLL-Jb", "R U R' F' R U R' U' R' F R2 U' R'"},
+	{"PLL-Na", "R U R' U R U R' F' R U R' U' R' F R2 U' R' U2 R U' R'"},
+	{"PLL-Nb", "R' U R U' R' F' U' F R U R' F R' F' R U' R"},
+	{"PLL-Ra", "R U' R' U' R U R D R' U' R D' R' U2 R'"},
+	{"PLL-Rb", "R2 F R U R U' R' F' R U2 R' U2 R"},
+	{"PLL-T", "R U R' U' R' F R2 U' R' U' R U R' F'"},
+	{"PLL-Ua", "M2 U M U2 M' U M2"},
+	{"PLL-Ub", "M2 U' M U2 M' U' M2"},
+	{"PLL-V", "R' U R' U' y R' F' R2 U' R' U R' F R F"},
+	{"PLL-Y", "F R U' R' U' R U R' F' R U R' U' R' F R F'"},
+	{"PLL-Z", "M' U M2 U M2 U M' U2 M2"},
+}
+
+var (
+	recognitionOnce sync.Once
+	ollSignatures   map[string]string // normalized signature -> case ID
+	pllSignatures   map[string]string
+	ollVariants     []caseVariants
+	pllVariants     []caseVariants
+)
+
+// caseVariants holds the keys of a reference case seen from every U-face
+// angle and with every U adjustment, for measuring how far a state is from
+// the case
+type caseVariants struct {
+	caseID string
+	keys   []string
+}
+
+// OLLCaseIDs returns the canonical OLL case IDs (OLL-1 through OLL-57)
+func OLLCaseIDs() []string {
+	return referenceCaseIDs(ollReferenceCases)
+}
+
+// PLLCaseIDs returns the canonical PLL case IDs (PLL-Aa through PLL-Z)
+func PLLCaseIDs() []string {
+	return referenceCaseIDs(pllReferenceCases)
+}
+
+func referenceCaseIDs(cases []referenceCase) []string {
+	ids := make([]string, len(cases))
+	for i, rc := range cases {
+		ids[i] = rc.CaseID
+	}
+	return ids
+}
+
+// RecognizeOLL identifies the OLL case of a 3x3 cube whose first two layers
+// are solved. The case is recognized from any U-face angle.
+func RecognizeOLL(c *Cube) (string, bool) {
+	if c.Size != 3 || !isF2LSolved(c) || isLastLayerOriented(c) {
+		return "", false
+	}
+	loadRecognitionTables()
+	caseID, ok := ollSignatures[normalizedSignature(c, ollKey)]
+	return caseID, ok
+}
+
+// RecognizePLL identifies the PLL case of a 3x3 cube whose first two layers
+// are solved and whose last layer is oriented. The case is recognized from
+// any U-face angle and regardless of the final U adjustment.
+func RecognizePLL(c *Cube) (string, bool) {
+	if c.Size != 3 || !isF2LSolved(c) || !isLastLayerOriented(c) {
+		return "", false
+	}
+	loadRecognitionTables()
+	caseID, ok := pllSignatures[normalizedSignature(c, pllKey)]
+	return caseID, ok
+}
+
+func loadRecognitionTables() {
+	recognitionOnce.Do(func() {
+		ollSignatures = buildSignatureTable(ollReferenceCases, ollKey)
+		pllSignatures = buildSignatureTable(pllReferenceCases, pllKey)
+		ollVariants = buildCaseVariants(ollReferenceCases, ollKey)
+		pllVariants = buildCaseVariants(pllReferenceCases, pllKey)
+	})
+}
+
+// NearestCase returns the OLL or PLL case, as category says, that c is
+// closest to, for feedback on a state the recognizers don't match, such as
+// one with a piece out of place mid-solve. The distance is the number of
+// stickers that differ from the case's state: those of the first two
+// layers that don't match their centers, plus the last layer stickers that
+// don't match the case from the closest U-face angle and U adjustment. For
+// PLL, last layer stickers count by their colors, and for OLL only by
+// whether they show the U color. A recognized case is at distance 0; ties
+// go to the case listed first. It returns "" and -1 if c isn't a 3x3 or
+// category isn't OLL or PLL.
+func NearestCase(c *Cube, category string) (caseID string, distance int) {
+	if c.Size != 3 {
+		return "", -1
+	}
+	loadRecognitionTables()
+
+	var variants []caseVariants
+	var key string
+	distance = f2lMismatches(c)
+	switch strings.ToUpper(strings.TrimSpace(category)) {
+	case "OLL":
+		variants, key = ollVariants, ollKey(c)
+	case "PLL":
+		variants, key = pllVariants, pllKey(c)
+		// pllKey reads only the sides, so a misoriented U sticker counts here
+		up := c.Faces[Up][1][1]
+		for row := 0; row < 3; row++ {
+			for col := 0; col < 3; col++ {
+				if c.Faces[Up][row][col] != up {
+					distance++
+				}
+			}
+		}
+	default:
+		return "", -1
+	}
+
+	best := -1
+	for _, v := range variants {
+		for _, k := range v.keys {
+			if d := keyDistance(key, k); best < 0 || d < best {
+				caseID, best = v.caseID, d
+			}
+		}
+	}
+	return caseID, distance + best
+}
+
+// buildCaseVariants sets up each reference case like buildSignatureTable
+// and records its key from all 16 angles and U adjustments
+func buildCaseVariants(cases []referenceCase, key func(*Cube) string) []caseVariants {
+	var variants []caseVariants
+	for _, rc := range cases {
+		moves, err := ParseScramble(rc.Moves)
+		if err != nil {
+			continue
+		}
+		c := NewCube(3)
+		c.ApplyInverseMoves(moves)
+
+		v := caseVariants{caseID: rc.CaseID}
+		for r := 0; r < 4; r++ {
+			adjusted := c.Clone()
+			for a := 0; a < 4; a++ {
+				v.keys = append(v.keys, key(adjusted))
+				adjusted.ApplyMove(Move{Face: Up, Clockwise: true})
+			}
+			c.ApplyMove(Move{Rotation: Y_Rotation, Clockwise: true})
+		}
+		variants = append(variants, v)
+	}
+	return variants
+}
+
+// keyDistance counts the positions where two keys of the same length differ
+func keyDistance(a, b string) int {
+	d := 0
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if a[i] != b[i] {
+			d++
+		}
+	}
+	return d
+}
+
+// buildSignatureTable sets up each reference case by applying the inverse of
+// its algorithm to a solved cube and records the resulting signature
+func buildSignatureTable(cases []referenceCase, key func(*Cube) string) map[string]string {
+	table := make(map[string]string, len(cases))
+	for _, rc := range cases {
+		moves, err := ParseScramble(rc.Moves)
+		if err != nil {
+			continue
+		}
+		c := NewCube(3)
+		c.ApplyInverseMoves(moves)
+		table[normalizedSignature(c, key)] = rc.CaseID
+	}
+	return table
+}
+
+// normalizedSignature returns the smallest key over all cube rotations about
+// the U axis combined with all U-layer adjustments
+func normalizedSignature(c *Cube, key func(*Cube) string) string {
+	best := ""
+	rotated := c.Clone()
+	for r := 0; r < 4; r++ {
+		adjusted := rotated.Clone()
+		for a := 0; a < 4; a++ {
+			if k := key(adjusted); best == "" || k < best {
+				best = k
+			}
+			adjusted.ApplyMove(Move{Face: Up, Clockwise: true})
+		}
+		rotated.ApplyMove(Move{Rotation: Y_Rotation, Clockwise: true})
+	}
+	return best
+}
+
+// lastLayerSides lists the side faces in U-turn order for reading the top row
+var lastLayerSides = []Face{Front, Left, Back, Right}
+
+// ollKey marks which last layer stickers show the U center color
+func ollKey(c *Cube) string {
+	var sb strings.Builder
+	up := c.Faces[Up][1][1]
+	for row := 0; row < 3; row++ {
+		for col := 0; col < 3; col++ {
+			sb.WriteByte(orientationBit(c.Faces[Up][row][col] == up))
+		}
+	}
+	for _, face := range lastLayerSides {
+		for col := 0; col < 3; col++ {
+			sb.WriteByte(orientationBit(c.Faces[face][0][col] == up))
+		}
+	}
+	return sb.String()
+}
+
+func orientationBit(oriented bool) byte {
+	if oriented {
+		return '1'
+	}
+	return '0'
+}
+
+// pllKey records, for each side sticker in the top row, how many faces away
+// its home face is. Using center-relative offsets makes the key independent
+// of the cube's color scheme and orientation.
+func pllKey(c *Cube) string {
+	var sb strings.Builder
+	for i, face := range lastLayerSides {
+		for col := 0; col < 3; col++ {
+			home := -1
+			for j, other := range lastLayerSides {
+				if c.Faces[face][0][col] == c.Faces[other][1][1] {
+					home = j
+				}
+			}
+			if home < 0 {
+				sb.WriteByte('?')
+				continue
+			}
+			sb.WriteByte(byte('0' + (home-i+4)%4))
+		}
+	}
+	return sb.String()
+}
+
+// isF2LSolved reports whether the D face and all but the top row of every
+// side face match their centers
+func isF2LSolved(c *Cube) bool {
+	down := c.Faces[Down][1][1]
+	for row := 0; row < c.Size; row++ {
+		for col := 0; col < c.Size; col++ {
+			if c.Faces[Down][row][col] != down {
+				return false
+			}
+		}
+	}
+	for _, face := range lastLayerSides {
+		center := c.Faces[face][1][1]
+		for row := 1; row < c.Size; row++ {
+			for col := 0; col < c.Size; col++ {
+				if c.Faces[face][row][col] != center {
+					return false
+				}
+			}
+		}
+	}
+	return true
+}
+
+// f2lMismatches counts the stickers of the D face and of all but the top
+// row of the side faces that don't match their centers
+func f2lMismatches(c *Cube) int {
+	count := 0
+	for _, face := range []Face{Down, Front, Left, Back, Right} {
+		center := c.Faces[face][1][1]
+		firstRow := 1
+		if face == Down {
+			firstRow = 0
+		}
+		for row := firstRow; row < c.Size; row++ {
+			for col := 0; col < c.Size; col++ {
+				if c.Faces[face][row][col] != center {
+					count++
+				}
+			}
+		}
+	}
+	return count
+}
+
+// isLastLayerOriented reports whether the whole U face matches its center
+func isLastLayerOriented(c *Cube) bool {
+	up := c.Faces[Up][1][1]
+	for row := 0; row < c.Size; row++ {
+		for col := 0; col < c.Size; col++ {
+			if c.Faces[Up][row][col] != up {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,111 @@
+package cube
+
+import (
+	"testing"
+)
+
+func setupCase(t *testing.T, moves string) *Cube {
+	t.Helper()
+	parsed, err := ParseScramble(moves)
+	if err != nil {
+		t.Fatalf("ParseScramble(%q) error: %v", moves, err)
+	}
+	c := NewCube(3)
+	c.ApplyInverseMoves(parsed)
+	return c
+}
+
+func TestReferenceCasesAreDistinct(t *testing.T) {
+	if got := len(OLLCaseIDs()); got != 57 {
+		t.Errorf("OLLCaseIDs() has %d cases, want 57", got)
+	}
+	if got := len(PLLCaseIDs()); got != 21 {
+		t.Errorf("PLLCaseIDs() has %d cases, want 21", got)
+	}
+
+	loadRecognitionTables()
+	if len(ollSignatures) != len(ollReferenceCases) {
+		t.Errorf("OLL reference cases produce %d distinct signatures, want %d", len(ollSignatures), len(ollReferenceCases))
+	}
+	if len(pllSignatures) != len(pllReferenceCases) {
+		t.Errorf("PLL reference cases produce %d distinct signatures, want %d", len(pllSignatures), len(pllReferenceCases))
+	}
+}
+
+func TestRecognizeOLL(t *testing.T) {
+	for _, rc := range ollReferenceCases {
+		c := setupCase(t, rc.Moves)
+		if !isF2LSolved(c) {
+			t.Errorf("%s: reference algorithm disturbs F2L", rc.CaseID)
+			continue
+		}
+
+		// Any U adjustment before the case should not change recognition
+		c.ApplyMove(Move{Face: Up, Clockwise: true})
+		if got, ok := RecognizeOLL(c); !ok || got != rc.CaseID {
+			t.Errorf("RecognizeOLL(%s setup) = %q, %v; want %s", rc.CaseID, got, ok, rc.CaseID)
+		}
+	}
+
+	if _, ok := RecognizeOLL(NewCube(3)); ok {
+		t.Error("RecognizeOLL should not recognize a solved cube")
+	}
+}
+
+func TestRecognizePLL(t *testing.T) {
+	for _, rc := range pllReferenceCases {
+		c := setupCase(t, rc.Moves)
+		if !isF2LSolved(c) || !isLastLayerOriented(c) {
+			t.Errorf("%s: reference algorithm is not a PLL", rc.CaseID)
+			continue
+		}
+
+		c.ApplyMove(Move{Face: Up, Clockwise: false})
+		if got, ok := RecognizePLL(c); !ok || got != rc.CaseID {
+			t.Errorf("RecognizePLL(%s setup) = %q, %v; want %s", rc.CaseID, got, ok, rc.CaseID)
+		}
+	}
+
+	// An OLL case is not a PLL case
+	if _, ok := RecognizePLL(setupCase(t, "R U R' U R U2 R'")); ok {
+		t.Error("RecognizePLL should reject an unoriented last layer")
+	}
+}
+
+func TestNearestCase(t *testing.T) {
+	for category, cases := range map[string][]referenceCase{"OLL": ollReferenceCases, "PLL": pllReferenceCases} {
+		for _, rc := range cases {
+			c := setupCase(t, rc.Moves)
+			c.ApplyMove(Move{Face: Up, Double: true})
+			if got, d := NearestCase(c, category); got != rc.CaseID || d != 0 {
+				t.Errorf("NearestCase(%s setup, %s) = %s, %d; want %s, 0", rc.CaseID, category, got, d, rc.CaseID)
+			}
+		}
+	}
+
+	// Ua with one of its cycled edges showing the wrong color is a sticker off
+	ua := setupCase(t, "M2 U M U2 M' U M2")
+	for _, face := range lastLayerSides {
+		if ua.Faces[face][0][1] != ua.Faces[face][1][1] {
+			ua.Faces[face][0][1] = ua.Faces[face][1][1]
+			break
+		}
+	}
+	if got, d := NearestCase(ua, "pll"); got != "PLL-Ua" || d != 1 {
+		t.Errorf("NearestCase(Ua with an edge off) = %s, %d; want PLL-Ua, 1", got, d)
+	}
+
+	// Mid-F2L states count the unsolved stickers below the last layer
+	sune := setupCase(t, "R U R' U R U2 R'")
+	sune.ApplyMoves(mustParseForSize(t, "R U R'", 3))
+	if _, d := NearestCase(sune, "OLL"); d < f2lMismatches(sune) || d == 0 {
+		t.Errorf("NearestCase(Sune with a pair out) distance = %d, want at least the %d F2L stickers", d, f2lMismatches(sune))
+	}
+
+	if got, d := NearestCase(NewCube(3), "F2L"); got != "" || d != -1 {
+		t.Errorf("NearestCase(F2L) = %s, %d; want no case", got, d)
+	}
+	if got, d := NearestCase(NewCube(4), "PLL"); got != "" || d != -1 {
+		t.Errorf("NearestCase(4x4) = %s, %d; want no case", got, d)
+	}
+}
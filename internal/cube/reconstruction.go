@@ -0,0 +1,49 @@
+package cube
+
+import "strings"
+
+// ParseReconstruction parses a solve reconstruction written one step per
+// line, with // comments naming each step:
+//
+//	y // inspection
+//	R U R' F2 // cross
+//
+// It returns the moves of every line in order, and for each move the
+// comment of the line it came from ("" if that line has none). A line
+// holding only a comment annotates the next line of moves that has no
+// comment of its own. A bad move is reported as a *ParseError with its
+// position among all the moves.
+func ParseReconstruction(text string) (moves []Move, annotations []string, err error) {
+	moves = []Move{}
+	annotations = []string{}
+	pending := ""
+
+	for _, line := range strings.Split(text, "\n") {
+		notation, comment, _ := strings.Cut(line, "//")
+		comment = strings.TrimSpace(comment)
+
+		parts := strings.Fields(notation)
+		if len(parts) == 0 {
+			if comment != "" {
+				pending = comment
+			}
+			continue
+		}
+		if comment == "" {
+			comment = pending
+		}
+		pending = ""
+
+		for _, part := range parts {
+			move, err := ParseMove(part)
+			if err != nil {
+				parseErr := err.(*ParseError)
+				return nil, nil, &ParseError{Token: part, Position: len(moves) + 1, Reason: parseErr.Reason}
+			}
+			moves = append(moves, move)
+			annotations = append(annotations, comment)
+		}
+	}
+
+	return moves, annotations, nil
+}
@@ -0,0 +1,54 @@
+package cube
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestParseReconstruction(t *testing.T) {
+	text := `y // inspection
+R U R' F2 // cross
+
+// first pair
+U R U' R'
+L' U L
+`
+	moves, annotations, err := ParseReconstruction(text)
+	if err != nil {
+		t.Fatalf("ParseReconstruction() error = %v", err)
+	}
+
+	if got := FormatMoves(moves); got != "y R U R' F2 U R U' R' L' U L" {
+		t.Errorf("moves = %s", got)
+	}
+
+	want := []string{
+		"inspection",
+		"cross", "cross", "cross", "cross",
+		"first pair", "first pair", "first pair", "first pair",
+		"", "", "",
+	}
+	if !reflect.DeepEqual(annotations, want) {
+		t.Errorf("annotations = %q, want %q", annotations, want)
+	}
+}
+
+func TestParseReconstructionEmpty(t *testing.T) {
+	moves, annotations, err := ParseReconstruction("// nothing yet\n\n")
+	if err != nil || len(moves) != 0 || len(annotations) != 0 {
+		t.Errorf("ParseReconstruction(comment only) = %v, %v, %v", moves, annotations, err)
+	}
+}
+
+func TestParseReconstructionError(t *testing.T) {
+	_, _, err := ParseReconstruction("R U // cross\nF Q2 // pair")
+
+	var parseErr *ParseError
+	if !errors.As(err, &parseErr) {
+		t.Fatalf("error %v is not a *ParseError", err)
+	}
+	if parseErr.Token != "Q2" || parseErr.Position != 4 {
+		t.Errorf("ParseError = %+v, want token Q2 at position 4", parseErr)
+	}
+}
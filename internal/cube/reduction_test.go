@@ -0,0 +1,86 @@
+package cube
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// outerTurns returns random outer-layer turns, which keep a reduced cube
+// reduced
+func outerTurns(rng *rand.Rand, n int) []Move {
+	faces := []Face{Front, Back, Left, Right, Up, Down}
+	moves := make([]Move, n)
+	for i := range moves {
+		moves[i] = Move{Face: faces[rng.Intn(len(faces))], Clockwise: rng.Intn(2) == 0, Double: rng.Intn(3) == 0}
+	}
+	return moves
+}
+
+func TestReductionState(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+
+	for _, size := range []int{4, 5, 6} {
+		solved := NewCube(size)
+		if !solved.CentersSolved() || !solved.EdgesPaired() {
+			t.Errorf("%dx%d: solved cube is not reduced", size, size)
+		}
+
+		// Outer turns and rotations leave centers and dedges intact
+		reduced := NewCube(size)
+		reduced.ApplyMoves(outerTurns(rng, 30))
+		reduced.ApplyMoves([]Move{{Rotation: X_Rotation, Clockwise: true}, {Rotation: Y_Rotation, Double: true}})
+		if !reduced.CentersSolved() || !reduced.EdgesPaired() {
+			t.Errorf("%dx%d: outer turns broke the reduction", size, size)
+		}
+		if reduced.IsSolved() {
+			t.Errorf("%dx%d: outer turns left the cube solved", size, size)
+		}
+
+		scrambled := NewCube(size)
+		scramble, err := GenerateScramble(size, ScrambleOptions{Length: 40, Rand: rng})
+		if err != nil {
+			t.Fatal(err)
+		}
+		scrambled.ApplyMoves(scramble)
+		if scrambled.CentersSolved() || scrambled.EdgesPaired() {
+			t.Errorf("%dx%d: scramble %s left the cube reduced", size, size, FormatMoves(scramble))
+		}
+	}
+}
+
+func TestReductionInnerSlice(t *testing.T) {
+	// A lone inner slice splits every dedge it crosses and shifts centers
+	c := NewCube(4)
+	c.ApplyMove(Move{Face: Right, Layer: 1, Clockwise: true})
+	if c.CentersSolved() {
+		t.Error("CentersSolved() = true after an inner slice turn")
+	}
+	if c.EdgesPaired() {
+		t.Error("EdgesPaired() = true after an inner slice turn")
+	}
+
+	// A slice turn undone after an outer turn breaks only the dedges: this
+	// is the standard edge pairing setup Uw R U R' F R' F' R Uw'
+	c = NewCube(4)
+	moves, err := ParseScramble("Uw R U R' F R' F' R Uw'")
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.ApplyMoves(moves)
+	if !c.CentersSolved() {
+		t.Error("CentersSolved() = false after an edge pairing algorithm")
+	}
+	if c.EdgesPaired() {
+		t.Error("EdgesPaired() = true after an edge pairing algorithm")
+	}
+}
+
+func TestReductionSmallCubes(t *testing.T) {
+	for _, size := range []int{2, 3} {
+		c := NewCube(size)
+		c.ApplyMoves(outerTurns(rand.New(rand.NewSource(2)), 20))
+		if !c.CentersSolved() || !c.EdgesPaired() {
+			t.Errorf("%dx%d has no centers or wings to build but reports unreduced", size, size)
+		}
+	}
+}
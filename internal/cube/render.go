@@ -0,0 +1,165 @@
+package cube
+
+import (
+	"io"
+	"strings"
+)
+
+// RenderOptions controls how Render draws a cube
+type RenderOptions struct {
+	Color   bool // ANSI colored letters
+	Unicode bool // Colored Unicode squares (takes precedence over Color)
+	Compact bool // Omit the blank lines between the Up, middle, and Down bands
+	Narrow  bool // One column per sticker: colored letters instead of Unicode squares
+
+	// Scheme, if set, overrides the active color scheme
+	Scheme *ColorScheme
+
+	// Highlight, if set, selects the stickers to draw normally; all other
+	// stickers are dimmed
+	Highlight func(face Face, row, col int) bool
+}
+
+// Render writes the cube to w in an unfolded cross layout:
+//
+//	    U
+//	L F R B
+//	    D
+//
+// Faces are N stickers wide and one space apart, so the layout lines up
+// for any size as long as every sticker takes the same number of columns.
+// It returns any error from writing to w.
+func (c *Cube) Render(w io.Writer, opts RenderOptions) error {
+	var sb strings.Builder
+
+	if opts.Narrow && opts.Unicode {
+		opts.Unicode, opts.Color = false, true
+	}
+
+	// Create padding to align top/bottom with front face
+	var leftPadding string
+	if opts.Unicode {
+		// Unicode blocks are double-width: (c.Size * 2) + 1 space
+		leftPadding = strings.Repeat(" ", (c.Size*2)+1)
+	} else {
+		// Single-width characters: c.Size + 1 space
+		leftPadding = strings.Repeat(" ", c.Size) + " "
+	}
+
+	bandBreak := func() {
+		if !opts.Compact {
+			sb.WriteString("\n")
+		}
+	}
+
+	// Top face (Up) - aligned with Front face
+	for row := 0; row < c.Size; row++ {
+		sb.WriteString(leftPadding)
+		for col := 0; col < c.Size; col++ {
+			sb.WriteString(c.renderSticker(Up, row, col, opts))
+		}
+		sb.WriteString("\n")
+	}
+	bandBreak()
+
+	// Middle row: Left, Front, Right, Back
+	middle := []Face{Left, Front, Right, Back}
+	for row := 0; row < c.Size; row++ {
+		for i, face := range middle {
+			if i > 0 {
+				sb.WriteString(" ") // Space between faces
+			}
+			for col := 0; col < c.Size; col++ {
+				sb.WriteString(c.renderSticker(face, row, col, opts))
+			}
+		}
+		sb.WriteString("\n")
+	}
+	bandBreak()
+
+	// Bottom face (Down) - aligned with Front face
+	for row := 0; row < c.Size; row++ {
+		sb.WriteString(leftPadding)
+		for col := 0; col < c.Size; col++ {
+			sb.WriteString(c.renderSticker(Down, row, col, opts))
+		}
+		sb.WriteString("\n")
+	}
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// renderSticker formats one sticker, dimming it if it is outside the
+// highlight mask
+func (c *Cube) renderSticker(face Face, row, col int, opts RenderOptions) string {
+	scheme := activeScheme
+	if opts.Scheme != nil {
+		scheme = *opts.Scheme
+	}
+
+	color := c.Faces[face][row][col]
+	if opts.Highlight == nil || opts.Highlight(face, row, col) {
+		return scheme.Format(color, opts.Color, opts.Unicode)
+	}
+
+	switch {
+	case opts.Unicode:
+		return scheme.Glyphs[Grey]
+	case opts.Color:
+		return "\033[90m" + color.String() + "\033[0m" // Dark gray letter
+	default:
+		return "."
+	}
+}
+
+// IsometricString draws the cube as seen from above its front right
+// corner, showing the Up, Front, and Right faces:
+//
+//	      U U U
+//	    U U U R
+//	  U U U R R
+//	F F F R R R
+//	F F F R R
+//	F F F R
+//
+// Every sticker takes two columns, so Unicode squares sit flush while
+// letters are followed by a space. Faces are sheared a whole sticker per
+// line rather than half of one, which keeps the stickers on a grid.
+func (c *Cube) IsometricString(opts RenderOptions) string {
+	if opts.Narrow && opts.Unicode {
+		opts.Unicode, opts.Color = false, true
+	}
+	n := c.Size
+
+	// grid[line][cell] holds a sticker, or "" where nothing is drawn
+	grid := make([][]string, 2*n)
+	for line := range grid {
+		grid[line] = make([]string, 2*n)
+	}
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			grid[i][n-i+j] = c.renderSticker(Up, i, j, opts)
+			grid[n+i][j] = c.renderSticker(Front, i, j, opts)
+			grid[n+i-j][n+j] = c.renderSticker(Right, i, j, opts)
+		}
+	}
+
+	var sb strings.Builder
+	for _, cells := range grid {
+		var line strings.Builder
+		for _, sticker := range cells {
+			switch {
+			case sticker == "":
+				line.WriteString("  ")
+			case opts.Unicode:
+				line.WriteString(sticker)
+			default:
+				line.WriteString(sticker + " ")
+			}
+		}
+		sb.WriteString(strings.TrimRight(line.String(), " "))
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
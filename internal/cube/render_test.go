@@ -0,0 +1,187 @@
+package cube
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRenderMatchesUnfoldedString(t *testing.T) {
+	c := NewCube(3)
+	moves, _ := ParseScramble("R U F'")
+	c.ApplyMoves(moves)
+
+	for _, opts := range []RenderOptions{{}, {Color: true}, {Unicode: true}} {
+		var sb strings.Builder
+		c.Render(&sb, opts)
+		if want := c.UnfoldedString(opts.Color, opts.Unicode); sb.String() != want {
+			t.Errorf("Render(%+v) = %q, want %q", opts, sb.String(), want)
+		}
+	}
+}
+
+// failingWriter fails every write
+type failingWriter struct{}
+
+func (failingWriter) Write(p []byte) (int, error) {
+	return 0, errors.New("disk full")
+}
+
+func TestRenderReturnsWriteError(t *testing.T) {
+	if err := NewCube(3).Render(failingWriter{}, RenderOptions{}); err == nil || err.Error() != "disk full" {
+		t.Errorf("Render() error = %v, want the writer's error", err)
+	}
+}
+
+func TestRenderCompact(t *testing.T) {
+	var sb strings.Builder
+	NewCube(2).Render(&sb, RenderOptions{Compact: true})
+
+	want := "   YY\n" +
+		"   YY\n" +
+		"OO BB RR GG\n" +
+		"OO BB RR GG\n" +
+		"   WW\n" +
+		"   WW\n"
+	if sb.String() != want {
+		t.Errorf("compact render = %q, want %q", sb.String(), want)
+	}
+}
+
+func TestRenderHighlight(t *testing.T) {
+	var sb strings.Builder
+	NewCube(2).Render(&sb, RenderOptions{
+		Compact: true,
+		Highlight: func(face Face, row, col int) bool {
+			return face == Up || (face == Front && row == 0)
+		},
+	})
+
+	want := "   YY\n" +
+		"   YY\n" +
+		".. BB .. ..\n" +
+		".. .. .. ..\n" +
+		"   ..\n" +
+		"   ..\n"
+	if sb.String() != want {
+		t.Errorf("highlighted render = %q, want %q", sb.String(), want)
+	}
+}
+
+func TestRenderColorScheme(t *testing.T) {
+	c := NewCube(2)
+
+	var sb strings.Builder
+	c.Render(&sb, RenderOptions{Unicode: true, Compact: true, Scheme: &ColorblindScheme})
+	if !strings.Contains(sb.String(), "🔺🔺") || strings.Contains(sb.String(), "🟥") {
+		t.Errorf("colorblind render should draw red as triangles:\n%s", sb.String())
+	}
+
+	// The active scheme applies when no scheme is given
+	defer SetColorScheme(ActiveColorScheme())
+	SetColorScheme(HighContrastScheme)
+	if got := c.UnfoldedString(true, false); !strings.Contains(got, HighContrastScheme.Letters[Red]) {
+		t.Errorf("UnfoldedString should use the active scheme, got %q", got)
+	}
+	if got := c.UnfoldedString(false, false); strings.Contains(got, "\033") {
+		t.Errorf("plain output should not depend on the scheme, got %q", got)
+	}
+}
+
+func TestParseColorScheme(t *testing.T) {
+	for _, name := range ColorSchemeNames() {
+		scheme, err := ParseColorScheme(name)
+		if err != nil || scheme.Name != name {
+			t.Errorf("ParseColorScheme(%q) = %q, %v", name, scheme.Name, err)
+		}
+	}
+	if _, err := ParseColorScheme("sepia"); err == nil {
+		t.Error("ParseColorScheme should reject unknown themes")
+	}
+}
+
+var updateGolden = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+// TestRenderGolden compares nets of scrambled 2x2 to 5x5 cubes with the
+// files in testdata, so layout changes show up as diffs. Run with -update
+// to rewrite them after an intended change.
+func TestRenderGolden(t *testing.T) {
+	moves, err := ParseScramble("R U F' L D2 B")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for size := 2; size <= 5; size++ {
+		c := NewCube(size)
+		c.ApplyMoves(moves)
+
+		for suffix, opts := range map[string]RenderOptions{
+			"":         {},
+			"_unicode": {Unicode: true},
+			"_compact": {Unicode: true, Compact: true, Narrow: true},
+		} {
+			var sb strings.Builder
+			c.Render(&sb, opts)
+
+			path := filepath.Join("testdata", fmt.Sprintf("net_%dx%d%s.golden", size, size, suffix))
+			if *updateGolden {
+				if err := os.WriteFile(path, []byte(sb.String()), 0o644); err != nil {
+					t.Fatal(err)
+				}
+				continue
+			}
+			want, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("%v (run with -update to create it)", err)
+			}
+			if sb.String() != string(want) {
+				t.Errorf("%s differs from the render:\n%s", path, sb.String())
+			}
+		}
+	}
+}
+
+func TestRenderNarrow(t *testing.T) {
+	c := NewCube(2)
+
+	var narrow, letters strings.Builder
+	c.Render(&narrow, RenderOptions{Unicode: true, Narrow: true})
+	c.Render(&letters, RenderOptions{Color: true})
+	if narrow.String() != letters.String() {
+		t.Errorf("narrow Unicode render = %q, want colored letters %q", narrow.String(), letters.String())
+	}
+}
+
+// TestIsometricGolden compares isometric views of a solved and a scrambled
+// 3x3 with the files in testdata. Run with -update to rewrite them.
+func TestIsometricGolden(t *testing.T) {
+	scrambled := NewCube(3)
+	moves, err := ParseScramble("R U F' L D2 B")
+	if err != nil {
+		t.Fatal(err)
+	}
+	scrambled.ApplyMoves(moves)
+
+	for name, c := range map[string]*Cube{"solved": NewCube(3), "scrambled": scrambled} {
+		got := c.IsometricString(RenderOptions{})
+
+		path := filepath.Join("testdata", fmt.Sprintf("iso_3x3_%s.golden", name))
+		if *updateGolden {
+			if err := os.WriteFile(path, []byte(got), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			continue
+		}
+		want, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("%v (run with -update to create it)", err)
+		}
+		if got != string(want) {
+			t.Errorf("%s differs from the render:\n%s", path, got)
+		}
+	}
+}
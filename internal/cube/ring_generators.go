@@ -35,8 +35,8 @@ func ringL(N, k int) []Coord {
 	for r := 0; r < N; r++ {
 		ring = append(ring, Coord{Front, r, k})
 	}
-	// Down face: column k, rows N-1 to 0 (reversed)
-	for r := N - 1; r >= 0; r-- {
+	// Down face: column k, rows 0 to N-1 (row 0 of Down is next to Front)
+	for r := 0; r < N; r++ {
 		ring = append(ring, Coord{Down, r, k})
 	}
 	// Back face: column N-1-k, rows N-1 to 0 (reversed)
@@ -119,16 +119,16 @@ func ringB(N, k int) []Coord {
 	for c := N - 1; c >= 0; c-- {
 		ring = append(ring, Coord{Up, k, c})
 	}
-	// Left face: column k, rows N-1 to 0 (reversed)
-	for r := N - 1; r >= 0; r-- {
+	// Left face: column k, rows 0 to N-1
+	for r := 0; r < N; r++ {
 		ring = append(ring, Coord{Left, r, k})
 	}
 	// Down face: row N-1-k, columns 0 to N-1
 	for c := 0; c < N; c++ {
 		ring = append(ring, Coord{Down, N - 1 - k, c})
 	}
-	// Right face: column N-1-k, rows 0 to N-1
-	for r := 0; r < N; r++ {
+	// Right face: column N-1-k, rows N-1 to 0 (reversed)
+	for r := N - 1; r >= 0; r-- {
 		ring = append(ring, Coord{Right, r, N - 1 - k})
 	}
 	return ring
@@ -169,21 +169,22 @@ func ringE(N, k int) []Coord {
 	}
 	centerRow := N / 2
 	var ring []Coord
+	// E follows D, so the ring runs in the same order as ringD
 	// Front face: row centerRow, columns 0 to N-1
 	for c := 0; c < N; c++ {
 		ring = append(ring, Coord{Front, centerRow, c})
 	}
-	// Left face: row centerRow, columns 0 to N-1
+	// Right face: row centerRow, columns 0 to N-1
 	for c := 0; c < N; c++ {
-		ring = append(ring, Coord{Left, centerRow, c})
+		ring = append(ring, Coord{Right, centerRow, c})
 	}
 	// Back face: row centerRow, columns 0 to N-1
 	for c := 0; c < N; c++ {
 		ring = append(ring, Coord{Back, centerRow, c})
 	}
-	// Right face: row centerRow, columns 0 to N-1
+	// Left face: row centerRow, columns 0 to N-1
 	for c := 0; c < N; c++ {
-		ring = append(ring, Coord{Right, centerRow, c})
+		ring = append(ring, Coord{Left, centerRow, c})
 	}
 	return ring
 }
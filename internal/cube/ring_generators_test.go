@@ -0,0 +1,50 @@
+package cube
+
+import "testing"
+
+// TestOppositeRingsMatch checks that L and B, which have no engine of their
+// own, move the same stickers as R and F. Layer k of L is layer N-1-k of R
+// turned the other way, so a turn along ringL must undo a turn along ringR;
+// the same holds for B and F.
+func TestOppositeRingsMatch(t *testing.T) {
+	pairs := []struct {
+		name     string
+		ring     func(N, k int) []Coord
+		opposite func(N, k int) []Coord
+	}{
+		{"L", ringL, ringR},
+		{"B", ringB, ringF},
+	}
+
+	for _, pair := range pairs {
+		for N := 2; N <= 6; N++ {
+			for k := 0; k < N; k++ {
+				ring := pair.ring(N, k)
+				opposite := pair.opposite(N, N-1-k)
+				if !undoesRing(ring, opposite, N) {
+					t.Errorf("%dx%d: ring%s(%d) = %v does not undo %v", N, N, pair.name, k, ring, opposite)
+				}
+			}
+		}
+	}
+}
+
+// undoesRing reports whether turning along ring, which moves each sticker
+// step places on, puts back every sticker a turn along other moved
+func undoesRing(ring, other []Coord, step int) bool {
+	if len(ring) != len(other) {
+		return false
+	}
+	n := len(other)
+	next := make(map[Coord]Coord, n)
+	for i, coord := range other {
+		next[coord] = other[(i+step)%n]
+	}
+	for i, coord := range ring {
+		moved, ok := next[ring[(i+step)%n]]
+		if !ok || moved != coord {
+			return false
+		}
+	}
+	return true
+}
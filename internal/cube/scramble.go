@@ -0,0 +1,243 @@
+package cube
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// DefaultScrambleLength is the number of random moves used when no length
+// is given
+const DefaultScrambleLength = 25
+
+// MaxOptimalSearchDepth is the longest solution OptimalLength can find.
+// Longer ones would need a full optimal solver, which this package does not
+// have yet.
+const MaxOptimalSearchDepth = 8
+
+// ScrambleOptions controls GenerateScramble
+type ScrambleOptions struct {
+	Length int        // Number of random moves (default DefaultScrambleLength)
+	Rand   *rand.Rand // Random source (default seeded from the clock)
+}
+
+// GenerateScramble returns a random move sequence for a cube of the given
+// size. Consecutive moves never turn the same face, and a face is not turned
+// again across a move on its opposite face (R L R), so no moves cancel.
+func GenerateScramble(size int, opts ScrambleOptions) ([]Move, error) {
+	if err := ValidateSize(size); err != nil {
+		return nil, err
+	}
+
+	length := opts.Length
+	if length <= 0 {
+		length = DefaultScrambleLength
+	}
+
+	rng := opts.Rand
+	if rng == nil {
+		rng = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+	return randomMoves(size, length, rng), nil
+}
+
+// randomMoves generates length random non-cancelling moves. Cubes larger
+// than 3x3 also get wide moves, as in WCA scrambles.
+func randomMoves(size, length int, rng *rand.Rand) []Move {
+	faces := []Face{Right, Left, Up, Down, Front, Back}
+	moves := make([]Move, 0, length)
+
+	for len(moves) < length {
+		face := faces[rng.Intn(len(faces))]
+		if n := len(moves); n > 0 {
+			prev := moves[n-1].Face
+			if face == prev {
+				continue
+			}
+			if n > 1 && face == moves[n-2].Face && oppositeFace(face) == prev {
+				continue
+			}
+		}
+
+		move := Move{Face: face, Clockwise: true}
+		switch rng.Intn(3) {
+		case 1:
+			move.Clockwise = false
+		case 2:
+			move.Double = true
+		}
+		if size > 3 && rng.Intn(2) == 1 {
+			move.Wide = true
+		}
+		moves = append(moves, move)
+	}
+
+	return moves
+}
+
+// oppositeFace returns the face across the cube from f
+func oppositeFace(f Face) Face {
+	switch f {
+	case Front:
+		return Back
+	case Back:
+		return Front
+	case Left:
+		return Right
+	case Right:
+		return Left
+	case Up:
+		return Down
+	default:
+		return Up
+	}
+}
+
+// solvedDistanceDepth is how far from solved the distance table reaches.
+// Searching that far from both ends covers solutions up to
+// MaxOptimalSearchDepth moves.
+const solvedDistanceDepth = MaxOptimalSearchDepth / 2
+
+var (
+	solvedDistanceOnce sync.Once
+	solvedDistances    map[string]int
+)
+
+// OptimalLength returns the length of the shortest face-turn solution for a
+// 3x3 cube, in half-turn metric, if it is at most limit moves. It reports
+// false if the cube needs more moves or limit exceeds MaxOptimalSearchDepth.
+// The cube may be held in any orientation.
+func OptimalLength(c *Cube, limit int) (int, bool) {
+	if c.Size != 3 || limit > MaxOptimalSearchDepth {
+		return 0, false
+	}
+
+	solvedDistanceOnce.Do(func() {
+		solvedDistances = buildDistanceTable(NewCube(3), solvedDistanceDepth)
+	})
+
+	// The table holds states with centers in the solved orientation
+	c = c.Clone()
+	standardOrientation(c)
+
+	// Every solution of length L passes through a state at most
+	// solvedDistanceDepth moves from solved after max(0, L-solvedDistanceDepth)
+	// moves, so the first search depth that reaches the table gives the
+	// optimum.
+	frontier := map[string]*Cube{cubeStateKey(c): c}
+	seen := map[string]bool{cubeStateKey(c): true}
+	for depth := 0; depth <= limit; depth++ {
+		best := -1
+		for key := range frontier {
+			if d, ok := solvedDistances[key]; ok && (best < 0 || d < best) {
+				best = d
+			}
+		}
+		if best >= 0 {
+			if depth+best > limit {
+				return 0, false
+			}
+			return depth + best, true
+		}
+		if depth+solvedDistanceDepth >= limit {
+			break
+		}
+
+		next := make(map[string]*Cube)
+		for _, state := range frontier {
+			for _, move := range faceTurns {
+				neighbor := state.Clone()
+				neighbor.ApplyMove(move)
+				key := cubeStateKey(neighbor)
+				if !seen[key] {
+					seen[key] = true
+					next[key] = neighbor
+				}
+			}
+		}
+		frontier = next
+	}
+
+	return 0, false
+}
+
+// Scramble difficulty categories returned by EstimateDifficulty
+const (
+	DifficultyEasy   = "Easy"   // Optimal solution of at most 5 moves
+	DifficultyMedium = "Medium" // Optimal solution of 6 to MaxOptimalSearchDepth moves
+	DifficultyHard   = "Hard"   // No solution within MaxOptimalSearchDepth moves
+)
+
+// OptimalUnknown is the optimal length EstimateDifficulty reports when the
+// scramble needs more than MaxOptimalSearchDepth moves
+const OptimalUnknown = -1
+
+// EstimateDifficulty applies a scramble to a solved 3x3 and buckets it by
+// its optimal solution length in half-turn metric. Scrambles solvable within
+// MaxOptimalSearchDepth moves report their exact optimal length. Longer
+// scrambles are Hard with an optimal length of OptimalUnknown: the
+// Kociemba solver here is a bounded search that can't get that far either,
+// so nothing in this package can measure them.
+func EstimateDifficulty(scramble []Move) (optimalMoves int, category string) {
+	c := NewCube(3)
+	c.ApplyMoves(scramble)
+
+	optimal, found := OptimalLength(c, MaxOptimalSearchDepth)
+	switch {
+	case !found:
+		return OptimalUnknown, DifficultyHard
+	case optimal <= 5:
+		return optimal, DifficultyEasy
+	default:
+		return optimal, DifficultyMedium
+	}
+}
+
+// faceTurns lists the 18 half-turn metric moves of a 3x3
+var faceTurns = func() []Move {
+	var moves []Move
+	for _, face := range []Face{Right, Left, Up, Down, Front, Back} {
+		moves = append(moves,
+			Move{Face: face, Clockwise: true},
+			Move{Face: face, Clockwise: false},
+			Move{Face: face, Double: true},
+		)
+	}
+	return moves
+}()
+
+// buildDistanceTable maps every state within depth face turns of start to its
+// exact distance from start
+func buildDistanceTable(start *Cube, depth int) map[string]int {
+	distances := map[string]int{cubeStateKey(start): 0}
+	frontier := []*Cube{start}
+	for d := 1; d <= depth; d++ {
+		var next []*Cube
+		for _, state := range frontier {
+			for _, move := range faceTurns {
+				neighbor := state.Clone()
+				neighbor.ApplyMove(move)
+				key := cubeStateKey(neighbor)
+				if _, ok := distances[key]; !ok {
+					distances[key] = d
+					next = append(next, neighbor)
+				}
+			}
+		}
+		frontier = next
+	}
+	return distances
+}
+
+// cubeStateKey packs the sticker colors into a compact map key
+func cubeStateKey(c *Cube) string {
+	key := make([]byte, 0, 6*c.Size*c.Size)
+	for face := 0; face < 6; face++ {
+		for row := 0; row < c.Size; row++ {
+			for col := 0; col < c.Size; col++ {
+				key = append(key, byte(c.Faces[face][row][col]))
+			}
+		}
+	}
+	return string(key)
+}
@@ -0,0 +1,174 @@
+package cube
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestGenerateScrambleDoesNotCancel(t *testing.T) {
+	for _, size := range []int{2, 3, 4} {
+		moves, err := GenerateScramble(size, ScrambleOptions{Rand: rand.New(rand.NewSource(1))})
+		if err != nil {
+			t.Fatalf("GenerateScramble(%d) error = %v", size, err)
+		}
+		if len(moves) != DefaultScrambleLength {
+			t.Errorf("GenerateScramble(%d) returned %d moves, want %d", size, len(moves), DefaultScrambleLength)
+		}
+		for i := 1; i < len(moves); i++ {
+			if moves[i].Face == moves[i-1].Face {
+				t.Errorf("size %d: moves %d and %d turn the same face: %v", size, i-1, i, moves)
+			}
+			if i > 1 && moves[i].Face == moves[i-2].Face && oppositeFace(moves[i].Face) == moves[i-1].Face {
+				t.Errorf("size %d: moves %d-%d turn a face across its opposite: %v", size, i-2, i, moves)
+			}
+		}
+	}
+}
+
+func TestGenerateScrambleSeeded(t *testing.T) {
+	a, _ := GenerateScramble(3, ScrambleOptions{Length: 20, Rand: rand.New(rand.NewSource(42))})
+	b, _ := GenerateScramble(3, ScrambleOptions{Length: 20, Rand: rand.New(rand.NewSource(42))})
+	if !reflect.DeepEqual(a, b) {
+		t.Errorf("same seed produced different scrambles: %v vs %v", a, b)
+	}
+}
+
+func TestOptimalLength(t *testing.T) {
+	tests := []struct {
+		scramble string
+		want     int
+	}{
+		{"", 0},
+		{"R", 1},
+		{"R U", 2},
+		{"R R", 1},
+		{"R U R' U'", 4},
+		{"R L R' L'", 0},
+	}
+
+	for _, tt := range tests {
+		c := NewCube(3)
+		moves, _ := ParseScramble(tt.scramble)
+		c.ApplyMoves(moves)
+		got, ok := OptimalLength(c, MaxOptimalSearchDepth)
+		if !ok || got != tt.want {
+			t.Errorf("OptimalLength(%q) = %d, %v; want %d", tt.scramble, got, ok, tt.want)
+		}
+	}
+
+	c := NewCube(3)
+	moves, _ := ParseScramble("R U F")
+	c.ApplyMoves(moves)
+	if _, ok := OptimalLength(c, 2); ok {
+		t.Error("OptimalLength should report false when the cube needs more than limit moves")
+	}
+}
+
+func TestEstimateDifficulty(t *testing.T) {
+	tests := []struct {
+		scramble string
+		optimal  int
+		category string
+	}{
+		{"", 0, DifficultyEasy},
+		{"R U R' U'", 4, DifficultyEasy},
+		{"R U F D L B", 6, DifficultyMedium},
+		{"R U F D L B R2 U2 F2 D'", OptimalUnknown, DifficultyHard},
+	}
+
+	for _, tt := range tests {
+		moves, _ := ParseScramble(tt.scramble)
+		optimal, category := EstimateDifficulty(moves)
+		if optimal != tt.optimal || category != tt.category {
+			t.Errorf("EstimateDifficulty(%q) = %d, %s; want %d, %s", tt.scramble, optimal, category, tt.optimal, tt.category)
+		}
+	}
+}
+
+// chiSquared returns Pearson's chi-squared statistic for counts against
+// an even spread over every bucket
+func chiSquared(counts []int) float64 {
+	total := 0
+	for _, n := range counts {
+		total += n
+	}
+	expected := float64(total) / float64(len(counts))
+	var stat float64
+	for _, n := range counts {
+		diff := float64(n) - expected
+		stat += diff * diff / expected
+	}
+	return stat
+}
+
+// TestGenerateScrambleDistribution checks a few thousand seeded random-move
+// scrambles for obvious bias. The bounds are chi-squared critical values at
+// p = 0.001 for each bucket count, so a fair generator fails about once in
+// a thousand seeds; with the seed fixed the test is deterministic.
+func TestGenerateScrambleDistribution(t *testing.T) {
+	const count = 3000
+	rng := rand.New(rand.NewSource(2430))
+
+	firstFace := make([]int, 6)
+	turn := make([]int, 3)
+	cornerPiece := make([]int, 8)
+	cornerTwist := make([]int, 3)
+	edgePiece := make([]int, 12)
+	seen := make(map[string]bool)
+
+	for i := 0; i < count; i++ {
+		moves, err := GenerateScramble(3, ScrambleOptions{Rand: rng})
+		if err != nil {
+			t.Fatalf("GenerateScramble error = %v", err)
+		}
+		if len(moves) != DefaultScrambleLength {
+			t.Fatalf("scramble %d has %d moves, want %d", i, len(moves), DefaultScrambleLength)
+		}
+
+		firstFace[moves[0].Face]++
+		for _, move := range moves {
+			switch {
+			case move.Double:
+				turn[2]++
+			case move.Clockwise:
+				turn[0]++
+			default:
+				turn[1]++
+			}
+		}
+
+		c := NewCube(3)
+		c.ApplyMoves(moves)
+		if c.IsSolved() {
+			t.Errorf("scramble %d leaves the cube solved: %s", i, FormatMoves(moves))
+		}
+		seen[c.String()] = true
+
+		corners, twist, edges := c.CornerPermutation(), c.CornerOrientation(), c.EdgePermutation()
+		cornerPiece[corners[0]]++
+		cornerTwist[twist[0]]++
+		edgePiece[edges[0]]++
+	}
+
+	if len(seen) != count {
+		t.Errorf("%d scrambles reached only %d distinct states", count, len(seen))
+	}
+
+	checks := []struct {
+		name   string
+		counts []int
+		limit  float64
+	}{
+		{"first move face", firstFace, 20.52},
+		{"turn direction", turn, 13.82},
+		{"piece in the first corner slot", cornerPiece, 24.32},
+		{"twist of the first corner slot", cornerTwist, 13.82},
+		{"piece in the first edge slot", edgePiece, 31.26},
+	}
+	for _, check := range checks {
+		if stat := chiSquared(check.counts); stat > check.limit {
+			t.Errorf("%s is uneven: counts %v, chi-squared %.2f > %.2f", check.name, check.counts, stat, check.limit)
+		}
+	}
+}
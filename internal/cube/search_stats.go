@@ -0,0 +1,68 @@
+package cube
+
+// searchStats counts the work a solve's searches do, for the
+// NodesExamined and StatesVisited fields of its SolverResult. The methods
+// do nothing on a nil *searchStats, so searches run outside a solve, such
+// as from tests, need no setup. Counting is also where a search notices
+// that its solve was cancelled.
+type searchStats struct {
+	nodes  int
+	states int
+	cancel <-chan struct{} // The solver's Cancel channel, or nil
+}
+
+// node counts a node expanded by an A* or depth-first search
+func (st *searchStats) node() {
+	if st != nil {
+		st.nodes++
+		st.checkCancel(st.nodes)
+	}
+}
+
+// state counts a state taken off a breadth-first search queue
+func (st *searchStats) state() {
+	if st != nil {
+		st.states++
+		st.checkCancel(st.states)
+	}
+}
+
+// checkCancel unwinds the search with a solveCancelled panic if the cancel
+// channel is closed, looking only every cancelCheckInterval counts
+func (st *searchStats) checkCancel(count int) {
+	if st.cancel == nil || count%cancelCheckInterval != 0 {
+		return
+	}
+	select {
+	case <-st.cancel:
+		panic(solveCancelled{})
+	default:
+	}
+}
+
+// trackSearch starts counting search effort in *stats for a solve watching
+// cancel, and returns a function that, deferred, copies the counts into
+// *result and stops counting. If the solve was cancelled it recovers the
+// search's panic and sets *err to ErrSolveCancelled instead. A solve
+// started by another one already counting, such as a CFOP fallback to the
+// beginner solver, adds to the outer counts and leaves reporting them, and
+// any cancellation, to the outer solve.
+func trackSearch(stats **searchStats, cancel <-chan struct{}, result **SolverResult, err *error) func() {
+	if *stats != nil {
+		return func() {}
+	}
+	*stats = &searchStats{cancel: cancel}
+	return func() {
+		if r := recover(); r != nil {
+			if _, ok := r.(solveCancelled); !ok {
+				panic(r)
+			}
+			*result, *err = nil, ErrSolveCancelled
+		}
+		if *result != nil {
+			(*result).NodesExamined = (*stats).nodes
+			(*result).StatesVisited = (*stats).states
+		}
+		*stats = nil
+	}
+}
@@ -0,0 +1,48 @@
+package cube
+
+import "testing"
+
+func TestSolverResultSearchEffort(t *testing.T) {
+	scrambled := NewCube(3)
+	scrambled.ApplyMoves([]Move{{Face: Right, Clockwise: true}, {Face: Up, Clockwise: true}})
+
+	for _, solver := range []Solver{&CFOPSolver{}, &KociembaSolver{}} {
+		first, err := solver.Solve(scrambled)
+		if err != nil {
+			t.Fatalf("%s: Solve() error = %v", solver.Name(), err)
+		}
+		if first.NodesExamined == 0 && first.StatesVisited == 0 {
+			t.Errorf("%s: Solve() reported no search effort", solver.Name())
+		}
+
+		// Counts start over with each solve
+		second, err := solver.Solve(scrambled)
+		if err != nil {
+			t.Fatalf("%s: Solve() error = %v", solver.Name(), err)
+		}
+		if second.NodesExamined != first.NodesExamined || second.StatesVisited != first.StatesVisited {
+			t.Errorf("%s: second solve reported %d nodes and %d states, first %d and %d", solver.Name(),
+				second.NodesExamined, second.StatesVisited, first.NodesExamined, first.StatesVisited)
+		}
+
+		solved, err := solver.Solve(NewCube(3))
+		if err != nil {
+			t.Fatalf("%s: Solve(solved) error = %v", solver.Name(), err)
+		}
+		if solved.NodesExamined != 0 || solved.StatesVisited != 0 {
+			t.Errorf("%s: solving a solved cube reported %d nodes and %d states", solver.Name(), solved.NodesExamined, solved.StatesVisited)
+		}
+	}
+}
+
+func TestSolveToGoalSearchEffort(t *testing.T) {
+	c := NewCube(3)
+	c.ApplyMove(Move{Face: Right, Clockwise: true})
+	result, err := SolveToGoal(c, (*Cube).IsSolved, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.NodesExamined == 0 {
+		t.Error("SolveToGoal() reported no nodes examined")
+	}
+}
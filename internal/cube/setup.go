@@ -0,0 +1,33 @@
+package cube
+
+import "fmt"
+
+// Setup moves
+//
+// To present a case for practice, a trainer applies the inverse of an
+// algorithm that solves it to a solved cube. Doing the algorithm then
+// solves the cube again. A random U turn after the setup shows the case
+// at another angle, as it turns up in a real solve.
+
+// SetupMovesFor returns the moves that set up a case, named by its case
+// ID (such as "OLL-27"), on a solved 3x3: the inverse of the case's
+// shortest algorithm in HTM. Applying them and then that algorithm
+// leaves the cube solved.
+func SetupMovesFor(caseID string) ([]Move, error) {
+	return SetupMovesWithAUF(caseID, 0)
+}
+
+// SetupMovesWithAUF returns SetupMovesFor's moves followed by the U turn
+// AUFMoves gives for quarterTurns, which shows the case at another angle.
+// The algorithm then solves it after AUFMoves(-quarterTurns).
+func SetupMovesWithAUF(caseID string, quarterTurns int) ([]Move, error) {
+	alg, ok := ShortestAlgorithmFor(caseID, HTM)
+	if !ok {
+		return nil, fmt.Errorf("no algorithm for case '%s'", caseID)
+	}
+	moves, err := ParseScramble(alg.Moves)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse algorithm moves: %w", err)
+	}
+	return append(InvertSequence(moves), AUFMoves(quarterTurns)...), nil
+}
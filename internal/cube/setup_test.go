@@ -0,0 +1,53 @@
+package cube
+
+import "testing"
+
+func TestSetupMovesFor(t *testing.T) {
+	for _, caseID := range CaseIDs() {
+		alg, ok := ShortestAlgorithmFor(caseID, HTM)
+		if !ok {
+			continue
+		}
+		moves, _ := ParseScramble(alg.Moves)
+
+		setup, err := SetupMovesFor(caseID)
+		if err != nil {
+			t.Errorf("SetupMovesFor(%s) error = %v", caseID, err)
+			continue
+		}
+		c := NewCube(3)
+		c.ApplyMoves(setup)
+		c.ApplyMoves(moves)
+		if !c.IsSolved() {
+			t.Errorf("%s: setup %s then %s doesn't solve the cube", caseID, FormatMoves(setup), alg.Moves)
+		}
+	}
+
+	if _, err := SetupMovesFor("OLL-99"); err == nil {
+		t.Error("SetupMovesFor(OLL-99) should fail")
+	}
+}
+
+func TestSetupMovesWithAUF(t *testing.T) {
+	alg, _ := ShortestAlgorithmFor("PLL-T", HTM)
+	moves, _ := ParseScramble(alg.Moves)
+	plain, _ := SetupMovesFor("PLL-T")
+
+	for turns := 0; turns < 4; turns++ {
+		setup, err := SetupMovesWithAUF("PLL-T", turns)
+		if err != nil {
+			t.Fatalf("SetupMovesWithAUF(PLL-T, %d) error = %v", turns, err)
+		}
+		if want := len(plain) + len(AUFMoves(turns)); len(setup) != want {
+			t.Errorf("SetupMovesWithAUF(PLL-T, %d) has %d moves, want %d", turns, len(setup), want)
+		}
+
+		c := NewCube(3)
+		c.ApplyMoves(setup)
+		c.ApplyMoves(AUFMoves(-turns))
+		c.ApplyMoves(moves)
+		if !c.IsSolved() {
+			t.Errorf("%d turns: setup %s, AUF, then %s doesn't solve the cube", turns, FormatMoves(setup), alg.Moves)
+		}
+	}
+}
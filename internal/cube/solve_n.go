@@ -0,0 +1,51 @@
+package cube
+
+import "fmt"
+
+// Multiple solutions
+//
+// A search-based solver can keep going after its first solution and
+// return alternatives, which is useful for fewest-moves study or for
+// letting a user pick the solution they like. Alternatives are kept
+// diverse by giving each a different first move.
+
+// MultiSolver is a Solver that can return alternative solutions
+type MultiSolver interface {
+	Solver
+
+	// SolveN returns up to n distinct solutions, shortest first. Like
+	// Solve, it never modifies the cube it is given.
+	SolveN(cube *Cube, n int) ([]*SolverResult, error)
+}
+
+// SolveN returns up to n distinct solutions for c from solver, shortest
+// first. Solvers that implement MultiSolver search for alternatives; any
+// other solver returns its one solution. A solved cube has just the empty
+// solution. Like SolveVerified, it checks every solution and returns a
+// *WrongSolutionError if one doesn't solve the cube.
+func SolveN(solver Solver, c *Cube, n int) ([]*SolverResult, error) {
+	if n < 1 {
+		return nil, fmt.Errorf("solution count must be at least 1, got %d", n)
+	}
+
+	var results []*SolverResult
+	if multi, ok := solver.(MultiSolver); ok {
+		var err error
+		if results, err = multi.SolveN(c, n); err != nil {
+			return nil, err
+		}
+	} else {
+		result, err := solver.Solve(c)
+		if err != nil {
+			return nil, err
+		}
+		results = []*SolverResult{result}
+	}
+
+	for _, result := range results {
+		if err := verifySolution(solver, c, result); err != nil {
+			return nil, err
+		}
+	}
+	return results, nil
+}
@@ -0,0 +1,99 @@
+package cube
+
+import "testing"
+
+// checkSolutions checks that every result solves c and that no two start
+// with the same move
+func checkSolutions(t *testing.T, c *Cube, results []*SolverResult) {
+	t.Helper()
+	firstMoves := make(map[Move]bool)
+	for _, result := range results {
+		if len(result.Solution) > 0 {
+			if firstMoves[result.Solution[0]] {
+				t.Errorf("two solutions start with %s", result.Solution[0].String())
+			}
+			firstMoves[result.Solution[0]] = true
+		}
+		if result.Steps != len(result.Solution) {
+			t.Errorf("Steps = %d, want %d", result.Steps, len(result.Solution))
+		}
+
+		solved := c.Clone()
+		solved.ApplyMoves(result.Solution)
+		if !solved.IsSolved() {
+			t.Errorf("solution %s doesn't solve the cube", FormatMoves(result.Solution))
+		}
+	}
+}
+
+func TestSolveNOptimal2x2(t *testing.T) {
+	c := NewCube(2)
+	moves, _ := ParseMoves("R U2 F' R U R'")
+	c.ApplyMoves(moves)
+	before := c.Checksum()
+
+	results, err := SolveN(&Optimal2x2Solver{}, c, 3)
+	if err != nil {
+		t.Fatalf("SolveN() error = %v", err)
+	}
+	if len(results) != 3 {
+		t.Fatalf("SolveN() returned %d solutions, want 3", len(results))
+	}
+	if c.Checksum() != before {
+		t.Error("SolveN() modified the input cube")
+	}
+	checkSolutions(t, c, results)
+	for i, result := range results[1:] {
+		if result.Steps < results[i].Steps || result.Steps > results[0].Steps+2 {
+			t.Errorf("solution %d has %d moves after one of %d", i+2, result.Steps, results[i].Steps)
+		}
+	}
+
+	// Every first move gives a solution
+	results, _ = SolveN(&Optimal2x2Solver{}, c, 20)
+	if len(results) != len(twoByTwoMoves) {
+		t.Errorf("SolveN(20) returned %d solutions, want %d", len(results), len(twoByTwoMoves))
+	}
+	checkSolutions(t, c, results)
+}
+
+func TestSolveNKociemba(t *testing.T) {
+	c := NewCube(3)
+	moves, _ := ParseMoves("R2 U D")
+	c.ApplyMoves(moves)
+
+	results, err := SolveN(&KociembaSolver{}, c, 4)
+	if err != nil {
+		t.Fatalf("SolveN() error = %v", err)
+	}
+	if len(results) != 4 {
+		t.Fatalf("SolveN() returned %d solutions, want 4", len(results))
+	}
+	checkSolutions(t, c, results)
+	for _, result := range results[1:] {
+		if result.Steps < results[0].Steps || result.Steps > results[0].Steps+kociembaAlternativeSlack {
+			t.Errorf("alternative %s has %d moves, first solution has %d", FormatMoves(result.Solution), result.Steps, results[0].Steps)
+		}
+	}
+}
+
+func TestSolveNSingleSolution(t *testing.T) {
+	// A solved cube has only the empty solution
+	results, err := SolveN(&KociembaSolver{}, NewCube(3), 5)
+	if err != nil || len(results) != 1 || results[0].Steps != 0 {
+		t.Errorf("SolveN(solved) = %v, %v", results, err)
+	}
+
+	// Solvers without alternatives return their one solution
+	c := NewCube(3)
+	c.ApplyMove(Move{Face: Right, Clockwise: true})
+	results, err = SolveN(&CFOPSolver{}, c, 3)
+	if err != nil || len(results) != 1 {
+		t.Fatalf("SolveN(CFOP) = %v, %v", results, err)
+	}
+	checkSolutions(t, c, results)
+
+	if _, err := SolveN(&KociembaSolver{}, c, 0); err == nil {
+		t.Error("SolveN(n = 0) should fail")
+	}
+}
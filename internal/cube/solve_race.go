@@ -0,0 +1,109 @@
+package cube
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// Solver racing
+//
+// No one solver is fastest on every cube: Kociemba is quick on hard
+// scrambles, but CFOP or the beginner method can finish first on a cube a
+// few moves from solved. SolveRace runs several at once and takes whichever
+// answers first. Once the race is decided, the losers that support
+// SetCancel are cancelled, and SolveRace waits the moment it takes them to
+// stop; any others run to completion in the background and their results
+// are thrown away.
+
+// SolveRace solves c with every solver at once and returns the first
+// solution that checks out, as SolveVerified would. Each solver works on
+// its own copy of c, so c is not modified, but the solvers must be
+// distinct values since they run concurrently. SolveRace sets the Cancel
+// channel of each solver that has one and clears it again before
+// returning. If every solver fails, it
+// returns the error of the first solver in the list; if ctx is done before
+// any succeeds, it returns ctx.Err().
+func SolveRace(ctx context.Context, c *Cube, solvers ...Solver) (*SolverResult, error) {
+	if len(solvers) == 0 {
+		return nil, errors.New("no solvers to race")
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	type outcome struct {
+		index  int
+		result *SolverResult
+		err    error
+	}
+	// Buffered so losers finishing after the race is decided don't block
+	outcomes := make(chan outcome, len(solvers))
+
+	// Closing done cancels the losers; stopping waits for them to notice
+	done := make(chan struct{})
+	var stopping sync.WaitGroup
+	defer func() {
+		close(done)
+		stopping.Wait()
+	}()
+
+	for i, solver := range solvers {
+		cancellable := SetCancel(solver, done)
+		if cancellable {
+			stopping.Add(1)
+		}
+		go func(i int, solver Solver, c *Cube) {
+			result, err := SolveVerified(solver, c)
+			if cancellable {
+				SetCancel(solver, nil)
+				stopping.Done()
+			}
+			outcomes <- outcome{i, result, err}
+		}(i, solver, c.Clone())
+	}
+
+	errs := make([]error, len(solvers))
+	for range solvers {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case o := <-outcomes:
+			if o.err == nil {
+				return o.result, nil
+			}
+			errs[o.index] = o.err
+		}
+	}
+	return nil, errs[0]
+}
+
+// RaceSolver is a Solver that races Solvers with SolveRace. Its zero value
+// races the beginner, CFOP, Kociemba, and 2x2 solvers, each of which fails
+// quickly on a cube size it doesn't handle.
+type RaceSolver struct {
+	Solvers []Solver
+	Timeout time.Duration // Give up after this long; 0 waits for the winner
+}
+
+// Solve returns the first solution any of the raced solvers finds
+func (s *RaceSolver) Solve(c *Cube) (*SolverResult, error) {
+	solvers := s.Solvers
+	if len(solvers) == 0 {
+		solvers = []Solver{&BeginnerSolver{}, &CFOPSolver{}, &KociembaSolver{}, &Optimal2x2Solver{}}
+	}
+
+	ctx := context.Background()
+	if s.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.Timeout)
+		defer cancel()
+	}
+	return SolveRace(ctx, c, solvers...)
+}
+
+// Name returns the solver name
+func (s *RaceSolver) Name() string {
+	return "race"
+}
@@ -0,0 +1,129 @@
+package cube
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestSolveRace(t *testing.T) {
+	scramble := "R U R' U'"
+	c := NewCube(3)
+	moves, _ := ParseScramble(scramble)
+	c.ApplyMoves(moves)
+	before := c.Checksum()
+
+	// CFOP and the beginner method answer first here, with solutions that
+	// don't check out, so Kociemba wins
+	result, err := SolveRace(context.Background(), c, &BeginnerSolver{}, &CFOPSolver{}, &KociembaSolver{})
+	if err != nil {
+		t.Fatalf("SolveRace() error = %v", err)
+	}
+	AssertSolves(t, scramble, FormatMoves(result.Solution))
+	if c.Checksum() != before {
+		t.Error("SolveRace modified the cube")
+	}
+}
+
+func TestSolveRaceSkipsFailures(t *testing.T) {
+	// Only the 2x2 solver handles a 2x2; the others fail and lose
+	c := NewCube(2)
+	moves, _ := ParseScramble("R U F' R2")
+	c.ApplyMoves(moves)
+
+	result, err := (&RaceSolver{}).Solve(c)
+	if err != nil {
+		t.Fatalf("RaceSolver.Solve() error = %v", err)
+	}
+	c.ApplyMoves(result.Solution)
+	if !c.IsSolved() {
+		t.Errorf("solution %s doesn't solve the 2x2", FormatMoves(result.Solution))
+	}
+}
+
+func TestSolveRaceErrors(t *testing.T) {
+	if _, err := SolveRace(context.Background(), NewCube(3)); err == nil {
+		t.Error("SolveRace() with no solvers should fail")
+	}
+
+	// Neither solver handles a 5x5, and the first solver's error wins
+	var unsolvable *UnsolvableError
+	_, err := SolveRace(context.Background(), NewCube(5), &Optimal2x2Solver{}, &KociembaSolver{})
+	if !errors.As(err, &unsolvable) {
+		t.Errorf("SolveRace(5x5) error = %v, want *UnsolvableError", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	c := NewCube(3)
+	moves, _ := ParseScramble("R U R' U'")
+	c.ApplyMoves(moves)
+	if _, err := SolveRace(ctx, c, &KociembaSolver{}); !errors.Is(err, context.Canceled) {
+		t.Errorf("SolveRace(cancelled) error = %v, want context.Canceled", err)
+	}
+}
+
+// hardScramble is far enough from solved that Kociemba searches for minutes
+const hardScramble = "R U F D L B R2 U2 F2 D' L' B'"
+
+// withinTime fails t if f doesn't return within limit
+func withinTime(t *testing.T, limit time.Duration, name string, f func()) {
+	t.Helper()
+	finished := make(chan struct{})
+	go func() {
+		f()
+		close(finished)
+	}()
+	select {
+	case <-finished:
+	case <-time.After(limit):
+		t.Fatalf("%s still running after %v", name, limit)
+	}
+}
+
+func TestSolveCancel(t *testing.T) {
+	c := NewCube(3)
+	moves, _ := ParseScramble(hardScramble)
+	c.ApplyMoves(moves)
+
+	// The beginner solver finishes this cube before its first check
+	for _, solver := range []Solver{&CFOPSolver{}, &KociembaSolver{}} {
+		cancel := make(chan struct{})
+		close(cancel)
+		if !SetCancel(solver, cancel) {
+			t.Fatalf("SetCancel(%s) reported no cancellation support", solver.Name())
+		}
+		withinTime(t, 5*time.Second, solver.Name(), func() {
+			result, err := solver.Solve(c)
+			if result != nil || !errors.Is(err, ErrSolveCancelled) {
+				t.Errorf("%s: cancelled Solve() = %v, %v; want ErrSolveCancelled", solver.Name(), result, err)
+			}
+		})
+	}
+	if SetCancel(&Optimal2x2Solver{}, nil) {
+		t.Error("SetCancel should report no support for the 2x2 solver")
+	}
+}
+
+func TestSolveRaceCancelsLosers(t *testing.T) {
+	c := NewCube(3)
+	moves, _ := ParseScramble(hardScramble)
+	c.ApplyMoves(moves)
+
+	// The inverse solver wins at once; Kociemba must be stopped, since
+	// SolveRace waits for it
+	kociemba := &KociembaSolver{}
+	winner := &inverseSolver{known: map[string][]Move{c.String(): moves}}
+	withinTime(t, 5*time.Second, "SolveRace", func() {
+		result, err := SolveRace(context.Background(), c, winner, kociemba)
+		if err != nil {
+			t.Errorf("SolveRace() error = %v", err)
+		} else if result.Steps != len(moves) {
+			t.Errorf("SolveRace() = %s, want the inverse solver's solution", FormatMoves(result.Solution))
+		}
+	})
+	if kociemba.Cancel != nil {
+		t.Error("SolveRace left the loser's Cancel channel set")
+	}
+}
@@ -0,0 +1,50 @@
+package cube
+
+import (
+	"fmt"
+	"time"
+)
+
+// SolveToState returns moves that turn from into to, using solver for the
+// search: it solves from, then plays a solution of to backwards, and
+// cancels what it can where the two meet. If the two are held
+// differently, the moves include the rotation between them. Neither cube
+// is modified.
+//
+// The cubes must be the same size and both solvable by solver. If no
+// rotation lines up their solved states, as when they use different color
+// schemes, SolveToState returns an error.
+func SolveToState(solver Solver, from, to *Cube) (*SolverResult, error) {
+	if from.Size != to.Size {
+		return nil, fmt.Errorf("can't reach a %dx%d state from a %dx%d", to.Size, to.Size, from.Size, from.Size)
+	}
+	start := time.Now()
+
+	solveFrom, err := SolveVerified(solver, from)
+	if err != nil {
+		return nil, fmt.Errorf("solving the start state: %w", err)
+	}
+	solveTo, err := SolveVerified(solver, to)
+	if err != nil {
+		return nil, fmt.Errorf("solving the end state: %w", err)
+	}
+
+	// Both solutions end on a solved cube, but not necessarily held the
+	// same way; rotate between them so the second one plays back in the
+	// frame it was found in
+	solvedFrom := from.Clone()
+	solvedFrom.ApplyMoves(solveFrom.Solution)
+	solvedTo := to.Clone()
+	solvedTo.ApplyMoves(solveTo.Solution)
+	for _, rotation := range orientationRotations {
+		rotated := solvedFrom.Clone()
+		rotated.ApplyMoves(rotation)
+		if rotated.String() != solvedTo.String() {
+			continue
+		}
+		path := append(append([]Move{}, solveFrom.Solution...), rotation...)
+		path = OptimizeMoves(append(path, InvertSequence(solveTo.Solution)...))
+		return &SolverResult{Solution: path, Steps: len(path), Duration: time.Since(start)}, nil
+	}
+	return nil, fmt.Errorf("no sequence of moves turns the start state into the end state; check that both use the same colors")
+}
@@ -0,0 +1,56 @@
+package cube
+
+import "testing"
+
+func TestSolveToState(t *testing.T) {
+	tests := []struct {
+		name     string
+		from, to string // Scrambles applied to solved cubes
+	}{
+		{"same state", "R U", "R U"},
+		{"one move apart", "R U R'", "R U R' F"},
+		{"unrelated", "F2 D L'", "R U2 B"},
+		{"rotated", "R U", "R U y"},
+		{"rotated and turned", "x R U", "F L2"},
+		{"from solved", "", "R U F'"},
+	}
+
+	solver := &KociembaSolver{}
+	for _, tt := range tests {
+		from, to := NewCube(3), NewCube(3)
+		fromMoves, _ := ParseScramble(tt.from)
+		toMoves, _ := ParseScramble(tt.to)
+		from.ApplyMoves(fromMoves)
+		to.ApplyMoves(toMoves)
+		start := from.Checksum()
+
+		result, err := SolveToState(solver, from, to)
+		if err != nil {
+			t.Errorf("%s: SolveToState() error = %v", tt.name, err)
+			continue
+		}
+		if from.Checksum() != start {
+			t.Errorf("%s: SolveToState modified the start state", tt.name)
+		}
+		from.ApplyMoves(result.Solution)
+		if from.String() != to.String() {
+			t.Errorf("%s: %s doesn't reach the end state", tt.name, FormatMoves(result.Solution))
+		}
+	}
+}
+
+func TestSolveToStateErrors(t *testing.T) {
+	solver := &KociembaSolver{}
+	if _, err := SolveToState(solver, NewCube(3), NewCube(2)); err == nil {
+		t.Error("SolveToState between sizes succeeded")
+	}
+
+	// A different color scheme can't be reached with moves
+	other, err := NewCubeWithScheme(3, japaneseScheme)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := SolveToState(solver, NewCube(3), other); err == nil {
+		t.Error("SolveToState to another color scheme succeeded")
+	}
+}
@@ -0,0 +1,27 @@
+package cube
+
+// SolveVerified solves c with solver and checks the solution on a copy of
+// c, returning a *WrongSolutionError instead of a solution that doesn't
+// solve the cube. Callers at the edge of the program use it so a solver
+// bug fails loudly rather than handing back bad moves.
+func SolveVerified(solver Solver, c *Cube) (*SolverResult, error) {
+	result, err := solver.Solve(c)
+	if err != nil {
+		return nil, err
+	}
+	if err := verifySolution(solver, c, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// verifySolution applies result's solution to a copy of c and reports a
+// *WrongSolutionError if that doesn't solve it
+func verifySolution(solver Solver, c *Cube, result *SolverResult) error {
+	check := c.Clone()
+	check.ApplyMoves(result.Solution)
+	if !check.IsSolved() {
+		return &WrongSolutionError{Solver: solver.Name(), Solution: result.Solution}
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package cube
+
+import (
+	"errors"
+	"testing"
+)
+
+// fixedSolver returns the same solution for every cube
+type fixedSolver struct {
+	solution []Move
+}
+
+func (s *fixedSolver) Name() string { return "fixed" }
+
+func (s *fixedSolver) Solve(cube *Cube) (*SolverResult, error) {
+	return &SolverResult{Solution: s.solution, Steps: len(s.solution)}, nil
+}
+
+func TestSolveVerified(t *testing.T) {
+	c := NewCube(3)
+	c.ApplyMove(Move{Face: Right, Clockwise: true})
+	before := c.Checksum()
+
+	right := &fixedSolver{solution: []Move{{Face: Right}}}
+	if result, err := SolveVerified(right, c); err != nil || result.Steps != 1 {
+		t.Errorf("SolveVerified(right solution) = %v, %v", result, err)
+	}
+
+	wrong := &fixedSolver{solution: []Move{{Face: Up}}}
+	_, err := SolveVerified(wrong, c)
+	var wrongErr *WrongSolutionError
+	if !errors.As(err, &wrongErr) || wrongErr.Solver != "fixed" {
+		t.Errorf("SolveVerified(wrong solution) error = %v, want a *WrongSolutionError", err)
+	}
+	if _, err := SolveN(wrong, c, 1); !errors.As(err, &wrongErr) {
+		t.Errorf("SolveN(wrong solution) error = %v, want a *WrongSolutionError", err)
+	}
+
+	if c.Checksum() != before {
+		t.Error("SolveVerified modified the input cube")
+	}
+}
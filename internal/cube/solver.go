@@ -1,7 +1,12 @@
 package cube
 
 import (
+	"errors"
 	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
 	"time"
 )
 
@@ -10,29 +15,68 @@ type SolverResult struct {
 	Solution []Move
 	Steps    int
 	Duration time.Duration
+
+	// Search effort, for comparing solvers and seeing why a solve was
+	// slow. NodesExamined counts nodes expanded by A* and depth-first
+	// searches; StatesVisited counts states taken off breadth-first
+	// search queues. Both are 0 for solvers that don't search.
+	NodesExamined int
+	StatesVisited int
 }
 
-// Solver interface for different solving algorithms
+// Solver interface for different solving algorithms. Solve never modifies
+// the cube it is given; apply the returned solution to get the solved cube.
+// Solvers count their search effort while solving, so one solver value
+// must not run two solves at once.
 type Solver interface {
 	Solve(cube *Cube) (*SolverResult, error)
 	Name() string
 }
 
 // BeginnerSolver implements layer-by-layer method (placeholder)
-type BeginnerSolver struct{}
+type BeginnerSolver struct {
+	// Trace, if set, receives a line per stage and search (see SetTrace)
+	Trace io.Writer
+
+	// Cancel, if set, stops the solve with ErrSolveCancelled once it is
+	// closed (see SetCancel)
+	Cancel <-chan struct{}
+
+	// CrossColor is the color the cross is built in, found by its center.
+	// The zero value is White.
+	CrossColor Color
+
+	// CrossFace is where the cross is built: Down, or Up for cubers who
+	// learned to build it on top. Any other face, including the zero
+	// value Front, means Down. The solution starts with the whole-cube
+	// rotation that brings CrossColor to CrossFace, if the cube isn't
+	// already held that way.
+	CrossFace Face
+
+	stats *searchStats // Search effort of the solve in progress
+}
 
 func (s *BeginnerSolver) Name() string {
 	return "Beginner"
 }
 
-func (s *BeginnerSolver) Solve(cube *Cube) (*SolverResult, error) {
+func (s *BeginnerSolver) Solve(cube *Cube) (result *SolverResult, err error) {
+	defer trackSearch(&s.stats, s.Cancel, &result, &err)()
 	start := time.Now()
 
 	// Only support 3x3 for now
 	if cube.Size != 3 {
-		return nil, fmt.Errorf("beginner solver only supports 3x3 cubes")
+		return nil, &UnsolvableError{Reason: "beginner solver only supports 3x3 cubes"}
+	}
+
+	// The layer-by-layer steps below build a white cross on D
+	if s.CrossColor != White || s.CrossFace == Up || cube.Faces[Down][1][1] != White {
+		return s.solveOriented(cube)
 	}
 
+	// Work on a copy so the caller's cube is never modified
+	cube = cube.Clone()
+
 	// Check if cube is already solved
 	if cube.IsSolved() {
 		return &SolverResult{
@@ -48,10 +92,13 @@ func (s *BeginnerSolver) Solve(cube *Cube) (*SolverResult, error) {
 	workingCube := s.copyCube(cube)
 
 	// Step 1: Solve white cross (4 white edges on bottom)
+	tracef(s.Trace, "beginner: solving white cross")
 	crossMoves, err := s.solveWhiteCross(workingCube)
 	if err != nil {
+		tracef(s.Trace, "beginner: white cross failed: %v", err)
 		return nil, fmt.Errorf("failed to solve white cross: %w", err)
 	}
+	tracef(s.Trace, "beginner: white cross solved in %d moves", len(crossMoves))
 	solution = append(solution, crossMoves...)
 	workingCube.ApplyMoves(crossMoves)
 
@@ -69,6 +116,72 @@ func (s *BeginnerSolver) Solve(cube *Cube) (*SolverResult, error) {
 	}, nil
 }
 
+// solveOriented solves with the cross in CrossColor on CrossFace. It
+// rotates a copy of cube so CrossColor is on the D face, repaints it to
+// look like a standard white-down cube, solves that with the default
+// settings, and puts the rotation in front of the solution. A cross built
+// on Up is solved upside down and its moves turned over to match.
+func (s *BeginnerSolver) solveOriented(cube *Cube) (*SolverResult, error) {
+	start := time.Now()
+	onUp := s.CrossFace == Up
+	crossFace := Down
+	if onUp {
+		crossFace = Up
+	}
+
+	var rotation []Move
+	found := false
+	for _, candidate := range orientationRotations {
+		rotated := cube.Clone()
+		rotated.ApplyMoves(candidate)
+		if rotated.Faces[crossFace][1][1] == s.CrossColor && (!found || len(candidate) < len(rotation)) {
+			rotation, found = candidate, true
+		}
+	}
+	if !found {
+		return nil, &UnsolvableError{Reason: fmt.Sprintf("no %s center to build the cross on", s.CrossColor)}
+	}
+	tracef(s.Trace, "beginner: building the %s cross on %s after %q", s.CrossColor, crossFace, FormatMoves(rotation))
+
+	rotated := cube.Clone()
+	rotated.ApplyMoves(rotation)
+	if onUp {
+		rotated.ApplyMove(Move{Rotation: X_Rotation, Double: true})
+	}
+	recolorToStandard(rotated)
+
+	inner := *s
+	inner.CrossColor, inner.CrossFace = White, Down
+	result, err := inner.Solve(rotated)
+	if err != nil {
+		return nil, err
+	}
+
+	moves := result.Solution
+	if onUp {
+		moves = turnOver(moves)
+	}
+	solution := append(append([]Move{}, rotation...), moves...)
+	return &SolverResult{
+		Solution: solution,
+		Steps:    len(solution),
+		Duration: time.Since(start),
+	}, nil
+}
+
+// turnOver rewrites face turns for a cube held upside down (turned with
+// x2): U and D swap places, as do F and B, and each keeps its direction.
+// The beginner solver only produces face turns, which is all this handles.
+func turnOver(moves []Move) []Move {
+	flipped := map[Face]Face{Up: Down, Down: Up, Front: Back, Back: Front, Right: Right, Left: Left}
+	turned := make([]Move, len(moves))
+	for i, move := range moves {
+		move.Face = flipped[move.Face]
+		turned[i] = move
+	}
+	return turned
+}
+
 // Try inverse of simple single moves
 func (s *BeginnerSolver) trySingleMoveInverse(cube *Cube) ([]Move, error) {
 	// Test common single moves and their inverses
@@ -241,8 +354,9 @@ func (s *BeginnerSolver) breadthFirstSearch(cube *Cube, maxDepth int) ([]Move, e
 			queue = queue[1:]
 			
 			statesExamined++
+			s.stats.state()
 			if statesExamined > maxStates {
-				return nil, fmt.Errorf("search exceeded maximum states (%d)", maxStates)
+				return nil, &SearchLimitError{MaxStates: maxStates}
 			}
 			
 			// Try each possible move
@@ -269,7 +383,7 @@ func (s *BeginnerSolver) breadthFirstSearch(cube *Cube, maxDepth int) ([]Move, e
 		}
 	}
 	
-	return nil, fmt.Errorf("no solution found within %d moves", maxDepth)
+	return nil, &SearchLimitError{MaxDepth: maxDepth}
 }
 
 // Create a copy of a cube
@@ -316,11 +430,12 @@ func (s *BeginnerSolver) iterativeDeepeningSearch(cube *Cube, maxDepth int) ([]M
 		}
 	}
 	
-	return nil, fmt.Errorf("no solution found within %d moves", maxDepth)
+	return nil, &SearchLimitError{MaxDepth: maxDepth}
 }
 
 // Depth-limited search with recursion
 func (s *BeginnerSolver) depthLimitedSearch(cube *Cube, target *Cube, path []Move, limit int, depth int) ([]Move, bool) {
+	s.stats.node()
 	// Check if solved
 	if s.cubesMatch(cube, target) {
 		return path, true
@@ -349,8 +464,8 @@ func (s *BeginnerSolver) depthLimitedSearch(cube *Cube, target *Cube, path []Mov
 	
 	// Try each possible move
 	for _, move := range moves {
-		// Avoid immediate move cancellation (simple pruning)
-		if len(path) > 0 && s.isOppositeMove(path[len(path)-1], move) {
+		// Skip moves that combine with or commute past the last one
+		if len(path) > 0 && RedundantAfterIn(path[len(path)-1], move, moves) {
 			continue
 		}
 		
@@ -373,35 +488,21 @@ func (s *BeginnerSolver) depthLimitedSearch(cube *Cube, target *Cube, path []Mov
 	return nil, false
 }
 
-// Check if two moves are opposites (for basic pruning)
-func (s *BeginnerSolver) isOppositeMove(move1, move2 Move) bool {
-	// Same face, opposite direction
-	return move1.Face == move2.Face && move1.Clockwise != move2.Clockwise &&
-		!move1.Double && !move2.Double
-}
-
 // Simple heuristic: count misplaced stickers (admissible but not very tight)
 func (s *BeginnerSolver) heuristic(cube *Cube) int {
-	solvedCube := NewCube(cube.Size)
-	misplaced := 0
-	
 	// Count misplaced stickers
-	for face := 0; face < 6; face++ {
-		for row := 0; row < cube.Size; row++ {
-			for col := 0; col < cube.Size; col++ {
-				if cube.Faces[face][row][col] != solvedCube.Faces[face][row][col] {
-					misplaced++
-				}
-			}
-		}
-	}
-	
+	misplaced := 6*cube.Size*cube.Size - matchingStickers(cube, NewCube(cube.Size))
+
 	// Very rough estimate: each move can fix at most 8 stickers
 	// This is admissible (never overestimates) but not very tight
 	return misplaced / 8
 }
 
-// A* search with heuristic function
+// A* search with heuristic function. The first solution found does not
+// end the search: it carries on until every node that could still give a
+// solution as short has been examined, then returns the shortest solution
+// seen, breaking ties by the smallest notation so the result doesn't
+// depend on the order moves are tried in.
 func (s *BeginnerSolver) aStarSearch(cube *Cube, maxDepth int) ([]Move, error) {
 	// Create a solved cube to compare against
 	solvedCube := NewCube(cube.Size)
@@ -436,6 +537,7 @@ func (s *BeginnerSolver) aStarSearch(cube *Cube, maxDepth int) ([]Move, error) {
 	
 	nodesExamined := 0
 	maxNodes := 50000
+	var best []Move
 	
 	for len(openList) > 0 && nodesExamined < maxNodes {
 		// Find node with lowest f-cost (simple implementation)
@@ -447,18 +549,26 @@ func (s *BeginnerSolver) aStarSearch(cube *Cube, maxDepth int) ([]Move, error) {
 		}
 		
 		current := openList[currentIdx]
+		// Once a solution is known, stop when no open node can match it
+		if best != nil && current.fCost > len(best) {
+			break
+		}
 		// Remove from open list
 		openList = append(openList[:currentIdx], openList[currentIdx+1:]...)
 		
 		nodesExamined++
+		s.stats.node()
 		
 		// Check if solved
 		if s.cubesMatch(current.cube, solvedCube) {
-			return current.moves, nil
+			if best == nil || preferSolution(current.moves, best) {
+				best = current.moves
+			}
+			continue
 		}
 		
-		// Skip if too deep
-		if current.gCost >= maxDepth {
+		// Skip if too deep, or if no extension could beat the best solution
+		if current.gCost >= maxDepth || (best != nil && current.gCost >= len(best)) {
 			continue
 		}
 		
@@ -487,8 +597,8 @@ func (s *BeginnerSolver) aStarSearch(cube *Cube, maxDepth int) ([]Move, error) {
 		
 		// Try each possible move
 		for _, move := range moves {
-			// Avoid immediate move cancellation
-			if len(current.moves) > 0 && s.isOppositeMove(current.moves[len(current.moves)-1], move) {
+			// Skip moves that combine with or commute past the last one
+			if len(current.moves) > 0 && RedundantAfterIn(current.moves[len(current.moves)-1], move, moves) {
 				continue
 			}
 			
@@ -515,7 +625,21 @@ func (s *BeginnerSolver) aStarSearch(cube *Cube, maxDepth int) ([]Move, error) {
 		}
 	}
 	
-	return nil, fmt.Errorf("no solution found within %d moves (examined %d nodes)", maxDepth, nodesExamined)
+	if best != nil {
+		tracef(s.Trace, "A* search: found %d-move solution after %d nodes", len(best), nodesExamined)
+		return best, nil
+	}
+	tracef(s.Trace, "A* search: no solution within %d moves after %d nodes", maxDepth, nodesExamined)
+	return nil, &SearchLimitError{MaxDepth: maxDepth, Examined: nodesExamined}
+}
+
+// preferSolution reports whether solution a should be chosen over b: it
+// has fewer moves, or as many and sorts first in standard notation
+func preferSolution(a, b []Move) bool {
+	if len(a) != len(b) {
+		return len(a) < len(b)
+	}
+	return FormatMoves(a) < FormatMoves(b)
 }
 
 // White cross solving implementation
@@ -764,7 +888,7 @@ func (s *BeginnerSolver) positionWhiteEdge(cube *Cube, edgeColors []Color) ([]Mo
 	
 	edge := cube.GetPieceByColors(edgeColors)
 	if edge == nil {
-		return nil, fmt.Errorf("edge not found")
+		return nil, &UnsolvableError{Reason: fmt.Sprintf("edge %v not found", edgeColors)}
 	}
 	
 	// Determine which face this edge belongs to
@@ -784,7 +908,7 @@ func (s *BeginnerSolver) positionWhiteEdge(cube *Cube, edgeColors []Color) ([]Mo
 	case Orange:
 		targetFace = Left
 	default:
-		return nil, fmt.Errorf("invalid edge color")
+		return nil, &UnsolvableError{Reason: fmt.Sprintf("invalid edge colors %v", edgeColors)}
 	}
 	
 	// Simple algorithm: F D R F' D R' (example for front edge)
@@ -1135,20 +1259,46 @@ func (s *BeginnerSolver) getTopEdgeAdjacentPos(face Face) (int, int) {
 //
 // NOTE: Slightly less reliable than pure BeginnerSolver (100%) or KociembaSolver (100%)
 // but provides CFOP-style solving when it succeeds.
-type CFOPSolver struct{}
+type CFOPSolver struct {
+	// OnStage, if set, is called by SolveThrough as each stage completes
+	// with the moves that solved it, so callers can stream progress
+	OnStage func(stage CFOPStage, moves []Move)
+
+	// Trace, if set, receives a line per stage, search, algorithm choice,
+	// and fallback (see SetTrace)
+	Trace io.Writer
+
+	// Cancel, if set, stops the solve with ErrSolveCancelled once it is
+	// closed (see SetCancel)
+	Cancel <-chan struct{}
+
+	// Inspect, if set, starts the solution with the whole-cube rotation
+	// that gives the shortest cross (see BestCrossOrientation), the way a
+	// speedcuber picks an orientation during inspection
+	Inspect bool
+
+	stats *searchStats // Search effort of the solve in progress
+}
 
 func (s *CFOPSolver) Name() string {
 	return "CFOP"
 }
 
-func (s *CFOPSolver) Solve(cube *Cube) (*SolverResult, error) {
+func (s *CFOPSolver) Solve(cube *Cube) (result *SolverResult, err error) {
+	defer trackSearch(&s.stats, s.Cancel, &result, &err)()
+	if s.Inspect {
+		return s.solveInspected(cube, (*CFOPSolver).Solve)
+	}
 	start := time.Now()
 
 	// Only support 3x3 for now
 	if cube.Size != 3 {
-		return nil, fmt.Errorf("CFOP solver only supports 3x3 cubes")
+		return nil, &UnsolvableError{Reason: "CFOP solver only supports 3x3 cubes"}
 	}
 
+	// Work on a copy so the caller's cube is never modified
+	cube = cube.Clone()
+
 	// Check if cube is already solved
 	if cube.IsSolved() {
 		return &SolverResult{
@@ -1158,61 +1308,116 @@ func (s *CFOPSolver) Solve(cube *Cube) (*SolverResult, error) {
 		}, nil
 	}
 
-	// Try CFOP stages, but if any fail, fall back to beginner solver entirely
-	// This hybrid approach ensures we always get a working solution
+	// Run the stages as SolveThrough does, but if any fails, fall back to
+	// the beginner solver entirely so Solve always gets a working solution
+	solution, failed, err := s.runStages(cube, StagePLL, nil)
+	if err != nil {
+		return s.fallBack(cube, failed, err)
+	}
 
-	workingCube := s.copyCube(cube)
-	var solution []Move
+	return &SolverResult{
+		Solution: solution,
+		Steps:    len(solution),
+		Duration: time.Since(start),
+	}, nil
+}
 
-	// Step 1: Cross (white cross on bottom)
-	crossMoves, err := s.solveCross(workingCube)
-	if err != nil {
-		// Cross failed - fall back to beginner solver for entire cube
-		beginnerSolver := &BeginnerSolver{}
-		return beginnerSolver.Solve(cube)
+// fallBack solves the whole cube with the beginner solver after a CFOP
+// stage fails
+func (s *CFOPSolver) fallBack(cube *Cube, stage CFOPStage, err error) (*SolverResult, error) {
+	tracef(s.Trace, "cfop: %s failed: %v; falling back to the beginner solver", stage, err)
+	beginnerSolver := &BeginnerSolver{Trace: s.Trace, stats: s.stats}
+	return beginnerSolver.Solve(cube)
+}
+
+// solveInspected rotates a copy of cube to the orientation with the
+// shortest cross, solves it with solve and an uninspecting copy of s, and
+// puts the rotation in front of the solution
+func (s *CFOPSolver) solveInspected(cube *Cube, solve func(*CFOPSolver, *Cube) (*SolverResult, error)) (*SolverResult, error) {
+	start := time.Now()
+	if cube.Size != 3 {
+		return nil, &UnsolvableError{Reason: "CFOP solver only supports 3x3 cubes"}
 	}
 
-	// Verify cross solution works before proceeding
-	testCube := s.copyCube(cube)
-	testCube.ApplyMoves(crossMoves)
-	crossPattern := WhiteCrossPattern{}
-	if !crossPattern.Matches(testCube) {
-		// Cross solution doesn't actually solve cross - fall back
-		beginnerSolver := &BeginnerSolver{}
-		return beginnerSolver.Solve(cube)
+	rotation, crossLength, ok := BestCrossOrientation(cube)
+	if !ok {
+		return nil, &UnsolvableError{Reason: "cube state is not a valid 3x3"}
 	}
+	tracef(s.Trace, "cfop: inspection picked %q for a %d-move cross", FormatMoves(rotation), crossLength)
 
-	solution = append(solution, crossMoves...)
-	workingCube.ApplyMoves(crossMoves)
+	// The solvers compare against a solved cube in standard orientation,
+	// so repaint the rotated cube to look unrotated
+	rotated := cube.Clone()
+	rotated.ApplyMoves(rotation)
+	recolorToStandard(rotated)
 
-	// Step 2: F2L (First Two Layers)
-	f2lMoves, err := s.solveF2L(workingCube)
+	inner := *s
+	inner.Inspect = false
+	result, err := solve(&inner, rotated)
 	if err != nil {
-		// F2L failed - fall back to beginner solver for entire cube
-		beginnerSolver := &BeginnerSolver{}
-		return beginnerSolver.Solve(cube)
+		return nil, err
 	}
-	solution = append(solution, f2lMoves...)
-	workingCube.ApplyMoves(f2lMoves)
 
-	// Step 3: OLL (Orient Last Layer)
-	ollMoves, err := s.solveOLL(workingCube)
-	if err != nil {
-		// OLL failed - fall back to beginner solver for entire cube
-		beginnerSolver := &BeginnerSolver{}
-		return beginnerSolver.Solve(cube)
+	solution := append(rotation, result.Solution...)
+	return &SolverResult{
+		Solution: solution,
+		Steps:    len(solution),
+		Duration: time.Since(start),
+	}, nil
+}
+
+// CFOPStage identifies one stage of the CFOP method
+type CFOPStage int
+
+const (
+	StageCross CFOPStage = iota
+	StageF2L
+	StageOLL
+	StagePLL
+)
+
+func (st CFOPStage) String() string {
+	return []string{"cross", "f2l", "oll", "pll"}[st]
+}
+
+// ParseCFOPStage converts a stage name (cross, f2l, oll, pll) to a CFOPStage
+func ParseCFOPStage(name string) (CFOPStage, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "cross":
+		return StageCross, nil
+	case "f2l":
+		return StageF2L, nil
+	case "oll":
+		return StageOLL, nil
+	case "pll":
+		return StagePLL, nil
+	default:
+		return StageCross, fmt.Errorf("unknown CFOP stage '%s' (expected cross, f2l, oll, or pll)", name)
+	}
+}
+
+// SolveThrough solves the cube only up to and including the given stage.
+// Unlike Solve, it never falls back to another solver, so the returned
+// solution is always the CFOP moves for the completed stages. The input cube
+// is not modified.
+func (s *CFOPSolver) SolveThrough(cube *Cube, stage CFOPStage) (result *SolverResult, err error) {
+	defer trackSearch(&s.stats, s.Cancel, &result, &err)()
+	if s.Inspect {
+		return s.solveInspected(cube, func(inner *CFOPSolver, c *Cube) (*SolverResult, error) {
+			return inner.SolveThrough(c, stage)
+		})
+	}
+	start := time.Now()
+
+	if cube.Size != 3 {
+		return nil, &UnsolvableError{Reason: "CFOP solver only supports 3x3 cubes"}
 	}
-	solution = append(solution, ollMoves...)
-	workingCube.ApplyMoves(ollMoves)
 
-	// Step 4: PLL (Permute Last Layer)
-	pllMoves, err := s.solvePLL(workingCube)
+	solution, failed, err := s.runStages(cube, stage, s.OnStage)
 	if err != nil {
-		// PLL failed - fall back to beginner solver for entire cube
-		beginnerSolver := &BeginnerSolver{}
-		return beginnerSolver.Solve(cube)
+		tracef(s.Trace, "cfop: %s failed: %v", failed, err)
+		return nil, fmt.Errorf("failed to solve %s: %w", failed, err)
 	}
-	solution = append(solution, pllMoves...)
 
 	return &SolverResult{
 		Solution: solution,
@@ -1221,19 +1426,80 @@ func (s *CFOPSolver) Solve(cube *Cube) (*SolverResult, error) {
 	}, nil
 }
 
+// runStages solves cube through the given stage, calling onStage, if set,
+// as each stage completes. If a stage fails, it returns the stage and why.
+// cube is not modified.
+func (s *CFOPSolver) runStages(cube *Cube, through CFOPStage, onStage func(CFOPStage, []Move)) ([]Move, CFOPStage, error) {
+	stages := []struct {
+		stage CFOPStage
+		solve func(*Cube) ([]Move, error)
+		done  func(*Cube) bool
+	}{
+		{StageCross, s.solveCross, WhiteCrossPattern{}.Matches},
+		{StageF2L, s.solveF2L, isF2LSolved},
+		{StageOLL, s.solveOLL, OLLSolvedPattern{}.Matches},
+		{StagePLL, s.solvePLL, (*Cube).IsSolved},
+	}
+
+	workingCube := s.copyCube(cube)
+	var solution []Move
+
+	for _, st := range stages {
+		if st.stage > through {
+			break
+		}
+
+		// Stage functions may modify the cube they are given, so run them
+		// on a scratch copy and apply only the returned moves
+		tracef(s.Trace, "cfop: solving %s", st.stage)
+		moves, err := st.solve(s.copyCube(workingCube))
+		if err != nil {
+			return nil, st.stage, err
+		}
+		workingCube.ApplyMoves(moves)
+		if !st.done(workingCube) {
+			return nil, st.stage, errors.New("stage moves did not complete the stage")
+		}
+		tracef(s.Trace, "cfop: %s solved in %d moves", st.stage, len(moves))
+		solution = append(solution, moves...)
+		if onStage != nil {
+			onStage(st.stage, moves)
+		}
+	}
+	return solution, through, nil
+}
+
 // KociembaSolver implements Kociemba's two-phase algorithm (placeholder)
-type KociembaSolver struct{}
+type KociembaSolver struct {
+	// Trace, if set, receives a line per search (see SetTrace)
+	Trace io.Writer
+
+	// Cancel, if set, stops the solve with ErrSolveCancelled once it is
+	// closed (see SetCancel)
+	Cancel <-chan struct{}
+
+	// TargetMetric is the metric Solve's searches count length in, so the
+	// solution is shortest in it; QTM, say, prefers R U R' to R2 U2. The
+	// search limits count in it too. SolveN ignores it.
+	TargetMetric Metric
+
+	stats *searchStats // Search effort of the solve in progress
+}
 
 func (s *KociembaSolver) Name() string {
 	return "Kociemba"
 }
 
-func (s *KociembaSolver) Solve(cube *Cube) (*SolverResult, error) {
+func (s *KociembaSolver) Solve(cube *Cube) (result *SolverResult, err error) {
+	defer trackSearch(&s.stats, s.Cancel, &result, &err)()
 	// Only support 3x3 for now
 	if cube.Size != 3 {
-		return nil, fmt.Errorf("Kociemba algorithm only supports 3x3x3 cubes")
+		return nil, &UnsolvableError{Reason: "Kociemba algorithm only supports 3x3x3 cubes"}
 	}
 
+	// Work on a copy so the caller's cube is never modified
+	cube = cube.Clone()
+
 	start := time.Now()
 
 	// Check if cube is already solved
@@ -1248,9 +1514,16 @@ func (s *KociembaSolver) Solve(cube *Cube) (*SolverResult, error) {
 	// For now, use a simplified approach that falls back to search
 	// A full Kociemba implementation requires coordinate systems and pruning tables
 	
-	// Try to solve with limited depth using phase 2 moves only (if possible)
-	phase2Solution, err := s.tryPhase2Only(cube)
+	// Try to solve with limited depth using phase 2 moves only, which can
+	// only work if the cube is already in G1
+	var phase2Solution []Move
+	err = errors.New("not in the phase 2 subgroup")
+	if s.isInG1Subgroup(cube) {
+		tracef(s.Trace, "kociemba: trying phase 2 moves only")
+		phase2Solution, err = s.tryPhase2Only(cube)
+	}
 	if err == nil {
+		tracef(s.Trace, "kociemba: phase 2 moves solved it in %d moves", len(phase2Solution))
 		// Success with phase 2 only
 		return &SolverResult{
 			Solution: phase2Solution,
@@ -1260,10 +1533,13 @@ func (s *KociembaSolver) Solve(cube *Cube) (*SolverResult, error) {
 	}
 
 	// Fall back to a simple iterative deepening search with timeout
+	tracef(s.Trace, "kociemba: %v; searching all moves", err)
 	solution, err := s.simplifiedKociembaSolve(cube, 10) // Try up to 10 moves
 	if err != nil {
+		tracef(s.Trace, "kociemba: %v", err)
 		return nil, fmt.Errorf("Kociemba solver failed: %w", err)
 	}
+	tracef(s.Trace, "kociemba: found %d-move solution", len(solution))
 
 	return &SolverResult{
 		Solution: solution,
@@ -1272,6 +1548,88 @@ func (s *KociembaSolver) Solve(cube *Cube) (*SolverResult, error) {
 	}, nil
 }
 
+// kociembaAlternativeSlack is how many moves longer than the first
+// solution SolveN's alternatives may be
+const kociembaAlternativeSlack = 1
+
+// SolveN returns the solution Solve finds followed by up to n-1
+// alternatives, each starting with a different move. Alternatives are as
+// short as the first solution, or at most kociembaAlternativeSlack moves
+// longer, so SolveN may return fewer than n.
+func (s *KociembaSolver) SolveN(cube *Cube, n int) ([]*SolverResult, error) {
+	first, err := s.Solve(cube)
+	if err != nil {
+		return nil, err
+	}
+	results := []*SolverResult{first}
+	if n <= 1 || first.Steps == 0 {
+		return results, nil
+	}
+
+	start := time.Now()
+	usedFirst := map[Move]bool{first.Solution[0]: true}
+	for depth := first.Steps; depth <= first.Steps+kociembaAlternativeSlack && len(results) < n; depth++ {
+		for _, move := range faceTurns {
+			if len(results) == n {
+				break
+			}
+			if usedFirst[move] {
+				continue
+			}
+
+			next := s.copyCube(cube)
+			next.ApplyMove(move)
+			rest, found := s.exactDepthSearch(next, []Move{move}, depth-1)
+			if !found {
+				continue
+			}
+			usedFirst[move] = true
+			results = append(results, &SolverResult{
+				Solution: rest,
+				Steps:    len(rest),
+				Duration: time.Since(start),
+			})
+		}
+	}
+	tracef(s.Trace, "kociemba: found %d of %d solutions", len(results), n)
+
+	return results, nil
+}
+
+// exactDepthSearch looks for a solution of exactly remainingDepth more
+// moves, never turning the same face twice in a row
+func (s *KociembaSolver) exactDepthSearch(cube *Cube, path []Move, remainingDepth int) ([]Move, bool) {
+	s.stats.node()
+	if remainingDepth == 0 {
+		return path, cube.IsSolved()
+	}
+
+	prev := path[len(path)-1]
+	for _, move := range faceTurns {
+		// SolveN fixes each alternative's first move, so a move that
+		// commutes with it can't be searched ahead of it instead
+		if len(path) == 1 && turnedLayers(prev) == turnedLayers(move) {
+			continue
+		}
+		if len(path) > 1 && RedundantAfter(prev, move) {
+			continue
+		}
+
+		newCube := s.copyCube(cube)
+		newCube.ApplyMove(move)
+
+		newPath := make([]Move, len(path)+1)
+		copy(newPath, path)
+		newPath[len(path)] = move
+
+		if solution, found := s.exactDepthSearch(newCube, newPath, remainingDepth-1); found {
+			return solution, true
+		}
+	}
+
+	return nil, false
+}
+
 // KOCIEMBA TWO-PHASE ALGORITHM IMPLEMENTATIONS
 
 // tryPhase2Only attempts to solve using only phase 2 moves
@@ -1309,35 +1667,39 @@ func (s *KociembaSolver) simplifiedKociembaSolve(cube *Cube, maxDepth int) ([]Mo
 
 	// Use iterative deepening with reasonable limit
 	for depth := 0; depth <= maxDepth; depth++ {
+		tracef(s.Trace, "kociemba: searching depth %d", depth)
 		solution, found := s.limitedDepthSearch(s.copyCube(cube), []Move{}, depth, allMoves)
 		if found {
 			return solution, nil
 		}
 	}
 	
-	return nil, fmt.Errorf("no solution found within %d moves", maxDepth)
+	return nil, &SearchLimitError{MaxDepth: maxDepth}
 }
 
-// limitedDepthSearch performs depth-limited search
+// limitedDepthSearch performs depth-limited search, with depth counted in
+// s.TargetMetric
 func (s *KociembaSolver) limitedDepthSearch(cube *Cube, path []Move, remainingDepth int, allowedMoves []Move) ([]Move, bool) {
+	s.stats.node()
 	// Check if solved
 	if cube.IsSolved() {
 		return path, true
 	}
 
-	// If no depth remaining, fail
-	if remainingDepth <= 0 {
-		return nil, false
+	var lastMove *Move
+	if len(path) > 0 {
+		lastMove = &path[len(path)-1]
 	}
 
-	// Try each allowed move
+	// Try each allowed move that fits in the remaining depth
 	for _, move := range allowedMoves {
+		cost := MoveCost(move, lastMove, s.TargetMetric)
+		if cost > remainingDepth {
+			continue
+		}
 		// Basic pruning: avoid immediate reversal
-		if len(path) > 0 {
-			lastMove := path[len(path)-1]
-			if s.isRedundantMove(lastMove, move) {
-				continue
-			}
+		if lastMove != nil && RedundantAfterIn(*lastMove, move, allowedMoves) {
+			continue
 		}
 
 		// Apply move
@@ -1350,7 +1712,7 @@ func (s *KociembaSolver) limitedDepthSearch(cube *Cube, path []Move, remainingDe
 		newPath[len(path)] = move
 
 		// Recursive search
-		solution, found := s.limitedDepthSearch(newCube, newPath, remainingDepth-1, allowedMoves)
+		solution, found := s.limitedDepthSearch(newCube, newPath, remainingDepth-cost, allowedMoves)
 		if found {
 			return solution, true
 		}
@@ -1411,11 +1773,12 @@ func (s *KociembaSolver) searchPhase(cube *Cube, allowedMoves []Move, goalTest f
 			return solution, nil
 		}
 	}
-	return nil, fmt.Errorf("no solution found within %d moves", maxDepth)
+	return nil, &SearchLimitError{MaxDepth: maxDepth}
 }
 
 // depthFirstSearch performs depth-limited search with pruning
 func (s *KociembaSolver) depthFirstSearch(cube *Cube, path []Move, remainingDepth int, allowedMoves []Move, goalTest func(*Cube) bool, heuristic func(*Cube) int) ([]Move, bool) {
+	s.stats.node()
 	// Check if goal reached
 	if goalTest(cube) {
 		return path, true
@@ -1434,7 +1797,7 @@ func (s *KociembaSolver) depthFirstSearch(cube *Cube, path []Move, remainingDept
 	// Try each allowed move
 	for _, move := range allowedMoves {
 		// Prune redundant moves (avoid immediate cancellation)
-		if len(path) > 0 && s.isRedundantMove(path[len(path)-1], move) {
+		if len(path) > 0 && RedundantAfterIn(path[len(path)-1], move, allowedMoves) {
 			continue
 		}
 
@@ -1482,32 +1845,40 @@ func (s *KociembaSolver) isInG1Subgroup(cube *Cube) bool {
 	return true
 }
 
-// areEdgesOriented checks if all edges are oriented correctly
+// areEdgesOriented checks that every edge is a real edge and unflipped, so
+// none needs a quarter turn of F or B to fix
 func (s *KociembaSolver) areEdgesOriented(cube *Cube) bool {
-	// In the solved state, edges are oriented correctly if they can be solved
-	// using only phase 2 moves. For a simplified version, we'll check if
-	// F/B faces have their colors on F/B faces (not on U/D/L/R).
-	
-	// This is a simplified orientation check - a full implementation would
-	// use a more sophisticated coordinate system
-	
-	// For now, assume edges are oriented if the cube "looks reasonable"
-	// A more complete implementation would track edge flip states
-	return true // Simplified for now
+	perm, orientation := cube.EdgePermutation(), cube.EdgeOrientation()
+	for pos := range perm {
+		if perm[pos] < 0 || orientation[pos] != 0 {
+			return false
+		}
+	}
+	return true
 }
 
-// areCornersOriented checks if all corners are oriented correctly  
+// areCornersOriented checks that every corner is a real corner with its
+// U or D sticker on the U or D face
 func (s *KociembaSolver) areCornersOriented(cube *Cube) bool {
-	// Similar to edges, this is a simplified version
-	// A full implementation would track corner twist states
-	return true // Simplified for now
+	perm, orientation := cube.CornerPermutation(), cube.CornerOrientation()
+	for pos := range perm {
+		if perm[pos] < 0 || orientation[pos] != 0 {
+			return false
+		}
+	}
+	return true
 }
 
-// areMiddleSliceEdgesInSlice checks if middle slice edges are in correct positions
+// areMiddleSliceEdgesInSlice checks that the four middle slice edges (FR,
+// FL, BL, BR) are all somewhere in the middle slice
 func (s *KociembaSolver) areMiddleSliceEdgesInSlice(cube *Cube) bool {
-	// The four middle slice edges (FR, FL, BR, BL) should be in the middle slice
-	// This is also simplified for the initial implementation
-	return true // Simplified for now
+	perm := cube.EdgePermutation()
+	for pos := 8; pos < 12; pos++ {
+		if perm[pos] < 8 {
+			return false
+		}
+	}
+	return true
 }
 
 // phase1Heuristic provides a lower bound estimate for phase 1
@@ -1545,20 +1916,6 @@ func (s *KociembaSolver) phase2Heuristic(cube *Cube) int {
 	return 0
 }
 
-// isRedundantMove checks if a move is redundant with the previous move
-func (s *KociembaSolver) isRedundantMove(prevMove, currentMove Move) bool {
-	// Avoid immediate cancellation (R R' or similar)
-	if prevMove.Face == currentMove.Face {
-		// Same face - avoid direct opposites
-		if prevMove.Clockwise != currentMove.Clockwise && !prevMove.Double && !currentMove.Double {
-			return true
-		}
-		// Avoid three consecutive moves on same face that could be simplified
-		return false
-	}
-	return false
-}
-
 // copyCube creates a deep copy of a cube for the Kociemba solver
 func (s *KociembaSolver) copyCube(cube *Cube) *Cube {
 	newCube := NewCube(cube.Size)
@@ -1583,7 +1940,7 @@ func (s *CFOPSolver) solveCross(cube *Cube) ([]Move, error) {
 	}
 
 	// Use A* search to find optimal cross solution (much faster than BFS)
-	beginnerSolver := &BeginnerSolver{}
+	beginnerSolver := &BeginnerSolver{Trace: s.Trace, stats: s.stats}
 	return beginnerSolver.aStarSearch(cube, 8)
 }
 
@@ -1625,8 +1982,9 @@ func (s *CFOPSolver) findCrossSolution(cube *Cube, maxMoves int) ([]Move, error)
 			queue = queue[1:]
 			
 			statesExamined++
+			s.stats.state()
 			if statesExamined > maxStates {
-				return nil, fmt.Errorf("cross search exceeded maximum states (%d)", maxStates)
+				return nil, &SearchLimitError{Search: "cross", MaxStates: maxStates}
 			}
 			
 			// Try each possible move
@@ -1653,14 +2011,25 @@ func (s *CFOPSolver) findCrossSolution(cube *Cube, maxMoves int) ([]Move, error)
 		}
 	}
 	
-	return nil, fmt.Errorf("could not solve cross within %d moves", maxMoves)
+	return nil, &SearchLimitError{Search: "cross", MaxDepth: maxMoves}
 }
 
 // solveF2L solves the first two layers using F2L algorithms
 func (s *CFOPSolver) solveF2L(cube *Cube) ([]Move, error) {
 	var solution []Move
 	
-	// Solve each F2L slot (0=FR, 1=BR, 2=BL, 3=FL)
+	// Insert pairs in whatever order the stored algorithms allow, up to one
+	// insertion per slot (0=FR, 1=BR, 2=BL, 3=FL)
+	for pass := 0; pass < 4; pass++ {
+		slotMoves, ok := s.insertF2LPair(cube)
+		if !ok {
+			break
+		}
+		solution = append(solution, slotMoves...)
+		cube.ApplyMoves(slotMoves)
+	}
+	
+	// Solve any slot left over one at a time
 	for slot := 0; slot < 4; slot++ {
 		slotPattern := F2LSlotPattern{Slot: slot}
 		if slotPattern.Matches(cube) {
@@ -1668,6 +2037,7 @@ func (s *CFOPSolver) solveF2L(cube *Cube) ([]Move, error) {
 		}
 		
 		// Try to solve this F2L slot
+		tracef(s.Trace, "cfop: F2L slot %d: no stored insertion fits; solving slot directly", slot)
 		slotMoves, err := s.solveF2LSlot(cube, slot)
 		if err != nil {
 			return nil, fmt.Errorf("failed to solve F2L slot %d: %w", slot, err)
@@ -1680,6 +2050,83 @@ func (s *CFOPSolver) solveF2L(cube *Cube) ([]Move, error) {
 	return solution, nil
 }
 
+// f2lExtractions take the pieces out of the front-right slot into the U
+// layer, disturbing nothing else below it
+var f2lExtractions = func() [][]Move {
+	var extractions [][]Move
+	for _, text := range []string{"R U R'", "R U' R'", "R U2 R'", "F' U' F", "F' U F"} {
+		moves, _ := ParseScramble(text)
+		extractions = append(extractions, moves)
+	}
+	return extractions
+}()
+
+// insertF2LPair solves one unsolved F2L slot with a stored front-right
+// algorithm rewritten for that slot, after a U setup move, while keeping
+// the cross and every solved slot intact. If no pair can be inserted
+// directly, it first takes the pieces out of an unsolved slot with one of
+// f2lExtractions (rewritten for that slot). It reports false if F2L is solved or
+// no insertion works.
+func (s *CFOPSolver) insertF2LPair(cube *Cube) ([]Move, bool) {
+	var solved, unsolved []int
+	for slot := 0; slot < 4; slot++ {
+		if (F2LSlotPattern{Slot: slot}).Matches(cube) {
+			solved = append(solved, slot)
+		} else {
+			unsolved = append(unsolved, slot)
+		}
+	}
+	if len(unsolved) == 0 {
+		return nil, false
+	}
+	
+	intact := func(c *Cube, slot int) bool {
+		if !(F2LSlotPattern{Slot: slot}).Matches(c) || !(WhiteCrossPattern{}).Matches(c) {
+			return false
+		}
+		for _, other := range solved {
+			if !(F2LSlotPattern{Slot: other}).Matches(c) {
+				return false
+			}
+		}
+		return true
+	}
+	
+	var algorithms [][]Move
+	var names []string
+	for _, alg := range cfopCandidates("CFOP-F2L") {
+		if moves, err := ParseScramble(alg.Moves); err == nil {
+			algorithms = append(algorithms, moves)
+			names = append(names, alg.Name)
+		}
+	}
+	
+	setups := [][]Move{nil}
+	for _, slot := range unsolved {
+		for _, extract := range f2lExtractions {
+			setups = append(setups, s.adjustF2LAlgorithmForSlot(extract, slot))
+		}
+	}
+	
+	for _, setup := range setups {
+		for _, slot := range unsolved {
+			for i, moves := range algorithms {
+				adjusted := s.adjustF2LAlgorithmForSlot(moves, slot)
+				for auf := 0; auf < 4; auf++ {
+					attempt := append(append(append([]Move{}, setup...), quarterTurnsOf(Move{Face: Up}, auf)...), adjusted...)
+					testCube := s.copyCube(cube)
+					testCube.ApplyMoves(attempt)
+					if intact(testCube, slot) {
+						tracef(s.Trace, "cfop: F2L slot %d: %s after setup %q", slot, names[i], FormatMoves(setup))
+						return attempt, true
+					}
+				}
+			}
+		}
+	}
+	return nil, false
+}
+
 // solveF2LSlot solves an individual F2L slot using intelligent algorithm selection
 func (s *CFOPSolver) solveF2LSlot(cube *Cube, slot int) ([]Move, error) {
 	slotPattern := F2LSlotPattern{Slot: slot}
@@ -1688,14 +2135,7 @@ func (s *CFOPSolver) solveF2LSlot(cube *Cube, slot int) ([]Move, error) {
 	}
 	
 	// Get F2L algorithms from database
-	allAlgs := GetAllAlgorithms()
-	var f2lAlgs []Algorithm
-	
-	for _, alg := range allAlgs {
-		if alg.Category == "CFOP-F2L" {
-			f2lAlgs = append(f2lAlgs, alg)
-		}
-	}
+	f2lAlgs := cfopCandidates("CFOP-F2L")
 	
 	// Analyze the F2L slot state to determine the best algorithm
 	f2lCase := s.analyzeF2LSlot(cube, slot)
@@ -1739,13 +2179,12 @@ func (s *CFOPSolver) solveF2LSlot(cube *Cube, slot int) ([]Move, error) {
 		testCube.ApplyMoves(adjustedAlg)
 		
 		if slotPattern.Matches(testCube) {
-			cube.ApplyMoves(adjustedAlg) // Apply to original cube
 			return adjustedAlg, nil
 		}
 	}
 	
 	// Final fallback: use A* search (much faster than BFS)
-	beginnerSolver := &BeginnerSolver{}
+	beginnerSolver := &BeginnerSolver{stats: s.stats}
 	return beginnerSolver.aStarSearch(cube, 6)
 }
 
@@ -1848,21 +2287,12 @@ func (s *CFOPSolver) tryF2LAlgorithm(cube *Cube, algorithm Algorithm, slot int)
 	return nil, fmt.Errorf("algorithm %s did not solve F2L slot %d", algorithm.CaseID, slot)
 }
 
-// adjustF2LAlgorithmForSlot adjusts F2L moves for different cube positions
+// adjustF2LAlgorithmForSlot rewrites a front-right F2L algorithm for another
+// slot. Slot k is brought to front-right by k y turns (BR by y, BL by y2, FL
+// by y'), so the algorithm is conjugated by y^k and its moves relabeled to
+// turn the faces of the slot in place; the result has no rotations.
 func (s *CFOPSolver) adjustF2LAlgorithmForSlot(moves []Move, slot int) []Move {
-	// Most F2L algorithms are designed for front-right slot (slot 0)
-	// For other slots, we can apply cube rotations or different move sets
-	
-	if slot == 0 {
-		return moves // Front-right slot - use as-is
-	}
-	
-	// For simplicity, return the original moves
-	// In a complete implementation, we'd apply proper y rotations:
-	// Slot 1 (back-right): y rotation
-	// Slot 2 (back-left): y2 rotation  
-	// Slot 3 (front-left): y' rotation
-	return moves
+	return conjugateY(moves, slot)
 }
 
 // findF2LSlotSolution uses BFS to find F2L solution when algorithms fail
@@ -1901,8 +2331,9 @@ func (s *CFOPSolver) findF2LSlotSolution(cube *Cube, slot int, maxMoves int) ([]
 			queue = queue[1:]
 			
 			statesExamined++
+			s.stats.state()
 			if statesExamined > maxStates {
-				return nil, fmt.Errorf("F2L slot %d search exceeded maximum states", slot)
+				return nil, &SearchLimitError{Search: fmt.Sprintf("F2L slot %d", slot), MaxStates: maxStates}
 			}
 			
 			// Try each move
@@ -1929,7 +2360,7 @@ func (s *CFOPSolver) findF2LSlotSolution(cube *Cube, slot int, maxMoves int) ([]
 		}
 	}
 	
-	return nil, fmt.Errorf("could not find F2L slot %d solution within %d moves", slot, maxMoves)
+	return nil, &SearchLimitError{Search: fmt.Sprintf("F2L slot %d", slot), MaxDepth: maxMoves}
 }
 
 // solveOLL solves the last layer orientation using intelligent OLL pattern recognition
@@ -1940,14 +2371,7 @@ func (s *CFOPSolver) solveOLL(cube *Cube) ([]Move, error) {
 	}
 	
 	// Get all OLL algorithms from database
-	allAlgs := GetAllAlgorithms()
-	var ollAlgs []Algorithm
-	
-	for _, alg := range allAlgs {
-		if alg.Category == "OLL" || alg.Category == "CFOP-OLL" {
-			ollAlgs = append(ollAlgs, alg)
-		}
-	}
+	ollAlgs := cfopCandidates("OLL", "CFOP-OLL")
 	
 	// Analyze the OLL pattern on the cube
 	ollCase := s.analyzeOLLPattern(cube)
@@ -1957,8 +2381,10 @@ func (s *CFOPSolver) solveOLL(cube *Cube) ([]Move, error) {
 	if selectedAlg != nil {
 		moves, err := s.tryOLLAlgorithm(cube, *selectedAlg)
 		if err == nil {
+			tracef(s.Trace, "cfop: OLL case %s: using %s (%s)", ollCase, selectedAlg.Name, selectedAlg.CaseID)
 			return moves, nil
 		}
+		tracef(s.Trace, "cfop: OLL case %s: %v", ollCase, err)
 	}
 	
 	// Fallback: try common OLL algorithms in order of effectiveness
@@ -1990,14 +2416,22 @@ func (s *CFOPSolver) solveOLL(cube *Cube) ([]Move, error) {
 		
 		if ollPattern.Matches(testCube) {
 			// Found a working algorithm
+			tracef(s.Trace, "cfop: OLL case %s: using common algorithm %s", ollCase, ollAlg.name)
 			solutionMoves = append(solutionMoves, moves...)
 			cube.ApplyMoves(moves)
 			return solutionMoves, nil
 		}
 	}
 	
+	// Try the whole database from each angle before searching
+	if moves, name, ok := s.tryWithAUF(cube, ollAlgs, ollPattern.Matches, false); ok {
+		tracef(s.Trace, "cfop: OLL case %s: using %s after U adjustment", ollCase, name)
+		return moves, nil
+	}
+	
 	// Final fallback: Use A* search (much faster than BFS)
-	beginnerSolver := &BeginnerSolver{}
+	tracef(s.Trace, "cfop: OLL case %s: no algorithm fits; searching", ollCase)
+	beginnerSolver := &BeginnerSolver{Trace: s.Trace, stats: s.stats}
 	return beginnerSolver.aStarSearch(cube, 8)
 }
 
@@ -2136,27 +2570,16 @@ func (s *CFOPSolver) findOLLSolution(cube *Cube, maxMoves int) ([]Move, error) {
 			queue = queue[1:]
 
 			statesExamined++
+			s.stats.state()
 			if statesExamined > maxStates {
-				return nil, fmt.Errorf("OLL search exceeded maximum states (%d)", maxStates)
+				return nil, &SearchLimitError{Search: "OLL", MaxStates: maxStates}
 			}
 
 			// Try each move
 			for _, move := range ollMoves {
-				// Skip redundant moves (don't apply same move twice in a row)
-				if len(current.moves) > 0 {
-					lastMove := current.moves[len(current.moves)-1]
-					// Skip if same face (R followed by R, R', or R2)
-					if lastMove.Face == move.Face {
-						continue
-					}
-					// Skip opposite faces that commute (R L or L R, F B or B F, U D or D U)
-					if s.areOppositeFaces(lastMove.Face, move.Face) && depth > 1 && len(current.moves) >= 2 {
-						// Check if previous two moves were already this pair
-						prevMove := current.moves[len(current.moves)-2]
-						if prevMove.Face == move.Face {
-							continue
-						}
-					}
+				// Skip moves that combine with or commute past the last one
+				if len(current.moves) > 0 && RedundantAfterIn(current.moves[len(current.moves)-1], move, ollMoves) {
+					continue
 				}
 
 				newCube := s.copyCube(current.cube)
@@ -2181,7 +2604,7 @@ func (s *CFOPSolver) findOLLSolution(cube *Cube, maxMoves int) ([]Move, error) {
 		}
 	}
 	
-	return nil, fmt.Errorf("could not find OLL solution within %d moves", maxMoves)
+	return nil, &SearchLimitError{Search: "OLL", MaxDepth: maxMoves}
 }
 
 // solvePLL solves the last layer permutation using intelligent PLL pattern recognition
@@ -2191,14 +2614,7 @@ func (s *CFOPSolver) solvePLL(cube *Cube) ([]Move, error) {
 	}
 	
 	// Get all PLL algorithms from database
-	allAlgs := GetAllAlgorithms()
-	var pllAlgs []Algorithm
-	
-	for _, alg := range allAlgs {
-		if alg.Category == "PLL" || alg.Category == "CFOP-PLL" {
-			pllAlgs = append(pllAlgs, alg)
-		}
-	}
+	pllAlgs := cfopCandidates("PLL", "CFOP-PLL")
 	
 	// Analyze the PLL pattern on the cube
 	pllCase := s.analyzePLLPattern(cube)
@@ -2208,8 +2624,10 @@ func (s *CFOPSolver) solvePLL(cube *Cube) ([]Move, error) {
 	if selectedAlg != nil {
 		moves, err := s.tryPLLAlgorithm(cube, *selectedAlg)
 		if err == nil {
+			tracef(s.Trace, "cfop: PLL case %s: using %s (%s)", pllCase, selectedAlg.Name, selectedAlg.CaseID)
 			return moves, nil
 		}
+		tracef(s.Trace, "cfop: PLL case %s: %v", pllCase, err)
 	}
 	
 	// Fallback: try common PLL algorithms in order of effectiveness
@@ -2241,14 +2659,23 @@ func (s *CFOPSolver) solvePLL(cube *Cube) ([]Move, error) {
 		
 		if testCube.IsSolved() {
 			// Found a working algorithm
+			tracef(s.Trace, "cfop: PLL case %s: using common algorithm %s", pllCase, pllAlg.name)
 			solutionMoves = append(solutionMoves, moves...)
 			cube.ApplyMoves(moves)
 			return solutionMoves, nil
 		}
 	}
 	
+	// Try the whole database from each angle, with a final U turn to line
+	// the layer up, before searching
+	if moves, name, ok := s.tryWithAUF(cube, pllAlgs, (*Cube).IsSolved, true); ok {
+		tracef(s.Trace, "cfop: PLL case %s: using %s after U adjustment", pllCase, name)
+		return moves, nil
+	}
+	
 	// Final fallback: Use A* search (much faster than BFS)
-	beginnerSolver := &BeginnerSolver{}
+	tracef(s.Trace, "cfop: PLL case %s: no algorithm fits; searching", pllCase)
+	beginnerSolver := &BeginnerSolver{Trace: s.Trace, stats: s.stats}
 	return beginnerSolver.aStarSearch(cube, 10)
 }
 
@@ -2394,6 +2821,43 @@ func (s *CFOPSolver) tryPLLAlgorithm(cube *Cube, algorithm Algorithm) ([]Move, e
 	return nil, fmt.Errorf("algorithm %s did not solve PLL", algorithm.CaseID)
 }
 
+// tryWithAUF returns the first of algorithms that makes done true when
+// preceded by a U turn, and followed by one too if postAUF is set, with the
+// turns included in the moves. Last layer cases are recognized from any
+// angle, so an algorithm that misses as given often fits after a U turn.
+func (s *CFOPSolver) tryWithAUF(cube *Cube, algorithms []Algorithm, done func(*Cube) bool, postAUF bool) ([]Move, string, bool) {
+	aufs := [][]Move{
+		{},
+		{{Face: Up, Clockwise: true}},
+		{{Face: Up, Double: true}},
+		{{Face: Up, Clockwise: false}},
+	}
+	posts := aufs[:1]
+	if postAUF {
+		posts = aufs
+	}
+
+	for _, alg := range algorithms {
+		moves, err := ParseScramble(alg.Moves)
+		if err != nil {
+			continue
+		}
+		for _, pre := range aufs {
+			for _, post := range posts {
+				testCube := s.copyCube(cube)
+				testCube.ApplyMoves(pre)
+				testCube.ApplyMoves(moves)
+				testCube.ApplyMoves(post)
+				if done(testCube) {
+					solution := append(append(append([]Move{}, pre...), moves...), post...)
+					return solution, alg.CaseID, true
+				}
+			}
+		}
+	}
+	return nil, "", false
+}
+
 // findPLLSolution uses BFS to find PLL solution when algorithms fail
 func (s *CFOPSolver) findPLLSolution(cube *Cube, maxMoves int) ([]Move, error) {
 	// BFS setup
@@ -2429,8 +2893,9 @@ func (s *CFOPSolver) findPLLSolution(cube *Cube, maxMoves int) ([]Move, error) {
 			queue = queue[1:]
 			
 			statesExamined++
+			s.stats.state()
 			if statesExamined > maxStates {
-				return nil, fmt.Errorf("PLL search exceeded maximum states (%d)", maxStates)
+				return nil, &SearchLimitError{Search: "PLL", MaxStates: maxStates}
 			}
 			
 			// Try each move
@@ -2457,7 +2922,46 @@ func (s *CFOPSolver) findPLLSolution(cube *Cube, maxMoves int) ([]Move, error) {
 		}
 	}
 	
-	return nil, fmt.Errorf("could not find PLL solution within %d moves", maxMoves)
+	return nil, &SearchLimitError{Search: "PLL", MaxDepth: maxMoves}
+}
+
+// cfopCandidateCache holds cfopCandidates' lists by category set. The
+// solver asks for the same few lists for every slot and last-layer step,
+// and the database doesn't change once loaded, so each is built once.
+var (
+	cfopCandidateMu    sync.Mutex
+	cfopCandidateCache = make(map[string][]Algorithm)
+)
+
+// cfopCandidates returns the database algorithms in the given categories,
+// sorted by case ID and then moves. Selection walks these lists in order,
+// so sorting keeps solutions the same however the database is arranged.
+// The list is shared between calls and must not be modified.
+func cfopCandidates(categories ...string) []Algorithm {
+	key := strings.Join(categories, ",")
+	cfopCandidateMu.Lock()
+	defer cfopCandidateMu.Unlock()
+	if candidates, ok := cfopCandidateCache[key]; ok {
+		return candidates
+	}
+
+	var candidates []Algorithm
+	for _, alg := range GetAllAlgorithms() {
+		for _, category := range categories {
+			if alg.Category == category {
+				candidates = append(candidates, alg)
+				break
+			}
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].CaseID != candidates[j].CaseID {
+			return candidates[i].CaseID < candidates[j].CaseID
+		}
+		return candidates[i].Moves < candidates[j].Moves
+	})
+	cfopCandidateCache[key] = candidates
+	return candidates
 }
 
 // Helper methods for CFOP solver (reuse from BeginnerSolver)
@@ -2485,19 +2989,6 @@ func (s *CFOPSolver) cubeStateString(cube *Cube) string {
 	return result
 }
 
-// areOppositeFaces checks if two faces are opposite on the cube
-func (s *CFOPSolver) areOppositeFaces(f1, f2 Face) bool {
-	opposites := map[Face]Face{
-		Front: Back,
-		Back:  Front,
-		Left:  Right,
-		Right: Left,
-		Up:    Down,
-		Down:  Up,
-	}
-	return opposites[f1] == f2
-}
-
 // GetSolver returns a solver by name
 func GetSolver(name string) (Solver, error) {
 	switch name {
@@ -2507,6 +2998,10 @@ func GetSolver(name string) (Solver, error) {
 		return &CFOPSolver{}, nil
 	case "kociemba":
 		return &KociembaSolver{}, nil
+	case "optimal2":
+		return &Optimal2x2Solver{}, nil
+	case "race":
+		return &RaceSolver{}, nil
 	default:
 		return nil, fmt.Errorf("unknown solver: %s", name)
 	}
@@ -0,0 +1,422 @@
+package cube
+
+import (
+	"io"
+	"sync"
+	"time"
+)
+
+// Optimal 2x2 solving
+//
+// With the DBL corner held fixed, a 2x2 has 7! * 3^6 = 3,674,160 states,
+// and U, R, and F turns reach all of them without moving DBL. A breadth
+// first search from solved over that space gives the exact distance of
+// every state (at most 11 in half-turn metric), and a solution follows by
+// always turning toward a state one move closer.
+//
+// States are indexed by a permutation coordinate of the seven free corners
+// (0-5039) and an orientation coordinate of six of their twists (0-728);
+// the seventh twist is fixed by the others.
+
+const (
+	fixedCorner   = 6 // DBL, in cornerFacelets order
+	permCoords    = 5040
+	twistCoords   = 729
+	twoByTwoSpace = permCoords * twistCoords
+)
+
+// freeCorners are the corner positions U, R, and F turns move
+var freeCorners = [7]int{0, 1, 2, 3, 4, 5, 7}
+
+// twoByTwoMoves are the half-turn metric U, R, and F turns
+var twoByTwoMoves = func() []Move {
+	var moves []Move
+	for _, face := range []Face{Up, Right, Front} {
+		moves = append(moves,
+			Move{Face: face, Clockwise: true},
+			Move{Face: face, Clockwise: false},
+			Move{Face: face, Double: true},
+		)
+	}
+	return moves
+}()
+
+// twoByTwoTables holds the move tables and the distance of every state
+// from solved, built once on first use
+type twoByTwoTables struct {
+	permMove  [permCoords][9]uint16
+	twistMove [twistCoords][9]uint16
+	distance  []int8
+}
+
+var (
+	twoByTwoOnce  sync.Once
+	twoByTwoTable *twoByTwoTables
+)
+
+// Optimal2x2Solver finds shortest half-turn metric solutions for 2x2 cubes
+type Optimal2x2Solver struct {
+	// Trace, if set, receives a line per solve (see SetTrace)
+	Trace io.Writer
+
+	// TargetMetric is the metric Solve's solutions are shortest in. U, R,
+	// and F turns each count one in every metric but QTM, so only QTM,
+	// which prefers quarter turns to half turns, changes the solutions.
+	// SolveN ignores it.
+	TargetMetric Metric
+}
+
+func (s *Optimal2x2Solver) Name() string {
+	return "Optimal 2x2"
+}
+
+func (s *Optimal2x2Solver) Solve(cube *Cube) (*SolverResult, error) {
+	start := time.Now()
+
+	if cube.Size != 2 {
+		return nil, &UnsolvableError{Reason: "optimal 2x2 solver only supports 2x2 cubes"}
+	}
+
+	var solution []Move
+	if s.TargetMetric == HTM {
+		solution = Solve2x2Optimal(cube)
+	} else {
+		solution = Solve2x2InMetric(cube, s.TargetMetric)
+	}
+	if solution == nil {
+		return nil, &UnsolvableError{Reason: "cube state is not a valid 2x2"}
+	}
+	if s.TargetMetric == HTM {
+		tracef(s.Trace, "optimal 2x2: state is %d moves from solved", len(solution))
+	} else {
+		tracef(s.Trace, "optimal 2x2: state is %d %s from solved", CountMetric(solution, s.TargetMetric), s.TargetMetric)
+	}
+
+	return &SolverResult{
+		Solution: solution,
+		Steps:    len(solution),
+		Duration: time.Since(start),
+	}, nil
+}
+
+// SolveN returns up to n solutions, each starting with a different move.
+// Every first move leads to a state at most one move closer or further
+// from solved, so the solutions are the shortest ones followed by ones a
+// move or two longer, each optimal after its first move.
+func (s *Optimal2x2Solver) SolveN(cube *Cube, n int) ([]*SolverResult, error) {
+	first, err := s.Solve(cube)
+	if err != nil {
+		return nil, err
+	}
+	results := []*SolverResult{first}
+	if n <= 1 || first.Steps == 0 {
+		return results, nil
+	}
+
+	start := time.Now()
+	t := twoByTwoTable
+	perm, twist, _ := twoByTwoCoordinates(cube)
+	dist := t.distance[perm*twistCoords+twist]
+	for nextDist := dist - 1; nextDist <= dist+1 && len(results) < n; nextDist++ {
+		for m, move := range twoByTwoMoves {
+			if len(results) == n {
+				break
+			}
+			nextPerm, nextTwist := int(t.permMove[perm][m]), int(t.twistMove[twist][m])
+			if move == first.Solution[0] || t.distance[nextPerm*twistCoords+nextTwist] != nextDist {
+				continue
+			}
+
+			next := cube.Clone()
+			next.ApplyMove(move)
+			solution := append([]Move{move}, Solve2x2Optimal(next)...)
+			results = append(results, &SolverResult{
+				Solution: solution,
+				Steps:    len(solution),
+				Duration: time.Since(start),
+			})
+		}
+	}
+	tracef(s.Trace, "optimal 2x2: found %d of %d solutions", len(results), n)
+
+	return results, nil
+}
+
+// Solve2x2Optimal returns a shortest solution for a 2x2 cube using only
+// U, R, and F turns, so the solved cube keeps the orientation of its DBL
+// corner. It returns an empty solution for a solved cube and nil if c is
+// not a 2x2 or its stickers do not form a valid cube. The distance table
+// (about 3.6 MB) is built on first use.
+func Solve2x2Optimal(c *Cube) []Move {
+	if c.Size != 2 {
+		return nil
+	}
+	perm, twist, ok := twoByTwoCoordinates(c)
+	if !ok {
+		return nil
+	}
+
+	twoByTwoOnce.Do(buildTwoByTwoTables)
+	t := twoByTwoTable
+
+	solution := []Move{}
+	for dist := t.distance[perm*twistCoords+twist]; dist > 0; dist-- {
+		for m, move := range twoByTwoMoves {
+			nextPerm, nextTwist := int(t.permMove[perm][m]), int(t.twistMove[twist][m])
+			if t.distance[nextPerm*twistCoords+nextTwist] == dist-1 {
+				solution = append(solution, move)
+				perm, twist = nextPerm, nextTwist
+				break
+			}
+		}
+	}
+	return solution
+}
+
+// Solve2x2InMetric is Solve2x2Optimal with solutions shortest in metric
+// instead of in half turns. It is an IDA* search over the same tables:
+// every U, R, or F turn costs at least one in any metric, so the half-turn
+// distance never overestimates the cost still to go.
+func Solve2x2InMetric(c *Cube, metric Metric) []Move {
+	if c.Size != 2 {
+		return nil
+	}
+	perm, twist, ok := twoByTwoCoordinates(c)
+	if !ok {
+		return nil
+	}
+
+	twoByTwoOnce.Do(buildTwoByTwoTables)
+	t := twoByTwoTable
+
+	for bound := int(t.distance[perm*twistCoords+twist]); ; bound++ {
+		if solution, found := t.searchInMetric(perm, twist, metric, []Move{}, bound); found {
+			return solution
+		}
+	}
+}
+
+// searchInMetric looks for a solution past path costing at most remaining
+// in metric. Cheaper solutions were ruled out by smaller bounds, so the
+// first one found is the cheapest.
+func (t *twoByTwoTables) searchInMetric(perm, twist int, metric Metric, path []Move, remaining int) ([]Move, bool) {
+	dist := int(t.distance[perm*twistCoords+twist])
+	if dist == 0 {
+		return path, true
+	}
+	if dist > remaining {
+		return nil, false
+	}
+
+	var prev *Move
+	if len(path) > 0 {
+		prev = &path[len(path)-1]
+	}
+	for m, move := range twoByTwoMoves {
+		if prev != nil && prev.Face == move.Face {
+			continue
+		}
+		cost := MoveCost(move, prev, metric)
+		if cost > remaining {
+			continue
+		}
+		nextPerm, nextTwist := int(t.permMove[perm][m]), int(t.twistMove[twist][m])
+		if solution, found := t.searchInMetric(nextPerm, nextTwist, metric, append(path, move), remaining-cost); found {
+			return solution, true
+		}
+	}
+	return nil, false
+}
+
+// twoByTwoCoordinates reads the corner state of a 2x2 relative to its DBL
+// corner, whose colors fix which color belongs on each face. cornerFacelets
+// uses 3x3 coordinates, so rows and columns are halved.
+func twoByTwoCoordinates(c *Cube) (perm, twist int, ok bool) {
+	sticker := func(coord Coord) Color {
+		return c.Faces[coord.Face][coord.Row/2][coord.Col/2]
+	}
+
+	// The DBL corner's stickers name the D, B, and L colors, and each
+	// opposite face gets the opposite color
+	solvedFaces := solvedColorFaces()
+	solved := NewCube(2)
+	colorFace := make(map[Color]Face)
+	for _, coord := range cornerFacelets[fixedCorner] {
+		color := sticker(coord)
+		home, found := solvedFaces[color]
+		if !found {
+			return 0, 0, false
+		}
+		colorFace[color] = coord.Face
+		colorFace[solved.Faces[oppositeFace(home)][0][0]] = oppositeFace(coord.Face)
+	}
+	if len(colorFace) != 6 {
+		return 0, 0, false
+	}
+
+	var cp, co [8]int
+	seen := make(map[int]bool)
+	for pos, facelets := range cornerFacelets {
+		var faces [3]Face
+		for i, coord := range facelets {
+			face, found := colorFace[sticker(coord)]
+			if !found {
+				return 0, 0, false
+			}
+			faces[i] = face
+		}
+		piece, pieceTwist := cornerPiece(faces)
+		if piece < 0 || seen[piece] {
+			return 0, 0, false
+		}
+		seen[piece] = true
+		cp[pos], co[pos] = piece, pieceTwist
+	}
+
+	if cp[fixedCorner] != fixedCorner || co[fixedCorner] != 0 {
+		return 0, 0, false
+	}
+	sum := 0
+	for _, t := range co {
+		sum += t
+	}
+	if sum%3 != 0 {
+		return 0, 0, false
+	}
+
+	return encodeCornerPerm(cp), encodeCornerTwist(co), true
+}
+
+// solvedColorFaces maps each color to its face on a solved cube
+func solvedColorFaces() map[Color]Face {
+	solved := NewCube(2)
+	colorFace := make(map[Color]Face)
+	for face := Front; face <= Down; face++ {
+		colorFace[solved.Faces[face][0][0]] = face
+	}
+	return colorFace
+}
+
+// buildTwoByTwoTables derives each move's effect on the corners from the
+// move engine, builds the coordinate move tables, and runs the BFS
+func buildTwoByTwoTables() {
+	t := &twoByTwoTables{distance: make([]int8, twoByTwoSpace)}
+
+	// Corner effect of each move: position p receives the piece from
+	// position moveCP[p], twisted by moveCO[p]
+	colorFace := solvedColorFaces()
+	var moveCP, moveCO [9][8]int
+	for m, move := range twoByTwoMoves {
+		c := NewCube(2)
+		c.ApplyMove(move)
+		for pos, facelets := range cornerFacelets {
+			var faces [3]Face
+			for i, coord := range facelets {
+				faces[i] = colorFace[c.Faces[coord.Face][coord.Row/2][coord.Col/2]]
+			}
+			moveCP[m][pos], moveCO[m][pos] = cornerPiece(faces)
+		}
+	}
+
+	for p := 0; p < permCoords; p++ {
+		cp := decodeCornerPerm(p)
+		for m := range twoByTwoMoves {
+			var next [8]int
+			for pos := range next {
+				next[pos] = cp[moveCP[m][pos]]
+			}
+			t.permMove[p][m] = uint16(encodeCornerPerm(next))
+		}
+	}
+	for tw := 0; tw < twistCoords; tw++ {
+		co := decodeCornerTwist(tw)
+		for m := range twoByTwoMoves {
+			var next [8]int
+			for pos := range next {
+				next[pos] = (co[moveCP[m][pos]] + moveCO[m][pos]) % 3
+			}
+			t.twistMove[tw][m] = uint16(encodeCornerTwist(next))
+		}
+	}
+
+	for i := range t.distance {
+		t.distance[i] = -1
+	}
+	t.distance[0] = 0
+	for depth, found := int8(0), true; found; depth++ {
+		found = false
+		for i, dist := range t.distance {
+			if dist != depth {
+				continue
+			}
+			perm, twist := i/twistCoords, i%twistCoords
+			for m := range twoByTwoMoves {
+				next := int(t.permMove[perm][m])*twistCoords + int(t.twistMove[twist][m])
+				if t.distance[next] < 0 {
+					t.distance[next] = depth + 1
+					found = true
+				}
+			}
+		}
+	}
+
+	twoByTwoTable = t
+}
+
+// encodeCornerPerm ranks the pieces in the free corner positions (Lehmer
+// code); the solved permutation is 0
+func encodeCornerPerm(cp [8]int) int {
+	index := 0
+	for i, pos := range freeCorners {
+		smaller := 0
+		for _, later := range freeCorners[i+1:] {
+			if cp[later] < cp[pos] {
+				smaller++
+			}
+		}
+		index = index*(len(freeCorners)-i) + smaller
+	}
+	return index
+}
+
+// decodeCornerPerm is the inverse of encodeCornerPerm
+func decodeCornerPerm(index int) [8]int {
+	var digits [7]int
+	for i := len(freeCorners) - 1; i >= 0; i-- {
+		base := len(freeCorners) - i
+		digits[i] = index % base
+		index /= base
+	}
+
+	available := append([]int(nil), freeCorners[:]...)
+	var cp [8]int
+	cp[fixedCorner] = fixedCorner
+	for i, pos := range freeCorners {
+		cp[pos] = available[digits[i]]
+		available = append(available[:digits[i]], available[digits[i]+1:]...)
+	}
+	return cp
+}
+
+// encodeCornerTwist packs the twists of the first six free corners in base 3
+func encodeCornerTwist(co [8]int) int {
+	index := 0
+	for _, pos := range freeCorners[:6] {
+		index = index*3 + co[pos]
+	}
+	return index
+}
+
+// decodeCornerTwist is the inverse of encodeCornerTwist; the last free
+// corner takes whatever twist makes the total a multiple of 3
+func decodeCornerTwist(index int) [8]int {
+	var co [8]int
+	sum := 0
+	for i := 5; i >= 0; i-- {
+		co[freeCorners[i]] = index % 3
+		sum += index % 3
+		index /= 3
+	}
+	co[freeCorners[6]] = (3 - sum%3) % 3
+	return co
+}
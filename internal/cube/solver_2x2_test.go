@@ -0,0 +1,130 @@
+package cube
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestSolve2x2Optimal(t *testing.T) {
+	tests := []struct {
+		scramble string
+		want     int
+	}{
+		{"", 0},
+		{"R", 1},
+		{"R U", 2},
+		{"R U R' U'", 4},
+		{"L", 1}, // L is R x' on a 2x2, and U/R/F only fixes DBL
+		{"D2 B", 2},
+	}
+
+	for _, tt := range tests {
+		c := NewCube(2)
+		moves, err := ParseScramble(tt.scramble)
+		if err != nil {
+			t.Fatalf("ParseScramble(%q) error = %v", tt.scramble, err)
+		}
+		c.ApplyMoves(moves)
+
+		solution := Solve2x2Optimal(c)
+		if solution == nil {
+			t.Fatalf("Solve2x2Optimal(%q) = nil", tt.scramble)
+		}
+		if len(solution) != tt.want {
+			t.Errorf("Solve2x2Optimal(%q) = %s, want %d moves", tt.scramble, FormatMoves(solution), tt.want)
+		}
+		c.ApplyMoves(solution)
+		if !c.IsSolved() {
+			t.Errorf("Solve2x2Optimal(%q) = %s does not solve the cube", tt.scramble, FormatMoves(solution))
+		}
+	}
+}
+
+func TestOptimal2x2SolverRandomScrambles(t *testing.T) {
+	solver := &Optimal2x2Solver{}
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 50; i++ {
+		scramble, err := GenerateScramble(2, ScrambleOptions{Rand: rng})
+		if err != nil {
+			t.Fatalf("GenerateScramble error = %v", err)
+		}
+		c := NewCube(2)
+		c.ApplyMoves(scramble)
+		before := c.Checksum()
+
+		result, err := solver.Solve(c)
+		if err != nil {
+			t.Fatalf("Solve(%s) error = %v", FormatMoves(scramble), err)
+		}
+		if c.Checksum() != before {
+			t.Errorf("Solve(%s) modified the input cube", FormatMoves(scramble))
+		}
+		if result.Steps > 11 {
+			t.Errorf("Solve(%s) took %d moves, God's number for 2x2 is 11", FormatMoves(scramble), result.Steps)
+		}
+		c.ApplyMoves(result.Solution)
+		if !c.IsSolved() {
+			t.Errorf("Solve(%s) = %s does not solve the cube", FormatMoves(scramble), FormatMoves(result.Solution))
+		}
+	}
+}
+
+func TestOptimal2x2SolverRejects(t *testing.T) {
+	solver := &Optimal2x2Solver{}
+	if _, err := solver.Solve(NewCube(3)); err == nil {
+		t.Error("Optimal2x2Solver should reject non-2x2 cubes")
+	}
+
+	c := NewCube(2)
+	c.Faces[Up][0][0], c.Faces[Front][0][0] = c.Faces[Front][0][0], c.Faces[Up][0][0]
+	if _, err := solver.Solve(c); err == nil {
+		t.Error("Optimal2x2Solver should reject a cube with swapped stickers")
+	}
+}
+
+func TestOptimal2x2SolverTargetMetric(t *testing.T) {
+	// The shortest half-turn solution here has four half turns; a
+	// quarter-turn one needs two fewer quarter turns
+	moves, _ := ParseScramble("F' U D2 B L2 D2 B' D B' F' R2")
+	c := NewCube(2)
+	c.ApplyMoves(moves)
+
+	htm, err := (&Optimal2x2Solver{}).Solve(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	solver := &Optimal2x2Solver{}
+	if !SetTargetMetric(solver, QTM) {
+		t.Fatal("SetTargetMetric() = false for the 2x2 solver")
+	}
+	qtm, err := solver.Solve(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := CountMetric(htm.Solution, QTM); got != 13 {
+		t.Errorf("HTM solution %s is %d QTM, want 13", FormatMoves(htm.Solution), got)
+	}
+	if got := CountMetric(qtm.Solution, QTM); got != 11 {
+		t.Errorf("QTM solution %s is %d QTM, want 11", FormatMoves(qtm.Solution), got)
+	}
+	if len(qtm.Solution) < len(htm.Solution) {
+		t.Errorf("QTM solution %s is shorter in HTM than the optimal %s", FormatMoves(qtm.Solution), FormatMoves(htm.Solution))
+	}
+	solved := c.Clone()
+	solved.ApplyMoves(qtm.Solution)
+	if !solved.IsSolved() {
+		t.Errorf("QTM solution %s does not solve the cube", FormatMoves(qtm.Solution))
+	}
+
+	// Every other metric counts U, R, and F turns as HTM does
+	for _, metric := range []Metric{STM, ATM, ETM} {
+		if got := Solve2x2InMetric(c, metric); len(got) != len(htm.Solution) {
+			t.Errorf("Solve2x2InMetric(%s) = %s, want %d moves", metric, FormatMoves(got), len(htm.Solution))
+		}
+	}
+
+	if SetTargetMetric(&CFOPSolver{}, QTM) {
+		t.Error("SetTargetMetric() = true for CFOP, which doesn't search")
+	}
+}
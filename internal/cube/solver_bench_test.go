@@ -43,6 +43,8 @@ func BenchmarkCFOPSolver(b *testing.B) {
 	}{
 		{"1move", "F"},
 		{"2moves", "R U"},
+		{"4moves", "R U R' U'"},
+		{"6moves", "F R U R' U' F'"},
 	}
 
 	for _, bm := range benchmarks {
@@ -50,6 +52,7 @@ func BenchmarkCFOPSolver(b *testing.B) {
 			moves, _ := ParseScramble(bm.scramble)
 			solver := &CFOPSolver{}
 
+			b.ReportAllocs()
 			b.ResetTimer()
 			for i := 0; i < b.N; i++ {
 				cube := NewCube(3)
@@ -222,3 +225,40 @@ func BenchmarkPatternRecognition(b *testing.B) {
 		}
 	})
 }
+
+// BenchmarkSolvers runs every solver over the fixed benchmark scramble set,
+// short and long bands apart, and reports solution quality alongside time
+// per set. Each solve gets DefaultBenchmarkTimeout, and each iteration
+// solves all scrambles, so run it with -benchtime=1x:
+//
+//	go test ./internal/cube -run '^$' -bench BenchmarkSolvers -benchtime=1x -timeout 30m
+func BenchmarkSolvers(b *testing.B) {
+	bands := []struct {
+		name      string
+		scrambles [][]Move
+	}{
+		{"short", SolverBenchmarkScrambles(DefaultBenchmarkScrambleCount, DefaultBenchmarkScrambleSeed, DefaultBenchmarkScrambleMaxLength)},
+		{"long", SolverBenchmarkLongScrambles(DefaultBenchmarkScrambleSeed)},
+	}
+
+	for _, band := range bands {
+		for _, name := range []string{"beginner", "cfop", "kociemba"} {
+			b.Run(band.name+"/"+name, func(b *testing.B) {
+				solver, err := GetSolver(name)
+				if err != nil {
+					b.Fatalf("GetSolver(%q) error: %v", name, err)
+				}
+
+				var report SolverReport
+				for i := 0; i < b.N; i++ {
+					report = EvaluateSolverTimeout(solver, band.scrambles, DefaultBenchmarkTimeout)
+				}
+
+				b.ReportMetric(report.SuccessRate(), "%solved")
+				b.ReportMetric(float64(report.TimedOut), "timeouts")
+				b.ReportMetric(report.AvgMoves, "moves/solve")
+				b.ReportMetric(float64(report.P90Moves), "p90-moves")
+			})
+		}
+	}
+}
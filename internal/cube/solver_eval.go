@@ -0,0 +1,165 @@
+package cube
+
+import (
+	"errors"
+	"math/rand"
+	"sort"
+	"time"
+)
+
+// Defaults for the fixed solver benchmark scramble set. The short band is
+// what every solver should finish; the long band runs from there up to
+// realistic scramble lengths, which the search-based solvers do not all
+// finish yet, so each solve in it gets DefaultBenchmarkTimeout.
+const (
+	DefaultBenchmarkScrambleCount     = 100
+	DefaultBenchmarkScrambleSeed      = 1
+	DefaultBenchmarkScrambleMaxLength = 5
+
+	DefaultBenchmarkLongScrambleCount     = 20
+	DefaultBenchmarkLongScrambleMaxLength = 20
+	DefaultBenchmarkTimeout               = 2 * time.Second
+)
+
+// SolverBenchmarkScrambles returns a reproducible set of 3x3 scrambles for
+// comparing solvers. Lengths cycle from 1 to maxLength so the set covers a
+// range of difficulties.
+func SolverBenchmarkScrambles(count int, seed int64, maxLength int) [][]Move {
+	return SolverBenchmarkScrambleBand(count, seed, 1, maxLength)
+}
+
+// SolverBenchmarkScrambleBand is SolverBenchmarkScrambles with lengths
+// cycling from minLength to maxLength
+func SolverBenchmarkScrambleBand(count int, seed int64, minLength, maxLength int) [][]Move {
+	if minLength < 1 {
+		minLength = 1
+	}
+	if maxLength < minLength {
+		maxLength = minLength
+	}
+
+	rng := rand.New(rand.NewSource(seed))
+	scrambles := make([][]Move, count)
+	for i := range scrambles {
+		scrambles[i] = randomMoves(3, minLength+i%(maxLength-minLength+1), rng)
+	}
+	return scrambles
+}
+
+// SolverBenchmarkLongScrambles returns the long band of the benchmark set:
+// DefaultBenchmarkLongScrambleCount scrambles from just past the short
+// band up to DefaultBenchmarkLongScrambleMaxLength moves
+func SolverBenchmarkLongScrambles(seed int64) [][]Move {
+	return SolverBenchmarkScrambleBand(DefaultBenchmarkLongScrambleCount, seed, DefaultBenchmarkScrambleMaxLength+1, DefaultBenchmarkLongScrambleMaxLength)
+}
+
+// SolverReport summarizes how a solver performed on a set of scrambles
+type SolverReport struct {
+	Solver   string
+	Attempts int
+	Solved   int // Solutions that were returned and actually solve the cube
+	TimedOut int // Failures that ran out of time
+
+	// Move counts over solved scrambles only
+	AvgMoves    float64
+	MedianMoves int
+	P90Moves    int
+
+	// Times over all attempts
+	AvgTime time.Duration
+	MaxTime time.Duration
+}
+
+// SuccessRate returns the percentage of scrambles the solver solved
+func (r SolverReport) SuccessRate() float64 {
+	if r.Attempts == 0 {
+		return 0
+	}
+	return float64(r.Solved) / float64(r.Attempts) * 100
+}
+
+// EvaluateSolver runs the solver on each scramble and checks every returned
+// solution against the scrambled cube. A solver error or a solution that
+// leaves the cube unsolved counts as a failure.
+func EvaluateSolver(solver Solver, scrambles [][]Move) SolverReport {
+	return EvaluateSolverTimeout(solver, scrambles, 0)
+}
+
+// EvaluateSolverTimeout is EvaluateSolver with each solve stopped after
+// timeout, if it is positive, through the solver's Cancel channel (see
+// SetCancel). A solve that runs out of time counts as a failure and in
+// TimedOut. Solvers without a Cancel channel run without a time limit.
+func EvaluateSolverTimeout(solver Solver, scrambles [][]Move, timeout time.Duration) SolverReport {
+	report := SolverReport{Solver: solver.Name(), Attempts: len(scrambles)}
+
+	var moveCounts []int
+	var totalTime time.Duration
+	for _, scramble := range scrambles {
+		c := NewCube(3)
+		c.ApplyMoves(scramble)
+
+		start := time.Now()
+		result, err := solveWithin(solver, c, timeout)
+		elapsed := time.Since(start)
+
+		totalTime += elapsed
+		if elapsed > report.MaxTime {
+			report.MaxTime = elapsed
+		}
+
+		if errors.Is(err, ErrSolveCancelled) {
+			report.TimedOut++
+		}
+		if err != nil || result == nil {
+			continue
+		}
+		c.ApplyMoves(result.Solution)
+		if !c.IsSolved() {
+			continue
+		}
+		report.Solved++
+		moveCounts = append(moveCounts, len(result.Solution))
+	}
+
+	if report.Attempts > 0 {
+		report.AvgTime = totalTime / time.Duration(report.Attempts)
+	}
+	if len(moveCounts) > 0 {
+		sort.Ints(moveCounts)
+		total := 0
+		for _, n := range moveCounts {
+			total += n
+		}
+		report.AvgMoves = float64(total) / float64(len(moveCounts))
+		report.MedianMoves = percentile(moveCounts, 50)
+		report.P90Moves = percentile(moveCounts, 90)
+	}
+
+	return report
+}
+
+// solveWithin solves c, cancelling the solve after timeout if it is
+// positive and the solver supports SetCancel
+func solveWithin(solver Solver, c *Cube, timeout time.Duration) (*SolverResult, error) {
+	if timeout <= 0 {
+		return solver.Solve(c)
+	}
+	cancel := make(chan struct{})
+	if !SetCancel(solver, cancel) {
+		return solver.Solve(c)
+	}
+	defer SetCancel(solver, nil)
+
+	timer := time.AfterFunc(timeout, func() { close(cancel) })
+	defer timer.Stop()
+	return solver.Solve(c)
+}
+
+// percentile returns the nearest-rank percentile of sorted values
+func percentile(sorted []int, p int) int {
+	rank := (p*len(sorted) + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
@@ -0,0 +1,98 @@
+package cube
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// inverseSolver "solves" scrambles it was told about by inverting them
+type inverseSolver struct {
+	known map[string][]Move
+}
+
+func (s *inverseSolver) Name() string { return "Inverse" }
+
+func (s *inverseSolver) Solve(c *Cube) (*SolverResult, error) {
+	moves, ok := s.known[c.String()]
+	if !ok {
+		return nil, fmt.Errorf("unknown scramble")
+	}
+	return &SolverResult{Solution: InvertSequence(moves), Steps: len(moves)}, nil
+}
+
+func TestSolverBenchmarkScrambles(t *testing.T) {
+	a := SolverBenchmarkScrambles(10, 3, 4)
+	b := SolverBenchmarkScrambles(10, 3, 4)
+	if !reflect.DeepEqual(a, b) {
+		t.Fatal("same seed produced different scramble sets")
+	}
+	for i, scramble := range a {
+		if want := 1 + i%4; len(scramble) != want {
+			t.Errorf("scramble %d has %d moves, want %d", i, len(scramble), want)
+		}
+	}
+}
+
+func TestSolverBenchmarkScrambleBand(t *testing.T) {
+	for i, scramble := range SolverBenchmarkScrambleBand(9, 3, 6, 8) {
+		if want := 6 + i%3; len(scramble) != want {
+			t.Errorf("scramble %d has %d moves, want %d", i, len(scramble), want)
+		}
+	}
+
+	long := SolverBenchmarkLongScrambles(DefaultBenchmarkScrambleSeed)
+	if len(long) != DefaultBenchmarkLongScrambleCount {
+		t.Fatalf("long band has %d scrambles, want %d", len(long), DefaultBenchmarkLongScrambleCount)
+	}
+	for i, scramble := range long {
+		if len(scramble) <= DefaultBenchmarkScrambleMaxLength || len(scramble) > DefaultBenchmarkLongScrambleMaxLength {
+			t.Errorf("long scramble %d has %d moves, want %d-%d", i, len(scramble),
+				DefaultBenchmarkScrambleMaxLength+1, DefaultBenchmarkLongScrambleMaxLength)
+		}
+	}
+}
+
+func TestEvaluateSolver(t *testing.T) {
+	scrambles := SolverBenchmarkScrambles(4, 1, 4)
+	solver := &inverseSolver{known: map[string][]Move{}}
+	for _, scramble := range scrambles[:3] {
+		c := NewCube(3)
+		c.ApplyMoves(scramble)
+		solver.known[c.String()] = scramble
+	}
+
+	report := EvaluateSolver(solver, scrambles)
+	if report.Attempts != 4 || report.Solved != 3 {
+		t.Fatalf("report = %d/%d solved, want 3/4", report.Solved, report.Attempts)
+	}
+	if report.SuccessRate() != 75 {
+		t.Errorf("SuccessRate() = %v, want 75", report.SuccessRate())
+	}
+	if report.AvgMoves != 2 || report.MedianMoves != 2 || report.P90Moves != 3 {
+		t.Errorf("moves avg/median/p90 = %v/%d/%d, want 2/2/3", report.AvgMoves, report.MedianMoves, report.P90Moves)
+	}
+
+	// A solution that does not solve the cube is a failure
+	wrong := &inverseSolver{known: map[string][]Move{}}
+	for key, moves := range solver.known {
+		wrong.known[key] = append([]Move{{Face: Up, Clockwise: true}}, moves...)
+	}
+	if got := EvaluateSolver(wrong, scrambles).Solved; got != 0 {
+		t.Errorf("wrong solutions counted as solved: %d", got)
+	}
+}
+
+func TestEvaluateSolverTimeout(t *testing.T) {
+	hard, _ := ParseScramble(hardScramble)
+	scrambles := [][]Move{{{Face: Right, Clockwise: true}}, hard}
+
+	var report SolverReport
+	withinTime(t, 5*time.Second, "EvaluateSolverTimeout", func() {
+		report = EvaluateSolverTimeout(&KociembaSolver{}, scrambles, 100*time.Millisecond)
+	})
+	if report.Solved != 1 || report.TimedOut != 1 {
+		t.Errorf("report = %d solved, %d timed out, want 1 and 1", report.Solved, report.TimedOut)
+	}
+}
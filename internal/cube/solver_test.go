@@ -1,6 +1,7 @@
 package cube
 
 import (
+	"math/rand"
 	"testing"
 	"time"
 )
@@ -15,6 +16,7 @@ func TestGetSolver(t *testing.T) {
 		{"Beginner solver", "beginner", "Beginner", false},
 		{"CFOP solver", "cfop", "CFOP", false},
 		{"Kociemba solver", "kociemba", "Kociemba", false},
+		{"Optimal 2x2 solver", "optimal2", "Optimal 2x2", false},
 		{"Invalid solver", "invalid", "", true},
 		{"Empty string", "", "", true},
 	}
@@ -85,6 +87,7 @@ func TestCFOPSolverOnSimpleScrambles(t *testing.T) {
 				t.Fatalf("Cube should not be solved after applying scramble %q", tt.scramble)
 			}
 
+			before := cube.Checksum()
 			solver := &CFOPSolver{}
 			result, err := solver.Solve(cube)
 			if err != nil {
@@ -92,6 +95,9 @@ func TestCFOPSolverOnSimpleScrambles(t *testing.T) {
 				t.Logf("CFOPSolver couldn't solve scramble %q: %v", tt.scramble, err)
 				return
 			}
+			if cube.Checksum() != before {
+				t.Error("CFOPSolver.Solve() modified the input cube")
+			}
 
 			// Check solution length is reasonable
 			if len(result.Solution) > tt.maxMoves {
@@ -111,10 +117,8 @@ func TestCFOPSolverOnSimpleScrambles(t *testing.T) {
 				t.Errorf("Solution took too long: %v", result.Duration)
 			}
 
-			// Most importantly: cube should be solved
-			if !cube.IsSolved() {
-				t.Errorf("Cube should be solved after CFOP solution for scramble %q", tt.scramble)
-			}
+			// Most importantly: the solution should solve the cube
+			AssertSolves(t, tt.scramble, FormatMoves(result.Solution))
 		})
 	}
 }
@@ -125,7 +129,7 @@ func TestCFOPSolverVerification(t *testing.T) {
 
 	for _, scramble := range scrambles {
 		t.Run("Verify_"+scramble, func(t *testing.T) {
-			// Create two cubes - one for scrambling, one for verification
+			// Create two cubes - one to solve, one to check it isn't modified
 			cube1 := NewCube(3)
 			cube2 := NewCube(3)
 
@@ -145,17 +149,12 @@ func TestCFOPSolverVerification(t *testing.T) {
 				return // Skip this test case
 			}
 
-			// Apply solution to verification cube
-			cube2.ApplyMoves(result.Solution)
-
-			// Note: CFOP solver modifies the input cube during solving (this is a known behavior)
-			// So we just verify that the solution works on a fresh cube
-			if !cube1.IsSolved() {
-				t.Error("CFOP solver should solve the cube (either in-place or the solution should work)")
-			}
-			if !cube2.IsSolved() {
-				t.Error("Verification cube should be solved after applying solution")
+			// The solver must leave the input cube untouched
+			if cube1.Checksum() != cube2.Checksum() {
+				t.Error("CFOPSolver.Solve() modified the input cube")
 			}
+
+			AssertSolves(t, scramble, FormatMoves(result.Solution))
 		})
 	}
 }
@@ -176,6 +175,166 @@ func TestCFOPSolver4x4Rejection(t *testing.T) {
 	}
 }
 
+func TestCFOPSolverDeterministic(t *testing.T) {
+	scramble, _ := ParseScramble("R U R' U'")
+
+	var first string
+	for run := 0; run < 100; run++ {
+		cube := NewCube(3)
+		cube.ApplyMoves(scramble)
+
+		result, err := (&CFOPSolver{}).Solve(cube)
+		if err != nil {
+			t.Fatalf("Run %d: Solve() error = %v", run, err)
+		}
+
+		solution := FormatMoves(result.Solution)
+		if run == 0 {
+			first = solution
+		} else if solution != first {
+			t.Fatalf("Run %d: solution %q differs from first run %q", run, solution, first)
+		}
+	}
+}
+
+func TestCFOPSolveThrough(t *testing.T) {
+	solver := &CFOPSolver{}
+
+	tests := []struct {
+		scramble string
+		stage    CFOPStage
+		done     func(*Cube) bool
+	}{
+		{"R U", StageCross, WhiteCrossPattern{}.Matches},
+		{"R U R' U'", StageF2L, isF2LSolved},
+		{"R U R' U'", StageOLL, OLLSolvedPattern{}.Matches},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.scramble+" through "+tt.stage.String(), func(t *testing.T) {
+			cube := NewCube(3)
+			moves, _ := ParseScramble(tt.scramble)
+			cube.ApplyMoves(moves)
+			before := cube.Checksum()
+
+			result, err := solver.SolveThrough(cube, tt.stage)
+			if err != nil {
+				t.Fatalf("SolveThrough() error = %v", err)
+			}
+			if cube.Checksum() != before {
+				t.Error("SolveThrough() modified the input cube")
+			}
+
+			cube.ApplyMoves(result.Solution)
+			if !tt.done(cube) {
+				t.Errorf("Solution %v does not complete %s stage", result.Solution, tt.stage)
+			}
+		})
+	}
+}
+
+func TestCFOPSolvesEveryF2LSlot(t *testing.T) {
+	// Cross-preserving scrambles that take pairs out of all four slots
+	scrambles := []string{
+		"R U R' L' U' L B U B' F' U' F",
+		"L U L' U2 B' U' B R' U R U' F U F'",
+		"B' U' B B U B' F' U' F U2 R U' R' L' U' L L' U' L L U' L'",
+	}
+
+	for _, scramble := range scrambles {
+		moves, err := ParseScramble(scramble)
+		if err != nil {
+			t.Fatalf("ParseScramble(%q) error = %v", scramble, err)
+		}
+		c := NewCube(3)
+		c.ApplyMoves(moves)
+
+		result, err := (&CFOPSolver{}).SolveThrough(c, StageF2L)
+		if err != nil {
+			t.Errorf("SolveThrough(%q, F2L) error = %v", scramble, err)
+			continue
+		}
+		for _, move := range result.Solution {
+			if move.Rotation != NoRotation {
+				t.Errorf("F2L solution %s for %q contains a rotation", FormatMoves(result.Solution), scramble)
+				break
+			}
+		}
+	}
+}
+
+// TestCFOPSolveSolves checks Solve, not just SolveThrough, on scrambles
+// where stages must apply each move exactly once
+func TestCFOPSolveSolves(t *testing.T) {
+	scrambles := []string{
+		"R U R' U'",
+		"F R U R' U' F'",
+		"R U R' L' U' L B U B' F' U' F",
+		"L U L' U2 B' U' B R' U R U' F U F'",
+		"B' U' B B U B' F' U' F U2 R U' R' L' U' L L' U' L L U' L'",
+		"R U R2 F", // The cross needs R2, which its quarter-turn search makes as R R
+	}
+
+	for _, scramble := range scrambles {
+		moves, err := ParseScramble(scramble)
+		if err != nil {
+			t.Fatalf("ParseScramble(%q) error = %v", scramble, err)
+		}
+		c := NewCube(3)
+		c.ApplyMoves(moves)
+
+		result, err := (&CFOPSolver{}).Solve(c)
+		if err != nil {
+			t.Errorf("Solve(%q) error = %v", scramble, err)
+			continue
+		}
+		AssertSolves(t, scramble, FormatMoves(result.Solution))
+	}
+}
+
+func TestCFOPSolveThroughOnStage(t *testing.T) {
+	var stages []CFOPStage
+	var streamed []Move
+	solver := &CFOPSolver{OnStage: func(stage CFOPStage, moves []Move) {
+		stages = append(stages, stage)
+		streamed = append(streamed, moves...)
+	}}
+
+	cube := NewCube(3)
+	moves, _ := ParseScramble("R U R' U'")
+	cube.ApplyMoves(moves)
+
+	result, err := solver.SolveThrough(cube, StagePLL)
+	if err != nil {
+		t.Fatalf("SolveThrough() error = %v", err)
+	}
+
+	want := []CFOPStage{StageCross, StageF2L, StageOLL, StagePLL}
+	if len(stages) != len(want) {
+		t.Fatalf("OnStage called for %v, want %v", stages, want)
+	}
+	for i := range want {
+		if stages[i] != want[i] {
+			t.Errorf("OnStage call %d = %s, want %s", i, stages[i], want[i])
+		}
+	}
+	if FormatMoves(streamed) != FormatMoves(result.Solution) {
+		t.Errorf("Streamed moves %q do not match solution %q", FormatMoves(streamed), FormatMoves(result.Solution))
+	}
+}
+
+func TestParseCFOPStage(t *testing.T) {
+	for _, name := range []string{"cross", "f2l", "oll", "pll"} {
+		stage, err := ParseCFOPStage(name)
+		if err != nil || stage.String() != name {
+			t.Errorf("ParseCFOPStage(%q) = %v, %v", name, stage, err)
+		}
+	}
+	if _, err := ParseCFOPStage("roux"); err == nil {
+		t.Error("ParseCFOPStage(\"roux\") should fail")
+	}
+}
+
 // Beginner Solver Tests  
 func TestBeginnerSolverOnSolvedCube(t *testing.T) {
 	cube := NewCube(3)
@@ -218,24 +377,23 @@ func TestBeginnerSolverOnSimpleScrambles(t *testing.T) {
 			}
 			cube.ApplyMoves(moves)
 
+			before := cube.Checksum()
 			solver := &BeginnerSolver{}
 			result, err := solver.Solve(cube)
 			if err != nil {
 				t.Fatalf("BeginnerSolver failed on scramble %q: %v", tt.scramble, err)
 			}
+			if cube.Checksum() != before {
+				t.Error("BeginnerSolver.Solve() modified the input cube")
+			}
 
 			// Check timing
 			if result.Duration > tt.timeout {
 				t.Errorf("Solution took too long: %v (max %v)", result.Duration, tt.timeout)
 			}
 
-			// Apply the solution to verify it works
-			cube.ApplyMoves(result.Solution)
-			
 			// Cube should be solved after applying the solution
-			if !cube.IsSolved() {
-				t.Errorf("Cube should be solved after applying beginner solution for scramble %q", tt.scramble)
-			}
+			AssertSolves(t, tt.scramble, FormatMoves(result.Solution))
 		})
 	}
 }
@@ -267,13 +425,14 @@ func TestSolverComparison(t *testing.T) {
 				t.Fatalf("CFOPSolver failed: %v", err)
 			}
 
-			// Apply solutions to verify they work (only if not already solved)
-			if !cube1.IsSolved() {
-				cube1.ApplyMoves(beginnerResult.Solution)
-			}
-			if !cube2.IsSolved() {
-				cube2.ApplyMoves(cfopResult.Solution)
+			// Neither solver should modify its input cube
+			if cube1.String() != cube2.String() {
+				t.Error("Solvers should leave their input cubes unchanged")
 			}
+
+			// Apply solutions to verify they work
+			cube1.ApplyMoves(beginnerResult.Solution)
+			cube2.ApplyMoves(cfopResult.Solution)
 			
 			// Both should solve the cube after applying solutions
 			if !cube1.IsSolved() {
@@ -352,11 +511,15 @@ func TestKociembaSolverOnSimpleScrambles(t *testing.T) {
 				t.Fatalf("Cube should not be solved after applying scramble %q", tt.scramble)
 			}
 
+			before := cube.Checksum()
 			solver := &KociembaSolver{}
 			result, err := solver.Solve(cube)
 			if err != nil {
 				t.Fatalf("KociembaSolver couldn't solve scramble %q: %v", tt.scramble, err)
 			}
+			if cube.Checksum() != before {
+				t.Error("KociembaSolver.Solve() modified the input cube")
+			}
 
 			// Check solution length is reasonable
 			if len(result.Solution) > tt.maxMoves {
@@ -376,13 +539,8 @@ func TestKociembaSolverOnSimpleScrambles(t *testing.T) {
 				t.Errorf("Solution took too long: %v", result.Duration)
 			}
 
-			// Apply the solution to verify it works
-			cube.ApplyMoves(result.Solution)
-			
 			// Most importantly: cube should be solved after applying the solution
-			if !cube.IsSolved() {
-				t.Errorf("Cube should be solved after applying Kociemba solution for scramble %q", tt.scramble)
-			}
+			AssertSolves(t, tt.scramble, FormatMoves(result.Solution))
 		})
 	}
 }
@@ -393,7 +551,7 @@ func TestKociembaSolverVerification(t *testing.T) {
 
 	for _, scramble := range scrambles {
 		t.Run("Verify_"+scramble, func(t *testing.T) {
-			// Create two cubes - one for scrambling, one for verification
+			// Create two cubes - one to solve, one to check it isn't modified
 			cube1 := NewCube(3)
 			cube2 := NewCube(3)
 
@@ -412,24 +570,12 @@ func TestKociembaSolverVerification(t *testing.T) {
 				t.Fatalf("Kociemba solver failed on %q: %v", scramble, err)
 			}
 
-			// Apply solution to verification cube
-			cube2.ApplyMoves(result.Solution)
-
-			// Apply solution to the original cube to verify
-			cube1.ApplyMoves(result.Solution)
-			
-			// Both cubes should be solved and identical
-			if !cube1.IsSolved() {
-				t.Error("Original cube should be solved after applying solution")
-			}
-			if !cube2.IsSolved() {
-				t.Error("Verification cube should be solved")
+			// The solver must leave the input cube untouched
+			if cube1.Checksum() != cube2.Checksum() {
+				t.Error("KociembaSolver.Solve() modified the input cube")
 			}
 
-			// They should match exactly
-			if cube1.String() != cube2.String() {
-				t.Error("Cubes should be identical after applying solution")
-			}
+			AssertSolves(t, scramble, FormatMoves(result.Solution))
 		})
 	}
 }
@@ -449,3 +595,215 @@ func TestKociembaSolver4x4Rejection(t *testing.T) {
 		t.Errorf("Expected error message %q, got %q", expectedMsg, err.Error())
 	}
 }
+
+func TestKociembaG1Subgroup(t *testing.T) {
+	tests := []struct {
+		scramble                  string
+		edges, corners, slice, g1 bool
+	}{
+		{"", true, true, true, true},
+		{"U D' R2 F2 L2 B2", true, true, true, true},
+		{"x M2", true, true, true, true},
+		{"F", false, false, false, false},
+		{"R", true, false, false, false},
+		{"F R U R' U' F'", false, false, true, false},
+		{"R U R U2 R' U' R", true, true, false, false},
+	}
+
+	solver := &KociembaSolver{}
+	for _, tt := range tests {
+		moves, err := ParseScramble(tt.scramble)
+		if err != nil {
+			t.Fatal(err)
+		}
+		c := NewCube(3)
+		c.ApplyMoves(moves)
+
+		if got := solver.areEdgesOriented(c); got != tt.edges {
+			t.Errorf("%q: areEdgesOriented() = %v, want %v", tt.scramble, got, tt.edges)
+		}
+		if got := solver.areCornersOriented(c); got != tt.corners {
+			t.Errorf("%q: areCornersOriented() = %v, want %v", tt.scramble, got, tt.corners)
+		}
+		if got := solver.areMiddleSliceEdgesInSlice(c); got != tt.slice {
+			t.Errorf("%q: areMiddleSliceEdgesInSlice() = %v, want %v", tt.scramble, got, tt.slice)
+		}
+		if got := solver.isInG1Subgroup(c); got != tt.g1 {
+			t.Errorf("%q: isInG1Subgroup() = %v, want %v", tt.scramble, got, tt.g1)
+		}
+	}
+}
+
+func TestAStarSearchPrefersSmallestNotation(t *testing.T) {
+	tests := []struct {
+		scramble string
+		want     string
+	}{
+		{"R L", "L' R'"},
+		{"U D'", "D U'"},
+		{"F B", "B' F'"},
+		{"R U", "U' R'"},
+	}
+
+	for _, tt := range tests {
+		moves, err := ParseScramble(tt.scramble)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for run := 0; run < 2; run++ {
+			c := NewCube(3)
+			c.ApplyMoves(moves)
+			solution, err := (&BeginnerSolver{}).aStarSearch(c, 6)
+			if err != nil {
+				t.Fatalf("%s: %v", tt.scramble, err)
+			}
+			if got := FormatMoves(solution); got != tt.want {
+				t.Errorf("%s: aStarSearch() = %q, want %q", tt.scramble, got, tt.want)
+			}
+		}
+	}
+}
+
+func TestPreferSolution(t *testing.T) {
+	parse := func(s string) []Move {
+		moves, err := ParseScramble(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return moves
+	}
+
+	if !preferSolution(parse("R"), parse("B F")) {
+		t.Error("a shorter solution should win")
+	}
+	if !preferSolution(parse("B F"), parse("F B")) {
+		t.Error("equal lengths should be ordered by notation")
+	}
+	if preferSolution(parse("F B"), parse("F B")) {
+		t.Error("a solution should not be preferred over itself")
+	}
+}
+
+// kociembaMarginMoves is how many moves longer than optimal a Kociemba
+// solution to a short scramble may be
+const kociembaMarginMoves = 2
+
+// TestKociembaNearOptimal checks Kociemba against the optimal solution
+// length on seeded short scrambles, so a solver that still solves the cube
+// but returns a bloated solution fails. The scrambles stay in the phase 2
+// group (U, D, and half turns of the other faces), where this solver's
+// search is fast enough to run many of them.
+func TestKociembaNearOptimal(t *testing.T) {
+	phase2 := []Move{
+		{Face: Up, Clockwise: true}, {Face: Up}, {Face: Up, Double: true},
+		{Face: Down, Clockwise: true}, {Face: Down}, {Face: Down, Double: true},
+		{Face: Right, Double: true}, {Face: Left, Double: true},
+		{Face: Front, Double: true}, {Face: Back, Double: true},
+	}
+
+	rng := rand.New(rand.NewSource(2382))
+	for i := 0; i < 24; i++ {
+		var scramble []Move
+		for len(scramble) < 1+i%6 {
+			move := phase2[rng.Intn(len(phase2))]
+			if len(scramble) > 0 && scramble[len(scramble)-1].Face == move.Face {
+				continue
+			}
+			scramble = append(scramble, move)
+		}
+
+		c := NewCube(3)
+		c.ApplyMoves(scramble)
+		optimal, ok := OptimalLength(c, MaxOptimalSearchDepth)
+		if !ok {
+			t.Fatalf("%s: no optimal length within %d moves", FormatMoves(scramble), MaxOptimalSearchDepth)
+		}
+
+		result, err := (&KociembaSolver{}).Solve(c)
+		if err != nil {
+			t.Errorf("%s: Solve() error = %v", FormatMoves(scramble), err)
+			continue
+		}
+		c.ApplyMoves(result.Solution)
+		if !c.IsSolved() {
+			t.Errorf("%s: solution %s doesn't solve the cube", FormatMoves(scramble), FormatMoves(result.Solution))
+		}
+		if len(result.Solution) > optimal+kociembaMarginMoves {
+			t.Errorf("%s: solution %s has %d moves, optimal is %d",
+				FormatMoves(scramble), FormatMoves(result.Solution), len(result.Solution), optimal)
+		}
+	}
+}
+
+func TestBeginnerSolverCrossOptions(t *testing.T) {
+	// crossBuilt reports whether the cross edges on face are home and
+	// unflipped, measured against the centers
+	crossBuilt := func(c *Cube, face Face) bool {
+		edges := crossEdges
+		if face == Up {
+			edges = [4]int{0, 1, 2, 3}
+		}
+		for _, edge := range edges {
+			if !c.edgeSolved(edge) {
+				return false
+			}
+		}
+		return true
+	}
+
+	scramble, _ := ParseScramble("R U R' U'")
+	turnedOver := NewCube(3)
+	turnedOver.ApplyMoves(scramble)
+	turnedOver.ApplyMove(Move{Rotation: X_Rotation, Double: true})
+
+	yellowDown, err := NewCubeWithScheme(3, map[Face]Color{
+		Front: Blue, Back: Green, Left: Orange, Right: Red, Up: White, Down: Yellow,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	yellowDown.ApplyMoves(scramble)
+
+	tests := []struct {
+		name     string
+		cube     *Cube
+		solver   *BeginnerSolver
+		face     Face
+		rotation string
+	}{
+		{"white down on a turned over cube", turnedOver, &BeginnerSolver{}, Down, "x2"},
+		{"white up on a turned over cube", turnedOver, &BeginnerSolver{CrossFace: Up}, Up, ""},
+		{"yellow cross", yellowDown, &BeginnerSolver{CrossColor: Yellow, CrossFace: Down}, Down, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before := tt.cube.Checksum()
+			result, err := tt.solver.Solve(tt.cube)
+			if err != nil {
+				t.Fatalf("Solve() error = %v", err)
+			}
+			if tt.cube.Checksum() != before {
+				t.Error("Solve() modified the cube")
+			}
+
+			rotations := 0
+			for rotations < len(result.Solution) && result.Solution[rotations].Rotation != NoRotation {
+				rotations++
+			}
+			if got := FormatMoves(result.Solution[:rotations]); got != tt.rotation {
+				t.Errorf("solution starts with rotation %q, want %q", got, tt.rotation)
+			}
+
+			c := tt.cube.Clone()
+			c.ApplyMoves(result.Solution)
+			if !crossBuilt(c, tt.face) {
+				t.Errorf("solution %s doesn't build the cross on %s", FormatMoves(result.Solution), tt.face)
+			}
+		})
+	}
+
+	if _, err := (&BeginnerSolver{CrossColor: Grey}).Solve(NewCube(3)); err == nil {
+		t.Error("Solve() with no center of the cross color should fail")
+	}
+}
@@ -0,0 +1,132 @@
+package cube
+
+// Supercubes
+//
+// On a plain cube a center looks the same however it is turned, so solving
+// ignores it. On a supercube or picture cube each center has a marked
+// direction, and a cube is only solved once every center points the right
+// way. The stickers can't record that, so a Supercube keeps the twist of
+// each center alongside them. Only odd cubes have a fixed center to track;
+// on even cubes Supercube checks nothing beyond the stickers.
+//
+// A move that turns a center turns it exactly as the whole-cube rotation
+// about the same axis would, so trackCenters follows the center's own
+// sticker and the one above it through that rotation on a 3x3 to see where
+// the center lands and which way it then points.
+
+// sliceFace is the face whose turning direction each slice and rotation
+// follows: M turns like L, E like D, S like F, and x, y, and z like R, U,
+// and F
+var sliceFace = map[MoveType]MoveType{
+	MoveM: MoveL,
+	MoveE: MoveD,
+	MoveS: MoveF,
+	MoveX: MoveR,
+	MoveY: MoveU,
+	MoveZ: MoveF,
+}
+
+// moveTypeFace is the face a face move turns
+var moveTypeFace = map[MoveType]Face{
+	MoveR: Right,
+	MoveL: Left,
+	MoveU: Up,
+	MoveD: Down,
+	MoveF: Front,
+	MoveB: Back,
+}
+
+// ringFaces is the four faces around each face, whose centers a turn of
+// the middle layer parallel to it moves
+var ringFaces = map[Face][4]Face{
+	Right: rotationCycles[X_Rotation],
+	Left:  rotationCycles[X_Rotation],
+	Up:    rotationCycles[Y_Rotation],
+	Down:  rotationCycles[Y_Rotation],
+	Front: rotationCycles[Z_Rotation],
+	Back:  rotationCycles[Z_Rotation],
+}
+
+// CenterTwist returns how far the center in position is turned from where
+// it started, in clockwise quarter turns as seen on the face's own grid.
+// It is always 0 unless Supercube is set on an odd cube.
+func (c *Cube) CenterTwist(position Face) int {
+	return c.centerTwist[position]
+}
+
+// trackCenters updates the center twists for a move on an odd cube
+func (c *Cube) trackCenters(move Move) {
+	if c.Size%2 == 0 {
+		return
+	}
+	moveType, quarterTurns := moveToMoveType(move)
+	if moveType == unsupportedMoveType {
+		return
+	}
+	from, to := getAffectedLayers(move, c.Size)
+	if face, ok := sliceFace[moveType]; ok {
+		moveType = face
+	}
+	face := moveTypeFace[moveType]
+	turns := func(layer int) bool { return layer >= from && layer < to }
+
+	var moved []Face
+	if turns(0) {
+		moved = append(moved, face)
+	}
+	if turns(c.Size - 1) {
+		moved = append(moved, oppositeFace(face))
+	}
+	if turns(c.Size / 2) {
+		ring := ringFaces[face]
+		moved = append(moved, ring[:]...)
+	}
+
+	twist := c.centerTwist
+	for _, position := range moved {
+		dest, delta := turnCenter(moveType, quarterTurns, position)
+		twist[dest] = (c.centerTwist[position] + delta) % 4
+	}
+	c.centerTwist = twist
+}
+
+// turnCenter returns where the center in position lands when the whole
+// cube turns like moveType, and how many clockwise quarter turns it gains
+func turnCenter(moveType MoveType, quarterTurns int, position Face) (Face, int) {
+	center := stickerIndex(position, 1, 1, 3)
+	above := stickerIndex(position, 0, 1, 3)
+	for layer := 0; layer < 3; layer++ {
+		perm := getPermutation(3, moveType, layer, quarterTurns)
+		center, above = perm[center], perm[above]
+	}
+
+	dest, _, _ := indexToCoord(center, 3)
+	_, row, col := indexToCoord(above, 3)
+	switch {
+	case row == 1 && col == 2:
+		return dest, 1
+	case row == 2:
+		return dest, 2
+	case col == 0:
+		return dest, 3
+	}
+	return dest, 0
+}
+
+// centersSolved reports whether every center points the way it would on
+// a solved cube held the same way. Whole-cube rotations turn centers too,
+// so a rotated cube is compared with a solved cube given the same rotation.
+func (c *Cube) centersSolved() bool {
+	if c.IsHomeOrientation() {
+		return c.centerTwist == [6]int{}
+	}
+	for _, rotation := range orientationRotations {
+		solved := NewCube(3)
+		solved.Supercube = true
+		solved.ApplyMoves(rotation)
+		if solved.FaceAt(Up) == c.FaceAt(Up) && solved.FaceAt(Front) == c.FaceAt(Front) {
+			return solved.centerTwist == c.centerTwist
+		}
+	}
+	return false
+}
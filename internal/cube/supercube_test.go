@@ -0,0 +1,98 @@
+package cube
+
+import "testing"
+
+func newSupercube(t *testing.T, size int, scramble string) *Cube {
+	t.Helper()
+	c := NewCube(size)
+	c.Supercube = true
+	moves, err := ParseScrambleForSize(scramble, size)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.ApplyMoves(moves)
+	return c
+}
+
+func TestSupercubeIsSolved(t *testing.T) {
+	tests := []struct {
+		scramble string
+		size     int
+		solved   bool
+	}{
+		{"", 3, true},
+		{"U U U U", 3, true},
+		{"R U R' U' R U R' U' R U R' U' R U R' U' R U R' U' R U R' U'", 3, true},
+		{"M M'", 3, true},
+		{"x y z2 y'", 3, true},
+		{"M2 U M2 U2 M2 U M2 M2 U M2 U2 M2 U M2", 3, true}, // H-perm twice
+		{"U R L U2 R' L' U R L U2 R' L'", 3, false},
+		{"U R L U2 R' L' U R L U2 R' L' U2", 3, false},
+		{"R U R' U R U2 R'", 3, false},
+		{"U U U U", 5, true},
+		{"U R L U2 R' L' U R L U2 R' L'", 5, false},
+		{"R U R' U' R U R' U' R U R' U' R U R' U' R U R' U' R U R' U'", 4, true}, // No fixed centers to turn
+	}
+
+	for _, tt := range tests {
+		c := newSupercube(t, tt.size, tt.scramble)
+		if got := c.IsSolved(); got != tt.solved {
+			t.Errorf("%dx%d supercube after %q: IsSolved() = %v, want %v", tt.size, tt.size, tt.scramble, got, tt.solved)
+		}
+	}
+}
+
+func TestSupercubeHPerm(t *testing.T) {
+	c := newSupercube(t, 3, "M2 U M2 U2 M2 U M2")
+	if got := c.CenterTwist(Up); got != 2 {
+		t.Errorf("H-perm left the U center twisted %d, want 2", got)
+	}
+	if c.IsSolved() {
+		t.Error("supercube after H-perm reports solved")
+	}
+}
+
+func TestSupercubeCenterOnly(t *testing.T) {
+	// Turns the U center 180 degrees and leaves every sticker solved
+	c := newSupercube(t, 3, "U R L U2 R' L' U R L U2 R' L'")
+	plain := c.Clone()
+	plain.Supercube = false
+	if !plain.IsSolved() {
+		t.Fatal("stickers aren't solved")
+	}
+	if got := c.CenterTwist(Up); got != 2 {
+		t.Errorf("U center twisted %d, want 2", got)
+	}
+	if c.IsSolved() {
+		t.Error("supercube with a twisted center reports solved")
+	}
+	if c.Clone().IsSolved() {
+		t.Error("Clone() lost the center twists")
+	}
+}
+
+// TestSupercubeConjugates checks center tracking against whole-cube
+// rotations: a face move conjugated by a rotation is another face move,
+// and must turn the centers the same way
+func TestSupercubeConjugates(t *testing.T) {
+	for _, move := range faceTurns {
+		for _, rotation := range orientationRotations {
+			conjugate := append(append(append([]Move{}, rotation...), move), InvertSequence(rotation)...)
+			c := NewCube(3)
+			c.Supercube = true
+			c.ApplyMoves(conjugate)
+
+			for _, other := range faceTurns {
+				d := NewCube(3)
+				d.Supercube = true
+				d.ApplyMove(other)
+				if d.String() != c.String() {
+					continue
+				}
+				if d.centerTwist != c.centerTwist {
+					t.Errorf("%s turns centers %v, but %s does %v", FormatMoves(conjugate), c.centerTwist, other, d.centerTwist)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package cube
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ColorScheme maps each cube color to how it is drawn in a terminal. Both
+// tables are indexed by Color, with Grey (the wildcard) last.
+type ColorScheme struct {
+	Name    string
+	Letters [7]string // ANSI colored single-width letters, used for --color
+	Glyphs  [7]string // Double-width Unicode glyphs, used for block output
+}
+
+// DefaultScheme uses muted ANSI colors and colored Unicode squares
+var DefaultScheme = ColorScheme{
+	Name: "default",
+	Letters: [7]string{
+		"\033[37mW\033[0m", // Light gray for white
+		"\033[33mY\033[0m", // Muted yellow
+		"\033[31mR\033[0m", // Muted red
+		"\033[35mO\033[0m", // Muted magenta for orange
+		"\033[34mB\033[0m", // Muted blue
+		"\033[32mG\033[0m", // Muted green
+		"\033[90m.\033[0m", // Dark gray for wildcard
+	},
+	Glyphs: [7]string{"⬜", "🟨", "🟥", "🟧", "🟦", "🟩", "⬛"},
+}
+
+// ColorblindScheme uses the Okabe-Ito palette, which stays distinct under
+// the common forms of color blindness, and gives red, orange, and green
+// glyphs different shapes so they never rely on hue alone
+var ColorblindScheme = ColorScheme{
+	Name: "colorblind",
+	Letters: [7]string{
+		"\033[1;97mW\033[0m",       // Bright white
+		"\033[1;38;5;227mY\033[0m", // Yellow
+		"\033[1;38;5;166mR\033[0m", // Vermillion
+		"\033[1;38;5;214mO\033[0m", // Orange
+		"\033[1;38;5;33mB\033[0m",  // Blue
+		"\033[1;38;5;36mG\033[0m",  // Bluish green
+		"\033[90m.\033[0m",         // Dark gray for wildcard
+	},
+	Glyphs: [7]string{"⬜", "🟨", "🔺", "🔶", "🟦", "🟢", "⬛"},
+}
+
+// HighContrastScheme draws bold letters on solid backgrounds, readable on
+// both dark and light terminals
+var HighContrastScheme = ColorScheme{
+	Name: "high-contrast",
+	Letters: [7]string{
+		"\033[1;30;107mW\033[0m",      // Black on bright white
+		"\033[1;30;103mY\033[0m",      // Black on bright yellow
+		"\033[1;97;41mR\033[0m",       // White on red
+		"\033[1;30;48;5;208mO\033[0m", // Black on orange
+		"\033[1;97;44mB\033[0m",       // White on blue
+		"\033[1;30;102mG\033[0m",      // Black on bright green
+		"\033[1;97;100m.\033[0m",      // White on gray for wildcard
+	},
+	Glyphs: [7]string{"⬜", "🟨", "🟥", "🟧", "🟦", "🟩", "⬛"},
+}
+
+// colorSchemes lists the presets in the order ColorSchemeNames reports them
+var colorSchemes = []ColorScheme{DefaultScheme, ColorblindScheme, HighContrastScheme}
+
+// activeScheme is used wherever no scheme is given explicitly
+var activeScheme = DefaultScheme
+
+// ParseColorScheme returns the preset with the given name
+func ParseColorScheme(name string) (ColorScheme, error) {
+	for _, scheme := range colorSchemes {
+		if strings.EqualFold(scheme.Name, name) {
+			return scheme, nil
+		}
+	}
+	return DefaultScheme, fmt.Errorf("unknown theme: %s (available: %s)", name, strings.Join(ColorSchemeNames(), ", "))
+}
+
+// ColorSchemeNames returns the names of the preset color schemes
+func ColorSchemeNames() []string {
+	names := make([]string, len(colorSchemes))
+	for i, scheme := range colorSchemes {
+		names[i] = scheme.Name
+	}
+	return names
+}
+
+// SetColorScheme selects the scheme used by Render, UnfoldedString, and the
+// Color formatting methods when no scheme is given explicitly
+func SetColorScheme(scheme ColorScheme) {
+	activeScheme = scheme
+}
+
+// ActiveColorScheme returns the scheme selected with SetColorScheme
+func ActiveColorScheme() ColorScheme {
+	return activeScheme
+}
+
+// Format draws one sticker: a Unicode glyph, an ANSI colored letter, or a
+// plain letter
+func (s ColorScheme) Format(color Color, useColor bool, useUnicode bool) string {
+	switch {
+	case useUnicode:
+		return s.Glyphs[color]
+	case useColor:
+		return s.Letters[color]
+	default:
+		return color.String()
+	}
+}
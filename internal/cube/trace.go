@@ -0,0 +1,40 @@
+package cube
+
+import (
+	"fmt"
+	"io"
+)
+
+// Solver tracing
+//
+// Each solver has a Trace writer. When it is set, the solver writes one
+// line per event as it works: the stage it is on, how many states a search
+// examined, which algorithm it picked, and why it fell back to another
+// method. With no writer set the trace calls return at once, so solving is
+// no slower; searches report only when they finish, never per state.
+
+// tracef writes one trace line to w, or does nothing if w is nil
+func tracef(w io.Writer, format string, args ...interface{}) {
+	if w == nil {
+		return
+	}
+	fmt.Fprintf(w, format+"\n", args...)
+}
+
+// SetTrace sets the trace writer of any of this package's solvers and
+// reports whether solver supports tracing
+func SetTrace(solver Solver, w io.Writer) bool {
+	switch s := solver.(type) {
+	case *BeginnerSolver:
+		s.Trace = w
+	case *CFOPSolver:
+		s.Trace = w
+	case *KociembaSolver:
+		s.Trace = w
+	case *Optimal2x2Solver:
+		s.Trace = w
+	default:
+		return false
+	}
+	return true
+}
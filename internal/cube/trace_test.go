@@ -0,0 +1,52 @@
+package cube
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestSolverTrace(t *testing.T) {
+	moves, _ := ParseScramble("R U R' U'")
+	c := NewCube(3)
+	c.ApplyMoves(moves)
+
+	var trace bytes.Buffer
+	solver := &CFOPSolver{}
+	if !SetTrace(solver, &trace) {
+		t.Fatal("SetTrace(CFOPSolver) reported no tracing support")
+	}
+	if _, err := solver.Solve(c); err != nil {
+		t.Fatalf("Solve() error = %v", err)
+	}
+
+	for _, want := range []string{"cfop: solving cross", "cfop: f2l solved in", "cfop: solving pll"} {
+		if !strings.Contains(trace.String(), want) {
+			t.Errorf("trace is missing %q:\n%s", want, trace.String())
+		}
+	}
+}
+
+func TestSolverTraceOff(t *testing.T) {
+	c := NewCube(2)
+	c.ApplyMove(Move{Face: Right, Clockwise: true})
+
+	// No writer: solving works and nothing is traced
+	solver := &Optimal2x2Solver{}
+	if result, err := solver.Solve(c); err != nil || len(result.Solution) != 1 {
+		t.Errorf("Solve() = %v, %v", result, err)
+	}
+
+	var trace bytes.Buffer
+	SetTrace(solver, &trace)
+	if _, err := solver.Solve(c); err != nil {
+		t.Fatalf("Solve() error = %v", err)
+	}
+	if got := trace.String(); got != "optimal 2x2: state is 1 moves from solved\n" {
+		t.Errorf("trace = %q", got)
+	}
+
+	if SetTrace(&inverseSolver{}, &trace) {
+		t.Error("SetTrace should report no support for a solver without a Trace field")
+	}
+}
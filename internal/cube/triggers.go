@@ -0,0 +1,89 @@
+package cube
+
+import (
+	"sort"
+	"strings"
+	"sync"
+)
+
+// triggerSequences holds the move strings of every multi-move algorithm in
+// the Trigger category, longest first, built once on first use
+var (
+	triggerSequencesOnce sync.Once
+	triggerSequences     [][]string
+)
+
+// GroupTriggers splits a sequence into the triggers from the algorithm
+// database's Trigger category (sexy move, sledgehammer, ...) so a learner
+// can see how to finger it. Scanning left to right, the longest trigger
+// starting at each move becomes a group; moves that start no trigger are
+// returned as single-move groups.
+func GroupTriggers(moves []Move) [][]Move {
+	triggerSequencesOnce.Do(buildTriggerSequences)
+
+	notation := make([]string, len(moves))
+	for i, move := range moves {
+		notation[i] = move.String()
+	}
+
+	var groups [][]Move
+	for i := 0; i < len(moves); {
+		length := 1
+		for _, trigger := range triggerSequences {
+			if sequenceAt(notation, i, trigger) {
+				length = len(trigger)
+				break
+			}
+		}
+		groups = append(groups, moves[i:i+length])
+		i += length
+	}
+	return groups
+}
+
+// FormatTriggerGroups writes groups from GroupTriggers as notation with each
+// trigger in parentheses, e.g. "(R U R' U') (R' F R F') U"
+func FormatTriggerGroups(groups [][]Move) string {
+	parts := make([]string, len(groups))
+	for i, group := range groups {
+		parts[i] = FormatMoves(group)
+		if len(group) > 1 {
+			parts[i] = "(" + parts[i] + ")"
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// buildTriggerSequences collects the distinct Trigger algorithms of two or
+// more moves, sorted longest first
+func buildTriggerSequences() {
+	seen := make(map[string]bool)
+	for _, alg := range GetByCategory("Trigger") {
+		moves, err := ParseScramble(alg.Moves)
+		if err != nil || len(moves) < 2 {
+			continue
+		}
+		key := FormatMoves(moves)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		triggerSequences = append(triggerSequences, strings.Fields(key))
+	}
+	sort.SliceStable(triggerSequences, func(i, j int) bool {
+		return len(triggerSequences[i]) > len(triggerSequences[j])
+	})
+}
+
+// sequenceAt reports whether notation contains trigger starting at index i
+func sequenceAt(notation []string, i int, trigger []string) bool {
+	if i+len(trigger) > len(notation) {
+		return false
+	}
+	for j, move := range trigger {
+		if notation[i+j] != move {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,36 @@
+package cube
+
+import "testing"
+
+func TestGroupTriggers(t *testing.T) {
+	tests := []struct {
+		moves string
+		want  string
+	}{
+		{"R U R' U' R' F R F'", "(R U R' U') (R' F R F')"},
+		{"F R U R' U' F'", "F (R U R' U') F'"},
+		{"R U2 R' U' R U' R'", "R U2 R' U' R U' R'"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.moves, func(t *testing.T) {
+			moves, err := ParseScramble(tt.moves)
+			if err != nil {
+				t.Fatalf("ParseScramble(%q) error = %v", tt.moves, err)
+			}
+			groups := GroupTriggers(moves)
+			if got := FormatTriggerGroups(groups); got != tt.want {
+				t.Errorf("GroupTriggers(%q) = %q, want %q", tt.moves, got, tt.want)
+			}
+
+			var regrouped []Move
+			for _, group := range groups {
+				regrouped = append(regrouped, group...)
+			}
+			if FormatMoves(regrouped) != FormatMoves(moves) {
+				t.Errorf("Groups %v do not reassemble into %q", groups, tt.moves)
+			}
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package cube
+
+import "fmt"
+
+// Validate reports whether the stickers form a state that legal moves can
+// reach from solved. It returns nil for a valid cube and an
+// *UnsolvableError naming the first problem found otherwise.
+//
+// Every size is checked for sticker counts. 2x2 and 3x3 cubes are also
+// checked piece by piece, which catches a single twisted corner, a flipped
+// edge, or two swapped pieces. Cubes with wildcard stickers can't be
+// checked and are reported invalid.
+func (c *Cube) Validate() error {
+	invalid := func(format string, args ...interface{}) error {
+		return &UnsolvableError{Reason: fmt.Sprintf(format, args...)}
+	}
+
+	counts := make(map[Color]int)
+	for face := 0; face < 6; face++ {
+		for row := 0; row < c.Size; row++ {
+			for col := 0; col < c.Size; col++ {
+				counts[c.Faces[face][row][col]]++
+			}
+		}
+	}
+	if counts[Grey] > 0 {
+		return invalid("cube has %d wildcard stickers", counts[Grey])
+	}
+	for color := White; color <= Green; color++ {
+		if counts[color] != c.Size*c.Size {
+			return invalid("cube has %d %s stickers, want %d", counts[color], color.String(), c.Size*c.Size)
+		}
+	}
+
+	switch c.Size {
+	case 2:
+		if _, _, ok := twoByTwoCoordinates(c); !ok {
+			return invalid("corners do not form a reachable 2x2 state")
+		}
+	case 3:
+		return c.validate3x3()
+	}
+	return nil
+}
+
+// IsValid reports whether Validate finds no problems
+func (c *Cube) IsValid() bool {
+	return c.Validate() == nil
+}
+
+// validate3x3 checks the centers, then that every corner and edge appears
+// once with legal twist, flip, and permutation parity
+func (c *Cube) validate3x3() error {
+	invalid := func(format string, args ...interface{}) error {
+		return &UnsolvableError{Reason: fmt.Sprintf(format, args...)}
+	}
+
+	centersOK := false
+	for _, solved := range solvedCubeOrientations(3) {
+		match := true
+		for face := 0; face < 6; face++ {
+			if c.Faces[face][1][1] != solved.Faces[face][1][1] {
+				match = false
+				break
+			}
+		}
+		if match {
+			centersOK = true
+			break
+		}
+	}
+	if !centersOK {
+		return invalid("centers are not arranged as on a real cube")
+	}
+
+	cp, co := c.CornerPermutation(), c.CornerOrientation()
+	ep, eo := c.EdgePermutation(), c.EdgeOrientation()
+	for pos, piece := range cp {
+		if piece < 0 {
+			return invalid("%s corner stickers do not form a corner", cornerNames[pos])
+		}
+	}
+	for pos, piece := range ep {
+		if piece < 0 {
+			return invalid("%s edge stickers do not form an edge", edgeNames[pos])
+		}
+	}
+
+	cornerParity, ok := permutationParity(cp[:])
+	if !ok {
+		return invalid("a corner appears more than once")
+	}
+	edgeParity, ok := permutationParity(ep[:])
+	if !ok {
+		return invalid("an edge appears more than once")
+	}
+
+	twist, flip := 0, 0
+	for _, t := range co {
+		twist += t
+	}
+	for _, f := range eo {
+		flip += f
+	}
+	switch {
+	case twist%3 != 0:
+		return invalid("corner twist is off by %d (a twisted corner)", twist%3)
+	case flip%2 != 0:
+		return invalid("edge orientation is odd (a flipped edge)")
+	case cornerParity != edgeParity:
+		return invalid("permutation parity is odd (two pieces swapped)")
+	}
+	return nil
+}
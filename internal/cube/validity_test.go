@@ -0,0 +1,66 @@
+package cube
+
+import "testing"
+
+func TestValidate(t *testing.T) {
+	scrambled := func(size int, scramble string) *Cube {
+		c := NewCube(size)
+		moves, err := ParseScramble(scramble)
+		if err != nil {
+			t.Fatalf("ParseScramble(%q) error = %v", scramble, err)
+		}
+		c.ApplyMoves(moves)
+		return c
+	}
+
+	flipped := scrambled(3, "R U F")
+	flipped.Faces[Up][2][1], flipped.Faces[Front][0][1] = flipped.Faces[Front][0][1], flipped.Faces[Up][2][1]
+
+	twisted := NewCube(3)
+	twisted.Faces[Up][2][2], twisted.Faces[Right][0][0], twisted.Faces[Front][0][2] =
+		twisted.Faces[Front][0][2], twisted.Faces[Up][2][2], twisted.Faces[Right][0][0]
+
+	swapped := NewCube(3)
+	swapped.Faces[Up][2][1], swapped.Faces[Up][1][2] = swapped.Faces[Up][1][2], swapped.Faces[Up][2][1]
+	swapped.Faces[Front][0][1], swapped.Faces[Right][0][1] = swapped.Faces[Right][0][1], swapped.Faces[Front][0][1]
+
+	twisted2x2 := NewCube(2)
+	twisted2x2.Faces[Up][1][1], twisted2x2.Faces[Right][0][0], twisted2x2.Faces[Front][0][1] =
+		twisted2x2.Faces[Front][0][1], twisted2x2.Faces[Up][1][1], twisted2x2.Faces[Right][0][0]
+
+	wildcard := NewCube(3)
+	wildcard.Faces[Down][0][0] = Grey
+
+	tests := []struct {
+		name  string
+		cube  *Cube
+		valid bool
+	}{
+		{"solved", NewCube(3), true},
+		{"scrambled", scrambled(3, "R U R' F2 D B' L"), true},
+		{"rotated", scrambled(3, "x y R U"), true},
+		{"scrambled 2x2", scrambled(2, "R U F' R2"), true},
+		{"scrambled 4x4", scrambled(4, "Rw U 2R"), true},
+		{"flipped edge", flipped, false},
+		{"twisted corner", twisted, false},
+		{"swapped edges", swapped, false},
+		{"twisted 2x2 corner", twisted2x2, false},
+		{"wildcard", wildcard, false},
+	}
+
+	for _, tt := range tests {
+		err := tt.cube.Validate()
+		if (err == nil) != tt.valid {
+			t.Errorf("%s: Validate() = %v, want valid %t", tt.name, err, tt.valid)
+		}
+	}
+}
+
+func TestOptimalLengthAnyOrientation(t *testing.T) {
+	c := NewCube(3)
+	moves, _ := ParseScramble("R U F y x2")
+	c.ApplyMoves(moves)
+	if got, found := OptimalLength(c, 4); !found || got != 3 {
+		t.Errorf("OptimalLength = %d, %t, want 3, true", got, found)
+	}
+}
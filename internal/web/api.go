@@ -0,0 +1,633 @@
+// Package web serves the cube solver as a versioned JSON API.
+//
+// Every endpoint takes and returns JSON. Failures are reported as an
+// ErrorResponse with a status code that follows the error's type:
+//
+//	400 Bad Request           malformed JSON, a bad move, CFEN, or option
+//	422 Unprocessable Entity  an unsolvable state, a search that gave up, or
+//	                          no solution within the request's max_moves
+//	500 Internal Server Error a solver bug, such as a solution that turns
+//	                          out not to solve the cube
+//
+// Requests with the wrong HTTP method get the router's plain 405 response.
+//
+// A virtual cube client can take moves from the keyboard: it reads the key
+// layout from GET /api/v1/keymap and sends what the user types to POST
+// /api/v1/keystrokes, which applies the keys' moves.
+//
+// GET /ws streams a CFOP solve over a WebSocket: the client sends a
+// StreamRequest and receives a StreamEvent as each stage completes, then
+// the solution.
+//
+// Two unversioned endpoints are meant for deployment: GET /health answers
+// as long as the server is up, and GET /ready also solves small cubes end
+// to end, answering 503 if the solvers or algorithm database are broken.
+package web
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ehrlich-b/cube/internal/cfen"
+	"github.com/ehrlich-b/cube/internal/cube"
+)
+
+const (
+	// maxRequestBytes bounds the size of a request body
+	maxRequestBytes = 1 << 20
+
+	// maxDimension bounds the cube size a request may ask for
+	maxDimension = 20
+
+	// maxScrambleLength bounds the length of a generated scramble
+	maxScrambleLength = 1000
+
+	// maxSolutionCount bounds how many solutions a solve may ask for
+	maxSolutionCount = 10
+
+	// defaultSearchLimit is how many matches an algorithm search returns
+	// unless it asks for a different limit
+	defaultSearchLimit = 10
+)
+
+// SolveRequest is the body of POST /api/v1/solve
+type SolveRequest struct {
+	Scramble  string `json:"scramble"`            // Moves applied to the start state
+	Algorithm string `json:"algorithm,omitempty"` // Solver name (default cfop, or optimal2 on a 2x2)
+	Dimension int    `json:"dimension,omitempty"` // Cube size (default 3, or the start CFEN's size)
+	Start     string `json:"start,omitempty"`     // Starting state as CFEN (default solved)
+	MaxMoves  int    `json:"max_moves,omitempty"` // Longest acceptable solution (default no limit)
+}
+
+// SolveResponse is the result of POST /api/v1/solve
+type SolveResponse struct {
+	Solution      string   `json:"solution"`               // Space-separated moves
+	Steps         int      `json:"steps"`                  // Number of moves
+	DurationMS    float64  `json:"duration_ms"`            // Time spent solving
+	NodesExamined int      `json:"nodes_examined"`         // Nodes the solver's A* and depth-first searches expanded
+	StatesVisited int      `json:"states_visited"`         // States the solver's breadth-first searches visited
+	CFEN          string   `json:"cfen"`                   // State after the solution
+	Solved        bool     `json:"solved"`                 // Whether the solution solves the cube
+	Alternatives  []string `json:"alternatives,omitempty"` // Other solutions, shortest first, when ?count= asks for them
+}
+
+// ScrambleRequest is the body of POST /api/v1/scramble
+type ScrambleRequest struct {
+	Dimension int   `json:"dimension,omitempty"` // Cube size (default 3)
+	Length    int   `json:"length,omitempty"`    // Number of moves (default 25)
+	Seed      int64 `json:"seed,omitempty"`      // Random seed for a reproducible scramble
+}
+
+// ScrambleResponse is the result of POST /api/v1/scramble
+type ScrambleResponse struct {
+	Scramble string `json:"scramble"` // Space-separated moves
+	CFEN     string `json:"cfen"`     // State after the scramble
+}
+
+// VerifyRequest is the body of POST /api/v1/verify
+type VerifyRequest struct {
+	Algorithm string `json:"algorithm"`        // Moves to check
+	Start     string `json:"start,omitempty"`  // Starting state as CFEN (default solved 3x3)
+	Target    string `json:"target,omitempty"` // Target pattern as CFEN, wildcards allowed (default solved)
+}
+
+// VerifyResponse is the result of POST /api/v1/verify
+type VerifyResponse struct {
+	Matches bool   `json:"matches"` // Whether the result matches the target
+	Moves   int    `json:"moves"`   // Number of moves in the algorithm
+	CFEN    string `json:"cfen"`    // State after the algorithm
+}
+
+// AnimateRequest is the body of POST /api/v1/animate
+type AnimateRequest struct {
+	Scramble  string `json:"scramble"`            // Moves applied to a solved 3x3
+	Algorithm string `json:"algorithm,omitempty"` // Solver name (default cfop)
+}
+
+// AnimateResponse is the result of POST /api/v1/animate
+type AnimateResponse struct {
+	Keyframes []cfen.Keyframe `json:"keyframes"` // The scrambled state, then one keyframe per solution move
+}
+
+// KeymapResponse is the result of GET /api/v1/keymap
+type KeymapResponse struct {
+	Keymap map[string]string `json:"keymap"` // Key -> move, such as "j": "U"
+}
+
+// KeystrokesRequest is the body of POST /api/v1/keystrokes
+type KeystrokesRequest struct {
+	Keys      string            `json:"keys"`                // Typed keys; spaces are ignored
+	Keymap    map[string]string `json:"keymap,omitempty"`    // Key -> move (default the GET /api/v1/keymap layout)
+	Dimension int               `json:"dimension,omitempty"` // Cube size (default 3, or the start CFEN's size)
+	Start     string            `json:"start,omitempty"`     // Starting state as CFEN (default solved)
+}
+
+// KeystrokesResponse is the result of POST /api/v1/keystrokes
+type KeystrokesResponse struct {
+	Moves  string `json:"moves"`  // Space-separated moves the keys turn
+	CFEN   string `json:"cfen"`   // State after the moves
+	Solved bool   `json:"solved"` // Whether the cube is solved
+}
+
+// AlgorithmResponse is one entry of GET /api/v1/algorithms
+type AlgorithmResponse struct {
+	Name        string `json:"name"`
+	CaseID      string `json:"case_id,omitempty"`
+	Category    string `json:"category"`
+	Moves       string `json:"moves"`
+	MoveCount   int    `json:"move_count"`
+	Description string `json:"description,omitempty"`
+}
+
+// ErrorResponse is the body of every failed request
+type ErrorResponse struct {
+	Error string `json:"error"`
+	Kind  string `json:"kind"` // "bad_request", "parse", "unsolvable", "search_limit", "unavailable", or "internal"
+}
+
+// StatusResponse is the body of a successful GET /health or GET /ready
+type StatusResponse struct {
+	Status string `json:"status"` // "ok" or "ready"
+}
+
+// readinessCheck is a solve GET /ready makes: a short seeded scramble of a
+// cube, solved by the named solver
+type readinessCheck struct {
+	dimension int
+	solver    string
+}
+
+// readinessChecks cover each cube size with a solver that handles it quickly
+var readinessChecks = []readinessCheck{
+	{2, "optimal2"},
+	{3, "cfop"},
+}
+
+// NewAPI returns a handler serving the v1 API:
+//
+//	POST /api/v1/solve       SolveRequest    -> SolveResponse (?count= for alternatives)
+//	POST /api/v1/scramble    ScrambleRequest -> ScrambleResponse
+//	POST /api/v1/verify      VerifyRequest   -> VerifyResponse
+//	GET  /api/v1/algorithms  ?category=&q=&limit= -> []AlgorithmResponse
+//	POST /api/v1/animate     AnimateRequest  -> AnimateResponse
+//	GET  /api/v1/keymap                      -> KeymapResponse
+//	POST /api/v1/keystrokes  KeystrokesRequest -> KeystrokesResponse
+//	GET  /ws                 StreamRequest   -> StreamEvent per CFOP stage, then the solution (WebSocket)
+//	GET  /health                             -> StatusResponse
+//	GET  /ready                              -> StatusResponse, or 503
+func NewAPI() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /api/v1/solve", handleSolve)
+	mux.HandleFunc("POST /api/v1/scramble", handleScramble)
+	mux.HandleFunc("POST /api/v1/verify", handleVerify)
+	mux.HandleFunc("GET /api/v1/algorithms", handleAlgorithms)
+	mux.HandleFunc("POST /api/v1/animate", handleAnimate)
+	mux.HandleFunc("GET /api/v1/keymap", handleKeymap)
+	mux.HandleFunc("POST /api/v1/keystrokes", handleKeystrokes)
+	mux.HandleFunc("GET /ws", handleStream)
+	mux.HandleFunc("GET /health", handleHealth)
+	mux.HandleFunc("GET /ready", handleReady)
+	return mux
+}
+
+func handleSolve(w http.ResponseWriter, r *http.Request) {
+	var req SolveRequest
+	if !decode(w, r, &req) {
+		return
+	}
+
+	count := 1
+	if value := r.URL.Query().Get("count"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 || n > maxSolutionCount {
+			writeError(w, badRequest(fmt.Errorf("count must be between 1 and %d", maxSolutionCount)))
+			return
+		}
+		count = n
+	}
+
+	if req.MaxMoves < 0 {
+		writeError(w, badRequest(errors.New("max_moves must not be negative")))
+		return
+	}
+
+	c, err := startCube(req.Start, req.Dimension)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	moves, err := cube.ParseScrambleForSize(req.Scramble, c.Size)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	c.ApplyMoves(moves)
+
+	algorithm := req.Algorithm
+	if algorithm == "" {
+		algorithm = defaultSolver(c.Size)
+	}
+	solver, err := cube.GetSolver(algorithm)
+	if err != nil {
+		writeError(w, badRequest(err))
+		return
+	}
+
+	results, err := cube.SolveN(solver, c, count)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if req.MaxMoves > 0 {
+		results = withinLength(results, req.MaxMoves)
+		if len(results) == 0 {
+			writeError(w, &cube.SearchLimitError{MaxDepth: req.MaxMoves})
+			return
+		}
+	}
+	result := results[0]
+	var alternatives []string
+	for _, alternative := range results[1:] {
+		alternatives = append(alternatives, cube.FormatMoves(alternative.Solution))
+	}
+
+	c.ApplyMoves(result.Solution)
+	state, err := cfen.GenerateCFEN(c)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, SolveResponse{
+		Solution:      cube.FormatMoves(result.Solution),
+		Steps:         result.Steps,
+		DurationMS:    float64(result.Duration.Microseconds()) / 1000,
+		NodesExamined: result.NodesExamined,
+		StatesVisited: result.StatesVisited,
+		CFEN:          state,
+		Solved:        c.IsSolved(),
+		Alternatives:  alternatives,
+	})
+}
+
+func handleScramble(w http.ResponseWriter, r *http.Request) {
+	var req ScrambleRequest
+	if !decode(w, r, &req) {
+		return
+	}
+
+	dimension, err := checkDimension(req.Dimension)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if req.Length < 0 || req.Length > maxScrambleLength {
+		writeError(w, badRequest(fmt.Errorf("length must be between 0 and %d", maxScrambleLength)))
+		return
+	}
+
+	seed := req.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	moves, err := cube.GenerateScramble(dimension, cube.ScrambleOptions{
+		Length: req.Length,
+		Rand:   rand.New(rand.NewSource(seed)),
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	c := cube.NewCube(dimension)
+	c.ApplyMoves(moves)
+	state, err := cfen.GenerateCFEN(c)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, ScrambleResponse{Scramble: cube.FormatMoves(moves), CFEN: state})
+}
+
+func handleVerify(w http.ResponseWriter, r *http.Request) {
+	var req VerifyRequest
+	if !decode(w, r, &req) {
+		return
+	}
+
+	c, err := startCube(req.Start, 0)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	target := req.Target
+	if target == "" {
+		target = cfen.SolvedCFEN(c.Size)
+	}
+	targetState, err := cfen.ParseCFEN(target)
+	if err != nil {
+		writeError(w, badRequest(fmt.Errorf("target: %w", err)))
+		return
+	}
+	if targetState.Dimension != c.Size {
+		writeError(w, badRequest(fmt.Errorf("start and target dimensions must match (%d vs %d)", c.Size, targetState.Dimension)))
+		return
+	}
+
+	moves, err := cube.ParseScrambleForSize(req.Algorithm, c.Size)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	c.ApplyMoves(moves)
+
+	matches, err := targetState.MatchesCube(c)
+	if err != nil {
+		writeError(w, badRequest(err))
+		return
+	}
+	state, err := cfen.GenerateCFEN(c)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, VerifyResponse{Matches: matches, Moves: len(moves), CFEN: state})
+}
+
+// defaultSolver names the solver used when a request doesn't pick one: one
+// that finishes the solve for the cube's size
+func defaultSolver(size int) string {
+	if size == 2 {
+		return "optimal2"
+	}
+	return "cfop"
+}
+
+func handleAlgorithms(w http.ResponseWriter, r *http.Request) {
+	category := r.URL.Query().Get("category")
+	query := r.URL.Query().Get("q")
+
+	limit := defaultSearchLimit
+	if value := r.URL.Query().Get("limit"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 0 {
+			writeError(w, badRequest(errors.New("limit must be 0 (all matches) or more")))
+			return
+		}
+		limit = n
+	}
+
+	var algorithms []cube.Algorithm
+	switch {
+	case query != "":
+		algorithms = cube.LookupAlgorithmN(query, limit, 0)
+	case category != "":
+		algorithms = cube.GetByCategory(category)
+	default:
+		algorithms = cube.GetAllAlgorithms()
+	}
+
+	response := []AlgorithmResponse{}
+	for _, alg := range algorithms {
+		if category != "" && !strings.EqualFold(alg.Category, category) {
+			continue
+		}
+		response = append(response, AlgorithmResponse{
+			Name:        alg.Name,
+			CaseID:      alg.CaseID,
+			Category:    alg.Category,
+			Moves:       alg.Moves,
+			MoveCount:   alg.MoveCount,
+			Description: alg.Description,
+		})
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+func handleAnimate(w http.ResponseWriter, r *http.Request) {
+	var req AnimateRequest
+	if !decode(w, r, &req) {
+		return
+	}
+
+	algorithm := req.Algorithm
+	if algorithm == "" {
+		algorithm = defaultSolver(3)
+	}
+	if _, err := cube.GetSolver(algorithm); err != nil {
+		writeError(w, badRequest(err))
+		return
+	}
+
+	keyframes, err := cfen.SolveToKeyframes(req.Scramble, algorithm)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, AnimateResponse{Keyframes: keyframes})
+}
+
+func handleKeymap(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, KeymapResponse{Keymap: cube.FormatKeymap(cube.DefaultKeymap())})
+}
+
+func handleKeystrokes(w http.ResponseWriter, r *http.Request) {
+	var req KeystrokesRequest
+	if !decode(w, r, &req) {
+		return
+	}
+
+	keymap := cube.DefaultKeymap()
+	if req.Keymap != nil {
+		var err error
+		if keymap, err = cube.ParseKeymap(req.Keymap); err != nil {
+			writeError(w, badRequest(err))
+			return
+		}
+	}
+	moves, err := cube.MovesFromKeystrokes(req.Keys, keymap)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	c, err := startCube(req.Start, req.Dimension)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	// Parse the moves again to reject any the cube is too small for
+	if moves, err = cube.ParseScrambleForSize(cube.FormatMoves(moves), c.Size); err != nil {
+		writeError(w, err)
+		return
+	}
+	c.ApplyMoves(moves)
+	state, err := cfen.GenerateCFEN(c)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, KeystrokesResponse{Moves: cube.FormatMoves(moves), CFEN: state, Solved: c.IsSolved()})
+}
+
+func handleHealth(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, StatusResponse{Status: "ok"})
+}
+
+func handleReady(w http.ResponseWriter, r *http.Request) {
+	if err := checkReady(); err != nil {
+		writeJSON(w, http.StatusServiceUnavailable, ErrorResponse{Error: err.Error(), Kind: "unavailable"})
+		return
+	}
+	writeJSON(w, http.StatusOK, StatusResponse{Status: "ready"})
+}
+
+// checkReady makes sure the algorithm database is loaded and every
+// readiness check's solver solves its scramble, turning a panic into an
+// error
+func checkReady() (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("solver panicked: %v", r)
+		}
+	}()
+
+	if len(cube.GetAllAlgorithms()) == 0 {
+		return errors.New("algorithm database is empty")
+	}
+
+	for _, check := range readinessChecks {
+		moves, err := cube.GenerateScramble(check.dimension, cube.ScrambleOptions{
+			Length: 3,
+			Rand:   rand.New(rand.NewSource(1)),
+		})
+		if err != nil {
+			return err
+		}
+		c := cube.NewCube(check.dimension)
+		c.ApplyMoves(moves)
+
+		solver, err := cube.GetSolver(check.solver)
+		if err != nil {
+			return err
+		}
+		if _, err := cube.SolveVerified(solver, c); err != nil {
+			return fmt.Errorf("%s solver on %s: %w", check.solver, cube.FormatMoves(moves), err)
+		}
+	}
+	return nil
+}
+
+// withinLength returns the results whose solutions are at most maxMoves
+// moves long
+func withinLength(results []*cube.SolverResult, maxMoves int) []*cube.SolverResult {
+	var kept []*cube.SolverResult
+	for _, result := range results {
+		if len(result.Solution) <= maxMoves {
+			kept = append(kept, result)
+		}
+	}
+	return kept
+}
+
+// startCube returns the cube a request starts from: the CFEN state if one
+// is given, otherwise a solved cube of the requested size
+func startCube(start string, dimension int) (*cube.Cube, error) {
+	if start == "" {
+		size, err := checkDimension(dimension)
+		if err != nil {
+			return nil, err
+		}
+		return cube.NewCube(size), nil
+	}
+
+	state, err := cfen.ParseCFEN(start)
+	if err != nil {
+		return nil, badRequest(fmt.Errorf("start: %w", err))
+	}
+	if dimension != 0 && dimension != state.Dimension {
+		return nil, badRequest(fmt.Errorf("CFEN dimension %d doesn't match dimension %d", state.Dimension, dimension))
+	}
+	if _, err := checkDimension(state.Dimension); err != nil {
+		return nil, err
+	}
+	return state.ToCube()
+}
+
+// checkDimension applies the default cube size and rejects sizes the API
+// won't build
+func checkDimension(dimension int) (int, error) {
+	if dimension == 0 {
+		return 3, nil
+	}
+	if dimension < cube.MinSize || dimension > maxDimension {
+		return 0, badRequest(fmt.Errorf("dimension must be between %d and %d", cube.MinSize, maxDimension))
+	}
+	return dimension, nil
+}
+
+// requestError is a request the API rejects before doing any cube work
+type requestError struct {
+	err error
+}
+
+func (e *requestError) Error() string { return e.err.Error() }
+func (e *requestError) Unwrap() error { return e.err }
+
+func badRequest(err error) error {
+	return &requestError{err: err}
+}
+
+// decode reads a JSON request body into v, writing a 400 response and
+// returning false if it can't
+func decode(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestBytes))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(v); err != nil {
+		writeError(w, badRequest(fmt.Errorf("invalid request body: %w", err)))
+		return false
+	}
+	return true
+}
+
+// writeError reports err with the status code its type calls for
+func writeError(w http.ResponseWriter, err error) {
+	status, kind := errorStatus(err)
+	writeJSON(w, status, ErrorResponse{Error: err.Error(), Kind: kind})
+}
+
+// errorStatus returns the status code and ErrorResponse kind for err
+func errorStatus(err error) (int, string) {
+	var (
+		parseErr   *cube.ParseError
+		unsolvable *cube.UnsolvableError
+		limit      *cube.SearchLimitError
+		request    *requestError
+	)
+
+	status, kind := http.StatusInternalServerError, "internal"
+	switch {
+	case errors.As(err, &parseErr):
+		status, kind = http.StatusBadRequest, "parse"
+	case errors.As(err, &request):
+		status, kind = http.StatusBadRequest, "bad_request"
+	case errors.As(err, &unsolvable):
+		status, kind = http.StatusUnprocessableEntity, "unsolvable"
+	case errors.As(err, &limit):
+		status, kind = http.StatusUnprocessableEntity, "search_limit"
+	}
+	return status, kind
+}
+
+// writeJSON writes v as the JSON response body
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,250 @@
+package web
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ehrlich-b/cube/internal/cfen"
+)
+
+// call sends a request to the API and decodes the JSON response into out
+func call(t *testing.T, method, path, body string, out interface{}) int {
+	t.Helper()
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	rec := httptest.NewRecorder()
+	NewAPI().ServeHTTP(rec, req)
+
+	if out != nil && rec.Code != http.StatusMethodNotAllowed {
+		if err := json.Unmarshal(rec.Body.Bytes(), out); err != nil {
+			t.Fatalf("%s %s: decoding %q: %v", method, path, rec.Body.String(), err)
+		}
+	}
+	return rec.Code
+}
+
+func TestSolve(t *testing.T) {
+	var resp SolveResponse
+	if code := call(t, "POST", "/api/v1/solve", `{"scramble": "R U F", "dimension": 2}`, &resp); code != http.StatusOK {
+		t.Fatalf("status = %d", code)
+	}
+	if !resp.Solved || resp.Steps != 3 || resp.CFEN != "YB|Y4/R4/B4/W4/O4/G4" {
+		t.Errorf("response = %+v", resp)
+	}
+}
+
+func TestSolveDefaultSolver(t *testing.T) {
+	var resp SolveResponse
+	if code := call(t, "POST", "/api/v1/solve", `{"scramble": "R U"}`, &resp); code != http.StatusOK {
+		t.Fatalf("status = %d %+v", code, resp)
+	}
+	if !resp.Solved || resp.CFEN != "YB|Y9/R9/B9/W9/O9/G9" {
+		t.Errorf("response = %+v", resp)
+	}
+}
+
+func TestSolveMaxMoves(t *testing.T) {
+	var resp SolveResponse
+	if code := call(t, "POST", "/api/v1/solve", `{"scramble": "R U F", "dimension": 2, "max_moves": 3}`, &resp); code != http.StatusOK {
+		t.Fatalf("status = %d", code)
+	}
+	if !resp.Solved || resp.Steps > 3 {
+		t.Errorf("response = %+v", resp)
+	}
+}
+
+func TestSolveAlternatives(t *testing.T) {
+	var resp SolveResponse
+	if code := call(t, "POST", "/api/v1/solve?count=3", `{"scramble": "R U F", "dimension": 2}`, &resp); code != http.StatusOK {
+		t.Fatalf("status = %d", code)
+	}
+	if !resp.Solved || len(resp.Alternatives) != 2 {
+		t.Fatalf("response = %+v", resp)
+	}
+	for _, alternative := range resp.Alternatives {
+		if alternative == resp.Solution {
+			t.Errorf("alternative %q repeats the solution", alternative)
+		}
+	}
+}
+
+func TestScramble(t *testing.T) {
+	var first, second ScrambleResponse
+	call(t, "POST", "/api/v1/scramble", `{"length": 12, "seed": 7}`, &first)
+	call(t, "POST", "/api/v1/scramble", `{"length": 12, "seed": 7}`, &second)
+	if len(strings.Fields(first.Scramble)) != 12 {
+		t.Errorf("scramble %q does not have 12 moves", first.Scramble)
+	}
+	if first != second {
+		t.Errorf("same seed gave %+v and %+v", first, second)
+	}
+}
+
+func TestVerify(t *testing.T) {
+	var resp VerifyResponse
+	body := `{"algorithm": "R U R' U R U2 R'", "start": "LL:YB|RYBY5O/G2Y/GBY/BR2/O2Y"}`
+	if code := call(t, "POST", "/api/v1/verify", body, &resp); code != http.StatusOK {
+		t.Fatalf("status = %d", code)
+	}
+	if !resp.Matches || resp.Moves != 7 {
+		t.Errorf("response = %+v", resp)
+	}
+}
+
+func TestAlgorithms(t *testing.T) {
+	var resp []AlgorithmResponse
+	if code := call(t, "GET", "/api/v1/algorithms?category=PLL", "", &resp); code != http.StatusOK {
+		t.Fatalf("status = %d", code)
+	}
+	if len(resp) == 0 {
+		t.Fatal("no PLL algorithms returned")
+	}
+	for _, alg := range resp {
+		if alg.Category != "PLL" {
+			t.Errorf("%s has category %s", alg.Name, alg.Category)
+		}
+	}
+}
+
+func TestAlgorithmsSearchLimit(t *testing.T) {
+	tests := []struct {
+		query string
+		count func(n int) bool
+	}{
+		{"?q=r", func(n int) bool { return n == defaultSearchLimit }},
+		{"?q=r&limit=3", func(n int) bool { return n == 3 }},
+		{"?q=r&limit=0", func(n int) bool { return n > defaultSearchLimit }},
+	}
+
+	for _, tt := range tests {
+		var resp []AlgorithmResponse
+		if code := call(t, "GET", "/api/v1/algorithms"+tt.query, "", &resp); code != http.StatusOK {
+			t.Fatalf("%s: status = %d", tt.query, code)
+		}
+		if !tt.count(len(resp)) {
+			t.Errorf("%s returned %d algorithms", tt.query, len(resp))
+		}
+	}
+}
+
+func TestAnimate(t *testing.T) {
+	var resp AnimateResponse
+	if code := call(t, "POST", "/api/v1/animate", `{"scramble": "R U", "algorithm": "cfop"}`, &resp); code != http.StatusOK {
+		t.Fatalf("status = %d", code)
+	}
+	want, err := cfen.SolveToKeyframes("R U", "cfop")
+	if err != nil {
+		t.Fatalf("SolveToKeyframes() error = %v", err)
+	}
+	if !reflect.DeepEqual(resp.Keyframes, want) {
+		t.Errorf("keyframes = %+v, want %+v", resp.Keyframes, want)
+	}
+	if n := len(resp.Keyframes); n < 2 || resp.Keyframes[0].Move != "" || resp.Keyframes[n-1].CFEN != "YB|Y9/R9/B9/W9/O9/G9" {
+		t.Errorf("keyframes should run from the scramble to solved: %+v", resp.Keyframes)
+	}
+}
+
+func TestAnimateDefaultSolver(t *testing.T) {
+	var resp AnimateResponse
+	if code := call(t, "POST", "/api/v1/animate", `{"scramble": "R U"}`, &resp); code != http.StatusOK {
+		t.Fatalf("status = %d", code)
+	}
+	if n := len(resp.Keyframes); n < 2 || resp.Keyframes[n-1].CFEN != "YB|Y9/R9/B9/W9/O9/G9" {
+		t.Errorf("keyframes should run from the scramble to solved: %+v", resp.Keyframes)
+	}
+}
+
+func TestKeymap(t *testing.T) {
+	var resp KeymapResponse
+	if code := call(t, "GET", "/api/v1/keymap", "", &resp); code != http.StatusOK {
+		t.Fatalf("status = %d", code)
+	}
+	if resp.Keymap["j"] != "U" || resp.Keymap["k"] != "R'" {
+		t.Errorf("keymap = %v", resp.Keymap)
+	}
+}
+
+func TestKeystrokes(t *testing.T) {
+	var resp KeystrokesResponse
+	if code := call(t, "POST", "/api/v1/keystrokes", `{"keys": "ijkf"}`, &resp); code != http.StatusOK {
+		t.Fatalf("status = %d", code)
+	}
+	if resp.Moves != "R U R' U'" || resp.Solved {
+		t.Errorf("response = %+v", resp)
+	}
+
+	// A custom keymap, undoing R from a CFEN start
+	body := `{"keys": "z", "keymap": {"z": "R'"}, "start": "YB|Y2BY2BY2B/R9/B2WB2WB2W/W2GW2GW2G/O9/YG2YG2YG2"}`
+	if code := call(t, "POST", "/api/v1/keystrokes", body, &resp); code != http.StatusOK {
+		t.Fatalf("status = %d", code)
+	}
+	if resp.Moves != "R'" || !resp.Solved {
+		t.Errorf("response = %+v", resp)
+	}
+}
+
+func TestHealthAndReady(t *testing.T) {
+	var resp StatusResponse
+	if code := call(t, "GET", "/health", "", &resp); code != http.StatusOK || resp.Status != "ok" {
+		t.Errorf("GET /health = %d %+v", code, resp)
+	}
+	if code := call(t, "GET", "/ready", "", &resp); code != http.StatusOK || resp.Status != "ready" {
+		t.Errorf("GET /ready = %d %+v", code, resp)
+	}
+}
+
+func TestReadyFailure(t *testing.T) {
+	saved := readinessChecks
+	defer func() { readinessChecks = saved }()
+
+	// The beginner solver can't handle 2x2 cubes
+	readinessChecks = append(readinessChecks, readinessCheck{2, "beginner"})
+
+	var resp ErrorResponse
+	if code := call(t, "GET", "/ready", "", &resp); code != http.StatusServiceUnavailable || resp.Kind != "unavailable" {
+		t.Errorf("GET /ready = %d %+v, want 503", code, resp)
+	}
+}
+
+func TestErrors(t *testing.T) {
+	tests := []struct {
+		name, method, path, body string
+		status                   int
+		kind                     string
+	}{
+		{"bad move", "POST", "/api/v1/solve", `{"scramble": "R Q"}`, http.StatusBadRequest, "parse"},
+		{"move too big for the cube", "POST", "/api/v1/solve", `{"scramble": "R 3R"}`, http.StatusBadRequest, "parse"},
+		{"bad JSON", "POST", "/api/v1/solve", `{"scramble": `, http.StatusBadRequest, "bad_request"},
+		{"unknown field", "POST", "/api/v1/scramble", `{"size": 3}`, http.StatusBadRequest, "bad_request"},
+		{"bad count", "POST", "/api/v1/solve?count=0", `{"scramble": "R"}`, http.StatusBadRequest, "bad_request"},
+		{"bad search limit", "GET", "/api/v1/algorithms?q=sune&limit=-1", "", http.StatusBadRequest, "bad_request"},
+		{"unknown solver", "POST", "/api/v1/solve", `{"scramble": "R", "algorithm": "magic"}`, http.StatusBadRequest, "bad_request"},
+		{"too big", "POST", "/api/v1/scramble", `{"dimension": 500}`, http.StatusBadRequest, "bad_request"},
+		{"huge solve dimension", "POST", "/api/v1/solve", `{"scramble": "R", "dimension": 1000000}`, http.StatusBadRequest, "bad_request"},
+		{"negative max_moves", "POST", "/api/v1/solve", `{"scramble": "R", "max_moves": -1}`, http.StatusBadRequest, "bad_request"},
+		{"over max_moves", "POST", "/api/v1/solve", `{"scramble": "R U F", "dimension": 2, "max_moves": 2}`, http.StatusUnprocessableEntity, "search_limit"},
+		{"unsolvable", "POST", "/api/v1/solve", `{"scramble": "R", "dimension": 4, "algorithm": "cfop"}`, http.StatusUnprocessableEntity, "unsolvable"},
+		{"animate bad move", "POST", "/api/v1/animate", `{"scramble": "R Q"}`, http.StatusBadRequest, "parse"},
+		{"animate unknown solver", "POST", "/api/v1/animate", `{"scramble": "R", "algorithm": "magic"}`, http.StatusBadRequest, "bad_request"},
+		{"bad CFEN", "POST", "/api/v1/verify", `{"algorithm": "R", "start": "nope"}`, http.StatusBadRequest, "bad_request"},
+		{"unmapped key", "POST", "/api/v1/keystrokes", `{"keys": "ij!"}`, http.StatusBadRequest, "parse"},
+		{"bad keymap", "POST", "/api/v1/keystrokes", `{"keys": "a", "keymap": {"a": "Q"}}`, http.StatusBadRequest, "parse"},
+		{"slice key on a 4x4", "POST", "/api/v1/keystrokes", `{"keys": "5", "dimension": 4}`, http.StatusBadRequest, "parse"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var resp ErrorResponse
+			if code := call(t, tt.method, tt.path, tt.body, &resp); code != tt.status || resp.Kind != tt.kind {
+				t.Errorf("status %d kind %q, want %d %q (%s)", code, resp.Kind, tt.status, tt.kind, resp.Error)
+			}
+		})
+	}
+
+	if code := call(t, "GET", "/api/v1/solve", "", nil); code != http.StatusMethodNotAllowed {
+		t.Errorf("GET /api/v1/solve status = %d, want 405", code)
+	}
+}
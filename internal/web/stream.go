@@ -0,0 +1,152 @@
+package web
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/ehrlich-b/cube/internal/cfen"
+	"github.com/ehrlich-b/cube/internal/cube"
+	"github.com/gorilla/websocket"
+)
+
+// streamWriteTimeout bounds how long sending one event may take
+const streamWriteTimeout = 10 * time.Second
+
+// streamSolveTimeout bounds how long a streamed solve may search before it
+// is stopped with a search_limit error
+var streamSolveTimeout = 30 * time.Second
+
+// StreamRequest is the first and only message a client sends on GET /ws
+type StreamRequest struct {
+	Scramble string `json:"scramble"`        // Moves applied to the start state
+	Start    string `json:"start,omitempty"` // Starting 3x3 state as CFEN (default solved)
+}
+
+// StreamEvent is one message GET /ws sends: a "stage" event as each CFOP
+// stage completes, then a "solution" event, or an "error" event instead
+// if the request or solve fails. The connection closes after the last.
+// A solve that runs past streamSolveTimeout ends with a search_limit
+// error, and one whose client disconnects is stopped without a word.
+type StreamEvent struct {
+	Event  string `json:"event"`            // "stage", "solution", or "error"
+	Stage  string `json:"stage,omitempty"`  // Stage completed: cross, f2l, oll, or pll
+	Moves  string `json:"moves,omitempty"`  // The stage's moves, or the whole solution
+	Steps  int    `json:"steps,omitempty"`  // Number of moves in the solution
+	CFEN   string `json:"cfen,omitempty"`   // State after the solution
+	Solved bool   `json:"solved,omitempty"` // Whether the solution solves the cube
+	Error  string `json:"error,omitempty"`  // What went wrong
+	Kind   string `json:"kind,omitempty"`   // Error kind, as in ErrorResponse
+}
+
+// upgrader accepts WebSocket connections for GET /ws. Origins are not
+// checked: like the rest of the API, the stream only reads public
+// algorithms and solves the cube it is sent.
+var upgrader = websocket.Upgrader{
+	CheckOrigin: func(r *http.Request) bool { return true },
+}
+
+// handleStream solves a 3x3 with CFOP over a WebSocket, sending each stage
+// as it completes so a front end can show progress on long solves
+func handleStream(w http.ResponseWriter, r *http.Request) {
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return // Upgrade has already answered with an HTTP error
+	}
+	defer conn.Close()
+
+	send := func(event StreamEvent) error {
+		conn.SetWriteDeadline(time.Now().Add(streamWriteTimeout))
+		return conn.WriteJSON(event)
+	}
+	sendError := func(err error) {
+		_, kind := errorStatus(err)
+		send(StreamEvent{Event: "error", Error: err.Error(), Kind: kind})
+	}
+
+	conn.SetReadLimit(maxRequestBytes)
+	var req StreamRequest
+	if err := conn.ReadJSON(&req); err != nil {
+		sendError(badRequest(fmt.Errorf("invalid request message: %w", err)))
+		return
+	}
+
+	c, err := startCube(req.Start, 3)
+	if err != nil {
+		sendError(err)
+		return
+	}
+	moves, err := cube.ParseScrambleForSize(req.Scramble, c.Size)
+	if err != nil {
+		sendError(err)
+		return
+	}
+	c.ApplyMoves(moves)
+
+	// Stop the solve when the client goes away or time runs out. The client
+	// sends nothing after its request, so a read only returns once the
+	// connection closes.
+	cancel := make(chan struct{})
+	var stopOnce sync.Once
+	var timedOut bool
+	stop := func(timeout bool) {
+		stopOnce.Do(func() {
+			timedOut = timeout
+			close(cancel)
+		})
+	}
+	timer := time.AfterFunc(streamSolveTimeout, func() { stop(true) })
+	defer timer.Stop()
+	go func() {
+		for {
+			if _, _, err := conn.NextReader(); err != nil {
+				stop(false)
+				return
+			}
+		}
+	}()
+
+	// Stop reporting stages once a send fails; the client has gone
+	var sendErr error
+	solver := &cube.CFOPSolver{
+		OnStage: func(stage cube.CFOPStage, moves []cube.Move) {
+			if sendErr == nil {
+				sendErr = send(StreamEvent{Event: "stage", Stage: stage.String(), Moves: cube.FormatMoves(moves)})
+			}
+		},
+		Cancel: cancel,
+	}
+	result, err := solver.SolveThrough(c, cube.StagePLL)
+	if sendErr != nil {
+		return
+	}
+	if errors.Is(err, cube.ErrSolveCancelled) {
+		if timedOut {
+			sendError(&cube.SearchLimitError{Search: "CFOP", Timeout: streamSolveTimeout})
+		}
+		return
+	}
+	if err != nil {
+		sendError(err)
+		return
+	}
+
+	c.ApplyMoves(result.Solution)
+	state, err := cfen.GenerateCFEN(c)
+	if err != nil {
+		sendError(err)
+		return
+	}
+	if err := send(StreamEvent{
+		Event:  "solution",
+		Moves:  cube.FormatMoves(result.Solution),
+		Steps:  result.Steps,
+		CFEN:   state,
+		Solved: c.IsSolved(),
+	}); err != nil {
+		return
+	}
+	conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""), time.Now().Add(streamWriteTimeout))
+}
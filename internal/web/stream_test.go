@@ -0,0 +1,130 @@
+package web
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ehrlich-b/cube/internal/cube"
+	"github.com/gorilla/websocket"
+)
+
+// hardScramble keeps CFOP searching for far longer than any test waits
+const hardScramble = "R U F D L B R2 U2 F2 D' L' B'"
+
+// dial connects to GET /ws on server and sends req
+func dial(t *testing.T, server *httptest.Server, req interface{}) *websocket.Conn {
+	t.Helper()
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http")+"/ws", nil)
+	if err != nil {
+		t.Fatalf("dialing /ws: %v", err)
+	}
+	if err := conn.WriteJSON(req); err != nil {
+		t.Fatalf("sending request: %v", err)
+	}
+	return conn
+}
+
+// stream sends req to GET /ws and returns every event up to the close
+func stream(t *testing.T, req interface{}) []StreamEvent {
+	t.Helper()
+	server := httptest.NewServer(NewAPI())
+	defer server.Close()
+
+	conn := dial(t, server, req)
+	defer conn.Close()
+
+	var events []StreamEvent
+	for {
+		var event StreamEvent
+		if err := conn.ReadJSON(&event); err != nil {
+			if !websocket.IsCloseError(err, websocket.CloseNormalClosure) && len(events) == 0 {
+				t.Fatalf("reading events: %v", err)
+			}
+			return events
+		}
+		events = append(events, event)
+	}
+}
+
+func TestStream(t *testing.T) {
+	events := stream(t, StreamRequest{Scramble: "R U R' U'"})
+	if len(events) != 5 {
+		t.Fatalf("got %d events, want four stages and a solution: %+v", len(events), events)
+	}
+
+	var stageMoves []string
+	for i, stage := range []string{"cross", "f2l", "oll", "pll"} {
+		if events[i].Event != "stage" || events[i].Stage != stage {
+			t.Errorf("event %d = %+v, want the %s stage", i, events[i], stage)
+		}
+		if events[i].Moves != "" {
+			stageMoves = append(stageMoves, events[i].Moves)
+		}
+	}
+
+	solution := events[4]
+	if solution.Event != "solution" || !solution.Solved || solution.CFEN != "YB|Y9/R9/B9/W9/O9/G9" {
+		t.Errorf("solution event = %+v", solution)
+	}
+	if got := strings.Join(stageMoves, " "); got != solution.Moves {
+		t.Errorf("stage moves %q don't add up to the solution %q", got, solution.Moves)
+	}
+	if moves, err := cube.ParseScramble(solution.Moves); err != nil || len(moves) != solution.Steps {
+		t.Errorf("solution %q doesn't have %d steps", solution.Moves, solution.Steps)
+	}
+}
+
+func TestStreamErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		req  interface{}
+		kind string
+	}{
+		{"bad move", StreamRequest{Scramble: "R Q"}, "parse"},
+		{"not a 3x3", StreamRequest{Start: "YB|Y4/R4/B4/W4/O4/G4"}, "bad_request"},
+		{"bad message", "R U", "bad_request"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events := stream(t, tt.req)
+			if len(events) != 1 || events[0].Event != "error" || events[0].Kind != tt.kind {
+				t.Errorf("events = %+v, want one %s error", events, tt.kind)
+			}
+		})
+	}
+}
+
+func TestStreamTimeout(t *testing.T) {
+	saved := streamSolveTimeout
+	defer func() { streamSolveTimeout = saved }()
+	streamSolveTimeout = 100 * time.Millisecond
+
+	events := stream(t, StreamRequest{Scramble: hardScramble})
+	last := events[len(events)-1]
+	if last.Event != "error" || last.Kind != "search_limit" {
+		t.Errorf("events = %+v, want a search_limit error last", events)
+	}
+}
+
+func TestStreamStopsWhenClientLeaves(t *testing.T) {
+	finished := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		handleStream(w, r)
+		close(finished)
+	}))
+	defer server.Close()
+
+	conn := dial(t, server, StreamRequest{Scramble: hardScramble})
+	time.Sleep(100 * time.Millisecond) // Let the solve get going
+	conn.Close()
+
+	select {
+	case <-finished:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the solve kept running after the client disconnected")
+	}
+}
@@ -1,11 +1,16 @@
 package main
 
 import (
+	"encoding/csv"
+	"encoding/json"
 	"fmt"
 	"os"
+	"regexp"
 	"sort"
+	"strconv"
 	"strings"
 
+	"github.com/ehrlich-b/cube/internal/cfen"
 	"github.com/ehrlich-b/cube/internal/cube"
 )
 
@@ -16,41 +21,141 @@ func main() {
 	}
 
 	command := os.Args[1]
+	output, fix, err := parseOptions(os.Args[2:])
+	if err != nil {
+		fmt.Println(err)
+		printUsage()
+		os.Exit(1)
+	}
+	if output != "text" && command != "relationships" && command != "duplicates" && command != "statistics" {
+		fmt.Printf("--output is only supported by relationships, duplicates, and statistics\n")
+		os.Exit(1)
+	}
+	if fix && command != "validate" {
+		fmt.Printf("--fix is only supported by validate\n")
+		os.Exit(1)
+	}
+
 	switch command {
 	case "relationships":
-		analyzeRelationships()
+		report := analyzeRelationships()
+		err = writeReport(output, report, report.printText, report.writeCSV)
 	case "duplicates":
-		findDuplicates()
+		report := findDuplicates()
+		err = writeReport(output, report, report.printText, report.writeCSV)
 	case "statistics":
-		showStatistics()
+		report := showStatistics()
+		err = writeReport(output, report, report.printText, report.writeCSV)
 	case "validate":
-		validateDatabase()
+		err = validateDatabase(fix)
+	case "coverage":
+		showCoverage()
 	default:
 		fmt.Printf("Unknown command: %s\n", command)
 		printUsage()
 	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
 }
 
 func printUsage() {
 	fmt.Println(`Algorithm Database Analyzer
 
-Usage: analyze-algorithms <command>
+Usage: analyze-algorithms <command> [--output text|json|csv] [--fix]
 
 Commands:
   relationships  Find inverse and mirror relationships
   duplicates     Find potential duplicate algorithms
   statistics     Show database statistics
-  validate       Validate database consistency`)
+  validate       Validate database consistency
+  coverage       Report which OLL/PLL cases are present, missing, or duplicated
+
+Options:
+  --output       Report format for relationships, duplicates, and statistics:
+                 text (default), json, or csv
+  --fix          With validate, rewrite redundant move sequences (R R, U U U,
+                 R R') in the database files to their simplified form; run
+                 from the repository root`)
 }
 
-func analyzeRelationships() {
-	fmt.Println("Analyzing algorithm relationships...")
+// parseOptions reads the --output and --fix options from the arguments
+// after the command
+func parseOptions(args []string) (output string, fix bool, err error) {
+	output = "text"
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch {
+		case arg == "--output" || arg == "-o":
+			if i+1 >= len(args) {
+				return "", false, fmt.Errorf("Missing value for %s", arg)
+			}
+			output = args[i+1]
+			i++
+		case strings.HasPrefix(arg, "--output="):
+			output = strings.TrimPrefix(arg, "--output=")
+		case arg == "--fix":
+			fix = true
+		default:
+			return "", false, fmt.Errorf("Unknown option: %s", arg)
+		}
+	}
+	switch output {
+	case "text", "json", "csv":
+		return output, fix, nil
+	}
+	return "", false, fmt.Errorf("Invalid value for --output: %s", output)
+}
 
-	algorithms := cube.GetAllAlgorithms()
+// writeReport writes a report to standard output as text, indented JSON,
+// or CSV
+func writeReport(output string, report interface{}, printText func(), writeCSV func(*csv.Writer) error) error {
+	switch output {
+	case "json":
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(report)
+	case "csv":
+		w := csv.NewWriter(os.Stdout)
+		if err := writeCSV(w); err != nil {
+			return err
+		}
+		w.Flush()
+		return w.Error()
+	}
+	printText()
+	return nil
+}
 
-	// Find potential inverse relationships
-	fmt.Println("=== POTENTIAL INVERSE RELATIONSHIPS ===")
-	inverseCount := 0
+// algorithmRef is an algorithm as the reports show it
+type algorithmRef struct {
+	Name        string `json:"name"`
+	CaseID      string `json:"case_id"`
+	Category    string `json:"category"`
+	Moves       string `json:"moves"`
+	Description string `json:"description,omitempty"`
+}
+
+func refOf(alg cube.Algorithm) algorithmRef {
+	return algorithmRef{Name: alg.Name, CaseID: alg.CaseID, Category: alg.Category, Moves: alg.Moves, Description: alg.Description}
+}
+
+// algorithmPair is two algorithms related as inverses or mirrors
+type algorithmPair struct {
+	First  algorithmRef `json:"first"`
+	Second algorithmRef `json:"second"`
+}
+
+// relationshipsReport lists the inverse and mirror pairs in the database
+type relationshipsReport struct {
+	Inverse []algorithmPair `json:"inverse"`
+	Mirror  []algorithmPair `json:"mirror"`
+}
+
+func analyzeRelationships() relationshipsReport {
+	algorithms := cube.GetAllAlgorithms()
+	report := relationshipsReport{Inverse: []algorithmPair{}, Mirror: []algorithmPair{}}
 
 	for i, alg1 := range algorithms {
 		for j, alg2 := range algorithms {
@@ -59,124 +164,148 @@ func analyzeRelationships() {
 			}
 
 			if areInverse(alg1.Moves, alg2.Moves) {
-				fmt.Printf("INVERSE PAIR:\n")
-				fmt.Printf("  %s (%s): %s\n", alg1.Name, alg1.CaseID, alg1.Moves)
-				fmt.Printf("  %s (%s): %s\n", alg2.Name, alg2.CaseID, alg2.Moves)
-				fmt.Println()
-				inverseCount++
+				report.Inverse = append(report.Inverse, algorithmPair{refOf(alg1), refOf(alg2)})
 			}
 		}
 	}
 
-	// Find potential mirror relationships
-	fmt.Println("=== POTENTIAL MIRROR RELATIONSHIPS ===")
-	mirrorCount := 0
-
+	infos := make([]mirrorInfo, len(algorithms))
+	valid := make([]bool, len(algorithms))
+	for i, alg := range algorithms {
+		infos[i], valid[i] = mirrorInfoOf(alg)
+	}
 	for i, alg1 := range algorithms {
 		for j, alg2 := range algorithms {
-			if i >= j {
+			if i >= j || !valid[i] || !valid[j] {
 				continue
 			}
 
-			if areMirror(alg1, alg2) {
-				fmt.Printf("MIRROR PAIR:\n")
-				fmt.Printf("  %s (%s): %s\n", alg1.Name, alg1.CaseID, alg1.Moves)
-				fmt.Printf("  %s (%s): %s\n", alg2.Name, alg2.CaseID, alg2.Moves)
-				if alg1.Description != "" && alg2.Description != "" {
-					fmt.Printf("  Descriptions: '%s' vs '%s'\n", alg1.Description, alg2.Description)
-				}
-				fmt.Println()
-				mirrorCount++
+			if areMirror(infos[i], infos[j]) {
+				report.Mirror = append(report.Mirror, algorithmPair{refOf(alg1), refOf(alg2)})
 			}
 		}
 	}
-
-	fmt.Printf("Summary: Found %d inverse pairs and %d mirror pairs\n", inverseCount, mirrorCount)
+	return report
 }
 
-func areInverse(moves1, moves2 string) bool {
-	// Parse moves and check if one is the inverse of the other
-	parsed1, err1 := cube.ParseScramble(moves1)
-	parsed2, err2 := cube.ParseScramble(moves2)
+func (r relationshipsReport) printText() {
+	fmt.Println("Analyzing algorithm relationships...")
 
-	if err1 != nil || err2 != nil {
-		return false
+	fmt.Println("=== POTENTIAL INVERSE RELATIONSHIPS ===")
+	for _, pair := range r.Inverse {
+		fmt.Printf("INVERSE PAIR:\n")
+		fmt.Printf("  %s (%s): %s\n", pair.First.Name, pair.First.CaseID, pair.First.Moves)
+		fmt.Printf("  %s (%s): %s\n", pair.Second.Name, pair.Second.CaseID, pair.Second.Moves)
+		fmt.Println()
 	}
 
-	// Generate inverse of moves1
-	inverse1 := generateInverse(parsed1)
-
-	// Check if inverse1 matches parsed2
-	if len(inverse1) != len(parsed2) {
-		return false
+	fmt.Println("=== POTENTIAL MIRROR RELATIONSHIPS ===")
+	for _, pair := range r.Mirror {
+		fmt.Printf("MIRROR PAIR:\n")
+		fmt.Printf("  %s (%s): %s\n", pair.First.Name, pair.First.CaseID, pair.First.Moves)
+		fmt.Printf("  %s (%s): %s\n", pair.Second.Name, pair.Second.CaseID, pair.Second.Moves)
+		if pair.First.Description != "" && pair.Second.Description != "" {
+			fmt.Printf("  Descriptions: '%s' vs '%s'\n", pair.First.Description, pair.Second.Description)
+		}
+		fmt.Println()
 	}
 
-	for i, move := range inverse1 {
-		if move != parsed2[i] {
-			return false
+	fmt.Printf("Summary: Found %d inverse pairs and %d mirror pairs\n", len(r.Inverse), len(r.Mirror))
+}
+
+// writeCSV writes one row per pair: the relationship, then each
+// algorithm's name, case ID, and moves
+func (r relationshipsReport) writeCSV(w *csv.Writer) error {
+	if err := w.Write([]string{"relationship", "name1", "case_id1", "moves1", "name2", "case_id2", "moves2"}); err != nil {
+		return err
+	}
+	for _, kind := range []struct {
+		name  string
+		pairs []algorithmPair
+	}{{"inverse", r.Inverse}, {"mirror", r.Mirror}} {
+		for _, pair := range kind.pairs {
+			row := []string{kind.name, pair.First.Name, pair.First.CaseID, pair.First.Moves,
+				pair.Second.Name, pair.Second.CaseID, pair.Second.Moves}
+			if err := w.Write(row); err != nil {
+				return err
+			}
 		}
 	}
-
-	return true
+	return nil
 }
 
-func areMirror(alg1, alg2 cube.Algorithm) bool {
-	// Check if algorithms are mirrors based on:
-	// 1. Same category
-	// 2. Similar move count
-	// 3. Names suggesting mirroring (e.g., "A" vs "B", "Left" vs "Right")
-	// 4. Similar descriptions
+// areInverse reports whether one sequence undoes the other: applying
+// moves1 then moves2 to a solved cube leaves it solved. Comparing states
+// rather than moves catches pairs written differently, such as with wide
+// turns, slices, or rotations, that a literal comparison misses.
+func areInverse(moves1, moves2 string) bool {
+	parsed1, err1 := cube.ParseScramble(moves1)
+	parsed2, err2 := cube.ParseScramble(moves2)
 
-	if alg1.Category != alg2.Category {
+	if err1 != nil || err2 != nil || len(parsed1) == 0 || len(parsed2) == 0 {
 		return false
 	}
 
-	// Move count should be similar (within 2 moves)
-	if abs(alg1.MoveCount-alg2.MoveCount) > 2 {
-		return false
+	c := cube.NewCube(3)
+	c.ApplyMoves(parsed1)
+	if c.IsSolved() {
+		return false // A sequence that changes nothing has no meaningful inverse
 	}
+	c.ApplyMoves(parsed2)
+	return c.IsSolved()
+}
 
-	// Check for name patterns suggesting mirrors
-	name1 := strings.ToLower(alg1.Name)
-	name2 := strings.ToLower(alg2.Name)
+// mirrorInfo holds what areMirror compares for one algorithm
+type mirrorInfo struct {
+	effect  string   // cube.EffectKey of the moves
+	mirrors []string // cube.MirrorEffectKeys of the moves
+}
 
-	// Common mirror patterns
-	mirrorPatterns := [][]string{
-		{"sune", "anti-sune"},
-		{"antisune", "sune"},
-		{" a", " b"},
-		{"-a", "-b"},
-		{"left", "right"},
-		{"clockwise", "counterclockwise"},
-		{"cw", "ccw"},
+// mirrorInfoOf computes alg's keys, or reports false if its moves don't
+// parse or leave a 3x3 solved
+func mirrorInfoOf(alg cube.Algorithm) (mirrorInfo, bool) {
+	moves, err := cube.ParseScramble(alg.Moves)
+	if err != nil || len(moves) == 0 {
+		return mirrorInfo{}, false
 	}
-
-	for _, pattern := range mirrorPatterns {
-		if (strings.Contains(name1, pattern[0]) && strings.Contains(name2, pattern[1])) ||
-			(strings.Contains(name1, pattern[1]) && strings.Contains(name2, pattern[0])) {
-			return true
-		}
+	c := cube.NewCube(3)
+	c.ApplyMoves(moves)
+	if c.IsSolved() {
+		return mirrorInfo{}, false
 	}
+	return mirrorInfo{effect: cube.EffectKey(moves), mirrors: cube.MirrorEffectKeys(moves)}, true
+}
 
-	// Check case ID patterns
-	case1 := strings.ToLower(alg1.CaseID)
-	case2 := strings.ToLower(alg2.CaseID)
-
-	// Pattern like "OLL-1" vs "OLL-2" or "PLL-Aa" vs "PLL-Ab"
-	if strings.Contains(case1, "a") && strings.Contains(case2, "b") {
-		base1 := strings.ReplaceAll(case1, "a", "")
-		base2 := strings.ReplaceAll(case2, "b", "")
-		if base1 == base2 {
+// areMirror reports whether two algorithms solve mirror images of the same
+// case: mirroring one through the M, E, or S plane gives the other's effect,
+// up to AUF and y rotation. Like areInverse it compares states, so names
+// and move strings don't matter. Algorithms with the same effect are the
+// same algorithm, not mirrors, even for cases symmetric under a mirror.
+func areMirror(info1, info2 mirrorInfo) bool {
+	if info1.effect == info2.effect {
+		return false
+	}
+	for _, key := range info1.mirrors {
+		if key == info2.effect {
 			return true
 		}
 	}
-
 	return false
 }
 
-func findDuplicates() {
-	fmt.Println("Finding potential duplicate algorithms...")
+// duplicateGroup is a set of algorithms with identical moves
+type duplicateGroup struct {
+	Moves      string         `json:"moves"`
+	Algorithms []algorithmRef `json:"algorithms"`
+}
+
+// duplicatesReport lists the sets of algorithms with identical moves,
+// sorted by their normalized moves
+type duplicatesReport struct {
+	Groups []duplicateGroup `json:"groups"`
+}
 
+func findDuplicates() duplicatesReport {
 	algorithms := cube.GetAllAlgorithms()
 
 	// Group by normalized moves
@@ -187,19 +316,50 @@ func findDuplicates() {
 		moveGroups[normalizedMoves] = append(moveGroups[normalizedMoves], alg)
 	}
 
-	duplicateCount := 0
+	report := duplicatesReport{Groups: []duplicateGroup{}}
 	for moves, group := range moveGroups {
 		if len(group) > 1 {
-			fmt.Printf("DUPLICATE MOVES: %s\n", moves)
+			duplicates := duplicateGroup{Moves: moves}
 			for _, alg := range group {
-				fmt.Printf("  %s (%s) - %s: %s\n", alg.Name, alg.CaseID, alg.Category, alg.Description)
+				duplicates.Algorithms = append(duplicates.Algorithms, refOf(alg))
 			}
-			fmt.Println()
-			duplicateCount++
+			report.Groups = append(report.Groups, duplicates)
 		}
 	}
+	sort.Slice(report.Groups, func(i, j int) bool {
+		return report.Groups[i].Moves < report.Groups[j].Moves
+	})
+	return report
+}
 
-	fmt.Printf("Found %d sets of algorithms with identical moves\n", duplicateCount)
+func (r duplicatesReport) printText() {
+	fmt.Println("Finding potential duplicate algorithms...")
+
+	for _, group := range r.Groups {
+		fmt.Printf("DUPLICATE MOVES: %s\n", group.Moves)
+		for _, alg := range group.Algorithms {
+			fmt.Printf("  %s (%s) - %s: %s\n", alg.Name, alg.CaseID, alg.Category, alg.Description)
+		}
+		fmt.Println()
+	}
+
+	fmt.Printf("Found %d sets of algorithms with identical moves\n", len(r.Groups))
+}
+
+// writeCSV writes one row per algorithm in a duplicate set, led by the
+// set's normalized moves
+func (r duplicatesReport) writeCSV(w *csv.Writer) error {
+	if err := w.Write([]string{"normalized_moves", "name", "case_id", "category", "moves", "description"}); err != nil {
+		return err
+	}
+	for _, group := range r.Groups {
+		for _, alg := range group.Algorithms {
+			if err := w.Write([]string{group.Moves, alg.Name, alg.CaseID, alg.Category, alg.Moves, alg.Description}); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
 }
 
 func normalizeMoves(moves string) string {
@@ -207,111 +367,150 @@ func normalizeMoves(moves string) string {
 	return strings.ReplaceAll(strings.ToLower(moves), " ", "")
 }
 
-func showStatistics() {
-	fmt.Println("Database Statistics")
+// categoryCount is how many algorithms a category holds
+type categoryCount struct {
+	Category   string `json:"category"`
+	Algorithms int    `json:"algorithms"`
+}
+
+// moveCountBucket is how many algorithms have a given move count
+type moveCountBucket struct {
+	Moves      int `json:"moves"`
+	Algorithms int `json:"algorithms"`
+}
 
+// statisticsReport counts the algorithms in the database, by category
+// (largest first) and by move count (shortest first)
+type statisticsReport struct {
+	Total        int               `json:"total"`
+	AverageMoves float64           `json:"average_moves"`
+	Categories   []categoryCount   `json:"categories"`
+	MoveCounts   []moveCountBucket `json:"move_counts"`
+}
+
+func showStatistics() statisticsReport {
 	algorithms := cube.GetAllAlgorithms()
+	report := statisticsReport{Total: len(algorithms)}
 
 	// Count by category
-	categoryCount := make(map[string]int)
+	categoryCounts := make(map[string]int)
+	moveCountDist := make(map[int]int)
 	totalMoves := 0
 
 	for _, alg := range algorithms {
-		categoryCount[alg.Category]++
+		categoryCounts[alg.Category]++
+		moveCountDist[alg.MoveCount]++
 		totalMoves += alg.MoveCount
 	}
-
-	fmt.Printf("Total algorithms: %d\n", len(algorithms))
-	fmt.Printf("Average moves per algorithm: %.1f\n", float64(totalMoves)/float64(len(algorithms)))
-	fmt.Println()
-
-	fmt.Println("Algorithms by category:")
+	if len(algorithms) > 0 {
+		report.AverageMoves = float64(totalMoves) / float64(len(algorithms))
+	}
 
 	// Sort categories by count
-	type categoryInfo struct {
-		name  string
-		count int
+	for cat, count := range categoryCounts {
+		report.Categories = append(report.Categories, categoryCount{cat, count})
 	}
+	sort.Slice(report.Categories, func(i, j int) bool {
+		if report.Categories[i].Algorithms != report.Categories[j].Algorithms {
+			return report.Categories[i].Algorithms > report.Categories[j].Algorithms
+		}
+		return report.Categories[i].Category < report.Categories[j].Category
+	})
 
-	var categories []categoryInfo
-	for cat, count := range categoryCount {
-		categories = append(categories, categoryInfo{cat, count})
+	for count, algorithms := range moveCountDist {
+		report.MoveCounts = append(report.MoveCounts, moveCountBucket{count, algorithms})
 	}
-
-	sort.Slice(categories, func(i, j int) bool {
-		return categories[i].count > categories[j].count
+	sort.Slice(report.MoveCounts, func(i, j int) bool {
+		return report.MoveCounts[i].Moves < report.MoveCounts[j].Moves
 	})
+	return report
+}
+
+func (r statisticsReport) printText() {
+	fmt.Println("Database Statistics")
+
+	fmt.Printf("Total algorithms: %d\n", r.Total)
+	fmt.Printf("Average moves per algorithm: %.1f\n", r.AverageMoves)
+	fmt.Println()
 
-	for _, cat := range categories {
-		fmt.Printf("  %-15s: %d\n", cat.name, cat.count)
+	fmt.Println("Algorithms by category:")
+	for _, cat := range r.Categories {
+		fmt.Printf("  %-15s: %d\n", cat.Category, cat.Algorithms)
 	}
 
 	// Move count distribution
 	fmt.Println("\nMove count distribution:")
-	moveCountDist := make(map[int]int)
-	for _, alg := range algorithms {
-		moveCountDist[alg.MoveCount]++
+	for _, bucket := range r.MoveCounts {
+		fmt.Printf("  %2d moves: %d algorithms\n", bucket.Moves, bucket.Algorithms)
 	}
+}
 
-	var moveCounts []int
-	for count := range moveCountDist {
-		moveCounts = append(moveCounts, count)
+// writeCSV writes one row per count: a category or a move count, and how
+// many algorithms it has, after a row for the total
+func (r statisticsReport) writeCSV(w *csv.Writer) error {
+	rows := [][]string{{"group", "value", "algorithms"}, {"total", "", strconv.Itoa(r.Total)}}
+	for _, cat := range r.Categories {
+		rows = append(rows, []string{"category", cat.Category, strconv.Itoa(cat.Algorithms)})
 	}
-	sort.Ints(moveCounts)
-
-	for _, count := range moveCounts {
-		fmt.Printf("  %2d moves: %d algorithms\n", count, moveCountDist[count])
+	for _, bucket := range r.MoveCounts {
+		rows = append(rows, []string{"moves", strconv.Itoa(bucket.Moves), strconv.Itoa(bucket.Algorithms)})
 	}
+	return w.WriteAll(rows)
 }
 
-func validateDatabase() {
+// validateDatabase reports every consistency problem it finds, and with
+// fix rewrites redundant move sequences in the database files
+func validateDatabase(fix bool) error {
 	fmt.Println("Validating database consistency...")
 
 	algorithms := cube.GetAllAlgorithms()
 	issues := 0
+	var missingRecognition []cube.Algorithm
 
 	fmt.Println("=== VALIDATION ISSUES ===")
 
-	for i, alg := range algorithms {
-		// Check for empty required fields
-		if alg.Name == "" {
-			fmt.Printf("Algorithm %d: Missing name\n", i+1)
-			issues++
-		}
+	// Missing fields, bad moves, and wrong move counts; entries with the
+	// first two are already left out of algorithms
+	for _, err := range cube.ValidateDatabase() {
+		fmt.Println(err)
+		issues++
+	}
+
+	// OLL and PLL entries that disturb the first two layers
+	for _, err := range cube.LastLayerMismatches() {
+		fmt.Println(err)
+		issues++
+	}
 
+	for _, alg := range algorithms {
 		if alg.CaseID == "" {
 			fmt.Printf("Algorithm '%s': Missing case ID\n", alg.Name)
 			issues++
 		}
 
-		if alg.Category == "" {
-			fmt.Printf("Algorithm '%s': Missing category\n", alg.Name)
+		// Check the moves do something
+		if moves, err := cube.ParseScramble(alg.Moves); err == nil && cube.IsIdentity(moves) {
+			fmt.Printf("Algorithm '%s': Moves '%s' leave the cube unchanged\n", alg.Name, alg.Moves)
 			issues++
 		}
 
-		if alg.Moves == "" {
-			fmt.Printf("Algorithm '%s': Missing moves\n", alg.Name)
+		// Check the stored pattern still matches what the moves do
+		if err := cfen.CheckAlgorithmPattern(alg); err != nil {
+			fmt.Printf("Algorithm '%s': %v\n", alg.Name, err)
 			issues++
 		}
 
-		// Check if moves parse correctly
-		if alg.Moves != "" {
-			_, err := cube.ParseScramble(alg.Moves)
-			if err != nil {
-				fmt.Printf("Algorithm '%s': Invalid moves '%s': %v\n", alg.Name, alg.Moves, err)
-				issues++
-			}
+		if !alg.HasRecognition() {
+			missingRecognition = append(missingRecognition, alg)
 		}
+	}
 
-		// Check move count consistency
-		if alg.Moves != "" {
-			moves, err := cube.ParseScramble(alg.Moves)
-			if err == nil && len(moves) != alg.MoveCount {
-				fmt.Printf("Algorithm '%s': Move count mismatch (stated: %d, actual: %d)\n",
-					alg.Name, alg.MoveCount, len(moves))
-				issues++
-			}
-		}
+	// Moves that cancel or merge, such as R R' or U U U
+	redundant := findRedundant(algorithms)
+	for _, r := range redundant {
+		fmt.Printf("Algorithm '%s': Moves '%s' simplify to '%s' (saves %d %s)\n",
+			r.alg.Name, r.alg.Moves, r.simplified, r.saved, plural(r.saved, "move"))
+		issues++
 	}
 
 	if issues == 0 {
@@ -320,37 +519,223 @@ func validateDatabase() {
 		fmt.Printf("❌ Found %d validation issues\n", issues)
 	}
 
+	if len(missingRecognition) > 0 {
+		fmt.Printf("\n=== MISSING RECOGNITION (%d, generated suggestions) ===\n", len(missingRecognition))
+		for _, alg := range missingRecognition {
+			fmt.Printf("%s (%s): %s\n", alg.Name, alg.CaseID, alg.GenerateRecognition())
+		}
+	}
+
 	fmt.Printf("\nDatabase summary: %d algorithms validated\n", len(algorithms))
+
+	if fix && len(redundant) > 0 {
+		return fixRedundant(redundant)
+	}
+	return nil
+}
+
+// redundancy is an algorithm whose moves simplify to fewer moves that do
+// the same thing
+type redundancy struct {
+	alg        cube.Algorithm
+	simplified string
+	moveCount  int // Moves in simplified
+	saved      int // Moves simplified saves
 }
 
-func generateInverse(moves []cube.Move) []cube.Move {
-	// Reverse the order and invert each move
-	var inverse []cube.Move
-	for i := len(moves) - 1; i >= 0; i-- {
-		move := moves[i]
-		// Invert the move
-		invertedMove := cube.Move{
-			Face:      move.Face,
-			Clockwise: !move.Clockwise,
-			Double:    move.Double,
-			Wide:      move.Wide,
-			WideDepth: move.WideDepth,
-			Layer:     move.Layer,
-			Slice:     move.Slice,
-			Rotation:  move.Rotation,
+// findRedundant returns the algorithms cube.OptimizeMoves shortens,
+// keeping only simplifications that give the same state on a 7x7, big
+// enough for any layer the database turns
+func findRedundant(algorithms []cube.Algorithm) []redundancy {
+	var results []redundancy
+	for _, alg := range algorithms {
+		moves, err := cube.ParseScramble(alg.Moves)
+		if err != nil {
+			continue
+		}
+		simplified := cube.OptimizeMoves(moves)
+		if len(simplified) >= len(moves) {
+			continue
 		}
-		// Handle double moves (they are their own inverse)
-		if move.Double {
-			invertedMove.Clockwise = move.Clockwise
+
+		original, shortened := cube.NewCube(7), cube.NewCube(7)
+		original.ApplyMoves(moves)
+		shortened.ApplyMoves(simplified)
+		if original.Checksum() != shortened.Checksum() {
+			fmt.Printf("Algorithm '%s': Simplifying '%s' changes what it does; left alone\n", alg.Name, alg.Moves)
+			continue
 		}
-		inverse = append(inverse, invertedMove)
+		results = append(results, redundancy{
+			alg:        alg,
+			simplified: cube.FormatMoves(simplified),
+			moveCount:  len(simplified),
+			saved:      len(moves) - len(simplified),
+		})
 	}
-	return inverse
+	return results
+}
+
+// databaseFiles are the Go files holding the algorithm database
+var databaseFiles = []string{
+	"internal/cube/algorithms.go",
+	"internal/cube/algorithms_imported.go",
 }
 
-func abs(x int) int {
-	if x < 0 {
-		return -x
+var (
+	movesLine     = regexp.MustCompile(`^(\s*Moves:\s*)"(.*)",\s*$`)
+	moveCountLine = regexp.MustCompile(`^(\s*MoveCount:\s*)\d+,\s*$`)
+)
+
+// fixRedundant rewrites the Moves of each redundant algorithm in the
+// database files to the simplified sequence, and its MoveCount to match
+func fixRedundant(redundant []redundancy) error {
+	simplified := make(map[string]redundancy)
+	for _, r := range redundant {
+		simplified[r.alg.Moves] = r
+	}
+
+	fixed := 0
+	for _, filename := range databaseFiles {
+		data, err := os.ReadFile(filename)
+		if err != nil {
+			return err
+		}
+		lines := strings.Split(string(data), "\n")
+
+		changed := false
+		for i := 0; i < len(lines); i++ {
+			match := movesLine.FindStringSubmatch(lines[i])
+			if match == nil {
+				continue
+			}
+			r, ok := simplified[match[2]]
+			if !ok {
+				continue
+			}
+			lines[i] = fmt.Sprintf("%s%q,", match[1], r.simplified)
+			// MoveCount follows Moves within the same entry
+			for j := i + 1; j < len(lines) && strings.TrimSpace(lines[j]) != "},"; j++ {
+				if count := moveCountLine.FindStringSubmatch(lines[j]); count != nil {
+					lines[j] = fmt.Sprintf("%s%d,", count[1], r.moveCount)
+					break
+				}
+			}
+			fmt.Printf("%s:%d %s: %s -> %s\n", filename, i+1, r.alg.Name, r.alg.Moves, r.simplified)
+			changed = true
+			fixed++
+		}
+
+		if changed {
+			if err := os.WriteFile(filename, []byte(strings.Join(lines, "\n")), 0644); err != nil {
+				return err
+			}
+		}
+	}
+	fmt.Printf("Fixed %d %s\n", fixed, plural(fixed, "algorithm"))
+	return nil
+}
+
+// plural returns word, with an s unless n is 1
+func plural(n int, word string) string {
+	if n == 1 {
+		return word
+	}
+	return word + "s"
+}
+
+func showCoverage() {
+	fmt.Println("Analyzing OLL/PLL case coverage...")
+
+	algorithms := cube.GetAllAlgorithms()
+
+	reportCoverage("OLL", cube.OLLCaseIDs(), filterCategories(algorithms, "OLL", "CFOP-OLL"), cube.RecognizeOLL)
+	fmt.Println()
+	reportCoverage("PLL", cube.PLLCaseIDs(), filterCategories(algorithms, "PLL", "CFOP-PLL"), cube.RecognizePLL)
+}
+
+func filterCategories(algorithms []cube.Algorithm, categories ...string) []cube.Algorithm {
+	var results []cube.Algorithm
+	for _, alg := range algorithms {
+		for _, category := range categories {
+			if alg.Category == category {
+				results = append(results, alg)
+				break
+			}
+		}
+	}
+	return results
+}
+
+// reportCoverage classifies each algorithm by the case it actually solves,
+// found by applying its inverse to a solved cube, rather than by its CaseID
+func reportCoverage(set string, caseIDs []string, algorithms []cube.Algorithm, recognize func(*cube.Cube) (string, bool)) {
+	fmt.Printf("=== %s COVERAGE ===\n", set)
+
+	byCase := make(map[string][]cube.Algorithm)
+	var mislabeled, unrecognized []string
+
+	for _, alg := range algorithms {
+		moves, err := cube.ParseScramble(alg.Moves)
+		if err != nil {
+			unrecognized = append(unrecognized, fmt.Sprintf("%s (%s): invalid moves: %v", alg.Name, alg.CaseID, err))
+			continue
+		}
+
+		c := cube.NewCube(3)
+		c.ApplyInverseMoves(moves)
+
+		caseID, ok := recognize(c)
+		if !ok {
+			unrecognized = append(unrecognized, fmt.Sprintf("%s (%s): %s", alg.Name, alg.CaseID, alg.Moves))
+			continue
+		}
+
+		byCase[caseID] = append(byCase[caseID], alg)
+		if !strings.EqualFold(caseID, alg.CaseID) {
+			mislabeled = append(mislabeled, fmt.Sprintf("%s labelled %s but solves %s: %s", alg.Name, alg.CaseID, caseID, alg.Moves))
+		}
+	}
+
+	var present, missing []string
+	duplicated := 0
+	for _, caseID := range caseIDs {
+		if len(byCase[caseID]) == 0 {
+			missing = append(missing, caseID)
+			continue
+		}
+		present = append(present, caseID)
+		if len(byCase[caseID]) > 1 {
+			duplicated++
+		}
+	}
+
+	fmt.Printf("Present: %d/%d cases\n", len(present), len(caseIDs))
+	if len(present) > 0 {
+		fmt.Printf("  %s\n", strings.Join(present, " "))
+	}
+
+	fmt.Printf("Missing: %d cases\n", len(missing))
+	if len(missing) > 0 {
+		fmt.Printf("  %s\n", strings.Join(missing, " "))
+	}
+
+	fmt.Printf("Duplicated: %d cases\n", duplicated)
+	for _, caseID := range caseIDs {
+		if len(byCase[caseID]) > 1 {
+			fmt.Printf("  %s:\n", caseID)
+			for _, alg := range byCase[caseID] {
+				fmt.Printf("    %s (%s): %s\n", alg.Name, alg.CaseID, alg.Moves)
+			}
+		}
+	}
+
+	fmt.Printf("Mislabeled: %d algorithms\n", len(mislabeled))
+	for _, line := range mislabeled {
+		fmt.Printf("  %s\n", line)
+	}
+
+	fmt.Printf("Unrecognized: %d algorithms\n", len(unrecognized))
+	for _, line := range unrecognized {
+		fmt.Printf("  %s\n", line)
 	}
-	return x
 }
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ehrlich-b/cube/internal/cube"
+)
+
+func main() {
+	count := cube.DefaultBenchmarkScrambleCount
+	seed := int64(cube.DefaultBenchmarkScrambleSeed)
+	maxLength := cube.DefaultBenchmarkScrambleMaxLength
+	longCount := cube.DefaultBenchmarkLongScrambleCount
+	timeout := cube.DefaultBenchmarkTimeout
+	solvers := []string{"beginner", "cfop", "kociemba"}
+
+	// Simple argument parsing
+	args := os.Args[1:]
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--help" || arg == "-h" {
+			printUsage()
+			return
+		}
+		if i+1 >= len(args) {
+			fmt.Printf("Missing value for %s\n", arg)
+			printUsage()
+			os.Exit(1)
+		}
+
+		value := args[i+1]
+		i++
+
+		var err error
+		switch arg {
+		case "--count":
+			count, err = strconv.Atoi(value)
+		case "--seed":
+			seed, err = strconv.ParseInt(value, 10, 64)
+		case "--max-length":
+			maxLength, err = strconv.Atoi(value)
+		case "--long-count":
+			longCount, err = strconv.Atoi(value)
+		case "--timeout":
+			timeout, err = time.ParseDuration(value)
+		case "--solvers":
+			solvers = strings.Split(value, ",")
+		default:
+			fmt.Printf("Unknown option: %s\n", arg)
+			printUsage()
+			os.Exit(1)
+		}
+		if err != nil {
+			fmt.Printf("Invalid value for %s: %s\n", arg, value)
+			os.Exit(1)
+		}
+	}
+
+	short := cube.SolverBenchmarkScrambles(count, seed, maxLength)
+	long := cube.SolverBenchmarkScrambleBand(longCount, seed, maxLength+1, cube.DefaultBenchmarkLongScrambleMaxLength)
+	fmt.Printf("Benchmarking %d scrambles of 1-%d moves and %d of %d-%d moves (seed %d, %v per solve)\n\n",
+		count, maxLength, longCount, maxLength+1, cube.DefaultBenchmarkLongScrambleMaxLength, seed, timeout)
+
+	var shortReports, longReports []cube.SolverReport
+	for _, name := range solvers {
+		solver, err := cube.GetSolver(strings.TrimSpace(name))
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		fmt.Printf("Running %s...\n", solver.Name())
+		shortReports = append(shortReports, cube.EvaluateSolverTimeout(solver, short, timeout))
+		longReports = append(longReports, cube.EvaluateSolverTimeout(solver, long, timeout))
+	}
+
+	printReports("Short scrambles", shortReports)
+	printReports("Long scrambles", longReports)
+}
+
+// printReports prints one table of solver reports
+func printReports(title string, reports []cube.SolverReport) {
+	fmt.Printf("\n=== Solver Benchmark: %s ===\n", title)
+	fmt.Printf("%-10s %8s %9s %10s %8s %8s %12s %12s\n", "Solver", "Solved", "Timeouts", "Avg moves", "Median", "P90", "Avg time", "Max time")
+	for _, r := range reports {
+		fmt.Printf("%-10s %7.1f%% %9d %10.1f %8d %8d %12v %12v\n",
+			r.Solver, r.SuccessRate(), r.TimedOut, r.AvgMoves, r.MedianMoves, r.P90Moves, r.AvgTime, r.MaxTime)
+	}
+}
+
+func printUsage() {
+	fmt.Println(`Solver Benchmark
+
+Usage: bench-solvers [options]
+
+Runs each solver over a fixed, seeded set of 3x3 scrambles and reports the
+success rate, move counts of successful solves, and time per solve. Short
+scrambles run from 1 to --max-length moves, long ones from there to 20.
+Each solve is stopped after --timeout and counted as a failure.
+
+Options:
+  --count N         Number of short scrambles (default 100)
+  --long-count N    Number of long scrambles (default 20)
+  --seed N          Random seed for the scramble set (default 1)
+  --max-length N    Short scramble lengths cycle from 1 to N (default 5)
+  --timeout D       Time limit per solve (default 2s)
+  --solvers LIST    Comma-separated solvers (default beginner,cfop,kociemba)
+
+Examples:
+  bench-solvers
+  bench-solvers --solvers beginner,cfop --max-length 10
+  bench-solvers --long-count 0              # Short scrambles only`)
+}
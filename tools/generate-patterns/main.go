@@ -23,8 +23,14 @@ func main() {
 		log.Fatalf("Error generating pattern: %v", err)
 	}
 
+	piecePattern, err := generatePiecePattern(moves)
+	if err != nil {
+		log.Fatalf("Error generating piece pattern: %v", err)
+	}
+
 	fmt.Printf("Moves: %s\n", moves)
 	fmt.Printf("Pattern: %s\n", pattern)
+	fmt.Printf("Piece pattern: %s\n", piecePattern)
 
 	// Also show move count
 	parsedMoves, err := cube.ParseScramble(moves)
@@ -37,25 +43,11 @@ func main() {
 
 // generatePattern creates a CFEN pattern by applying algorithm to solved cube
 func generatePattern(moves string) (string, error) {
-	// Create solved cube in canonical YB orientation
-	c := cube.NewCube(3)
-
-	// Parse and apply the algorithm moves
-	parsedMoves, err := cube.ParseScramble(moves)
-	if err != nil {
-		return "", err
-	}
-
-	// Apply moves
-	for _, move := range parsedMoves {
-		c.ApplyMove(move)
-	}
-
-	// Get after state as CFEN
-	afterCFEN, err := cfen.GenerateCFEN(c)
-	if err != nil {
-		return "", err
-	}
+	return cfen.AlgorithmPattern(moves, false)
+}
 
-	return afterCFEN, nil
+// generatePiecePattern creates a CFEN pattern that keeps only the pieces the
+// algorithm changes, greying every other piece as a whole
+func generatePiecePattern(moves string) (string, error) {
+	return cfen.AlgorithmPattern(moves, true)
 }
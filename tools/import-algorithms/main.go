@@ -12,6 +12,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/ehrlich-b/cube/internal/cfen"
 	"github.com/ehrlich-b/cube/internal/cube"
 )
 
@@ -33,6 +34,7 @@ type ImportConfig struct {
 	Verbose          bool
 	DryRun           bool
 	SkipDuplicates   bool
+	DedupByEffect    bool // Also treat algorithms with the same effect as duplicates
 	GeneratePatterns bool
 }
 
@@ -65,6 +67,8 @@ func main() {
 			config.GeneratePatterns = false
 		case "--allow-duplicates":
 			config.SkipDuplicates = false
+		case "--dedup-by-effect":
+			config.DedupByEffect = true
 		case "--quiet":
 			config.Verbose = false
 		case "--help":
@@ -89,6 +93,8 @@ Options:
   --dry-run          Show what would be imported without writing files
   --no-patterns      Skip pattern generation
   --allow-duplicates Allow duplicate algorithms
+  --dedup-by-effect  Also drop algorithms in the same category that produce
+                     the same pattern up to AUF and y rotation
   --quiet            Reduce output verbosity
   --help             Show this help message`)
 }
@@ -127,7 +133,9 @@ func runImport(config ImportConfig) error {
 
 	// Remove duplicates if requested
 	if config.SkipDuplicates {
-		allAlgorithms = removeDuplicates(allAlgorithms)
+		before := len(allAlgorithms)
+		allAlgorithms = removeDuplicates(allAlgorithms, config.DedupByEffect)
+		duplicateCount += before - len(allAlgorithms)
 	}
 
 	// Generate patterns if requested
@@ -281,6 +289,9 @@ func convertCSVToAlgorithm(record CSVRecord) (*cube.Algorithm, error) {
 	if err != nil {
 		return nil, fmt.Errorf("invalid moves '%s' (normalized from '%s'): %w", normalizedMoves, record.Moves, err)
 	}
+	if cube.IsIdentity(moves) {
+		return nil, fmt.Errorf("moves '%s' leave the cube unchanged", normalizedMoves)
+	}
 
 	algorithm := &cube.Algorithm{
 		Name:        record.Name,
@@ -318,78 +329,57 @@ func shouldSkipAlgorithm(moves string) bool {
 	return false
 }
 
+// normalizeAlgorithmMoves cleans up a CSV move sequence: grouping
+// parentheses and stray spaces go, and a sequence that parses is rewritten
+// the way FormatMoves writes it, so r2', R'2, and (y) come out as Rw2, R2,
+// and y. ParseMove owns the notation rules; this only strips formatting.
 func normalizeAlgorithmMoves(moves string) string {
-	// Remove extra whitespace and normalize move notation
-	moves = regexp.MustCompile(`\s+`).ReplaceAllString(strings.TrimSpace(moves), " ")
-
-	// Remove parentheses that are just for grouping (but preserve cube rotations)
-	moves = strings.ReplaceAll(moves, "(", "")
-	moves = strings.ReplaceAll(moves, ")", "")
-
-	// Handle cube rotations: (x), (y), (z) -> x, y, z
-	moves = regexp.MustCompile(`\b([xyz])\b`).ReplaceAllString(moves, "$1")
-	moves = regexp.MustCompile(`\b([xyz]')\b`).ReplaceAllString(moves, "$1")
-	moves = regexp.MustCompile(`\b([xyz]2)\b`).ReplaceAllString(moves, "$1")
-
-	// Convert lowercase wide moves to uppercase with 'w' suffix
-	// r -> Rw, f -> Fw, etc.
-	lowerCasePattern := regexp.MustCompile(`\b([rflbud])([2']?)\b`)
-	moves = lowerCasePattern.ReplaceAllStringFunc(moves, func(match string) string {
-		parts := lowerCasePattern.FindStringSubmatch(match)
-		face := strings.ToUpper(parts[1])
-		modifier := parts[2]
-		if modifier == "" {
-			return face + "w"
-		}
-		return face + "w" + modifier
-	})
-
-	// Convert M, E, S slice moves (they're already uppercase but need to be handled properly)
-	// No changes needed for these as they should already be supported
-
-	// Normalize some common variations
-	moves = strings.ReplaceAll(moves, "2'", "'2") // R2' -> R'2 (not standard but sometimes seen)
-
-	// Handle specific notations that might be problematic
+	moves = strings.NewReplacer("(", "", ")", "").Replace(moves)
+	moves = strings.Join(strings.Fields(moves), " ")
 	moves = strings.ReplaceAll(moves, " '", "'") // Fix spacing issues like "R '"
 
+	if parsed, err := cube.ParseScramble(moves); err == nil {
+		return cube.FormatMoves(parsed)
+	}
 	return moves
 }
 
-func removeDuplicates(algorithms []cube.Algorithm) []cube.Algorithm {
+// removeDuplicates keeps the first of each group of algorithms with the same
+// moves. With byEffect, algorithms in the same category that produce the
+// same pattern up to AUF and y rotation are also duplicates.
+func removeDuplicates(algorithms []cube.Algorithm, byEffect bool) []cube.Algorithm {
 	seen := make(map[string]bool)
+	seenEffects := make(map[string]bool)
 	var unique []cube.Algorithm
 
 	for _, alg := range algorithms {
 		// Create a key based on normalized moves
 		key := strings.ToLower(strings.ReplaceAll(alg.Moves, " ", ""))
+		if seen[key] {
+			continue
+		}
 
-		if !seen[key] {
-			seen[key] = true
-			unique = append(unique, alg)
+		if byEffect {
+			if moves, err := cube.ParseScramble(alg.Moves); err == nil {
+				effect := alg.Category + "|" + cube.EffectKey(moves)
+				if seenEffects[effect] {
+					continue
+				}
+				seenEffects[effect] = true
+			}
 		}
+
+		seen[key] = true
+		unique = append(unique, alg)
 	}
 
 	return unique
 }
 
+// generateAlgorithmPattern returns the masked pattern for an algorithm,
+// the same one regenerate-patterns writes
 func generateAlgorithmPattern(algorithm *cube.Algorithm) (string, error) {
-	// Create a solved YB cube
-	c := cube.NewCube(3)
-
-	// Apply the algorithm
-	moves, err := cube.ParseScramble(algorithm.Moves)
-	if err != nil {
-		return "", err
-	}
-
-	for _, move := range moves {
-		c.ApplyMove(move)
-	}
-
-	// Generate CFEN pattern (this would need to be implemented)
-	// For now, return empty string - patterns will be generated separately
-	return "", nil
+	return cfen.AlgorithmPattern(algorithm.Moves, true)
 }
 
 func writeAlgorithmsFile(algorithms []cube.Algorithm, filename string) error {
@@ -422,11 +412,11 @@ var ImportedAlgorithms = []Algorithm{
 		fmt.Fprintf(file, "\t\tCategory:    %s,\n", strconv.Quote(alg.Category))
 		fmt.Fprintf(file, "\t\tMoves:       %s,\n", strconv.Quote(alg.Moves))
 		fmt.Fprintf(file, "\t\tMoveCount:   %d,\n", alg.MoveCount)
-		fmt.Fprintf(file, "\t\tDescription: %s,\n", strconv.Quote(alg.Description))
-		fmt.Fprintf(file, "\t\tRecognition: %s,\n", strconv.Quote(alg.Recognition))
 		if alg.Pattern != "" {
 			fmt.Fprintf(file, "\t\tPattern:     %s,\n", strconv.Quote(alg.Pattern))
 		}
+		fmt.Fprintf(file, "\t\tDescription: %s,\n", strconv.Quote(alg.Description))
+		fmt.Fprintf(file, "\t\tRecognition: %s,\n", strconv.Quote(alg.Recognition))
 		fmt.Fprintf(file, "\t},\n")
 	}
 
@@ -0,0 +1,237 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/ehrlich-b/cube/internal/cfen"
+	"github.com/ehrlich-b/cube/internal/cube"
+)
+
+// databaseFiles are the Go files holding the algorithm database
+var databaseFiles = []string{
+	"internal/cube/algorithms.go",
+	"internal/cube/algorithms_imported.go",
+}
+
+func main() {
+	dryRun := false
+	full := false
+	var files []string
+
+	// Simple argument parsing
+	for _, arg := range os.Args[1:] {
+		switch arg {
+		case "--dry-run":
+			dryRun = true
+		case "--full":
+			full = true
+		case "--help", "-h":
+			printUsage()
+			return
+		default:
+			if strings.HasPrefix(arg, "-") {
+				fmt.Printf("Unknown option: %s\n", arg)
+				printUsage()
+				os.Exit(1)
+			}
+			files = append(files, arg)
+		}
+	}
+	if len(files) == 0 {
+		files = databaseFiles
+	}
+
+	var total summary
+	for _, filename := range files {
+		data, err := os.ReadFile(filename)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		lines, changes, stats := regenerate(strings.Split(string(data), "\n"), full)
+		total.add(stats)
+		for _, change := range changes {
+			fmt.Printf("%s:%d %s (%s)\n", filename, change.line, change.name, change.caseID)
+			if change.old != "" {
+				fmt.Printf("- Pattern: %s\n", change.old)
+			}
+			fmt.Printf("+ Pattern: %s\n", change.new)
+		}
+
+		if dryRun || len(changes) == 0 {
+			continue
+		}
+		if err := os.WriteFile(filename, []byte(strings.Join(lines, "\n")), 0644); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	verb := "Regenerated"
+	if dryRun {
+		verb = "Would regenerate"
+	}
+	fmt.Printf("%s patterns: %d updated, %d added, %d unchanged, %d skipped (moves need a bigger cube)\n",
+		verb, total.updated, total.added, total.unchanged, total.skipped)
+}
+
+func printUsage() {
+	fmt.Println(`Algorithm Pattern Regenerator
+
+Regenerates the Pattern of every algorithm in the database from its moves
+and rewrites the database files in place. A stored pattern keeps its style:
+masked (every piece the moves leave alone greyed out) or full. Algorithms
+without a pattern get a masked one, or a full one with --full. Algorithms
+whose moves need a bigger cube than a 3x3 are skipped.
+
+Usage: regenerate-patterns [options] [files...]
+
+Files default to internal/cube/algorithms.go and
+internal/cube/algorithms_imported.go, relative to the repository root.
+
+Options:
+  --dry-run  Show the changes without writing files
+  --full     Give algorithms without a pattern a full pattern, not a masked one
+  --help     Show this help message`)
+}
+
+// patternChange is a Pattern field regenerate changed or added
+type patternChange struct {
+	line         int // 1-based line of the Pattern field in the new file
+	name, caseID string
+	old, new     string // old is "" for an added pattern
+}
+
+// summary counts what regenerate did to each algorithm
+type summary struct {
+	updated, added, unchanged, skipped int
+}
+
+func (s *summary) add(other summary) {
+	s.updated += other.updated
+	s.added += other.added
+	s.unchanged += other.unchanged
+	s.skipped += other.skipped
+}
+
+// fieldLine matches a string or int field of an algorithm entry in the
+// database files, which gofmt lays out one field per line
+var fieldLine = regexp.MustCompile(`^\t\t(\w+):(\s+)(.*)$`)
+
+// entry is the lines of one algorithm entry, as indexes into the file
+type entry struct {
+	fields map[string]int    // field name -> line index
+	values map[string]string // unquoted string field values
+}
+
+// regenerate rewrites the Pattern fields of every algorithm entry in the
+// lines of a database file. An entry runs from a line "\t{" to "\t},".
+func regenerate(lines []string, full bool) ([]string, []patternChange, summary) {
+	var out []string
+	var changes []patternChange
+	var stats summary
+	var current *entry
+	start := 0
+
+	for _, line := range lines {
+		switch {
+		case line == "\t{":
+			current = &entry{fields: make(map[string]int), values: make(map[string]string)}
+			start = len(out)
+		case current != nil && line == "\t},":
+			entryLines, change, ok := regenerateEntry(out[start:], current, full, &stats)
+			out = append(out[:start], entryLines...)
+			if ok {
+				change.line += start + 1
+				changes = append(changes, change)
+			}
+			current = nil
+		case current != nil:
+			if m := fieldLine.FindStringSubmatch(line); m != nil {
+				current.fields[m[1]] = len(out) - start
+				if value, err := strconv.QuotedPrefix(m[3]); err == nil {
+					current.values[m[1]], _ = strconv.Unquote(value)
+				}
+			}
+		}
+		out = append(out, line)
+	}
+	return out, changes, stats
+}
+
+// regenerateEntry regenerates the Pattern of one entry, given its lines up
+// to but not including the closing "\t},". It returns the new lines and,
+// if the pattern changed or was added, the change with its line relative
+// to the entry.
+func regenerateEntry(lines []string, e *entry, full bool, stats *summary) ([]string, patternChange, bool) {
+	lines = append([]string(nil), lines...)
+	change := patternChange{name: e.values["Name"], caseID: e.values["CaseID"]}
+	moves, ok := e.values["Moves"]
+	if !ok {
+		return lines, change, false
+	}
+
+	old, hasPattern := e.values["Pattern"]
+	masked := !full
+	if hasPattern {
+		masked = isMasked(old)
+	}
+	pattern, err := cfen.AlgorithmPattern(moves, masked)
+	if err != nil {
+		stats.skipped++
+		return lines, change, false
+	}
+	change.new = pattern
+
+	if hasPattern {
+		if samePattern(old, pattern) {
+			stats.unchanged++
+			return lines, change, false
+		}
+		index := e.fields["Pattern"]
+		lines[index] = strings.Replace(lines[index], strconv.Quote(old), strconv.Quote(pattern), 1)
+		change.old, change.line = old, index
+		stats.updated++
+		return lines, change, true
+	}
+
+	// New patterns go after MoveCount, in the struct's field order
+	index, ok := e.fields["MoveCount"]
+	if !ok {
+		index = e.fields["Moves"]
+	}
+	patternLine := fmt.Sprintf("\t\tPattern:     %s,", strconv.Quote(pattern))
+	lines = append(lines[:index+1], append([]string{patternLine}, lines[index+1:]...)...)
+	change.line = index + 1
+	stats.added++
+	return lines, change, true
+}
+
+// isMasked reports whether a stored pattern has wildcard stickers
+func isMasked(pattern string) bool {
+	state, err := cfen.ParseCFEN(pattern)
+	if err != nil {
+		return false
+	}
+	for _, face := range state.Faces {
+		for _, color := range face.Stickers {
+			if color == cube.Grey {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// samePattern compares two patterns by their parsed forms, so run-length
+// spelling doesn't matter
+func samePattern(a, b string) bool {
+	stateA, errA := cfen.ParseCFEN(a)
+	stateB, errB := cfen.ParseCFEN(b)
+	return errA == nil && errB == nil && stateA.String() == stateB.String()
+}
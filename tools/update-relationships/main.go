@@ -1,10 +1,26 @@
 package main
 
 import (
+	"bytes"
 	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"log"
 	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ehrlich-b/cube/internal/cube"
 )
 
+// databaseFiles are the Go files holding algorithm literals, relative to
+// the database directory
+var databaseFiles = []string{"algorithms.go", "algorithms_imported.go"}
+
 func main() {
 	if len(os.Args) < 2 {
 		printUsage()
@@ -14,9 +30,26 @@ func main() {
 	command := os.Args[1]
 	switch command {
 	case "apply":
-		applyRelationships()
+		dir := "../../internal/cube"
+		dryRun := false
+		for i := 2; i < len(os.Args); i++ {
+			switch os.Args[i] {
+			case "--dir":
+				if i+1 < len(os.Args) {
+					dir = os.Args[i+1]
+					i++
+				}
+			case "--dry-run":
+				dryRun = true
+			}
+		}
+		if err := applyRelationships(dir, dryRun); err != nil {
+			log.Fatalf("Apply failed: %v", err)
+		}
 	case "preview":
 		previewRelationships()
+	case "discover":
+		discoverRelationships()
 	default:
 		fmt.Printf("Unknown command: %s\n", command)
 		printUsage()
@@ -26,11 +59,19 @@ func main() {
 func printUsage() {
 	fmt.Println(`Algorithm Relationship Updater
 
-Usage: update-relationships <command>
+Usage: update-relationships <command> [options]
 
 Commands:
-  apply    Apply discovered relationships to algorithm database
-  preview  Show what relationships would be applied`)
+  apply     Write verified relationships into the algorithm database files
+            (Inverse, Mirror, and Related fields). Re-running is a no-op.
+  preview   Show what relationships would be applied
+  discover  Group algorithms into equivalence classes by the cube state they
+            produce (inverse, mirrors, AUF, and y rotations)
+
+Options for apply:
+  --dir DIR   Directory holding algorithms.go and algorithms_imported.go
+              (default: ../../internal/cube)
+  --dry-run   Report what would change without writing files`)
 }
 
 func previewRelationships() {
@@ -55,15 +96,298 @@ func previewRelationships() {
 	}
 }
 
-func applyRelationships() {
-	fmt.Println("Applying discovered relationships to algorithm database...")
+func discoverRelationships() {
+	fmt.Println("Discovering relationships from cube states...")
+	fmt.Println()
 
-	// This would update the actual algorithm files
-	// For now, just show what would be changed
-	relationships := getKnownRelationships()
+	count := 0
+	for _, alg := range cube.GetAllAlgorithms() {
+		var related []string
+		for _, caseID := range cube.EquivalenceClass(alg) {
+			if caseID != alg.CaseID {
+				related = append(related, caseID)
+			}
+		}
+		if len(related) == 0 {
+			continue
+		}
+
+		fmt.Printf("Algorithm: %s (%s)\n", alg.Name, alg.CaseID)
+		fmt.Printf("  → Equivalent: %v\n", related)
+		count++
+	}
+
+	fmt.Printf("\nFound %d algorithms with equivalent cases\n", count)
+}
+
+func applyRelationships(dir string, dryRun bool) error {
+	fmt.Println("Applying verified relationships to algorithm database...")
+	fmt.Println()
+
+	updates := verifiedRelationships(getKnownRelationships())
+
+	changedFiles := 0
+	for _, name := range databaseFiles {
+		path := filepath.Join(dir, name)
+		src, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		updated, changed, err := rewriteRelationships(path, src, updates)
+		if err != nil {
+			return err
+		}
+		if len(changed) == 0 {
+			fmt.Printf("%s: up to date\n", name)
+			continue
+		}
+
+		fmt.Printf("%s: updating %s\n", name, strings.Join(changed, ", "))
+		changedFiles++
+		if dryRun {
+			continue
+		}
+		if err := os.WriteFile(path, updated, 0644); err != nil {
+			return err
+		}
+	}
+
+	switch {
+	case changedFiles == 0:
+		fmt.Println("\nNo changes needed")
+	case dryRun:
+		fmt.Println("\nDry run: no files written")
+	}
+	return nil
+}
+
+// relationshipUpdate holds the field values to write for one case ID.
+// Empty fields are left alone.
+type relationshipUpdate struct {
+	Inverse string   // Inverted move sequence
+	Mirror  string   // Case ID
+	Related []string // Case IDs, merged into any already listed
+}
+
+// verifiedRelationships checks each known relationship against the cube
+// states the algorithms produce and keeps only those that hold. An inverse
+// must undo the algorithm (up to AUF and y); mirror and related cases must
+// share its equivalence class.
+func verifiedRelationships(relationships []RelationshipInfo) map[string]relationshipUpdate {
+	updates := make(map[string]relationshipUpdate)
+	for _, rel := range relationships {
+		algs := cube.GetByCaseID(rel.CaseID)
+		if len(algs) == 0 {
+			fmt.Printf("  skip %s: not in database\n", rel.CaseID)
+			continue
+		}
+		alg := algs[0]
+		moves, err := cube.ParseScramble(alg.Moves)
+		if err != nil {
+			fmt.Printf("  skip %s: %v\n", rel.CaseID, err)
+			continue
+		}
+
+		class := make(map[string]bool)
+		for _, caseID := range cube.EquivalenceClass(alg) {
+			class[caseID] = true
+		}
+
+		var update relationshipUpdate
+		if rel.Inverse != "" {
+			inverse := cube.InvertSequence(moves)
+			if undoesAlgorithm(rel.Inverse, inverse) {
+				update.Inverse = cube.FormatMoves(inverse)
+			} else {
+				fmt.Printf("  skip %s inverse %s: does not undo it\n", rel.CaseID, rel.Inverse)
+			}
+		}
+		if rel.Mirror != "" {
+			if class[rel.Mirror] {
+				update.Mirror = rel.Mirror
+			} else {
+				fmt.Printf("  skip %s mirror %s: not equivalent\n", rel.CaseID, rel.Mirror)
+			}
+		}
+		for _, caseID := range rel.Related {
+			if class[caseID] {
+				update.Related = append(update.Related, caseID)
+			} else {
+				fmt.Printf("  skip %s related %s: not equivalent\n", rel.CaseID, caseID)
+			}
+		}
+		updates[rel.CaseID] = update
+	}
+	return updates
+}
 
-	fmt.Printf("Would update %d algorithms with relationship information\n", len(relationships))
-	fmt.Println("(Note: Actual file modification not implemented yet)")
+// undoesAlgorithm reports whether some algorithm for caseID has the same
+// effect as the inverted moves
+func undoesAlgorithm(caseID string, inverse []cube.Move) bool {
+	want := cube.EffectKey(inverse)
+	for _, alg := range cube.GetByCaseID(caseID) {
+		moves, err := cube.ParseScramble(alg.Moves)
+		if err == nil && cube.EffectKey(moves) == want {
+			return true
+		}
+	}
+	return false
+}
+
+// rewriteRelationships sets the relationship fields of every Algorithm
+// literal whose CaseID has an update, editing only the affected fields and
+// then gofmt-ing the file. It returns the new source and the case IDs that
+// changed; an unchanged file returns no case IDs.
+func rewriteRelationships(path string, src []byte, updates map[string]relationshipUpdate) ([]byte, []string, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, path, src, parser.ParseComments)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	type edit struct {
+		start, end int
+		text       string
+	}
+	var edits []edit
+	changed := make(map[string]bool)
+
+	ast.Inspect(file, func(n ast.Node) bool {
+		lit, ok := n.(*ast.CompositeLit)
+		if !ok {
+			return true
+		}
+		fields := make(map[string]*ast.KeyValueExpr)
+		for _, elt := range lit.Elts {
+			if kv, ok := elt.(*ast.KeyValueExpr); ok {
+				if key, ok := kv.Key.(*ast.Ident); ok {
+					fields[key.Name] = kv
+				}
+			}
+		}
+		caseField, ok := fields["CaseID"]
+		if !ok {
+			return true
+		}
+		caseID, err := strconv.Unquote(string(src[fset.Position(caseField.Value.Pos()).Offset:fset.Position(caseField.Value.End()).Offset]))
+		if err != nil {
+			return true
+		}
+		update, ok := updates[caseID]
+		if !ok {
+			return true
+		}
+
+		// Existing Related entries are kept; verified ones are added
+		related := update.Related
+		if kv, ok := fields["Related"]; ok && len(related) > 0 {
+			var existing []string
+			if list, ok := kv.Value.(*ast.CompositeLit); ok {
+				for _, elt := range list.Elts {
+					if basic, ok := elt.(*ast.BasicLit); ok {
+						if value, err := strconv.Unquote(basic.Value); err == nil {
+							existing = append(existing, value)
+						}
+					}
+				}
+			}
+			related = mergeCaseIDs(existing, related)
+		}
+
+		values := []struct{ field, text string }{
+			{"Inverse", quoteIfSet(update.Inverse)},
+			{"Mirror", quoteIfSet(update.Mirror)},
+			{"Related", stringSliceLiteral(related)},
+		}
+		var insert string
+		for _, value := range values {
+			if value.text == "" {
+				continue
+			}
+			if kv, ok := fields[value.field]; ok {
+				start := fset.Position(kv.Value.Pos()).Offset
+				end := fset.Position(kv.Value.End()).Offset
+				if string(src[start:end]) != value.text {
+					edits = append(edits, edit{start, end, value.text})
+					changed[caseID] = true
+				}
+				continue
+			}
+
+			insert += fmt.Sprintf("%s: %s,\n", value.field, value.text)
+		}
+
+		// Missing fields go on their own lines before the closing brace
+		if insert != "" {
+			closing := fset.Position(lit.Rbrace).Offset
+			lineStart := bytes.LastIndexByte(src[:closing], '\n') + 1
+			edits = append(edits, edit{lineStart, lineStart, insert})
+			changed[caseID] = true
+		}
+		return false
+	})
+
+	if len(edits) == 0 {
+		return src, nil, nil
+	}
+
+	// Apply from the end so earlier offsets stay valid
+	sort.Slice(edits, func(i, j int) bool { return edits[i].start > edits[j].start })
+	out := append([]byte(nil), src...)
+	for _, e := range edits {
+		out = append(out[:e.start], append([]byte(e.text), out[e.end:]...)...)
+	}
+
+	formatted, err := format.Source(out)
+	if err != nil {
+		return nil, nil, fmt.Errorf("formatting %s: %w", path, err)
+	}
+
+	var caseIDs []string
+	for caseID := range changed {
+		caseIDs = append(caseIDs, caseID)
+	}
+	sort.Strings(caseIDs)
+	return formatted, caseIDs, nil
+}
+
+// mergeCaseIDs appends the IDs in extra that existing doesn't already list
+func mergeCaseIDs(existing, extra []string) []string {
+	merged := append([]string(nil), existing...)
+	for _, caseID := range extra {
+		found := false
+		for _, have := range merged {
+			if have == caseID {
+				found = true
+				break
+			}
+		}
+		if !found {
+			merged = append(merged, caseID)
+		}
+	}
+	return merged
+}
+
+// quoteIfSet returns value as a Go string literal, or "" if it is empty
+func quoteIfSet(value string) string {
+	if value == "" {
+		return ""
+	}
+	return strconv.Quote(value)
+}
+
+// stringSliceLiteral returns values as a []string literal, or "" if empty
+func stringSliceLiteral(values []string) string {
+	if len(values) == 0 {
+		return ""
+	}
+	quoted := make([]string, len(values))
+	for i, value := range values {
+		quoted[i] = strconv.Quote(value)
+	}
+	return "[]string{" + strings.Join(quoted, ", ") + "}"
 }
 
 type RelationshipInfo struct {
@@ -151,5 +475,3 @@ func getKnownRelationships() []RelationshipInfo {
 		},
 	}
 }
-
-// Future: Add functionality to actually modify the algorithm database files
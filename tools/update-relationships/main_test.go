@@ -0,0 +1,158 @@
+package main
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// fixture is a small stand-in for the algorithm database files
+const fixture = `package cube
+
+// fixtureAlgorithms keeps its comments through a rewrite
+var fixtureAlgorithms = []Algorithm{
+	{
+		Name:   "T-Perm",
+		CaseID: "PLL-T",
+		Moves:  "R U R' U' R' F R2 U' R' U' R U R' F'",
+	},
+	{
+		Name:    "Sune",
+		CaseID:  "OLL-27",
+		Moves:   "R U R' U R U2 R'",
+		Mirror:  "OLL-99",
+		Related: []string{"OLL-26"},
+	},
+	// Untouched by every update below
+	{Name: "Dot", CaseID: "OLL-1", Moves: "R U2 R2 F R F' U2 R' F R F'"},
+}
+`
+
+func TestRewriteRelationships(t *testing.T) {
+	tests := []struct {
+		name    string
+		updates map[string]relationshipUpdate
+		changed []string
+		fields  map[string]map[string]string // CaseID -> field -> source text
+	}{
+		{
+			name:    "no updates",
+			updates: map[string]relationshipUpdate{},
+		},
+		{
+			name:    "unknown case",
+			updates: map[string]relationshipUpdate{"PLL-Z": {Mirror: "PLL-Z"}},
+		},
+		{
+			name:    "missing fields are added",
+			updates: map[string]relationshipUpdate{"PLL-T": {Inverse: "F R U'", Related: []string{"PLL-Jb"}}},
+			changed: []string{"PLL-T"},
+			fields: map[string]map[string]string{
+				"PLL-T": {"Inverse": `"F R U'"`, "Related": `[]string{"PLL-Jb"}`},
+			},
+		},
+		{
+			name:    "existing fields are replaced and merged",
+			updates: map[string]relationshipUpdate{"OLL-27": {Mirror: "OLL-26", Related: []string{"OLL-22", "OLL-26"}}},
+			changed: []string{"OLL-27"},
+			fields: map[string]map[string]string{
+				"OLL-27": {"Mirror": `"OLL-26"`, "Related": `[]string{"OLL-26", "OLL-22"}`},
+			},
+		},
+		{
+			name:    "matching fields are left alone",
+			updates: map[string]relationshipUpdate{"OLL-27": {Mirror: "OLL-99", Related: []string{"OLL-26"}}},
+		},
+		{
+			name: "several cases",
+			updates: map[string]relationshipUpdate{
+				"PLL-T":  {Mirror: "PLL-T"},
+				"OLL-27": {Inverse: "R U2 R' U' R U' R'"},
+			},
+			changed: []string{"OLL-27", "PLL-T"},
+			fields: map[string]map[string]string{
+				"PLL-T":  {"Mirror": `"PLL-T"`},
+				"OLL-27": {"Inverse": `"R U2 R' U' R U' R'"`, "Mirror": `"OLL-99"`},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, changed, err := rewriteRelationships("fixture.go", []byte(fixture), tt.updates)
+			if err != nil {
+				t.Fatalf("rewriteRelationships() error = %v", err)
+			}
+			if !reflect.DeepEqual(changed, tt.changed) {
+				t.Errorf("changed = %v, want %v", changed, tt.changed)
+			}
+			if len(tt.changed) == 0 && string(out) != fixture {
+				t.Errorf("unchanged source was rewritten:\n%s", out)
+			}
+
+			got := literalFields(t, out)
+			want := literalFields(t, []byte(fixture))
+			for caseID, fields := range tt.fields {
+				for field, text := range fields {
+					want[caseID][field] = text
+				}
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("fields = %v, want %v", got, want)
+			}
+			for _, comment := range []string{"// fixtureAlgorithms keeps", "// Untouched by every"} {
+				if !strings.Contains(string(out), comment) {
+					t.Errorf("comment %q was lost:\n%s", comment, out)
+				}
+			}
+
+			again, changed, err := rewriteRelationships("fixture.go", out, tt.updates)
+			if err != nil {
+				t.Fatalf("second rewriteRelationships() error = %v", err)
+			}
+			if len(changed) != 0 || string(again) != string(out) {
+				t.Errorf("second pass changed %v:\n%s", changed, again)
+			}
+		})
+	}
+}
+
+// literalFields returns the source text of every field of each composite
+// literal in src that has a CaseID, keyed by that CaseID
+func literalFields(t *testing.T, src []byte) map[string]map[string]string {
+	t.Helper()
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "fixture.go", src, 0)
+	if err != nil {
+		t.Fatalf("parsing rewritten source: %v\n%s", err, src)
+	}
+	text := func(n ast.Node) string {
+		return string(src[fset.Position(n.Pos()).Offset:fset.Position(n.End()).Offset])
+	}
+
+	literals := make(map[string]map[string]string)
+	ast.Inspect(file, func(n ast.Node) bool {
+		lit, ok := n.(*ast.CompositeLit)
+		if !ok {
+			return true
+		}
+		fields := make(map[string]string)
+		for _, elt := range lit.Elts {
+			if kv, ok := elt.(*ast.KeyValueExpr); ok {
+				if key, ok := kv.Key.(*ast.Ident); ok {
+					fields[key.Name] = text(kv.Value)
+				}
+			}
+		}
+		if caseID, err := strconv.Unquote(fields["CaseID"]); err == nil {
+			literals[caseID] = fields
+			return false
+		}
+		return true
+	})
+	return literals
+}
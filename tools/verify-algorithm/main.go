@@ -10,8 +10,12 @@ import (
 
 func main() {
 	if len(os.Args) < 2 {
-		fmt.Println("Usage: verify-algorithm <algorithm-name> [--verbose]")
+		fmt.Println("Usage: verify-algorithm <algorithm-name> [--start <cfen>] [--target <cfen>] [--verbose]")
 		fmt.Println("       verify-algorithm --list")
+		fmt.Println()
+		fmt.Println("By default the algorithm is applied to a solved cube and must produce its")
+		fmt.Println("pattern. With --start it must instead take that state to solved, unless")
+		fmt.Println("--target is also given.")
 		os.Exit(1)
 	}
 
@@ -21,7 +25,28 @@ func main() {
 	}
 
 	algorithmName := os.Args[1]
-	verbose := len(os.Args) > 2 && os.Args[2] == "--verbose"
+	verbose := false
+	startCFEN, targetCFEN := "", ""
+	for i := 2; i < len(os.Args); i++ {
+		switch os.Args[i] {
+		case "--verbose":
+			verbose = true
+		case "--start", "--target":
+			if i+1 >= len(os.Args) {
+				fmt.Printf("Error: %s requires a CFEN argument\n", os.Args[i])
+				os.Exit(1)
+			}
+			if os.Args[i] == "--start" {
+				startCFEN = os.Args[i+1]
+			} else {
+				targetCFEN = os.Args[i+1]
+			}
+			i++
+		default:
+			fmt.Printf("Error: unknown argument '%s'\n", os.Args[i])
+			os.Exit(1)
+		}
+	}
 
 	// Look up algorithm by name
 	algorithms := cube.LookupAlgorithm(algorithmName)
@@ -32,18 +57,26 @@ func main() {
 
 	algorithm := algorithms[0]
 
-	// Check if algorithm has pattern
-	if algorithm.Pattern == "" {
-		fmt.Printf("Error: algorithm '%s' has no pattern defined\n", algorithm.Name)
-		os.Exit(1)
-	}
-
 	// Update move count
 	algorithm.UpdateMoveCount()
 
-	// Use solved cube as start state for pattern verification
-	startCFEN := "YB|Y9/R9/B9/W9/O9/G9" // Solved cube
-	targetCFEN := algorithm.Pattern     // Expected pattern after applying algorithm
+	// Solved to pattern by default; a custom start state is checked against
+	// solved, the way OLL and PLL algorithms are used. Solved takes the size
+	// of whichever state was given.
+	solvedCFEN := cfen.SolvedCFEN(cfenSize(startCFEN, targetCFEN))
+	if targetCFEN == "" {
+		if startCFEN != "" {
+			targetCFEN = solvedCFEN
+		} else if algorithm.Pattern != "" {
+			targetCFEN = algorithm.Pattern // Expected pattern after applying algorithm
+		} else {
+			fmt.Printf("Error: algorithm '%s' has no pattern defined (use --start or --target)\n", algorithm.Name)
+			os.Exit(1)
+		}
+	}
+	if startCFEN == "" {
+		startCFEN = solvedCFEN
+	}
 
 	// Perform verification
 	err := verifyAlgorithm(algorithm, startCFEN, targetCFEN, verbose)
@@ -53,6 +86,17 @@ func main() {
 	}
 }
 
+// cfenSize returns the dimension of the first of states that parses, or 3
+// if none do
+func cfenSize(states ...string) int {
+	for _, state := range states {
+		if parsed, err := cfen.ParseCFEN(state); err == nil {
+			return parsed.Dimension
+		}
+	}
+	return 3
+}
+
 func listAlgorithms() {
 	algorithms := cube.AlgorithmDatabase
 	verifiableCount := 0
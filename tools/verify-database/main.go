@@ -10,6 +10,7 @@ import (
 
 func main() {
 	verbose := false
+	checkInverse := false
 	category := ""
 
 	// Simple argument parsing
@@ -17,6 +18,8 @@ func main() {
 		switch arg {
 		case "--verbose", "-v":
 			verbose = true
+		case "--check-inverse":
+			checkInverse = true
 		case "--category":
 			if i+1 < len(os.Args)-1 {
 				category = os.Args[i+2]
@@ -53,6 +56,7 @@ func main() {
 	passedCount := 0
 	failedCount := 0
 	errorCount := 0
+	inverseFailures := []cube.Algorithm{}
 
 	fmt.Printf("Verifying %d algorithms", totalCount)
 	if category != "" {
@@ -65,14 +69,14 @@ func main() {
 		fmt.Printf("[%d/%d] Testing %s (%s)...", i+1, totalCount, alg.Name, alg.CaseID)
 
 		// Set up start and target CFENs
-		startCFEN := "YB|Y9/R9/B9/W9/O9/G9" // Always start from solved cube
-		targetCFEN := alg.Pattern           // Expected pattern after applying algorithm
+		startCFEN := cfen.SolvedCFEN(3) // Always start from solved cube
+		targetCFEN := alg.Pattern       // Expected pattern after applying algorithm
 
 		// Perform verification
-		err := verifyAlgorithm(alg, startCFEN, targetCFEN, false)
+		err := cfen.VerifyAlgorithm(alg, startCFEN, targetCFEN)
 
 		if err != nil {
-			if err.Error() == "verification failed" {
+			if err == cfen.ErrVerificationFailed {
 				failedCount++
 				fmt.Printf(" ❌ FAIL\n")
 				if verbose {
@@ -88,6 +92,14 @@ func main() {
 		} else {
 			passedCount++
 			fmt.Printf(" ✅ PASS\n")
+
+			// Forward works, so an inverse failure points at the move engine
+			if checkInverse {
+				if err := verifyInverse(alg); err != nil {
+					inverseFailures = append(inverseFailures, alg)
+					fmt.Printf("    ⚠️  Inverse check failed: %v\n", err)
+				}
+			}
 		}
 
 		// Show detailed output for verbose mode
@@ -108,7 +120,19 @@ func main() {
 		fmt.Printf("⚠️  Errors: %d (%.1f%%)\n", errorCount, float64(errorCount)/float64(totalCount)*100)
 	}
 
-	if passedCount == totalCount {
+	if checkInverse {
+		fmt.Printf("\n=== Inverse Check ===\n")
+		if len(inverseFailures) == 0 {
+			fmt.Printf("✅ All %d passing algorithms return to solved when inverted\n", passedCount)
+		} else {
+			fmt.Printf("❌ %d algorithms pass forward but fail when inverted (likely a move-engine bug):\n", len(inverseFailures))
+			for _, alg := range inverseFailures {
+				fmt.Printf("  %s (%s): %s\n", alg.Name, alg.CaseID, alg.Moves)
+			}
+		}
+	}
+
+	if passedCount == totalCount && len(inverseFailures) == 0 {
 		fmt.Printf("\n🎉 All algorithms verified successfully!\n")
 	} else {
 		fmt.Printf("\n⚠️  Some algorithms failed verification. Use --verbose for details.\n")
@@ -116,41 +140,20 @@ func main() {
 	}
 }
 
-func verifyAlgorithm(algorithm cube.Algorithm, startCFEN, targetCFEN string, verbose bool) error {
-	// Parse start CFEN
-	startState, err := cfen.ParseCFEN(startCFEN)
-	if err != nil {
-		return fmt.Errorf("parsing start CFEN: %v", err)
-	}
-
-	// Parse target CFEN
-	targetState, err := cfen.ParseCFEN(targetCFEN)
-	if err != nil {
-		return fmt.Errorf("parsing target CFEN: %v", err)
-	}
-
-	// Convert start CFEN to cube
-	c, err := startState.ToCube()
-	if err != nil {
-		return fmt.Errorf("converting start CFEN to cube: %v", err)
-	}
-
-	// Parse and apply algorithm
+// verifyInverse applies the algorithm to a solved cube, which produces its
+// pattern, then applies the inverse sequence and checks the cube is solved again
+func verifyInverse(algorithm cube.Algorithm) error {
 	moves, err := cube.ParseScramble(algorithm.Moves)
 	if err != nil {
 		return fmt.Errorf("parsing algorithm moves: %v", err)
 	}
 
+	c := cube.NewCube(3)
 	c.ApplyMoves(moves)
+	c.ApplyInverseMoves(moves)
 
-	// Check if result matches target
-	matches, err := targetState.MatchesCube(c)
-	if err != nil {
-		return fmt.Errorf("matching result to target: %v", err)
-	}
-
-	if !matches {
-		return fmt.Errorf("verification failed")
+	if !c.IsSolved() {
+		return fmt.Errorf("inverse does not return pattern to solved")
 	}
 
 	return nil